@@ -49,6 +49,15 @@ func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return results, err
 }
 
+// EnqueueOnServices takes a list of ServiceActions and queues each one
+// up on the units of the designated service, returning the queued
+// Actions grouped by service.
+func (c *Client) EnqueueOnServices(arg params.ServiceActions) (params.ActionsByReceivers, error) {
+	results := params.ActionsByReceivers{}
+	err := c.facade.FacadeCall("EnqueueOnServices", arg, &results)
+	return results, err
+}
+
 // FindActionsByNames takes a list of action names and returns actions for
 // every name.
 func (c *Client) FindActionsByNames(arg params.FindActionsByNames) (params.ActionsByNames, error) {
@@ -84,8 +93,10 @@ func (c *Client) ListCompleted(arg params.Entities) (params.ActionsByReceivers,
 	return results, err
 }
 
-// Cancel attempts to cancel a queued up Action from running.
-func (c *Client) Cancel(arg params.Actions) (params.ActionResults, error) {
+// Cancel cancels the Actions represented by the given Entities. Pending
+// Actions are cancelled immediately; running Actions are stopped by
+// their receivers.
+func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	results := params.ActionResults{}
 	err := c.facade.FacadeCall("Cancel", arg, &results)
 	return results, err
@@ -11,9 +11,8 @@ import (
 
 // Create sends a request to create a backup of juju's state.  It
 // returns the metadata associated with the resulting backup.
-func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *Client) Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{Notes: notes}
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
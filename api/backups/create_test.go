@@ -27,6 +27,7 @@ func (s *createSuite) TestCreate(c *gc.C) {
 			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
 			p := paramsIn.(params.BackupsCreateArgs)
 			c.Check(p.Notes, gc.Equals, "important")
+			c.Check(p.EncryptionKey, gc.Equals, "secret")
 
 			if result, ok := resp.(*params.BackupsMetadataResult); ok {
 				*result = apiserverbackups.ResultFromMetadata(s.Meta)
@@ -39,7 +40,10 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important")
+	result, err := s.client.Create(params.BackupsCreateArgs{
+		Notes:         "important",
+		EncryptionKey: "secret",
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
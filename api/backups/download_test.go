@@ -22,7 +22,8 @@ type downloadSuite struct {
 var _ = gc.Suite(&downloadSuite{})
 
 func (s *downloadSuite) TestSuccessfulRequest(c *gc.C) {
-	store := backups.NewStorage(s.State)
+	store, err := backups.NewStorage(s.State)
+	c.Assert(err, jc.ErrorIsNil)
 	defer store.Close()
 	backupsState := backups.NewBackups(store)
 
@@ -32,6 +32,17 @@ func (APICallerFunc) BestFacadeVersion(facade string) int {
 	return 0
 }
 
+// BestVersionCaller is an APICallerFunc that reports a particular
+// facade version as the best supported by the server.
+type BestVersionCaller struct {
+	APICallerFunc
+	BestVersion int
+}
+
+func (c BestVersionCaller) BestFacadeVersion(facade string) int {
+	return c.BestVersion
+}
+
 func (APICallerFunc) ModelTag() (names.ModelTag, error) {
 	return coretesting.ModelTag, nil
 }
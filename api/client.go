@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -578,6 +579,28 @@ type DebugLogParams struct {
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
 	NoTail bool
+	// StartTime, if set, tells the server to only return logs written
+	// at or after this time.
+	StartTime time.Time
+	// EndTime, if set, tells the server to only return logs written
+	// before this time. The server does not wait for new logs to arrive
+	// when EndTime is set.
+	EndTime time.Time
+	// Message is a regular expression which the messages of returned
+	// logs must match.
+	Message string
+	// IncludeLocation lists source locations to include in the response.
+	// A location is a file name, optionally followed by ':' and a line
+	// number, and may contain '*' wildcards e.g.: uniter.go, *_test.go.
+	// If none are set, all locations are considered included.
+	IncludeLocation []string
+	// ExcludeLocation lists source locations to exclude from the response.
+	// As with IncludeLocation the values may contain '*' wildcards.
+	ExcludeLocation []string
+	// Format specifies how the server writes log records; it may be
+	// "text" (the default) or "json", in which case each record is
+	// written as a params.DebugLogRecord on its own line.
+	Format string
 }
 
 // WatchDebugLog returns a ReadCloser that the caller can read the log
@@ -599,6 +622,9 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 		"includeModule": args.IncludeModule,
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
+
+		"includeLocation": args.IncludeLocation,
+		"excludeLocation": args.ExcludeLocation,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -615,6 +641,18 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.UTC().Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.UTC().Format(time.RFC3339Nano))
+	}
+	if args.Message != "" {
+		attrs.Set("message", args.Message)
+	}
+	if args.Format != "" {
+		attrs.Set("format", args.Format)
+	}
 
 	connection, err := c.st.ConnectStream("/log", attrs)
 	if err != nil {
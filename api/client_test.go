@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -411,6 +412,13 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Level:         loggo.ERROR,
 		Replay:        true,
 		NoTail:        true,
+
+		StartTime:       time.Date(2016, 6, 19, 15, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2016, 6, 19, 16, 30, 0, 0, time.UTC),
+		Message:         "^hook failed",
+		IncludeLocation: []string{"uniter.go"},
+		ExcludeLocation: []string{"*_test.go"},
+		Format:          "json",
 	}
 
 	client := s.APIState.Client()
@@ -429,6 +437,13 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"level":         {"ERROR"},
 		"replay":        {"true"},
 		"noTail":        {"true"},
+
+		"startTime":       {"2016-06-19T15:00:00Z"},
+		"endTime":         {"2016-06-19T16:30:00Z"},
+		"message":         {"^hook failed"},
+		"includeLocation": params.IncludeLocation,
+		"excludeLocation": params.ExcludeLocation,
+		"format":          {"json"},
 	})
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju/permission"
 )
 
 var logger = loggo.GetLogger("juju.api.controller")
@@ -133,6 +134,97 @@ func (c *Client) ModelStatus(tags ...names.ModelTag) ([]base.ModelStatus, error)
 	return results, nil
 }
 
+// AuditLog returns the entries in the controller's audit log that match
+// the supplied filter, ordered from oldest to newest.
+func (c *Client) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	var result params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
+// ExportModel returns the serialized description of the model with
+// the given UUID.
+func (c *Client) ExportModel(modelUUID string) ([]byte, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.SerializedModelResults
+	if err := c.facade.FacadeCall("ExportModel", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Bytes, nil
+}
+
+// PrecheckModelMigration returns every problem that would prevent the
+// model with the given UUID from being migrated away from the
+// controller.
+func (c *Client) PrecheckModelMigration(modelUUID string) ([]params.MigrationIssue, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.MigrationIssuesResults
+	if err := c.facade.FacadeCall("PrecheckModelMigration", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Issues, nil
+}
+
+// ModelMigrationStatus returns the status and progress of the most
+// recent migration of the model with the given UUID.
+func (c *Client) ModelMigrationStatus(modelUUID string) (params.ModelMigrationStatus, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ModelMigrationStatusResults
+	if err := c.facade.FacadeCall("ModelMigrationStatus", args, &results); err != nil {
+		return params.ModelMigrationStatus{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ModelMigrationStatus{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ModelMigrationStatus{}, errors.Trace(result.Error)
+	}
+	if result.Status == nil {
+		return params.ModelMigrationStatus{}, errors.New("missing migration status")
+	}
+	return *result.Status, nil
+}
+
+// ImportModel creates a new model in the controller from a serialized
+// model description. If name is not empty the model is renamed, and if
+// newUUID is true the model is given a new UUID; the server refuses a
+// new UUID for models with provisioned machines.
+func (c *Client) ImportModel(bytes []byte, name string, newUUID bool) (params.Model, error) {
+	args := params.ImportModelArgs{
+		Bytes:   bytes,
+		Name:    name,
+		NewUUID: newUUID,
+	}
+	var result params.Model
+	if err := c.facade.FacadeCall("ImportModel", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // ModelMigrationSpec holds the details required to start the
 // migration of a single model.
 type ModelMigrationSpec struct {
@@ -203,3 +295,50 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.Id, nil
 }
+
+// GrantController grants a user access to the controller.
+func (c *Client) GrantController(user, access string) error {
+	return c.modifyControllerUser(params.GrantControllerAccess, user, access)
+}
+
+// RevokeController revokes a user's access to the controller.
+func (c *Client) RevokeController(user, access string) error {
+	return c.modifyControllerUser(params.RevokeControllerAccess, user, access)
+}
+
+func (c *Client) modifyControllerUser(action params.ControllerAction, user, access string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+
+	controllerAccess, err := permission.ParseControllerAccess(access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var accessPermission params.ControllerAccessPermission
+	switch controllerAccess {
+	case permission.ControllerAddModelAccess:
+		accessPermission = params.ControllerAddModelAccess
+	case permission.ControllerSuperuserAccess:
+		accessPermission = params.ControllerSuperuserAccess
+	default:
+		return errors.Errorf("unsupported controller access permission %v", controllerAccess)
+	}
+
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: userTag.String(),
+			Action:  action,
+			Access:  accessPermission,
+		}},
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("ModifyControllerAccess", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.OneError()
+}
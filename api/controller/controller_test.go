@@ -209,6 +209,120 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestAuditLogRecordsMutatingCalls(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	err := sysManager.RemoveBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sysManager.ListBlockedModels()
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := sysManager.AuditLog(params.AuditLogFilter{
+		UserTag: s.AdminUserTag(c).String(),
+		Facade:  "Controller",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	entry := entries[0]
+	c.Check(entry.UserTag, gc.Equals, s.AdminUserTag(c).String())
+	c.Check(entry.ModelTag, gc.Equals, s.State.ModelTag().String())
+	c.Check(entry.Method, gc.Equals, "RemoveBlocks")
+	c.Check(entry.Args, gc.Equals, `{"all":true}`)
+	c.Check(entry.Error, gc.Equals, "")
+}
+
+func (s *controllerSuite) TestExportImportModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "original"})
+	defer st.Close()
+
+	sysManager := s.OpenAPI(c)
+	bytes, err := sysManager.ExportModel(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bytes, gc.Not(gc.HasLen), 0)
+
+	model, err := sysManager.ImportModel(bytes, "copy", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Name, gc.Equals, "copy")
+	c.Check(model.UUID, gc.Not(gc.Equals), st.ModelUUID())
+}
+
+func (s *controllerSuite) TestGrantRevokeController(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	sysManager := s.OpenAPI(c)
+
+	err := sysManager.GrantController("bob", "superuser")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerSuperuserAccess)
+
+	err = sysManager.RevokeController("bob", "superuser")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+
+	err = sysManager.RevokeController("bob", "add-model")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestGrantControllerInvalidAccess(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	err := sysManager.GrantController("bob", "write")
+	c.Assert(err, gc.ErrorMatches, `invalid controller access permission "write"`)
+}
+
+func (s *controllerSuite) TestExportModelNotFound(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	_, err := sysManager.ExportModel(randomUUID())
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) TestPrecheckModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	sysManager := s.OpenAPI(c)
+	issues, err := sysManager.PrecheckModelMigration(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestPrecheckModelMigrationNotFound(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	_, err := sysManager.PrecheckModelMigration(randomUUID())
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) TestModelMigrationStatus(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	sysManager := s.OpenAPI(c)
+	id, err := sysManager.InitiateModelMigration(controller.ModelMigrationSpec{
+		ModelUUID:            st.ModelUUID(),
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := sysManager.ModelMigrationStatus(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Id, gc.Equals, id)
+	c.Check(status.Phase, gc.Equals, "QUIESCE")
+	c.Check(status.MinionReports.Phase, gc.Equals, "QUIESCE")
+}
+
+func (s *controllerSuite) TestModelMigrationStatusNotFound(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	_, err := sysManager.ModelMigrationStatus(randomUUID())
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
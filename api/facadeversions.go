@@ -57,7 +57,7 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelManager":                 2,
+	"ModelManager":                 3,
 	"NotifyWatcher":                1,
 	"Pinger":                       1,
 	"Provisioner":                  2,
@@ -66,19 +66,19 @@ var facadeVersions = map[string]int{
 	"RelationUnitsWatcher":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
-	"Service":                      3,
+	"Service":                      4,
 	"ServiceScaler":                1,
 	"Singular":                     1,
 	"Spaces":                       2,
 	"SSHClient":                    1,
 	"StatusHistory":                2,
-	"Storage":                      2,
+	"Storage":                      3,
 	"StorageProvisioner":           2,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       3,
+	"Uniter":                       4,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
@@ -75,3 +75,25 @@ func (s *Service) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposedSourceCIDRs returns the CIDRs from which the open ports of the
+// service may be accessed. No CIDRs are returned when the service is
+// not exposed.
+func (s *Service) ExposedSourceCIDRs() ([]string, error) {
+	var results params.StringsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposedSourceCIDRs", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
@@ -91,3 +91,26 @@ func (s *serviceSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestExposedSourceCIDRs(c *gc.C) {
+	err := s.service.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := s.apiService.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	err = s.service.SetExposedTo([]string{"10.0.0.0/8", "192.168.0.0/16"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiService.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+
+	err = s.service.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err = s.apiService.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
@@ -58,6 +58,29 @@ func (s *stateSuite) TestWatchModelMachines(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *stateSuite) TestWatchSubnets(c *gc.C) {
+	w, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Add a subnet, make sure it's detected.
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.1.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.1.2.0/24")
+	wc.AssertNoChange()
+
+	// Change its life cycle, make sure it's detected.
+	err = subnet.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.1.2.0/24")
+	wc.AssertNoChange()
+}
+
 func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	// Open some ports.
 	err := s.units[0].OpenPorts("tcp", 1234, 1400)
@@ -16,6 +16,9 @@ import (
 type MetricsAdderClient interface {
 	// AddMetricBatches stores specified metric batches in the state.
 	AddMetricBatches(batches []params.MetricBatchParam) (map[string]error, error)
+
+	// AddMetricFailures records specified metric failures in the state.
+	AddMetricFailures(failures []params.MetricFailureParam) (map[string]error, error)
 }
 
 // NewClient creates a new client for accessing the metricsadder API.
@@ -46,3 +49,20 @@ func (c *Client) AddMetricBatches(batches []params.MetricBatchParam) (map[string
 	}
 	return resultMap, nil
 }
+
+// AddMetricFailures implements the MetricsAdderClient interface.
+func (c *Client) AddMetricFailures(failures []params.MetricFailureParam) (map[string]error, error) {
+	parameters := params.MetricFailureParams{
+		Failures: failures,
+	}
+	results := new(params.ErrorResults)
+	err := c.facade.FacadeCall("AddMetricFailures", parameters, results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resultMap := make(map[string]error)
+	for i, result := range results.Results {
+		resultMap[failures[i].Failure.UUID] = result.Error
+	}
+	return resultMap, nil
+}
@@ -94,6 +94,40 @@ func (s *metricsAdderSuite) TestAddMetricBatchesFails(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *metricsAdderSuite) TestAddMetricFailures(c *gc.C) {
+	var called bool
+	var callParams params.MetricFailureParams
+	metricsadder.PatchFacadeCall(s, s.adder, func(request string, args, response interface{}) error {
+		p, ok := args.(params.MetricFailureParams)
+		c.Assert(ok, jc.IsTrue)
+		callParams = p
+		called = true
+		c.Assert(request, gc.Equals, "AddMetricFailures")
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		result.Results[0].Error = common.ServerError(common.ErrPerm)
+		return nil
+	})
+
+	failures := []params.MetricFailureParam{{
+		Tag: names.NewUnitTag("test-unit/0").String(),
+		Failure: params.MetricFailure{
+			UUID:    utils.MustNewUUID().String(),
+			Kind:    "send",
+			Message: "connection refused",
+			Time:    time.Now(),
+		},
+	}}
+
+	results, err := s.adder.AddMetricFailures(failures)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(callParams.Failures, gc.DeepEquals, failures)
+	result, ok := results[failures[0].Failure.UUID]
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(result.Error(), gc.Equals, "permission denied")
+}
+
 type metricsAdderIntegrationSuite struct {
 	jujutesting.JujuConnSuite
 
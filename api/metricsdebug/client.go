@@ -24,6 +24,10 @@ type Client struct {
 type MetricsDebugClient interface {
 	// GetMetrics will receive metrics collected by the given entity tag
 	GetMetrics(tag string) ([]params.MetricResult, error)
+
+	// GetMetricFailures will receive the failures to collect, spool or
+	// send metrics recorded for the given entity tag.
+	GetMetricFailures(tag string) ([]params.MetricFailureResult, error)
 }
 
 // MeterStatusClient defines methods on the metricsdebug API end point.
@@ -60,6 +64,26 @@ func (c *Client) GetMetrics(tag string) ([]params.MetricResult, error) {
 	return metrics, nil
 }
 
+// GetMetricFailures will receive the metric failures recorded for the
+// given entity.
+func (c *Client) GetMetricFailures(tag string) ([]params.MetricFailureResult, error) {
+	p := params.Entities{Entities: []params.Entity{
+		{tag},
+	}}
+	results := new(params.MetricFailureResults)
+	if err := c.facade.FacadeCall("GetMetricFailures", p, results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := results.OneError(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	failures := []params.MetricFailureResult{}
+	for _, r := range results.Results {
+		failures = append(failures, r.Failures...)
+	}
+	return failures, nil
+}
+
 // SetMeterStatus will set the meter status on the given entity tag.
 func (c *Client) SetMeterStatus(tag, code, info string) error {
 	args := params.MeterStatusParams{
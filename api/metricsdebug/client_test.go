@@ -100,6 +100,60 @@ func (s *metricsdebugSuiteMock) TestGetMetricsFacadeCallError(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *metricsdebugSuiteMock) TestGetMetricFailures(c *gc.C) {
+	var called bool
+	now := time.Now()
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Assert(request, gc.Equals, "GetMetricFailures")
+			c.Assert(a, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"service-wordpress"}}})
+			result := response.(*params.MetricFailureResults)
+			result.Results = []params.EntityMetricFailures{{
+				Failures: []params.MetricFailureResult{{
+					Time:    now,
+					Unit:    "wordpress/0",
+					Kind:    "collect",
+					Message: "hook failed",
+				}},
+			}}
+			called = true
+			return nil
+		})
+	client := metricsdebug.NewClient(apiCaller)
+	failures, err := client.GetMetricFailures("service-wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(failures, jc.DeepEquals, []params.MetricFailureResult{{
+		Time:    now,
+		Unit:    "wordpress/0",
+		Kind:    "collect",
+		Message: "hook failed",
+	}})
+}
+
+func (s *metricsdebugSuiteMock) TestGetMetricFailuresFails(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			result := response.(*params.MetricFailureResults)
+			result.Results = []params.EntityMetricFailures{{
+				Error: common.ServerError(errors.New("an error")),
+			}}
+			return nil
+		})
+	client := metricsdebug.NewClient(apiCaller)
+	failures, err := client.GetMetricFailures("unit-wordpress/0")
+	c.Assert(err, gc.ErrorMatches, "an error")
+	c.Assert(failures, gc.IsNil)
+}
+
 func (s *metricsdebugSuiteMock) TestSetMeterStatus(c *gc.C) {
 	var called bool
 	apiCaller := basetesting.APICallerFunc(
@@ -4,6 +4,10 @@
 package migrationmaster
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
@@ -32,6 +36,36 @@ type Client interface {
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// OpenResource returns a reader for the content of a resource of
+	// the model associated with the API connection. The caller is
+	// responsible for closing it.
+	OpenResource(serviceID, name string) (io.ReadCloser, error)
+
+	// SetProgress records the counts of the work done so far by the
+	// currently active model migration.
+	SetProgress(MigrationProgress) error
+
+	// MinionReports returns details of the agents which have and
+	// haven't reported on the current phase of the model migration.
+	MinionReports() (MinionReports, error)
+}
+
+// MigrationProgress holds counts of the work done by a model
+// migration.
+type MigrationProgress struct {
+	EntitiesExported int
+	EntitiesImported int
+	BinaryBytes      int64
+}
+
+// MinionReports holds the agents which have reported success or
+// failure for a migration phase, and those which are yet to report.
+type MinionReports struct {
+	Phase     migration.Phase
+	Succeeded []names.Tag
+	Failed    []names.Tag
+	Unknown   []names.Tag
 }
 
 // MigrationStatus returns the details for a migration as needed by
@@ -119,6 +153,49 @@ func (c *client) SetPhase(phase migration.Phase) error {
 	return c.caller.FacadeCall("SetPhase", args, nil)
 }
 
+// SetProgress implements Client.
+func (c *client) SetProgress(progress MigrationProgress) error {
+	args := params.MigrationProgress{
+		EntitiesExported: progress.EntitiesExported,
+		EntitiesImported: progress.EntitiesImported,
+		BinaryBytes:      progress.BinaryBytes,
+	}
+	return c.caller.FacadeCall("SetProgress", args, nil)
+}
+
+// MinionReports implements Client.
+func (c *client) MinionReports() (MinionReports, error) {
+	var empty MinionReports
+	var reports params.MinionReports
+	err := c.caller.FacadeCall("MinionReports", nil, &reports)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	phase, ok := migration.ParsePhase(reports.Phase)
+	if !ok {
+		return empty, errors.Errorf("invalid phase: %q", reports.Phase)
+	}
+	result := MinionReports{Phase: phase}
+	for _, group := range []struct {
+		tags []string
+		out  *[]names.Tag
+	}{
+		{reports.Succeeded, &result.Succeeded},
+		{reports.Failed, &result.Failed},
+		{reports.Unknown, &result.Unknown},
+	} {
+		for _, tagString := range group.tags {
+			tag, err := names.ParseTag(tagString)
+			if err != nil {
+				return empty, errors.Annotate(err, "parsing agent tag")
+			}
+			*group.out = append(*group.out, tag)
+		}
+	}
+	return result, nil
+}
+
 // Export implements Client.
 func (c *client) Export() ([]byte, error) {
 	var serialized params.SerializedModel
@@ -128,3 +205,23 @@ func (c *client) Export() ([]byte, error) {
 	}
 	return serialized.Bytes, nil
 }
+
+// OpenResource implements Client.
+func (c *client) OpenResource(serviceID, name string) (io.ReadCloser, error) {
+	httpClient, err := c.caller.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	args := url.Values{}
+	args.Set("service", serviceID)
+	args.Set("name", name)
+	req, err := http.NewRequest("GET", "/migrate/resources?"+args.Encode(), nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create HTTP request")
+	}
+	var resp *http.Response
+	if err := httpClient.Do(req, nil, &resp); err != nil {
+		return nil, errors.Annotatef(err, "cannot download resource %s/%s", serviceID, name)
+	}
+	return resp.Body, nil
+}
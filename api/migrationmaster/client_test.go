@@ -148,6 +148,61 @@ func (s *ClientSuite) TestSetPhaseError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetProgress(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	err := client.SetProgress(migrationmaster.MigrationProgress{
+		EntitiesExported: 3,
+		BinaryBytes:      42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.MigrationProgress{EntitiesExported: 3, BinaryBytes: 42}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetProgress", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestMinionReports(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType+"."+request, gc.Equals, "MigrationMaster.MinionReports")
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase:     "QUIESCE",
+			Succeeded: []string{"machine-0"},
+			Failed:    []string{"unit-foo-0"},
+			Unknown:   []string{"machine-1"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	reports, err := client.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, migrationmaster.MinionReports{
+		Phase:     migration.QUIESCE,
+		Succeeded: []names.Tag{names.NewMachineTag("0")},
+		Failed:    []names.Tag{names.NewUnitTag("foo/0")},
+		Unknown:   []names.Tag{names.NewMachineTag("1")},
+	})
+}
+
+func (s *ClientSuite) TestMinionReportsBadTag(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		out := result.(*params.MinionReports)
+		*out = params.MinionReports{
+			Phase:   "QUIESCE",
+			Unknown: []string{"carrot"},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.MinionReports()
+	c.Assert(err, gc.ErrorMatches, `parsing agent tag: "carrot" is not a valid tag`)
+}
+
 func (s *ClientSuite) TestExport(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -173,3 +228,12 @@ func (s *ClientSuite) TestExportError(c *gc.C) {
 	_, err := client.Export()
 	c.Assert(err, gc.ErrorMatches, "blam")
 }
+
+func (s *ClientSuite) TestOpenResourceNoHTTPClient(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller)
+	_, err := client.OpenResource("wordpress", "blob")
+	c.Assert(err, gc.ErrorMatches, "cannot retrieve HTTP client: no HTTP client available in this test")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/watcher"
 )
 
@@ -19,6 +20,11 @@ type Client interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report allows a migration minion to report whether it
+	// succeeded or failed to complete its actions for a migration
+	// phase.
+	Report(phase migration.Phase, success bool) error
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -44,3 +50,12 @@ func (c *client) Watch() (watcher.MigrationStatusWatcher, error) {
 	w := apiwatcher.NewMigrationStatusWatcher(c.caller.RawAPICaller(), result.NotifyWatcherId)
 	return w, nil
 }
+
+// Report implements Client.
+func (c *client) Report(phase migration.Phase, success bool) error {
+	args := params.MinionReport{
+		Phase:   phase.String(),
+		Success: success,
+	}
+	return c.caller.FacadeCall("Report", args, nil)
+}
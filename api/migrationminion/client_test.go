@@ -14,6 +14,7 @@ import (
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationminion"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 )
@@ -81,3 +82,20 @@ func (s *ClientSuite) TestWatchErr(c *gc.C) {
 	_, err := client.Watch()
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
+
+func (s *ClientSuite) TestReport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationminion.NewClient(apiCaller)
+	err := client.Report(migration.QUIESCE, true)
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMinion.Report", []interface{}{"", params.MinionReport{
+			Phase:   "QUIESCE",
+			Success: true,
+		}}},
+	})
+}
@@ -4,6 +4,12 @@
 package migrationtarget
 
 import (
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/httprequest"
 	"github.com/juju/names"
 
 	"github.com/juju/juju/api/base"
@@ -15,8 +21,8 @@ import (
 // target controller during a migration.
 type Client interface {
 	// Import takes a serialized model and imports it into the target
-	// controller.
-	Import([]byte) error
+	// controller, returning the number of entities imported.
+	Import([]byte) (int, error)
 
 	// Abort removes all data relating to a previously imported
 	// model.
@@ -24,6 +30,15 @@ type Client interface {
 
 	// Activate marks a migrated model as being ready to use.
 	Activate(string) error
+
+	// DryRun checks whether a serialized model could be imported into
+	// the target controller, without leaving it there, and returns any
+	// problems found.
+	DryRun([]byte) ([]params.MigrationIssue, error)
+
+	// UploadResource sends the content of a resource of a previously
+	// imported model to the target controller.
+	UploadResource(modelUUID, serviceID, name string, r io.ReadSeeker) error
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -37,9 +52,13 @@ type client struct {
 }
 
 // Import implements Client.
-func (c *client) Import(bytes []byte) error {
+func (c *client) Import(bytes []byte) (int, error) {
 	serialized := params.SerializedModel{Bytes: bytes}
-	return c.caller.FacadeCall("Import", serialized, nil)
+	var result params.ImportResult
+	if err := c.caller.FacadeCall("Import", serialized, &result); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return result.EntitiesImported, nil
 }
 
 // Abort implements Client.
@@ -53,3 +72,51 @@ func (c *client) Activate(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
 	return c.caller.FacadeCall("Activate", args, nil)
 }
+
+// DryRun implements Client.
+func (c *client) DryRun(bytes []byte) ([]params.MigrationIssue, error) {
+	serialized := params.SerializedModel{Bytes: bytes}
+	var result params.MigrationIssues
+	if err := c.caller.FacadeCall("DryRun", serialized, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Issues, nil
+}
+
+// rootHTTPCaller is implemented by API connections which can make
+// HTTP requests relative to the API server root rather than to a
+// model. The connection to the target controller isn't associated
+// with the model being migrated, so the model must be given in the
+// path.
+type rootHTTPCaller interface {
+	RootHTTPClient() (*httprequest.Client, error)
+}
+
+// UploadResource implements Client.
+func (c *client) UploadResource(modelUUID, serviceID, name string, r io.ReadSeeker) error {
+	caller, ok := c.caller.RawAPICaller().(rootHTTPCaller)
+	if !ok {
+		return errors.NotSupportedf("resource upload over this connection")
+	}
+	httpClient, err := caller.RootHTTPClient()
+	if err != nil {
+		return errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	args := url.Values{}
+	args.Set("service", serviceID)
+	args.Set("name", name)
+	path := "/model/" + modelUUID + "/migrate/resources?" + args.Encode()
+	req, err := http.NewRequest("POST", path, nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot create HTTP request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeRaw)
+	var result params.ErrorResult
+	if err := httpClient.Do(req, r, &result); err != nil {
+		return errors.Annotatef(err, "cannot upload resource %s/%s", serviceID, name)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
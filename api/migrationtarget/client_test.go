@@ -4,9 +4,12 @@
 package migrationtarget_test
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	apitesting "github.com/juju/juju/api/base/testing"
@@ -33,7 +36,7 @@ func (s *ClientSuite) getClientAndStub(c *gc.C) (migrationtarget.Client, *jujute
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
-	err := client.Import([]byte("foo"))
+	_, err := client.Import([]byte("foo"))
 
 	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
 	stub.CheckCalls(c, []jujutesting.StubCall{
@@ -42,6 +45,40 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportReportsEntities(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Import")
+		*(result.(*params.ImportResult)) = params.ImportResult{EntitiesImported: 7}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	imported, err := client.Import([]byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, gc.Equals, 7)
+}
+
+func (s *ClientSuite) TestDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationIssues)) = params.MigrationIssues{
+			Issues: []params.MigrationIssue{{Phase: "IMPORT", Message: "bad"}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	issues, err := client.DryRun([]byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, []params.MigrationIssue{{Phase: "IMPORT", Message: "bad"}})
+
+	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.DryRun", []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -58,6 +95,14 @@ func (s *ClientSuite) TestActivate(c *gc.C) {
 	s.AssertModelCall(c, stub, names.NewModelTag(uuid), "Activate", err)
 }
 
+func (s *ClientSuite) TestUploadResourceNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	err := client.UploadResource("fake", "wordpress", "blob", strings.NewReader("content"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) AssertModelCall(c *gc.C, stub *jujutesting.Stub, tag names.ModelTag, call string, err error) {
 	expectedArg := params.ModelArgs{ModelTag: tag.String()}
 	stub.CheckCalls(c, []jujutesting.StubCall{
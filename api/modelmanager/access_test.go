@@ -85,6 +85,35 @@ func (s *accessSuite) readOnlyUser(c *gc.C, action params.ModelAction) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *accessSuite) TestGrantModelWriteUser(c *gc.C) {
+	s.writeUser(c, params.GrantModelAccess)
+}
+
+func (s *accessSuite) TestRevokeModelWriteUser(c *gc.C) {
+	s.writeUser(c, params.RevokeModelAccess)
+}
+
+func (s *accessSuite) writeUser(c *gc.C, action params.ModelAction) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, gc.HasLen, 1)
+			c.Assert(string(req.Changes[0].Action), gc.Equals, string(action))
+			c.Assert(string(req.Changes[0].Access), gc.Equals, string(params.ModelWriteAccess))
+			c.Assert(req.Changes[0].ModelTag, gc.Equals, someModelTag)
+
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	err := accessCall(client, action, "bob", "write", someModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *accessSuite) TestGrantModelAdminUser(c *gc.C) {
 	s.adminUser(c, params.GrantModelAccess)
 }
@@ -101,7 +130,7 @@ func (s *accessSuite) adminUser(c *gc.C, action params.ModelAction) {
 			req := assertRequest(c, a)
 			c.Assert(req.Changes, gc.HasLen, 1)
 			c.Assert(string(req.Changes[0].Action), gc.Equals, string(action))
-			c.Assert(string(req.Changes[0].Access), gc.Equals, string(params.ModelWriteAccess))
+			c.Assert(string(req.Changes[0].Access), gc.Equals, string(params.ModelAdminAccess))
 			c.Assert(req.Changes[0].ModelTag, gc.Equals, someModelTag)
 
 			resp := assertResponse(c, result)
@@ -110,7 +139,7 @@ func (s *accessSuite) adminUser(c *gc.C, action params.ModelAction) {
 			return nil
 		})
 	client := modelmanager.NewClient(apiCaller)
-	err := accessCall(client, action, "bob", "write", someModelUUID)
+	err := accessCall(client, action, "bob", "admin", someModelUUID)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -10,6 +10,7 @@ package service
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/api"
@@ -81,6 +82,10 @@ type DeployArgs struct {
 	// Storage contains Constraints specifying how storage should be
 	// handled.
 	Storage map[string]storage.Constraints
+	// AttachStorage contains IDs of existing storage that should be
+	// attached to the service unit that will be deployed. This must
+	// be empty if NumUnits is not 1.
+	AttachStorage []string
 	// EndpointBindings
 	EndpointBindings map[string]string
 	// Collection of resource names for the service, with the value being the
@@ -92,6 +97,13 @@ type DeployArgs struct {
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
 func (c *Client) Deploy(args DeployArgs) error {
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return errors.New("cannot attach existing storage when more than one unit is requested")
+	}
+	attachStorage, err := storageTags(args.AttachStorage)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	deployArgs := params.ServicesDeploy{
 		Services: []params.ServiceDeploy{{
 			ServiceName:      args.ServiceName,
@@ -103,12 +115,12 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Constraints:      args.Cons,
 			Placement:        args.Placement,
 			Storage:          args.Storage,
+			AttachStorage:    attachStorage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		}},
 	}
 	var results params.ErrorResults
-	var err error
 	err = c.facade.FacadeCall("Deploy", deployArgs, &results)
 	if err != nil {
 		return err
@@ -131,6 +143,19 @@ func (c *Client) GetCharmURL(serviceName string) (*charm.URL, error) {
 	return charm.ParseURL(result.Result)
 }
 
+// ExportBundle returns the model's services, machines and relations as
+// bundle YAML.
+func (c *Client) ExportBundle() (string, error) {
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
 // SetCharmConfig holds the configuration for setting a new revision of a charm
 // on a service.
 type SetCharmConfig struct {
@@ -167,19 +192,60 @@ func (c *Client) Update(args params.ServiceUpdate) error {
 	return c.facade.FacadeCall("Update", args, nil)
 }
 
+// AddUnitsParams contains parameters for the AddUnits API method.
+type AddUnitsParams struct {
+	// ServiceName is the name of the service to which units
+	// will be added.
+	ServiceName string
+
+	// NumUnits is the number of units to deploy.
+	NumUnits int
+
+	// Placement directives on where the machines for the unit must be
+	// created.
+	Placement []*instance.Placement
+
+	// AttachStorage contains IDs of existing storage that should be
+	// attached to the service unit that will be deployed. This must
+	// be empty if NumUnits is not 1.
+	AttachStorage []string
+}
+
 // AddUnits adds a given number of units to a service using the specified
 // placement directives to assign units to machines.
-func (c *Client) AddUnits(service string, numUnits int, placement []*instance.Placement) ([]string, error) {
-	args := params.AddServiceUnits{
-		ServiceName: service,
-		NumUnits:    numUnits,
-		Placement:   placement,
+func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return nil, errors.New("cannot attach existing storage when more than one unit is requested")
+	}
+	attachStorage, err := storageTags(args.AttachStorage)
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	results := new(params.AddServiceUnitsResults)
-	err := c.facade.FacadeCall("AddUnits", args, results)
+	err = c.facade.FacadeCall("AddUnits", params.AddServiceUnits{
+		ServiceName:   args.ServiceName,
+		NumUnits:      args.NumUnits,
+		Placement:     args.Placement,
+		AttachStorage: attachStorage,
+	}, results)
 	return results.Units, err
 }
 
+// storageTags converts storage IDs to storage tag strings.
+func storageTags(storageIds []string) ([]string, error) {
+	if len(storageIds) == 0 {
+		return nil, nil
+	}
+	tags := make([]string, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		tags[i] = names.NewStorageTag(id).String()
+	}
+	return tags, nil
+}
+
 // DestroyUnits decreases the number of units dedicated to a service.
 func (c *Client) DestroyUnits(unitNames ...string) error {
 	params := params.DestroyServiceUnits{unitNames}
@@ -217,6 +283,23 @@ func (c *Client) Expose(service string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, but only to the given
+// CIDRs and the subnets of the given spaces. It fails against servers
+// older than Service facade version 4, which would otherwise ignore the
+// restrictions and expose the service to everyone.
+func (c *Client) ExposeTo(service string, cidrs, spaces []string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("exposing to CIDRs or spaces on this controller (need Service facade v4+)")
+	}
+	params := params.ServiceExpose{
+		ServiceName: service,
+		ToCIDRs:     cidrs,
+		ToSpaces:    spaces,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(service string) error {
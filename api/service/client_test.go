@@ -4,6 +4,7 @@
 package service_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -76,6 +77,27 @@ func (s *serviceSuite) TestSetServiceMetricCredentialsNoMocks(c *gc.C) {
 	c.Assert(service.MetricCredentials(), gc.DeepEquals, []byte("creds"))
 }
 
+func (s *serviceSuite) TestExposeTo(c *gc.C) {
+	service := s.Factory.MakeService(c, nil)
+	err := s.client.ExposeTo(service.Name(), []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = service.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.IsExposed(), jc.IsTrue)
+	c.Assert(service.ExposedToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
+func (s *serviceSuite) TestExposeToOldServer(c *gc.C) {
+	service.PatchBestAPIVersion(s, s.client, 3)
+	svc := s.Factory.MakeService(c, nil)
+	err := s.client.ExposeTo(svc.Name(), []string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `exposing to CIDRs or spaces on this controller \(need Service facade v4\+\) not supported`)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsFalse)
+}
+
 func (s *serviceSuite) TestSetServiceDeploy(c *gc.C) {
 	var called bool
 	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -119,6 +141,43 @@ func (s *serviceSuite) TestSetServiceDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestAddUnitsAttachStorage(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "AddUnits")
+		c.Assert(a, jc.DeepEquals, params.AddServiceUnits{
+			ServiceName:   "foo",
+			NumUnits:      1,
+			AttachStorage: []string{"storage-data-0"},
+		})
+		result := response.(*params.AddServiceUnitsResults)
+		result.Units = []string{"foo/0"}
+		return nil
+	})
+	units, err := s.client.AddUnits(service.AddUnitsParams{
+		ServiceName:   "foo",
+		NumUnits:      1,
+		AttachStorage: []string{"data/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"foo/0"})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := s.client.AddUnits(service.AddUnitsParams{
+		ServiceName:   "foo",
+		NumUnits:      2,
+		AttachStorage: []string{"data/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot attach existing storage when more than one unit is requested")
+}
+
 func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	var called bool
 	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -138,6 +197,33 @@ func (s *serviceSuite) TestServiceGetCharmURL(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ExportBundle")
+		c.Assert(a, gc.IsNil)
+
+		result := response.(*params.StringResult)
+		result.Result = "services: {}\n"
+		return nil
+	})
+	bundle, err := s.client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.Equals, "services: {}\n")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestExportBundleError(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		result := response.(*params.StringResult)
+		result.Error = common.ServerError(common.ErrPerm)
+		return nil
+	})
+	_, err := s.client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	var called bool
 	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
@@ -137,8 +137,15 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 	return results.Results, nil
 }
 
-// AddToUnit adds specified storage to desired units.
+// AddToUnit adds specified storage to desired units. Storage may only
+// be created from a snapshot on servers with Storage facade version 3
+// or later, as older servers ignore the snapshot.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+	for _, s := range storages {
+		if s.Snapshot != "" && c.facade.BestAPIVersion() < 3 {
+			return nil, errors.NotSupportedf("adding storage from a snapshot on this controller (need Storage facade v3+)")
+		}
+	}
 	out := params.ErrorResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -147,3 +154,86 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorRe
 	}
 	return out.Results, nil
 }
+
+// Attach attaches existing storage instances to a unit.
+func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("attaching storage on this controller (need Storage facade v3+)")
+	}
+	if !names.IsValidUnit(unitId) {
+		return nil, errors.NotValidf("unit ID %q", unitId)
+	}
+	in := params.StorageAttachmentIds{make([]params.StorageAttachmentId, len(storageIds))}
+	for i, storageId := range storageIds {
+		if !names.IsValidStorage(storageId) {
+			return nil, errors.NotValidf("storage ID %q", storageId)
+		}
+		in.Ids[i] = params.StorageAttachmentId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			UnitTag:    names.NewUnitTag(unitId).String(),
+		}
+	}
+	out := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Attach", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(out.Results),
+		)
+	}
+	return out.Results, nil
+}
+
+// Detach detaches storage instances from the units they are
+// attached to.
+func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("detaching storage on this controller (need Storage facade v3+)")
+	}
+	in := params.Entities{make([]params.Entity, len(storageIds))}
+	for i, storageId := range storageIds {
+		if !names.IsValidStorage(storageId) {
+			return nil, errors.NotValidf("storage ID %q", storageId)
+		}
+		in.Entities[i].Tag = names.NewStorageTag(storageId).String()
+	}
+	out := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Detach", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(out.Results),
+		)
+	}
+	return out.Results, nil
+}
+
+// CreateSnapshot requests snapshots of the specified volumes, returning
+// the ID of each requested snapshot.
+func (c *Client) CreateSnapshot(volumeIds []string) ([]params.StringResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("creating snapshots on this controller (need Storage facade v3+)")
+	}
+	in := params.Entities{make([]params.Entity, len(volumeIds))}
+	for i, volumeId := range volumeIds {
+		if !names.IsValidVolume(volumeId) {
+			return nil, errors.NotValidf("volume ID %q", volumeId)
+		}
+		in.Entities[i].Tag = names.NewVolumeTag(volumeId).String()
+	}
+	out := params.StringResults{}
+	if err := c.facade.FacadeCall("CreateSnapshot", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(volumeIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(volumeIds), len(out.Results),
+		)
+	}
+	return out.Results, nil
+}
@@ -581,3 +581,221 @@ func (s *storageMockSuite) TestAddToUnitFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(found, gc.HasLen, 0)
 }
+
+func (s *storageMockSuite) TestAttach(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Attach")
+			c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{[]params.StorageAttachmentId{
+				{StorageTag: "storage-data-0", UnitTag: "unit-mysql-1"},
+				{StorageTag: "storage-logs-1", UnitTag: "unit-mysql-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "boom"}},
+				},
+			}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Attach("mysql/1", []string{"data/0", "logs/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *storageMockSuite) TestAttachInvalidIds(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Attach("mysql", []string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, `unit ID "mysql" not valid`)
+	_, err = storageClient.Attach("mysql/1", []string{"data"})
+	c.Assert(err, gc.ErrorMatches, `storage ID "data" not valid`)
+}
+
+func (s *storageMockSuite) TestDetach(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Detach")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{Tag: "storage-data-0"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.Detach([]string{"data/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *storageMockSuite) TestDetachArityMismatch(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {}},
+			}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Detach([]string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, `expected 1 result\(s\), got 2`)
+}
+
+func (s *storageMockSuite) TestAttachOldServer(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Attach("mysql/1", []string{"data/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `attaching storage on this controller \(need Storage facade v3\+\) not supported`)
+}
+
+func (s *storageMockSuite) TestDetachOldServer(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.Detach([]string{"data/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `detaching storage on this controller \(need Storage facade v3\+\) not supported`)
+}
+
+func (s *storageMockSuite) TestCreateSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateSnapshot")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{Tag: "volume-0-1"},
+				{Tag: "volume-2"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{Result: "0/3"}, {Result: "4"}},
+			}
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	results, err := storageClient.CreateSnapshot([]string{"0/1", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StringResult{{Result: "0/3"}, {Result: "4"}})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotInvalidVolumeId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateSnapshot([]string{"data/0"})
+	c.Assert(err, gc.ErrorMatches, `volume ID "data/0" not valid`)
+}
+
+func (s *storageMockSuite) TestCreateSnapshotOldServer(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.CreateSnapshot([]string{"0/1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `creating snapshots on this controller \(need Storage facade v3\+\) not supported`)
+}
+
+func (s *storageMockSuite) TestAddToUnitFromSnapshotOldServer(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	storageClient := storage.NewClient(apiCaller)
+	_, err := storageClient.AddToUnit([]params.StorageAddParams{
+		{UnitTag: "unit-mysql-1", StorageName: "data", Snapshot: "4"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `adding storage from a snapshot on this controller \(need Storage facade v3\+\) not supported`)
+}
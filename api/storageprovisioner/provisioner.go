@@ -94,6 +94,12 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeSnapshots watches for lifecycle changes to snapshots of
+// volumes scoped to the entity with the tag passed to NewState.
+func (st *State) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -250,6 +256,21 @@ func (st *State) VolumeParams(tags []names.VolumeTag) ([]params.VolumeParamsResu
 	return results.Results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs.
+func (st *State) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotIds{Ids: ids}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(ids) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(ids), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -314,6 +335,21 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshots{VolumeSnapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
@@ -71,6 +71,31 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchVolumeSnapshots")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"machine-123"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeSnapshots()
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
 func (s *provisionerSuite) TestWatchFilesystems(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -398,6 +423,46 @@ func (s *provisionerSuite) TestVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "VolumeSnapshotParams")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshotIds{Ids: []string{"123/0"}})
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeSnapshotParamsResults{})
+		*(result.(*params.VolumeSnapshotParamsResults)) = params.VolumeSnapshotParamsResults{
+			Results: []params.VolumeSnapshotParamsResult{{
+				Result: params.VolumeSnapshotParams{
+					Snapshot:  "123/0",
+					VolumeTag: "volume-123-100",
+					VolumeId:  "vol-100",
+					Size:      1024,
+					Provider:  "loop",
+				},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotParams, err := st.VolumeSnapshotParams([]string{"123/0"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(snapshotParams, jc.DeepEquals, []params.VolumeSnapshotParamsResult{{
+		Result: params.VolumeSnapshotParams{
+			Snapshot:  "123/0",
+			VolumeTag: "volume-123-100",
+			VolumeId:  "vol-100",
+			Size:      1024,
+			Provider:  "loop",
+		},
+	}})
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -552,6 +617,44 @@ func (s *provisionerSuite) TestSetVolumeInfo(c *gc.C) {
 	c.Assert(errorResults[0].Error, gc.IsNil)
 }
 
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetVolumeSnapshotInfo")
+		c.Check(arg, gc.DeepEquals, params.VolumeSnapshots{
+			VolumeSnapshots: []params.VolumeSnapshot{{
+				Snapshot:  "123/0",
+				VolumeTag: "volume-123-100",
+				Info: params.VolumeSnapshotInfo{
+					SnapshotId: "snap-0",
+					Size:       1024,
+				},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: nil}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := st.SetVolumeSnapshotInfo([]params.VolumeSnapshot{{
+		Snapshot:  "123/0",
+		VolumeTag: "volume-123-100",
+		Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024},
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(errorResults, gc.HasLen, 1)
+	c.Assert(errorResults[0].Error, gc.IsNil)
+}
+
 func (s *provisionerSuite) TestSetFilesystemInfo(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -4,6 +4,9 @@
 package uniter_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -79,6 +82,61 @@ func (s *actionSuite) TestNewActionAndAccessors(c *gc.C) {
 	c.Assert(testParams, gc.DeepEquals, basicParams)
 }
 
+func (s *actionSuite) TestActionTimeout(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddActionWithTimeout("fakeaction", nil, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrievedAction, err := s.uniter.Action(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retrievedAction.Timeout(), gc.Equals, time.Minute)
+}
+
+func (s *actionSuite) TestActionCancelRequested(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cancelled, err := s.uniter.ActionCancelRequested(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled, jc.IsFalse)
+
+	_, err = a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cancelled, err = s.uniter.ActionCancelRequested(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled, jc.IsTrue)
+}
+
+func (s *actionSuite) TestActionLogMessage(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.ActionLogMessage(a.ActionTag(), "too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	err = s.uniter.ActionBegin(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionLogMessage(a.ActionTag(), "halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "halfway there")
+}
+
+func (s *actionSuite) TestActionLogMessageNotSupported(c *gc.C) {
+	a, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.patchNewState(c, uniter.NewStateV3)
+
+	err = s.uniter.ActionLogMessage(a.ActionTag(), "halfway there")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *actionSuite) TestActionComplete(c *gc.C) {
 	completed, err := s.uniterSuite.wordpressUnit.CompletedActions()
 	c.Assert(err, jc.ErrorIsNil)
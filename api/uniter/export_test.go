@@ -14,6 +14,7 @@ import (
 
 var (
 	NewSettings = newSettings
+	NewStateV3  = newStateV3
 )
 
 // PatchUnitResponse changes the internal FacadeCaller to one that lets you return
@@ -126,7 +126,11 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		if action.Timeout < 0 {
+			currentResult.Error = common.ServerError(errors.NotValidf("negative timeout %v", action.Timeout))
+			continue
+		}
+		enqueued, err := receiver.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -137,6 +141,76 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// EnqueueOnServices takes a list of ServiceActions and queues each one
+// up on every unit of the designated service, or on its leader only if
+// LeaderOnly is set. The results are grouped by service, with one
+// result for each unit.
+func (a *ActionAPI) EnqueueOnServices(arg params.ServiceActions) (params.ActionsByReceivers, error) {
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionsByReceivers{}, errors.Trace(err)
+	}
+
+	response := params.ActionsByReceivers{Actions: make([]params.ActionsByReceiver, len(arg.Actions))}
+	for i, action := range arg.Actions {
+		currentResult := &response.Actions[i]
+		svcTag, err := names.ParseServiceTag(action.ServiceTag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		currentResult.Receiver = svcTag.String()
+		if action.Timeout < 0 {
+			currentResult.Error = common.ServerError(errors.NotValidf("negative timeout %v", action.Timeout))
+			continue
+		}
+		units, err := a.serviceActionUnits(svcTag.Id(), action.LeaderOnly)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		currentResult.Actions = make([]params.ActionResult, len(units))
+		for j, unit := range units {
+			enqueued, err := unit.AddActionWithTimeout(action.Name, action.Parameters, action.Timeout)
+			if err != nil {
+				currentResult.Actions[j] = params.ActionResult{
+					Action: &params.Action{Receiver: unit.Tag().String(), Name: action.Name},
+					Error:  common.ServerError(err),
+				}
+				continue
+			}
+			currentResult.Actions[j] = common.MakeActionResult(unit.Tag(), enqueued)
+		}
+	}
+	return response, nil
+}
+
+// serviceActionUnits returns the units of the named service on which a
+// service-level action should be queued.
+func (a *ActionAPI) serviceActionUnits(serviceName string, leaderOnly bool) ([]*state.Unit, error) {
+	svc, err := a.state.Service(serviceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := svc.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("service %q has no units", serviceName)
+	}
+	if !leaderOnly {
+		return units, nil
+	}
+	checker := a.state.LeadershipChecker()
+	for _, unit := range units {
+		token := checker.LeadershipCheck(serviceName, unit.Name())
+		if err := token.Check(nil); err == nil {
+			return []*state.Unit{unit}, nil
+		}
+	}
+	return nil, errors.NotFoundf("leader of service %q", serviceName)
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
@@ -165,7 +239,9 @@ func (a *ActionAPI) ListCompleted(arg params.Entities) (params.ActionsByReceiver
 	return a.internalList(arg, completedActions)
 }
 
-// Cancel attempts to cancel enqueued Actions from running.
+// Cancel cancels the given Actions. Pending Actions are cancelled
+// immediately; running Actions are stopped by their receivers, which
+// then record them as cancelled.
 func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -189,7 +265,7 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		result, err := action.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled via the API"})
+		result, err := action.Cancel()
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -6,6 +6,7 @@ package action_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -277,6 +278,89 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithTimeout(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Timeout: time.Minute},
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Timeout: -time.Minute},
+		},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action.Timeout, gc.Equals, time.Minute)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "negative timeout -1m0s not valid")
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Timeout(), gc.Equals, time.Minute)
+}
+
+func (s *actionSuite) TestEnqueueOnServices(c *gc.C) {
+	factory := jujuFactory.NewFactory(s.State)
+	otherUnit := factory.MakeUnit(c, &jujuFactory.UnitParams{
+		Service: s.wordpress,
+		Machine: s.machine0,
+	})
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", otherUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.ServiceActions{
+		Actions: []params.ServiceAction{
+			{ServiceTag: s.wordpress.Tag().String(), Name: "fakeaction", Timeout: time.Minute},
+			{ServiceTag: s.wordpress.Tag().String(), Name: "fakeaction", LeaderOnly: true},
+			{ServiceTag: s.dummy.Tag().String(), Name: "fakeaction"},
+			{ServiceTag: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+		},
+	}
+	res, err := s.action.EnqueueOnServices(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Actions, gc.HasLen, 4)
+
+	all := res.Actions[0]
+	c.Assert(all.Error, gc.IsNil)
+	c.Assert(all.Receiver, gc.Equals, s.wordpress.Tag().String())
+	c.Assert(all.Actions, gc.HasLen, 2)
+	receivers := []string{all.Actions[0].Action.Receiver, all.Actions[1].Action.Receiver}
+	c.Assert(receivers, jc.SameContents, []string{
+		s.wordpressUnit.Tag().String(),
+		otherUnit.Tag().String(),
+	})
+	for _, result := range all.Actions {
+		c.Assert(result.Error, gc.IsNil)
+		c.Assert(result.Status, gc.Equals, params.ActionPending)
+		c.Assert(result.Action.Timeout, gc.Equals, time.Minute)
+	}
+
+	leader := res.Actions[1]
+	c.Assert(leader.Error, gc.IsNil)
+	c.Assert(leader.Actions, gc.HasLen, 1)
+	c.Assert(leader.Actions[0].Action.Receiver, gc.Equals, otherUnit.Tag().String())
+
+	c.Assert(res.Actions[2].Error, gc.ErrorMatches, `service "dummy" has no units`)
+	c.Assert(res.Actions[3].Error, gc.ErrorMatches, "id not found")
+
+	actions, err := otherUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+	actions, err = s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+}
+
+func (s *actionSuite) TestEnqueueOnServicesBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestEnqueueOnServicesBlocked")
+	_, err := s.action.EnqueueOnServices(params.ServiceActions{
+		Actions: []params.ServiceAction{
+			{ServiceTag: s.wordpress.Tag().String(), Name: "fakeaction"},
+		},
+	})
+	s.AssertBlocked(c, err, "TestEnqueueOnServicesBlocked")
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -634,6 +718,31 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestCancelRunning(c *gc.C) {
+	action, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: action.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Status, gc.Equals, params.ActionRunning)
+	c.Assert(results.Results[0].CancelRequested, jc.IsTrue)
+
+	// Once the unit has stopped it, the action can't be cancelled again.
+	_, err = s.wordpressUnit.CancelAction(action)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err = s.action.Cancel(params.Entities{
+		Entities: []params.Entity{{Tag: action.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot cancel action ".*": action is already cancelled`)
+}
+
 func (s *actionSuite) TestServicesCharmActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
@@ -114,10 +114,12 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		// can then check the credentials against the controller model
 		// machine.
 		if kind != names.MachineTagKind {
+			apiLoginFailures.Inc()
 			return fail, errors.Trace(err)
 		}
 		entity, err = a.checkCredsOfControllerMachine(req)
 		if err != nil {
+			apiLoginFailures.Inc()
 			return fail, errors.Trace(err)
 		}
 		// If we are here, then the entity will refer to a controller
@@ -201,6 +203,12 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		authedApi = newClientAuthRoot(authedApi, envUser)
 	}
 
+	// Record every call made by a user that might change the state
+	// of the controller or model, including those that are refused.
+	if isUser {
+		authedApi = newAuditingRoot(authedApi, a.root.state, entity.Tag().(names.UserTag))
+	}
+
 	a.root.rpcConn.ServeFinder(authedApi, serverError)
 
 	return loginResult, nil
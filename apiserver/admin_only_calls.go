@@ -0,0 +1,42 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+)
+
+// adminOnlyCalls specify the API calls that a user with write access
+// to a model may not make, as they destroy or upgrade the model,
+// remove the blocks protecting it, change its configuration (which, for
+// the controller model, points backups, log forwarding and metrics at
+// external endpoints), or act on the controller itself (backups,
+// restore, HA and cached images). The format of the calls
+// is "<facade>.<method>". As with readOnlyCalls, we are explicitly
+// ignoring the facade version.
+var adminOnlyCalls = set.NewStrings(
+	"Backups.Create",
+	"Backups.FinishRestore",
+	"Backups.Info",
+	"Backups.List",
+	"Backups.PrepareRestore",
+	"Backups.Remove",
+	"Backups.Restore",
+	"Block.SwitchBlockOff",
+	"Client.AbortCurrentUpgrade",
+	"Client.DestroyModel",
+	"Client.ModelSet",
+	"Client.ModelUnset",
+	"Client.SetModelAgentVersion",
+	"HighAvailability.EnableHA",
+	"HighAvailability.ResumeHAReplicationAfterUpgrade",
+	"HighAvailability.StopHAReplicationForUpgrade",
+	"ImageManager.DeleteImages",
+)
+
+// isCallAdminOnly returns whether or not the method on the facade
+// requires admin access to the model.
+func isCallAdminOnly(facade, method string) bool {
+	return adminOnlyCalls.Contains(facade + "." + method)
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+type adminOnlyCallsSuite struct {
+}
+
+var _ = gc.Suite(&adminOnlyCallsSuite{})
+
+func (*adminOnlyCallsSuite) TestAdminOnlyCallsExist(c *gc.C) {
+	// Iterate through the list of adminOnlyCalls and make sure
+	// that the facades are reachable.
+	maxVersion := map[string]int{}
+	for _, facade := range common.Facades.List() {
+		for _, ver := range facade.Versions {
+			if ver > maxVersion[facade.Name] {
+				maxVersion[facade.Name] = ver
+			}
+		}
+	}
+
+	for _, name := range adminOnlyCalls.Values() {
+		parts := strings.Split(name, ".")
+		facade, method := parts[0], parts[1]
+		_, _, err := lookupMethod(facade, maxVersion[facade], method)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*adminOnlyCallsSuite) TestAdminOnlyCall(c *gc.C) {
+	c.Check(isCallAdminOnly("Client", "DestroyModel"), jc.IsTrue)
+	c.Check(isCallAdminOnly("Backups", "Create"), jc.IsTrue)
+	c.Check(isCallAdminOnly("Backups", "Restore"), jc.IsTrue)
+	c.Check(isCallAdminOnly("HighAvailability", "EnableHA"), jc.IsTrue)
+	c.Check(isCallAdminOnly("ImageManager", "DeleteImages"), jc.IsTrue)
+	c.Check(isCallAdminOnly("ImageManager", "ListImages"), jc.IsFalse)
+	c.Check(isCallAdminOnly("Service", "Deploy"), jc.IsFalse)
+	c.Check(isCallAdminOnly("Action", "Enqueue"), jc.IsFalse)
+}
+
+func (*adminOnlyCallsSuite) TestAllBackupsCallsAdminOnly(c *gc.C) {
+	// Backups hold the whole controller's data; no Backups call may be
+	// made by a user with only write access to a model.
+	facade, err := common.Facades.GetType("Backups", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < facade.NumMethod(); i++ {
+		method := facade.Method(i).Name
+		c.Check(isCallAdminOnly("Backups", method), jc.IsTrue, gc.Commentf("Backups.%s", method))
+	}
+}
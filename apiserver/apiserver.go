@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/apihttp"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instrument"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
@@ -290,6 +291,12 @@ func (n *requestNotifier) ServerRequest(hdr *rpc.Header, body interface{}) {
 }
 
 func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}, timeSpent time.Duration) {
+	facade, method := requestMetricLabels(req)
+	apiRequests.With(facade, method).Inc()
+	apiRequestDuration.With(facade, method).Observe(timeSpent.Seconds())
+	if hdr.Error != "" {
+		apiRequestErrors.With(facade, method).Inc()
+	}
 	if req.Type == "Pinger" && req.Action == "Ping" {
 		return
 	}
@@ -305,11 +312,13 @@ func (n *requestNotifier) ServerReply(req rpc.Request, hdr *rpc.Header, body int
 
 func (n *requestNotifier) join(req *http.Request) {
 	active := atomic.AddInt32(n.count, 1)
+	apiConnections.Inc()
 	logger.Infof("[%X] API connection from %s, active connections: %d", n.id, req.RemoteAddr, active)
 }
 
 func (n *requestNotifier) leave() {
 	active := atomic.AddInt32(n.count, -1)
+	apiConnections.Dec()
 	logger.Infof("[%X] %s API connection terminated after %v, active connections: %d", n.id, n.tag(), time.Since(n.start), active)
 }
 
@@ -413,6 +422,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: strictCtxt,
 		},
 	)
+	add("/model/:modeluuid/migrate/resources",
+		&migrationResourcesHandler{
+			ctxt: httpCtxt,
+		},
+	)
 	add("/model/:modeluuid/api", mainAPIHandler)
 
 	add("/model/:modeluuid/images/:kind/:series/:arch/:filename",
@@ -456,6 +470,15 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			srv.authCtxt.userAuth.CreateLocalLoginMacaroon,
 		},
 	)
+	controllerCtxt := httpCtxt
+	controllerCtxt.controllerModelOnly = true
+	add("/metrics", &unitMetricsHandler{
+		ctxt: controllerCtxt,
+	})
+	add("/controller-metrics", &controllerMetricsHandler{
+		ctxt:     controllerCtxt,
+		registry: instrument.Default,
+	})
 	add("/", mainAPIHandler)
 
 	return endpoints
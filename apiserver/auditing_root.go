@@ -0,0 +1,218 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+// maxAuditArgsLength is the maximum length of the argument summary
+// recorded with each audit entry.
+const maxAuditArgsLength = 1024
+
+// redactedValue replaces the value of each secret argument field
+// recorded in the audit log.
+const redactedValue = "<redacted>"
+
+// secretFieldSubstrings lists the substrings which mark an argument
+// field, matched case-insensitively and ignoring "-" and "_", as
+// holding credentials or other secrets.
+var secretFieldSubstrings = []string{
+	"password",
+	"secret",
+	"credential",
+	"macaroon",
+	"token",
+}
+
+// configFieldNames lists the normalised names of argument fields which
+// hold configuration values. Configuration often holds passwords and
+// keys, so the values are never recorded.
+var configFieldNames = set.NewStrings(
+	"attrs",
+	"config",
+	"configyaml",
+	"options",
+	"settings",
+	"settingsstrings",
+	"settingsyaml",
+	"values",
+)
+
+// isSecretField returns whether the argument field with the given
+// name may hold a secret.
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	name = strings.Replace(name, "-", "", -1)
+	name = strings.Replace(name, "_", "", -1)
+	if configFieldNames.Contains(name) || strings.HasSuffix(name, "key") {
+		return true
+	}
+	for _, substring := range secretFieldSubstrings {
+		if strings.Contains(name, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecrets replaces the values of all secret fields in the
+// decoded JSON value v.
+func redactSecrets(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			if value != nil && isSecretField(name) {
+				v[name] = redactedValue
+			} else {
+				v[name] = redactSecrets(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactSecrets(value)
+		}
+	}
+	return v
+}
+
+// auditEntryRecorder describes the state method used to record audit
+// entries.
+type auditEntryRecorder interface {
+	PutAuditEntry(audit.AuditEntry) error
+}
+
+// auditingRoot records every call made by a user that may modify the
+// database, whether or not the call succeeds.
+type auditingRoot struct {
+	finder    rpc.MethodFinder
+	recorder  auditEntryRecorder
+	user      names.UserTag
+	modelUUID string
+}
+
+// newAuditingRoot returns a new auditingRoot that records calls made
+// by the given user against the given model.
+func newAuditingRoot(finder rpc.MethodFinder, st *state.State, user names.UserTag) *auditingRoot {
+	return &auditingRoot{
+		finder:    finder,
+		recorder:  st,
+		user:      user,
+		modelUUID: st.ModelUUID(),
+	}
+}
+
+// FindMethod implements rpc.MethodFinder. Calls which are not audited
+// are passed through untouched; all other calls are
+// recorded once they complete. Calls which are rejected because the
+// user lacks permission are recorded immediately.
+func (r *auditingRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.finder.FindMethod(rootName, version, methodName)
+	if !isCallAudited(rootName, methodName) {
+		return caller, err
+	}
+	if err != nil {
+		if errors.Cause(err) == common.ErrPerm {
+			r.record(rootName, version, methodName, reflect.Value{}, err)
+		}
+		return nil, err
+	}
+	return &auditingCaller{
+		MethodCaller: caller,
+		root:         r,
+		rootName:     rootName,
+		version:      version,
+		methodName:   methodName,
+	}, nil
+}
+
+// isCallAudited returns whether calls to the method on the facade
+// should be recorded in the audit log. Read only calls, and calls that
+// only maintain the connection itself, such as pings and watcher
+// iteration, are not recorded.
+func isCallAudited(facade, method string) bool {
+	if facade == "Pinger" || strings.HasSuffix(facade, "Watcher") {
+		return false
+	}
+	return !isCallReadOnly(facade, method)
+}
+
+// record writes an audit entry for a single call. Failure to record
+// the entry is logged, but does not affect the outcome of the call.
+func (r *auditingRoot) record(rootName string, version int, methodName string, arg reflect.Value, callErr error) {
+	entry := audit.AuditEntry{
+		Timestamp: state.GetClock().Now().UTC(),
+		UserTag:   r.user,
+		ModelUUID: r.modelUUID,
+		Facade:    rootName,
+		Version:   version,
+		Method:    methodName,
+		Args:      summariseArgs(arg),
+	}
+	if callErr != nil {
+		entry.Error = callErr.Error()
+	}
+	if err := r.recorder.PutAuditEntry(entry); err != nil {
+		logger.Warningf("cannot record audit entry for %s.%s by %s: %v", rootName, methodName, r.user.Id(), err)
+	}
+}
+
+// summariseArgs returns a JSON representation of the call arguments,
+// with the values of secret fields redacted, truncated to
+// maxAuditArgsLength.
+func summariseArgs(arg reflect.Value) string {
+	if !arg.IsValid() {
+		return ""
+	}
+	data, err := json.Marshal(arg.Interface())
+	if err != nil {
+		return ""
+	}
+	// Decode into generic values so that secrets are found however
+	// deeply they are nested, whatever the facade.
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return ""
+	}
+	data, err = json.Marshal(redactSecrets(decoded))
+	if err != nil {
+		return ""
+	}
+	if len(data) > maxAuditArgsLength {
+		return string(data[:maxAuditArgsLength]) + "..."
+	}
+	return string(data)
+}
+
+// auditingCaller wraps a MethodCaller so that the outcome of each call
+// is recorded in the audit log.
+type auditingCaller struct {
+	rpcreflect.MethodCaller
+	root       *auditingRoot
+	rootName   string
+	version    int
+	methodName string
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c *auditingCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	result, err := c.MethodCaller.Call(objId, arg)
+	c.root.record(c.rootName, c.version, c.methodName, arg, err)
+	return result, err
+}
@@ -0,0 +1,255 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+type auditingRootSuite struct {
+	recorder *fakeAuditRecorder
+	finder   *auditFakeFinder
+	root     *auditingRoot
+}
+
+var _ = gc.Suite(&auditingRootSuite{})
+
+const auditModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *auditingRootSuite) SetUpTest(c *gc.C) {
+	s.recorder = &fakeAuditRecorder{}
+	s.finder = &auditFakeFinder{}
+	s.root = &auditingRoot{
+		finder:    s.finder,
+		recorder:  s.recorder,
+		user:      names.NewUserTag("bob"),
+		modelUUID: auditModelUUID,
+	}
+}
+
+func (s *auditingRootSuite) call(c *gc.C, rootName, methodName string, arg interface{}) error {
+	caller, err := s.root.FindMethod(rootName, 1, methodName)
+	if err != nil {
+		return err
+	}
+	_, err = caller.Call("", reflect.ValueOf(arg))
+	return err
+}
+
+func (s *auditingRootSuite) TestReadOnlyCallNotRecorded(c *gc.C) {
+	err := s.call(c, "Client", "FullStatus", params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.recorder.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestConnectionMaintenanceNotRecorded(c *gc.C) {
+	err := s.call(c, "Pinger", "Ping", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.call(c, "AllWatcher", "Next", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.recorder.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestSuccessfulCallRecorded(c *gc.C) {
+	arg := params.Entities{Entities: []params.Entity{{Tag: "service-mysql"}}}
+	err := s.call(c, "Service", "Expose", arg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	entry := s.recorder.entries[0]
+	c.Check(entry.Timestamp.IsZero(), jc.IsFalse)
+	c.Check(entry.UserTag, gc.Equals, names.NewUserTag("bob"))
+	c.Check(entry.ModelUUID, gc.Equals, auditModelUUID)
+	c.Check(entry.Facade, gc.Equals, "Service")
+	c.Check(entry.Version, gc.Equals, 1)
+	c.Check(entry.Method, gc.Equals, "Expose")
+	c.Check(entry.Args, gc.Equals, `{"Entities":[{"Tag":"service-mysql"}]}`)
+	c.Check(entry.Error, gc.Equals, "")
+}
+
+func (s *auditingRootSuite) TestFailedCallRecorded(c *gc.C) {
+	s.finder.callErr = errors.New("boom")
+	err := s.call(c, "Service", "Expose", params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Error, gc.Equals, "boom")
+}
+
+func (s *auditingRootSuite) TestPermissionDeniedRecorded(c *gc.C) {
+	s.finder.findErr = common.ErrPerm
+	err := s.call(c, "Service", "Expose", params.Entities{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	entry := s.recorder.entries[0]
+	c.Check(entry.Method, gc.Equals, "Expose")
+	c.Check(entry.Args, gc.Equals, "")
+	c.Check(entry.Error, gc.Equals, common.ErrPerm.Error())
+}
+
+func (s *auditingRootSuite) TestUnknownMethodNotRecorded(c *gc.C) {
+	s.finder.findErr = &rpcreflect.CallNotImplementedError{RootMethod: "Service"}
+	err := s.call(c, "Service", "Unknown", params.Entities{})
+	c.Assert(err, gc.NotNil)
+	c.Assert(s.recorder.entries, gc.HasLen, 0)
+}
+
+func (s *auditingRootSuite) TestPasswordsRedacted(c *gc.C) {
+	arg := params.EntityPasswords{Changes: []params.EntityPassword{{
+		Tag:      "user-bob",
+		Password: "sekrit",
+	}}}
+	err := s.call(c, "UserManager", "SetPassword", arg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Args, gc.Equals, `{"Changes":[{"Password":"<redacted>","Tag":"user-bob"}]}`)
+}
+
+func (s *auditingRootSuite) TestServiceConfigRedacted(c *gc.C) {
+	err := s.call(c, "Service", "Set", params.ServiceSet{
+		ServiceName: "mysql",
+		Options:     map[string]string{"password": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.call(c, "Service", "Update", params.ServiceUpdate{
+		ServiceName:     "mysql",
+		SettingsStrings: map[string]string{"password": "sekrit"},
+		SettingsYAML:    "mysql:\n  password: sekrit\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.call(c, "Client", "ModelSet", params.ModelSet{
+		Config: map[string]interface{}{"secret-key": "sekrit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 3)
+	for _, entry := range s.recorder.entries {
+		c.Check(entry.Args, gc.Not(jc.Contains), "sekrit")
+	}
+	c.Check(s.recorder.entries[0].Args, gc.Equals, `{"Options":"<redacted>","ServiceName":"mysql"}`)
+}
+
+func (s *auditingRootSuite) TestCredentialsRedacted(c *gc.C) {
+	err := s.call(c, "Service", "SetMetricCredentials", params.ServiceMetricCredentials{
+		Creds: []params.ServiceMetricCredential{{
+			ServiceName:       "mysql",
+			MetricCredentials: []byte("sekrit"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mac, err := macaroon.New([]byte("root-key"), "id", "location")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.call(c, "Client", "AddCharmWithAuthorization", params.AddCharmWithAuthorization{
+		URL:                "cs:mysql",
+		CharmStoreMacaroon: mac,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 2)
+	c.Check(s.recorder.entries[0].Args, gc.Equals, `{"Creds":[{"MetricCredentials":"<redacted>","ServiceName":"mysql"}]}`)
+	c.Check(s.recorder.entries[1].Args, gc.Matches, `.*"CharmStoreMacaroon":"<redacted>".*`)
+	c.Check(s.recorder.entries[1].Args, jc.Contains, `"URL":"cs:mysql"`)
+}
+
+func (s *auditingRootSuite) TestBackupEncryptionKeyRedacted(c *gc.C) {
+	// The audit log is itself included in backups, so the key must
+	// never be recorded alongside the archives it protects.
+	err := s.call(c, "Backups", "Create", params.BackupsCreateArgs{
+		Notes:         "nightly",
+		EncryptionKey: "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	entry := s.recorder.entries[0]
+	c.Check(entry.Facade, gc.Equals, "Backups")
+	c.Check(entry.Method, gc.Equals, "Create")
+	c.Check(entry.Args, gc.Not(jc.Contains), "sekrit")
+	c.Check(entry.Args, gc.Equals, `{"EncryptionKey":"<redacted>","EncryptionPublicKey":"<redacted>","Notes":"nightly"}`)
+}
+
+func (s *auditingRootSuite) TestIsSecretField(c *gc.C) {
+	for _, name := range []string{
+		"Password", "password", "secret-key", "access_key", "EncryptionKey",
+		"MetricCredentials", "credentials", "CharmStoreMacaroon", "Config",
+		"ConfigYAML", "Options", "SettingsStrings", "token",
+	} {
+		c.Check(isSecretField(name), jc.IsTrue, gc.Commentf("%s", name))
+	}
+	for _, name := range []string{"Tag", "ServiceName", "URL", "Keys", "Entities"} {
+		c.Check(isSecretField(name), jc.IsFalse, gc.Commentf("%s", name))
+	}
+}
+
+func (s *auditingRootSuite) TestLongArgsTruncated(c *gc.C) {
+	arg := params.Entities{Entities: []params.Entity{{Tag: strings.Repeat("x", 2*maxAuditArgsLength)}}}
+	err := s.call(c, "Service", "Expose", arg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Args, gc.HasLen, maxAuditArgsLength+len("..."))
+}
+
+func (s *auditingRootSuite) TestRecordFailureDoesNotFailCall(c *gc.C) {
+	s.recorder.err = errors.New("mongo is sad")
+	err := s.call(c, "Service", "Expose", params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeAuditRecorder struct {
+	entries []audit.AuditEntry
+	err     error
+}
+
+func (r *fakeAuditRecorder) PutAuditEntry(entry audit.AuditEntry) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// auditFakeFinder returns callers which do nothing except return the
+// configured error.
+type auditFakeFinder struct {
+	findErr error
+	callErr error
+}
+
+func (f *auditFakeFinder) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	return auditFakeCaller{f.callErr}, nil
+}
+
+type auditFakeCaller struct {
+	err error
+}
+
+func (auditFakeCaller) ParamsType() reflect.Type {
+	return nil
+}
+
+func (auditFakeCaller) ResultType() reflect.Type {
+	return nil
+}
+
+func (c auditFakeCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	return reflect.Value{}, c.err
+}
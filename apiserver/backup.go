@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	apiserverbackups "github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/common"
@@ -19,9 +20,12 @@ import (
 	"github.com/juju/juju/state/backups"
 )
 
-var newBackups = func(st *state.State) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(st)
-	return backups.NewBackups(stor), stor
+var newBackups = func(st *state.State) (backups.Backups, io.Closer, error) {
+	stor, err := backups.NewStorage(st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // backupHandler handles backup requests.
@@ -32,13 +36,23 @@ type backupHandler struct {
 func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	// Validate before authenticate because the authentication is dependent
 	// on the state connection that is determined during the validation.
-	st, _, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
 	if err != nil {
 		h.sendError(resp, err)
 		return
 	}
+	// Backups hold the whole controller's data, so as with the Backups
+	// facade they are only available to model administrators.
+	if err := checkModelAdmin(st, entity.Tag().(names.UserTag)); err != nil {
+		h.sendError(resp, err)
+		return
+	}
 
-	backups, closer := newBackups(st)
+	backups, closer, err := newBackups(st)
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
 	defer closer.Close()
 
 	switch req.Method {
@@ -63,6 +77,28 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// checkModelAdmin returns common.ErrPerm unless the user is an
+// administrator of the model or of the controller.
+func checkModelAdmin(st *state.State, user names.UserTag) error {
+	isAdmin, err := st.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	modelUser, err := st.ModelUser(user)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !modelUser.Access().EqualOrGreaterThan(state.ModelAdminAccess) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (h *backupHandler) download(backups backups.Backups, resp http.ResponseWriter, req *http.Request) (string, error) {
 	args, err := h.parseGETArgs(req)
 	if err != nil {
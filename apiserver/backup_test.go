@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
 	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type backupsCommonSuite struct {
@@ -38,8 +39,8 @@ func (s *backupsCommonSuite) SetUpTest(c *gc.C) {
 
 	s.fake = &backupstesting.FakeBackups{}
 	s.PatchValue(apiserver.NewBackups,
-		func(st *state.State) (backups.Backups, io.Closer) {
-			return s.fake, ioutil.NopCloser(nil)
+		func(st *state.State) (backups.Backups, io.Closer, error) {
+			return s.fake, ioutil.NopCloser(nil), nil
 		},
 	)
 }
@@ -115,6 +116,20 @@ func (s *backupsSuite) TestAuthRequiresClientNotMachine(c *gc.C) {
 	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
 }
 
+func (s *backupsSuite) TestRequiresModelAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+		Access:   state.ModelWriteAccess,
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.backupURL(c),
+		tag:      user.Tag().String(),
+		password: "hunter2",
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
 type backupsWithMacaroonsSuite struct {
 	backupsCommonSuite
 }
@@ -81,9 +81,12 @@ func extractResourceValue(resources *common.Resources, key string) (string, erro
 	return strRes.String(), nil
 }
 
-var newBackups = func(st *state.State) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(st)
-	return backups.NewBackups(stor), stor
+var newBackups = func(st *state.State) (backups.Backups, io.Closer, error) {
+	stor, err := backups.NewStorage(st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // ResultFromMetadata updates the result with the information in the
@@ -105,6 +108,8 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Scheduled = meta.Scheduled
+	result.Encrypted = meta.Encrypted
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -137,6 +142,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Hostname = result.Hostname
 	meta.Origin.Version = result.Version
 	meta.Notes = result.Notes
+	meta.Scheduled = result.Scheduled
+	meta.Encrypted = result.Encrypted
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
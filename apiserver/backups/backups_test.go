@@ -55,8 +55,8 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 		fake.Error = errors.Errorf(err)
 	}
 	s.PatchValue(backupsAPI.NewBackups,
-		func(*state.State) (backups.Backups, io.Closer) {
-			return &fake, ioutil.NopCloser(nil)
+		func(*state.State) (backups.Backups, io.Closer, error) {
+			return &fake, ioutil.NopCloser(nil), nil
 		},
 	)
 	return &fake
@@ -16,7 +16,10 @@ var waitUntilReady = replicaset.WaitUntilReady
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
 func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
-	backupsMethods, closer := newBackups(a.st)
+	backupsMethods, closer, err := newBackups(a.st)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	session := a.st.MongoSession().Copy()
@@ -40,7 +43,11 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	}
 	meta.Notes = args.Notes
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	enc := backups.Encryption{
+		Key:       args.EncryptionKey,
+		PublicKey: args.EncryptionPublicKey,
+	}
+	err = backupsMethods.Create(meta, a.paths, dbInfo, enc)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
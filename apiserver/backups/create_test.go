@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -42,6 +43,20 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		EncryptionKey: "secret",
+	}
+	_, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.EncryptionArg, gc.Equals, statebackups.Encryption{Key: "secret"})
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
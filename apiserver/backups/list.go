@@ -13,7 +13,10 @@ import (
 func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
 	var result params.BackupsListResult
 
-	backups, closer := newBackups(a.st)
+	backups, closer, err := newBackups(a.st)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	metaList, err := backups.List()
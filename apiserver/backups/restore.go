@@ -23,7 +23,10 @@ var bootstrapNode = names.NewMachineTag("0")
 func (a *API) Restore(p params.RestoreArgs) error {
 
 	// Get hold of a backup file Reader
-	backup, closer := newBackups(a.st)
+	backup, closer, err := newBackups(a.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer closer.Close()
 
 	// Obtain the address of current machine, where we will be performing restore.
@@ -12,9 +12,10 @@ import (
 	"github.com/juju/juju/state"
 )
 
-// clientAuthRoot restricts API calls for users of a model. Initially the
-// authorisation checks are only for read only access to the model, but in the
-// near future, full ACL support is desirable.
+// clientAuthRoot restricts API calls for users of a model according to
+// their access level: read only users may only make calls that do not
+// modify the model, and users with write access may not make the calls
+// reserved for model administrators.
 type clientAuthRoot struct {
 	finder rpc.MethodFinder
 	user   *state.ModelUser
@@ -41,6 +42,9 @@ func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName str
 			return nil, errors.Trace(common.ErrPerm)
 		}
 	}
+	if r.user.Access() == state.ModelWriteAccess && isCallAdminOnly(rootName, methodName) {
+		return nil, errors.Trace(common.ErrPerm)
+	}
 
 	return caller, nil
 }
@@ -64,6 +64,36 @@ func (s *clientAuthRootSuite) TestReadOnlyUser(c *gc.C) {
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
 
+func (s *clientAuthRootSuite) TestWriteUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	// deploys and actions are fine
+	s.AssertCallGood(c, client, "Service", 3, "Deploy")
+	s.AssertCallGood(c, client, "Action", 1, "Enqueue")
+	// destroying the model is reserved for admins
+	s.AssertCallErrPerm(c, client, "Client", 1, "DestroyModel")
+	// as is changing model config, which can redirect backups, logs
+	// and metrics to other endpoints
+	s.AssertCallErrPerm(c, client, "Client", 1, "ModelSet")
+	s.AssertCallErrPerm(c, client, "Client", 1, "ModelUnset")
+	s.AssertCallGood(c, client, "Client", 1, "ModelGet")
+	// as are backups, restore and other calls which act on the controller
+	s.AssertCallErrPerm(c, client, "Backups", 1, "Create")
+	s.AssertCallErrPerm(c, client, "Backups", 1, "Restore")
+	s.AssertCallErrPerm(c, client, "HighAvailability", 2, "EnableHA")
+	s.AssertCallErrPerm(c, client, "ImageManager", 2, "DeleteImages")
+	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
+}
+
+func (s *clientAuthRootSuite) TestAdminUser(c *gc.C) {
+	envUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+	client := newClientAuthRoot(&fakeFinder{}, envUser)
+	s.AssertCallGood(c, client, "Client", 1, "DestroyModel")
+	s.AssertCallGood(c, client, "Client", 1, "ModelSet")
+	s.AssertCallGood(c, client, "Backups", 1, "Create")
+	s.AssertCallGood(c, client, "HighAvailability", 2, "EnableHA")
+}
+
 func isCallNotImplementedError(err error) bool {
 	_, ok := err.(*rpcreflect.CallNotImplementedError)
 	return ok
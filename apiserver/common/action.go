@@ -152,6 +152,51 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 		results.Results[i].Action = &params.Action{
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
+		}
+	}
+
+	return results
+}
+
+// ActionsCancelRequested reports, for each action passed in through args,
+// whether it was cancelled while it was running.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func ActionsCancelRequested(args params.Entities, actionFn func(string) (state.Action, error)) params.BoolResults {
+	results := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+
+	for i, arg := range args.Entities {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		results.Results[i].Result = action.CancelRequested()
+	}
+
+	return results
+}
+
+// LogActionsMessages appends the progress message passed in through
+// args to each identified action.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Messages)),
+	}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		if err := action.Log(arg.Value); err != nil {
+			results.Results[i].Error = ServerError(err)
 		}
 	}
 
@@ -238,12 +283,30 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 			Tag:        action.ActionTag().String(),
 			Name:       action.Name(),
 			Parameters: action.Parameters(),
+			Timeout:    action.Timeout(),
 		},
-		Status:    string(action.Status()),
-		Message:   message,
-		Output:    output,
-		Enqueued:  action.Enqueued(),
-		Started:   action.Started(),
-		Completed: action.Completed(),
+		Status:          string(action.Status()),
+		Message:         message,
+		Output:          output,
+		Enqueued:        action.Enqueued(),
+		Started:         action.Started(),
+		Completed:       action.Completed(),
+		CancelRequested: action.CancelRequested(),
+		Log:             actionMessages(action.Messages()),
+	}
+}
+
+// actionMessages converts the progress messages of an action for the API.
+func actionMessages(messages []state.ActionMessage) []params.ActionMessage {
+	if len(messages) == 0 {
+		return nil
+	}
+	result := make([]params.ActionMessage, len(messages))
+	for i, m := range messages {
+		result[i] = params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+		}
 	}
+	return result
 }
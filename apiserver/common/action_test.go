@@ -5,6 +5,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -143,7 +145,7 @@ func (s *actionsSuite) TestBeginActions(c *gc.C) {
 func (s *actionsSuite) TestGetActions(c *gc.C) {
 	args := entities("success", "fail", "notPending")
 	actionFn := makeGetActionByTagString(map[string]state.Action{
-		"success":    fakeAction{name: "floosh", status: state.ActionPending},
+		"success":    fakeAction{name: "floosh", status: state.ActionPending, timeout: time.Minute},
 		"notPending": fakeAction{status: state.ActionCancelled},
 	})
 
@@ -151,13 +153,56 @@ func (s *actionsSuite) TestGetActions(c *gc.C) {
 
 	c.Assert(results, jc.DeepEquals, params.ActionResults{
 		[]params.ActionResult{
-			{Action: &params.Action{Name: "floosh"}},
+			{Action: &params.Action{Name: "floosh", Timeout: time.Minute}},
 			{Error: common.ServerError(actionNotFoundErr)},
 			{Error: common.ServerError(common.ErrActionNotAvailable)},
 		},
 	})
 }
 
+func (s *actionsSuite) TestActionsCancelRequested(c *gc.C) {
+	args := entities("cancelled", "running", "notfound")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"cancelled": fakeAction{status: state.ActionRunning, cancelRequested: true},
+		"running":   fakeAction{status: state.ActionRunning},
+	})
+
+	results := common.ActionsCancelRequested(args, actionFn)
+
+	c.Assert(results, jc.DeepEquals, params.BoolResults{
+		[]params.BoolResult{
+			{Result: true},
+			{Result: false},
+			{Error: common.ServerError(actionNotFoundErr)},
+		},
+	})
+}
+
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: "running", Value: "50% done"},
+			{Tag: "notRunning", Value: "50% done"},
+			{Tag: "notfound", Value: "50% done"},
+		},
+	}
+	expectErr := errors.New("action is not running")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"running":    fakeAction{status: state.ActionRunning},
+		"notRunning": fakeAction{logErr: expectErr},
+	})
+
+	results := common.LogActionsMessages(args, actionFn)
+
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{Error: common.ServerError(expectErr)},
+			{Error: common.ServerError(actionNotFoundErr)},
+		},
+	})
+}
+
 func (s *actionsSuite) TestFinishActions(c *gc.C) {
 	args := params.ActionExecutionResults{
 		[]params.ActionExecutionResult{
@@ -329,11 +374,14 @@ type fakeEntity struct {
 
 type fakeAction struct {
 	state.Action
-	receiver  string
-	name      string
-	beginErr  error
-	finishErr error
-	status    state.ActionStatus
+	receiver        string
+	name            string
+	beginErr        error
+	finishErr       error
+	logErr          error
+	status          state.ActionStatus
+	timeout         time.Duration
+	cancelRequested bool
 }
 
 func (mock fakeAction) Status() state.ActionStatus {
@@ -356,10 +404,22 @@ func (mock fakeAction) Parameters() map[string]interface{} {
 	return nil
 }
 
+func (mock fakeAction) Timeout() time.Duration {
+	return mock.timeout
+}
+
+func (mock fakeAction) CancelRequested() bool {
+	return mock.cancelRequested
+}
+
 func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
 
+func (mock fakeAction) Log(string) error {
+	return mock.logErr
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
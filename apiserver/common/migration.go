@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// MigrationMinionReports returns the reports made by the migration
+// minions of a model for the current phase of its migration.
+func MigrationMinionReports(mig state.ModelMigration) (params.MinionReports, error) {
+	var result params.MinionReports
+	phase, err := mig.Phase()
+	if err != nil {
+		return result, errors.Annotate(err, "retrieving phase")
+	}
+	reports, err := mig.MinionReports()
+	if err != nil {
+		return result, errors.Annotate(err, "retrieving minion reports")
+	}
+	result.Phase = phase.String()
+	for _, tag := range reports.Succeeded {
+		result.Succeeded = append(result.Succeeded, tag.String())
+	}
+	for _, tag := range reports.Failed {
+		result.Failed = append(result.Failed, tag.String())
+	}
+	for _, tag := range reports.Unknown {
+		result.Unknown = append(result.Unknown, tag.String())
+	}
+	return result, nil
+}
+
+// MigrationProgressToParams converts the progress of a migration to
+// its API representation.
+func MigrationProgressToParams(progress state.MigrationProgress) params.MigrationProgress {
+	return params.MigrationProgress{
+		EntitiesExported: progress.EntitiesExported,
+		EntitiesImported: progress.EntitiesImported,
+		BinaryBytes:      progress.BinaryBytes,
+	}
+}
@@ -12,7 +12,11 @@ import (
 )
 
 var sendMetrics = func(st *state.State) error {
-	err := metricsender.SendMetrics(st, metricsender.DefaultMetricSender(), metricsender.DefaultMaxBatchesPerSend())
+	sender, err := metricsender.ControllerSender(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = metricsender.SendMetrics(st, sender, metricsender.DefaultMaxBatchesPerSend())
 	return errors.Trace(err)
 }
 
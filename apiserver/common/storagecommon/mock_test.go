@@ -76,8 +76,8 @@ func (i *fakeStorageInstance) Tag() names.Tag {
 	return i.tag
 }
 
-func (i *fakeStorageInstance) Owner() names.Tag {
-	return i.owner
+func (i *fakeStorageInstance) Owner() (names.Tag, bool) {
+	return i.owner, i.owner != nil
 }
 
 func (i *fakeStorageInstance) Kind() state.StorageKind {
@@ -93,6 +93,15 @@ func (a *fakeStorageAttachment) StorageInstance() names.StorageTag {
 	return a.storageTag
 }
 
+type fakeVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id string
+}
+
+func (s *fakeVolumeSnapshot) Id() string {
+	return s.id
+}
+
 type fakeVolume struct {
 	state.Volume
 	tag    names.VolumeTag
@@ -37,9 +37,11 @@ func VolumeParams(
 
 	var pool string
 	var size uint64
+	var snapshotId string
 	if stateVolumeParams, ok := v.Params(); ok {
 		pool = stateVolumeParams.Pool
 		size = stateVolumeParams.Size
+		snapshotId = stateVolumeParams.SnapshotId
 	} else {
 		volumeInfo, err := v.Info()
 		if err != nil {
@@ -65,6 +67,37 @@ func VolumeParams(
 		cfg.Attrs(),
 		volumeTags,
 		nil, // attachment params set by the caller
+		snapshotId,
+	}, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking the given
+// snapshot of the given volume, which must be provisioned.
+func VolumeSnapshotParams(
+	s state.VolumeSnapshot,
+	v state.Volume,
+	environConfig *config.Config,
+	poolManager poolmanager.PoolManager,
+) (params.VolumeSnapshotParams, error) {
+	volumeInfo, err := v.Info()
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	snapshotTags, err := storageTags(nil, environConfig)
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Annotate(err, "computing storage tags")
+	}
+	providerType, _, err := StoragePoolConfig(volumeInfo.Pool, poolManager)
+	if err != nil {
+		return params.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return params.VolumeSnapshotParams{
+		Snapshot:  s.Id(),
+		VolumeTag: v.Tag().String(),
+		VolumeId:  volumeInfo.VolumeId,
+		Size:      volumeInfo.Size,
+		Provider:  string(providerType),
+		Tags:      snapshotTags,
 	}, nil
 }
 
@@ -121,6 +154,18 @@ func VolumeToState(v params.Volume) (names.VolumeTag, state.VolumeInfo, error) {
 	}, nil
 }
 
+// VolumeSnapshotToState converts a params.VolumeSnapshot to
+// state.VolumeSnapshotInfo and the snapshot's ID.
+func VolumeSnapshotToState(s params.VolumeSnapshot) (string, state.VolumeSnapshotInfo, error) {
+	if s.Snapshot == "" {
+		return "", state.VolumeSnapshotInfo{}, errors.New("snapshot ID is empty")
+	}
+	return s.Snapshot, state.VolumeSnapshotInfo{
+		SnapshotId: s.Info.SnapshotId,
+		Size:       s.Info.Size,
+	}, nil
+}
+
 // VolumeFromState converts a state.Volume to params.Volume.
 func VolumeFromState(v state.Volume) (params.Volume, error) {
 	info, err := v.Info()
@@ -82,3 +82,52 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 		},
 	})
 }
+
+func (*volumesSuite) TestVolumeParamsFromSnapshot(c *gc.C) {
+	p, err := storagecommon.VolumeParams(
+		&fakeVolume{tag: names.NewVolumeTag("100"), params: &state.VolumeParams{
+			Pool: "loop", Size: 2048, SnapshotId: "snap-123",
+		}},
+		nil, // StorageInstance
+		testing.CustomModelConfig(c, nil),
+		&fakePoolManager{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Size, gc.Equals, uint64(2048))
+	c.Assert(p.SnapshotId, gc.Equals, "snap-123")
+}
+
+func (*volumesSuite) TestVolumeSnapshotParams(c *gc.C) {
+	p, err := storagecommon.VolumeSnapshotParams(
+		&fakeVolumeSnapshot{id: "0/1"},
+		&fakeVolume{tag: names.NewVolumeTag("0/0"), info: &state.VolumeInfo{
+			Pool: "loop", Size: 1024, VolumeId: "vol-0",
+		}},
+		testing.CustomModelConfig(c, nil),
+		&fakePoolManager{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p, jc.DeepEquals, params.VolumeSnapshotParams{
+		Snapshot:  "0/1",
+		VolumeTag: "volume-0-0",
+		VolumeId:  "vol-0",
+		Size:      1024,
+		Provider:  "loop",
+		Tags: map[string]string{
+			tags.JujuController: testing.ModelTag.Id(),
+			tags.JujuModel:      testing.ModelTag.Id(),
+		},
+	})
+}
+
+func (*volumesSuite) TestVolumeSnapshotParamsUnprovisioned(c *gc.C) {
+	_, err := storagecommon.VolumeSnapshotParams(
+		&fakeVolumeSnapshot{id: "0/1"},
+		&fakeVolume{tag: names.NewVolumeTag("0/0"), params: &state.VolumeParams{
+			Pool: "loop", Size: 1024,
+		}},
+		testing.CustomModelConfig(c, nil),
+		&fakePoolManager{},
+	)
+	c.Assert(err, gc.ErrorMatches, "volume 0/0 not provisioned")
+}
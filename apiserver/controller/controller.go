@@ -11,11 +11,14 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -35,6 +38,12 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	PrecheckModelMigration(params.Entities) (params.MigrationIssuesResults, error)
+	ModelMigrationStatus(params.Entities) (params.ModelMigrationStatusResults, error)
+	AuditLog(params.AuditLogFilter) (params.AuditLogResults, error)
+	ExportModel(params.Entities) (params.SerializedModelResults, error)
+	ImportModel(params.ImportModelArgs) (params.Model, error)
+	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -312,7 +321,7 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 
 	args := state.ModelMigrationSpec{
 		InitiatedBy: c.apiUser,
-		TargetInfo: migration.TargetInfo{
+		TargetInfo: coremigration.TargetInfo{
 			ControllerTag: controllerTag,
 			Addrs:         targetInfo.Addrs,
 			CACert:        targetInfo.CACert,
@@ -327,6 +336,315 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	return mig.Id(), nil
 }
 
+// PrecheckModelMigration reports every problem that would prevent each
+// of the specified models from being migrated away from this
+// controller, without starting a migration.
+func (c *ControllerAPI) PrecheckModelMigration(args params.Entities) (params.MigrationIssuesResults, error) {
+	results := params.MigrationIssuesResults{
+		Results: make([]params.MigrationIssuesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		issues, err := c.precheckOneModel(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Issues = issues
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) precheckOneModel(tag string) ([]params.MigrationIssue, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Close()
+
+	model, err := st.Export()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	issues, err := migration.SourcePrecheck(st, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return migration.IssuesToParams(issues), nil
+}
+
+// ModelMigrationStatus reports the status and progress of the most
+// recent migration of each of the specified models.
+func (c *ControllerAPI) ModelMigrationStatus(args params.Entities) (params.ModelMigrationStatusResults, error) {
+	results := params.ModelMigrationStatusResults{
+		Results: make([]params.ModelMigrationStatusResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		status, err := c.oneModelMigrationStatus(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Status = status
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) oneModelMigrationStatus(tag string) (*params.ModelMigrationStatus, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Close()
+
+	mig, err := st.GetModelMigration()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attempt, err := mig.Attempt()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reports, err := common.MigrationMinionReports(mig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	status := &params.ModelMigrationStatus{
+		Id:               mig.Id(),
+		Attempt:          attempt,
+		Phase:            reports.Phase,
+		StatusMessage:    mig.StatusMessage(),
+		StartTime:        mig.StartTime(),
+		PhaseChangedTime: mig.PhaseChangedTime(),
+		Progress:         common.MigrationProgressToParams(mig.Progress()),
+		MinionReports:    reports,
+	}
+	if endTime := mig.EndTime(); !endTime.IsZero() {
+		status.EndTime = &endTime
+	}
+	return status, nil
+}
+
+// AuditLog returns the entries in the controller's audit log that match
+// the supplied filter, ordered from oldest to newest.
+func (c *ControllerAPI) AuditLog(args params.AuditLogFilter) (params.AuditLogResults, error) {
+	var result params.AuditLogResults
+	var filter state.AuditLogFilter
+	if args.UserTag != "" {
+		user, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.User = user
+	}
+	if args.ModelTag != "" {
+		model, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.ModelUUID = model.Id()
+	}
+	filter.Facade = args.Facade
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	filter.Limit = args.Limit
+
+	entries, err := c.state.AuditEntries(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			Timestamp: entry.Timestamp,
+			UserTag:   entry.UserTag.String(),
+			ModelTag:  names.NewModelTag(entry.ModelUUID).String(),
+			Facade:    entry.Facade,
+			Version:   entry.Version,
+			Method:    entry.Method,
+			Args:      entry.Args,
+			Error:     entry.Error,
+		}
+	}
+	return result, nil
+}
+
+// ExportModel returns the serialized description of each of the
+// specified models.
+func (c *ControllerAPI) ExportModel(args params.Entities) (params.SerializedModelResults, error) {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		bytes, err := c.exportOneModel(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Bytes = bytes
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) exportOneModel(tag string) ([]byte, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Close()
+
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bytes, nil
+}
+
+// ImportModel creates a new model in the controller from a serialized
+// model description, such as one returned by ExportModel. Unlike a
+// migration, the model is usable as soon as the import completes.
+func (c *ControllerAPI) ImportModel(args params.ImportModelArgs) (params.Model, error) {
+	var result params.Model
+	model, err := description.Deserialize(args.Bytes)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.Name != "" {
+		model.UpdateConfig(map[string]interface{}{"name": args.Name})
+	}
+	if args.NewUUID {
+		// The agents of provisioned machines keep reporting to the
+		// model they were provisioned in; they are not redirected, so a
+		// copy sharing their instances would fight over them.
+		if id := provisionedMachine(model.Machines()); id != "" {
+			return result, errors.Errorf(
+				"cannot give a new UUID to a model with provisioned machines (machine %s)", id)
+		}
+		uuid, err := utils.NewUUID()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		model.UpdateConfig(map[string]interface{}{"uuid": uuid.String()})
+	}
+
+	dbModel, st, err := migration.ImportModelDescription(c.state, model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer st.Close()
+
+	if err := dbModel.SetMigrationMode(state.MigrationModeActive); err != nil {
+		return result, errors.Annotate(err, "activating imported model")
+	}
+	result.Name = dbModel.Name()
+	result.UUID = dbModel.UUID()
+	result.OwnerTag = dbModel.Owner().String()
+	return result, nil
+}
+
+// provisionedMachine returns the id of the first of the machines, or
+// their containers, which has a cloud instance, or "" if there is none.
+func provisionedMachine(machines []description.Machine) string {
+	for _, m := range machines {
+		if m.Instance() != nil {
+			return m.Id()
+		}
+		if id := provisionedMachine(m.Containers()); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ModifyControllerAccess changes the controller access granted to the
+// specified users.
+func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		err := c.changeControllerAccess(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) changeControllerAccess(arg params.ModifyControllerAccess) error {
+	targetUserTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Annotate(err, "could not modify controller access")
+	}
+	access, err := stateControllerAccess(arg.Access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	current, err := c.state.ControllerAccess(targetUserTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up controller access for user")
+	}
+	hasAccess := err == nil
+
+	switch arg.Action {
+	case params.GrantControllerAccess:
+		if hasAccess && current.EqualOrGreaterThan(access) {
+			return errors.Errorf("user already has %q access", current)
+		}
+		err := c.state.SetControllerAccess(targetUserTag, c.apiUser, access)
+		return errors.Annotate(err, "could not grant controller access")
+
+	case params.RevokeControllerAccess:
+		if !hasAccess || !current.EqualOrGreaterThan(access) {
+			return errors.Errorf("user does not have %q access", access)
+		}
+		if access == state.ControllerSuperuserAccess {
+			// Revoking superuser access leaves add-model access.
+			err := c.state.SetControllerAccess(targetUserTag, c.apiUser, state.ControllerAddModelAccess)
+			return errors.Annotate(err, "could not revoke controller access")
+		}
+		// Revoking add-model access removes all access.
+		err := c.state.RemoveControllerAccess(targetUserTag)
+		return errors.Annotate(err, "could not revoke controller access")
+
+	default:
+		return errors.Errorf("unknown action %q", arg.Action)
+	}
+}
+
+func stateControllerAccess(access params.ControllerAccessPermission) (state.ControllerAccess, error) {
+	switch access {
+	case params.ControllerAddModelAccess:
+		return state.ControllerAddModelAccess, nil
+	case params.ControllerSuperuserAccess:
+		return state.ControllerSuperuserAccess, nil
+	}
+	return state.ControllerUndefinedAccess, errors.Errorf("invalid controller access permission %q", access)
+}
+
 func (c *ControllerAPI) environStatus(tag string) (params.ModelStatus, error) {
 	var status params.ModelStatus
 	modelTag, err := names.ParseModelTag(tag)
@@ -6,6 +6,7 @@ package controller_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -17,11 +18,15 @@ import (
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/core/description"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
 )
 
 type controllerSuite struct {
@@ -386,3 +391,271 @@ func randomModelTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewModelTag(uuid).String()
 }
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	t0 := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	put := func(user, facade, method string, offset time.Duration) audit.AuditEntry {
+		entry := audit.AuditEntry{
+			Timestamp: t0.Add(offset),
+			UserTag:   names.NewUserTag(user),
+			ModelUUID: s.State.ModelUUID(),
+			Facade:    facade,
+			Version:   1,
+			Method:    method,
+		}
+		err := s.State.PutAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+		return entry
+	}
+	put("bob", "Service", "Expose", 0)
+	put("mary", "Service", "Unexpose", time.Minute)
+	last := put("bob", "Client", "ModelSet", 2*time.Minute)
+
+	from := t0.Add(time.Second)
+	result, err := s.controller.AuditLog(params.AuditLogFilter{
+		UserTag:  names.NewUserTag("bob").String(),
+		ModelTag: s.State.ModelTag().String(),
+		From:     &from,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, jc.DeepEquals, []params.AuditLogEntry{{
+		Timestamp: last.Timestamp,
+		UserTag:   "user-bob@local",
+		ModelTag:  s.State.ModelTag().String(),
+		Facade:    "Client",
+		Version:   1,
+		Method:    "ModelSet",
+	}})
+}
+
+func (s *controllerSuite) TestAuditLogBadFilter(c *gc.C) {
+	_, err := s.controller.AuditLog(params.AuditLogFilter{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+}
+
+func (s *controllerSuite) TestExportModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	result, err := s.controller.ExportModel(params.Entities{
+		Entities: []params.Entity{
+			{Tag: st.ModelTag().String()},
+			{Tag: randomModelTag()},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	model, err := description.Deserialize(result.Results[0].Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag(), gc.Equals, st.ModelTag())
+
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestPrecheckModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeMachine(c, nil)
+
+	result, err := s.controller.PrecheckModelMigration(params.Entities{
+		Entities: []params.Entity{
+			{Tag: st.ModelTag().String()},
+			{Tag: randomModelTag()},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+
+	// The model's agent version doesn't match the machine's tools.
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Issues, jc.DeepEquals, []params.MigrationIssue{{
+		Phase:   "PRECHECK",
+		Message: "machine 0 is running tools " + jujuversion.Current.String() + ", model agent version is 1.2.3",
+	}})
+
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestModelMigrationStatus(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	factory.NewFactory(st).MakeMachine(c, nil)
+
+	out, err := s.controller.InitiateModelMigration(params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.ModelMigrationTargetInfo{
+				ControllerTag: randomModelTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SetProgress(state.MigrationProgress{EntitiesExported: 5})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.ModelMigrationStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: st.ModelTag().String()},
+			{Tag: s.State.ModelTag().String()},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	status := result.Results[0].Status
+	c.Assert(status, gc.NotNil)
+	c.Check(status.Id, gc.Equals, st.ModelUUID()+":0")
+	c.Check(status.Attempt, gc.Equals, 0)
+	c.Check(status.Phase, gc.Equals, "QUIESCE")
+	c.Check(status.StartTime.IsZero(), jc.IsFalse)
+	c.Check(status.EndTime, gc.IsNil)
+	c.Check(status.Progress, jc.DeepEquals, params.MigrationProgress{EntitiesExported: 5})
+	c.Check(status.MinionReports, jc.DeepEquals, params.MinionReports{
+		Phase:   "QUIESCE",
+		Unknown: []string{"machine-0"},
+	})
+
+	// The controller model has never been migrated.
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestImportModelCopy(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	bytes, err := migration.ExportModel(st)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.ImportModel(params.ImportModelArgs{
+		Bytes:   bytes,
+		Name:    "copy",
+		NewUUID: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Name, gc.Equals, "copy")
+	c.Check(result.UUID, gc.Not(gc.Equals), st.ModelUUID())
+
+	model, err := s.State.GetModel(names.NewModelTag(result.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Name(), gc.Equals, "copy")
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeActive)
+	c.Check(result.OwnerTag, gc.Equals, model.Owner().String())
+}
+
+func (s *controllerSuite) TestImportModelCopyWithMachines(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	machine := factory.NewFactory(st).MakeMachine(c, nil)
+	bytes, err := migration.ExportModel(st)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.controller.ImportModel(params.ImportModelArgs{
+		Bytes:   bytes,
+		Name:    "copy",
+		NewUUID: true,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot give a new UUID to a model with provisioned machines \(machine `+machine.Id()+`\)`)
+}
+
+func (s *controllerSuite) TestImportModelExisting(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	bytes, err := migration.ExportModel(st)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.controller.ImportModel(params.ImportModelArgs{Bytes: bytes})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *controllerSuite) TestImportModelBadBytes(c *gc.C) {
+	_, err := s.controller.ImportModel(params.ImportModelArgs{Bytes: []byte("not a model")})
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *controllerSuite) modifyControllerAccess(c *gc.C, user names.UserTag, action params.ControllerAction, access params.ControllerAccessPermission) error {
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: user.String(),
+			Action:  action,
+			Access:  access,
+		}}}
+	result, err := s.controller.ModifyControllerAccess(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	return result.OneError()
+}
+
+func (s *controllerSuite) TestGrantControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+
+	err := s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerSuperuserAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessOnlyGreater(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, gc.ErrorMatches, `user already has "superuser" access`)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessInvalid(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyControllerAccess(c, user.UserTag(), params.GrantControllerAccess, "root")
+	c.Assert(err, gc.ErrorMatches, `invalid controller access permission "root"`)
+}
+
+func (s *controllerSuite) TestRevokeSuperuserLeavesAddModel(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), s.AdminUserTag(c), state.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+}
+
+func (s *controllerSuite) TestRevokeAddModelRemovesAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), s.AdminUserTag(c), state.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestRevokeControllerAccessNotHeld(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.modifyControllerAccess(c, user.UserTag(), params.RevokeControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, gc.ErrorMatches, `user does not have "add-model" access`)
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/instrument"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+var (
+	apiRequests = instrument.Default.NewCounterVec(
+		"juju_apiserver_requests_total",
+		"API requests handled, by facade and method.",
+		"facade", "method",
+	)
+	apiRequestErrors = instrument.Default.NewCounterVec(
+		"juju_apiserver_request_errors_total",
+		"API requests which returned an error, by facade and method.",
+		"facade", "method",
+	)
+	apiRequestDuration = instrument.Default.NewHistogramVec(
+		"juju_apiserver_request_duration_seconds",
+		"Time taken to handle API requests, by facade and method.",
+		instrument.DefaultBuckets,
+		"facade", "method",
+	)
+	apiConnections = instrument.Default.NewGaugeVec(
+		"juju_apiserver_connections",
+		"Active API connections.",
+	).With()
+	apiLoginFailures = instrument.Default.NewCounterVec(
+		"juju_apiserver_login_failures_total",
+		"API logins rejected because of bad credentials.",
+	).With()
+	logSinkConnections = instrument.Default.NewGaugeVec(
+		"juju_apiserver_logsink_connections",
+		"Agents connected to the log sink.",
+	).With()
+	logSinkRecords = instrument.Default.NewCounterVec(
+		"juju_apiserver_logsink_records_total",
+		"Log records received from agents.",
+	).With()
+	logSinkErrors = instrument.Default.NewCounterVec(
+		"juju_apiserver_logsink_errors_total",
+		"Log records which could not be written, by destination.",
+		"destination",
+	)
+)
+
+// unknownLabel is the facade and method label value given to API
+// requests for facades or methods which the server does not have.
+const unknownLabel = "unknown"
+
+// requestMetricLabels returns the facade and method labels under which
+// the request is counted. The names are chosen by the client, so only
+// those of facade methods the server actually serves are used; anything
+// else is counted under unknownLabel, which keeps the number of label
+// values bounded.
+func requestMetricLabels(req rpc.Request) (facade, method string) {
+	if req.Type == "Admin" {
+		adminType := rpcreflect.ObjTypeOf(reflect.TypeOf((*adminApiV3)(nil)))
+		if _, err := adminType.Method(req.Action); err == nil {
+			return req.Type, req.Action
+		}
+		return unknownLabel, unknownLabel
+	}
+	if _, _, err := lookupMethod(req.Type, req.Version, req.Action); err != nil {
+		return unknownLabel, unknownLabel
+	}
+	return req.Type, req.Action
+}
+
+// controllerMetricsHandler serves the controller's own metrics, in the
+// Prometheus text exposition format, to controller administrators.
+type controllerMetricsHandler struct {
+	ctxt     httpContext
+	registry *instrument.Registry
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *controllerMetricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if !isAdmin {
+		sendError(w, common.ErrPerm)
+		return
+	}
+	w.Header().Set("Content-Type", instrument.ContentType)
+	if _, err := h.registry.WriteTo(w); err != nil {
+		logger.Errorf("cannot write controller metrics: %v", err)
+	}
+}
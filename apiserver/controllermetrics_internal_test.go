@@ -0,0 +1,51 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+)
+
+type requestMetricLabelsSuite struct{}
+
+var _ = gc.Suite(&requestMetricLabelsSuite{})
+
+func (*requestMetricLabelsSuite) TestRequestMetricLabels(c *gc.C) {
+	for i, test := range []struct {
+		req    rpc.Request
+		facade string
+		method string
+	}{{
+		req:    rpc.Request{Type: "Admin", Version: 3, Action: "Login"},
+		facade: "Admin",
+		method: "Login",
+	}, {
+		req:    rpc.Request{Type: "Client", Version: 1, Action: "FullStatus"},
+		facade: "Client",
+		method: "FullStatus",
+	}, {
+		req:    rpc.Request{Type: "Admin", Version: 3, Action: "NoSuchMethod"},
+		facade: "unknown",
+		method: "unknown",
+	}, {
+		req:    rpc.Request{Type: "Client", Version: 1, Action: "NoSuchMethod"},
+		facade: "unknown",
+		method: "unknown",
+	}, {
+		req:    rpc.Request{Type: "Client", Version: 9999, Action: "FullStatus"},
+		facade: "unknown",
+		method: "unknown",
+	}, {
+		req:    rpc.Request{Type: "NoSuchFacade\n{}", Version: 1, Action: "Anything"},
+		facade: "unknown",
+		method: "unknown",
+	}} {
+		c.Logf("test %d: %+v", i, test.req)
+		facade, method := requestMetricLabels(test.req)
+		c.Check(facade, gc.Equals, test.facade)
+		c.Check(method, gc.Equals, test.method)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instrument"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type controllerMetricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&controllerMetricsSuite{})
+
+func (s *controllerMetricsSuite) metricsURL(c *gc.C) string {
+	return s.makeURL(c, "https", "/controller-metrics", nil).String()
+}
+
+func (s *controllerMetricsSuite) assertErrorResponse(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	body := assertResponse(c, resp, statusCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.ErrorMatches, msg)
+}
+
+func (s *controllerMetricsSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *controllerMetricsSuite) TestRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+		Access:   state.ModelReadAccess,
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.metricsURL(c),
+		tag:      user.Tag().String(),
+		password: "hunter2",
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *controllerMetricsSuite) TestRequiresGET(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *controllerMetricsSuite) TestServesMetrics(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, instrument.ContentType)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{
+		"juju_apiserver_connections",
+		"juju_apiserver_login_failures_total",
+		"juju_apiserver_logsink_records_total",
+		"juju_apiserver_request_duration_seconds",
+		"juju_apiserver_requests_total",
+		"juju_state_txn_retries_total",
+	} {
+		c.Check(string(body), jc.Contains, "# TYPE "+name+" ")
+	}
+	c.Check(string(body), gc.Matches, `(?s).*juju_apiserver_requests_total\{facade="Admin",method="Login"\} \d+\n.*`)
+}
+
+func (s *controllerMetricsSuite) TestUnknownRequestsShareLabel(c *gc.C) {
+	for _, facade := range []string{"NoSuchFacade", "OtherMadeUpFacade"} {
+		err := s.APIState.APICall(facade, 1, "", "Method", nil, nil)
+		c.Assert(err, gc.NotNil)
+	}
+
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(body), gc.Matches, `(?s).*juju_apiserver_requests_total\{facade="unknown",method="unknown"\} \d+\n.*`)
+	c.Check(string(body), gc.Not(jc.Contains), "NoSuchFacade")
+	c.Check(string(body), gc.Not(jc.Contains), "OtherMadeUpFacade")
+}
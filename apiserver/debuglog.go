@@ -8,8 +8,10 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -67,6 +69,16 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time; only logs written at or after it are sent
+//   endTime -> string - RFC3339 time; only logs written before it are sent
+//      - existing logs are sent back, but the command does not wait for new ones
+//   message -> string - regular expression which log messages must match
+//   includeLocation -> []string - lists source locations to include in the response
+//      - a file name optionally followed by ':' and a line number, which
+//        may contain '*' wildcards e.g.: uniter.go, uniter.go:42, *_test.go
+//   excludeLocation -> []string - lists source locations to exclude from the response
+//   format -> string - one of [text, json]; with json, each log record is
+//      sent as a JSON object on its own line
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -150,8 +162,24 @@ type debugLogParams struct {
 	excludeEntity []string
 	includeModule []string
 	excludeModule []string
+
+	startTime       time.Time
+	endTime         time.Time
+	message         string
+	includeLocation []string
+	excludeLocation []string
+	format          string
 }
 
+const (
+	// debugLogFormatText sends each log record as a line of text.
+	debugLogFormatText = "text"
+
+	// debugLogFormatJSON sends each log record as a JSON object on
+	// its own line.
+	debugLogFormatJSON = "json"
+)
+
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 	params := new(debugLogParams)
 
@@ -197,10 +225,46 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.filterLevel = level
 	}
 
+	for _, t := range []struct {
+		name  string
+		value *time.Time
+	}{
+		{"startTime", &params.startTime},
+		{"endTime", &params.endTime},
+	} {
+		if value := queryMap.Get(t.name); value != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, errors.Errorf("%s value %q is not a valid RFC3339 time", t.name, value)
+			}
+			*t.value = parsed
+		}
+	}
+	if !params.startTime.IsZero() && !params.endTime.IsZero() && !params.startTime.Before(params.endTime) {
+		return nil, errors.New("startTime must be before endTime")
+	}
+
+	if value := queryMap.Get("message"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("message value %q is not a valid regular expression: %v", value, err)
+		}
+		params.message = value
+	}
+
+	params.format = debugLogFormatText
+	if value := queryMap.Get("format"); value != "" {
+		if value != debugLogFormatText && value != debugLogFormatJSON {
+			return nil, errors.Errorf("format value %q is not one of %q, %q", value, debugLogFormatText, debugLogFormatJSON)
+		}
+		params.format = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
+	params.includeLocation = queryMap["includeLocation"]
+	params.excludeLocation = queryMap["excludeLocation"]
 
 	return params, nil
 }
@@ -4,12 +4,14 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
 
@@ -43,8 +45,11 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
-			line := formatLogRecord(rec)
-			_, err := socket.Write([]byte(line))
+			line, err := formatDebugLogRecord(rec, reqParams.format)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			_, err = socket.Write(line)
 			if err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -66,6 +71,12 @@ func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 		ExcludeEntity: reqParams.excludeEntity,
 		IncludeModule: reqParams.includeModule,
 		ExcludeModule: reqParams.excludeModule,
+
+		StartTime:       reqParams.startTime,
+		EndTime:         reqParams.endTime,
+		Message:         reqParams.message,
+		IncludeLocation: reqParams.includeLocation,
+		ExcludeLocation: reqParams.excludeLocation,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -84,6 +95,29 @@ func formatLogRecord(r *state.LogRecord) string {
 	)
 }
 
+func formatDebugLogRecord(r *state.LogRecord, format string) ([]byte, error) {
+	if format == debugLogFormatJSON {
+		return formatLogRecordJSON(r)
+	}
+	return []byte(formatLogRecord(r)), nil
+}
+
+func formatLogRecordJSON(r *state.LogRecord) ([]byte, error) {
+	data, err := json.Marshal(params.DebugLogRecord{
+		ModelUUID: r.ModelUUID,
+		Time:      r.Time.UTC(),
+		Entity:    r.Entity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Level:     r.Level.String(),
+		Message:   r.Message,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(data, '\n'), nil
+}
+
 func formatTime(t time.Time) string {
 	return t.In(time.UTC).Format("2006-01-02 15:04:05")
 }
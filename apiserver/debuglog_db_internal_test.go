@@ -28,24 +28,30 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
+	startTime := time.Date(2016, 6, 19, 15, 0, 0, 0, time.UTC)
+	endTime := time.Date(2016, 6, 19, 16, 0, 0, 0, time.UTC)
 	reqParams := &debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:    false,
+		noTail:          true,
+		backlog:         11,
+		filterLevel:     loggo.INFO,
+		includeEntity:   []string{"foo"},
+		includeModule:   []string{"bar"},
+		excludeEntity:   []string{"baz"},
+		excludeModule:   []string{"qux"},
+		startTime:       startTime,
+		endTime:         endTime,
+		message:         "^stuff",
+		includeLocation: []string{"uniter.go"},
+		excludeLocation: []string{"*_test.go"},
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
-		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
+		c.Assert(params.StartTime, gc.Equals, startTime)
+		c.Assert(params.EndTime, gc.Equals, endTime)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -53,6 +59,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.Message, gc.Equals, "^stuff")
+		c.Assert(params.IncludeLocation, jc.DeepEquals, []string{"uniter.go"})
+		c.Assert(params.ExcludeLocation, jc.DeepEquals, []string{"*_test.go"})
 
 		return newFakeLogTailer(), nil
 	})
@@ -124,6 +133,32 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestJSONFormat(c *gc.C) {
+	tailer := newFakeLogTailer()
+	tailer.logsCh <- &state.LogRecord{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Time:      time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		Entity:    "machine-99",
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Level:     loggo.INFO,
+		Message:   "stuff happened",
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	done := s.runRequest(&debugLogParams{format: debugLogFormatJSON, maxLines: 1}, nil)
+
+	s.assertOutput(c, []string{
+		"ok",
+		`{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","timestamp":"2015-06-19T15:34:37Z",` +
+			`"entity":"machine-99","module":"some.where","location":"code.go:42","level":"INFO",` +
+			`"message":"stuff happened"}` + "\n",
+	})
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestRequestStopsWhenTailerStops(c *gc.C) {
 	tailer := newFakeLogTailer()
 	s.PatchValue(&newLogTailer, func(_ state.LoggingState, params *state.LogTailerParams) (state.LogTailer, error) {
@@ -28,6 +28,36 @@ func (s *debugLogBaseSuite) TestBadParams(c *gc.C) {
 	s.assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogBaseSuite) TestBadFilterParams(c *gc.C) {
+	for i, test := range []struct {
+		values url.Values
+		err    string
+	}{{
+		values: url.Values{"startTime": {"yesterday"}},
+		err:    `startTime value "yesterday" is not a valid RFC3339 time`,
+	}, {
+		values: url.Values{"endTime": {"2016-06-19"}},
+		err:    `endTime value "2016-06-19" is not a valid RFC3339 time`,
+	}, {
+		values: url.Values{
+			"startTime": {"2016-06-19T16:00:00Z"},
+			"endTime":   {"2016-06-19T15:00:00Z"},
+		},
+		err: `startTime must be before endTime`,
+	}, {
+		values: url.Values{"message": {"(unclosed"}},
+		err:    `message value "\(unclosed" is not a valid regular expression: .*`,
+	}, {
+		values: url.Values{"format": {"yaml"}},
+		err:    `format value "yaml" is not one of "text", "json"`,
+	}} {
+		c.Logf("test %d: %v", i, test.values)
+		reader := s.openWebsocket(c, test.values)
+		assertJSONError(c, reader, test.err)
+		s.assertWebsocketClosed(c, reader)
+	}
+}
+
 func (s *debugLogBaseSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
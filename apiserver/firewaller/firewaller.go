@@ -152,6 +152,21 @@ func (f *FirewallerAPI) watchOneEnvironOpenedPorts(tag names.Tag) (string, []str
 	return "", nil, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the subnets of the model, so that the CIDRs of services exposed to
+// spaces can be kept up to date.
+func (f *FirewallerAPI) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.st.WatchSubnets()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
 // them.
@@ -279,6 +294,31 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedSourceCIDRs returns, for each given service, the CIDRs
+// from which its open ports may be accessed if it is exposed.
+func (f *FirewallerAPI) GetExposedSourceCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessService()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseServiceTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		service, err := f.getService(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = service.ExposedSourceCIDRs()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
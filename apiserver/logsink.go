@@ -79,18 +79,23 @@ func (h *logSinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			// formatted simple error.
 			h.sendError(socket, req, nil)
 
+			logSinkConnections.Inc()
+			defer logSinkConnections.Dec()
 			logCh := h.receiveLogs(socket)
 			for {
 				select {
 				case <-h.ctxt.stop():
 					return
 				case m := <-logCh:
+					logSinkRecords.Inc()
 					fileErr := h.logToFile(filePrefix, m)
 					if fileErr != nil {
+						logSinkErrors.With("file").Inc()
 						logger.Errorf("logging to logsink.log failed: %v", fileErr)
 					}
 					dbErr := dbLogger.Log(m.Time, m.Module, m.Location, m.Level, m.Message)
 					if dbErr != nil {
+						logSinkErrors.With("db").Inc()
 						logger.Errorf("logging to DB failed: %v", err)
 					}
 					if fileErr != nil || dbErr != nil {
@@ -19,12 +19,16 @@ func init() {
 type MetricsAdder interface {
 	// AddMetricBatches stores the specified metric batches in the state.
 	AddMetricBatches(batches params.MetricBatchParams) (params.ErrorResults, error)
+
+	// AddMetricFailures records the specified metric failures in the state.
+	AddMetricFailures(failures params.MetricFailureParams) (params.ErrorResults, error)
 }
 
 // MetricsAdderAPI implements the metrics adder interface and is the concrete
 // implementation of the API end point.
 type MetricsAdderAPI struct {
-	state *state.State
+	state      *state.State
+	authorizer common.Authorizer
 }
 
 var _ MetricsAdder = (*MetricsAdderAPI)(nil)
@@ -41,7 +45,8 @@ func NewMetricsAdderAPI(
 		return nil, common.ErrPerm
 	}
 	return &MetricsAdderAPI{
-		state: st,
+		state:      st,
+		authorizer: authorizer,
 	}, nil
 }
 
@@ -77,3 +82,42 @@ func (api *MetricsAdderAPI) AddMetricBatches(args params.MetricBatchParams) (par
 	}
 	return result, nil
 }
+
+// AddMetricFailures implements the MetricsAdder interface.
+func (api *MetricsAdderAPI) AddMetricFailures(args params.MetricFailureParams) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Failures)),
+	}
+	for i, failure := range args.Failures {
+		tag, err := names.ParseUnitTag(failure.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !api.canReportFor(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		_, err = api.state.AddMetricFailure(
+			state.MetricFailureParam{
+				UUID:    failure.Failure.UUID,
+				Unit:    tag,
+				Kind:    state.MetricFailureKind(failure.Failure.Kind),
+				Message: failure.Failure.Message,
+				Time:    failure.Failure.Time,
+			},
+		)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// canReportFor returns whether the authenticated agent may record
+// metric failures for the given unit. Unit agents may only report
+// about themselves.
+func (api *MetricsAdderAPI) canReportFor(tag names.UnitTag) bool {
+	if api.authorizer.AuthUnitAgent() {
+		return api.authorizer.AuthOwner(tag)
+	}
+	return api.authorizer.AuthMachineAgent()
+}
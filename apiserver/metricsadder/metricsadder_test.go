@@ -239,3 +239,78 @@ func (s *metricsAdderSuite) TestNewMetricsAdderAPIRefusesNonAgent(c *gc.C) {
 		}
 	}
 }
+
+func (s *metricsAdderSuite) TestAddMetricFailures(c *gc.C) {
+	uuid := utils.MustNewUUID().String()
+	now := time.Now().UTC().Truncate(time.Second)
+	result, err := s.adder.AddMetricFailures(params.MetricFailureParams{
+		Failures: []params.MetricFailureParam{{
+			Tag: s.meteredUnit.Tag().String(),
+			Failure: params.MetricFailure{
+				UUID:    uuid,
+				Kind:    "collect",
+				Message: "collect-metrics hook failed",
+				Time:    now,
+			},
+		}, {
+			Tag: s.meteredUnit.Tag().String(),
+			Failure: params.MetricFailure{
+				UUID: utils.MustNewUUID().String(),
+				Kind: "explode",
+				Time: now,
+			},
+		}, {
+			Tag: "not-a-tag",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `metric failure kind "explode" not valid`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"not-a-tag" is not a valid tag`)
+
+	failures, err := s.State.MetricFailuresForUnit(s.meteredUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].UUID(), gc.Equals, uuid)
+	c.Assert(failures[0].Kind(), gc.Equals, state.MetricCollectFailure)
+	c.Assert(failures[0].Message(), gc.Equals, "collect-metrics hook failed")
+	c.Assert(failures[0].Time().Equal(now), jc.IsTrue)
+}
+
+func (s *metricsAdderSuite) TestAddMetricFailuresUnitAgentOwnUnitOnly(c *gc.C) {
+	authorizer := s.authorizer
+	authorizer.Tag = s.meteredUnit.Tag()
+	adder, err := metricsadder.NewMetricsAdderAPI(s.State, nil, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	result, err := adder.AddMetricFailures(params.MetricFailureParams{
+		Failures: []params.MetricFailureParam{{
+			Tag: s.meteredUnit.Tag().String(),
+			Failure: params.MetricFailure{
+				UUID: utils.MustNewUUID().String(),
+				Kind: "collect",
+				Time: now,
+			},
+		}, {
+			Tag: s.mysqlUnit.Tag().String(),
+			Failure: params.MetricFailure{
+				UUID: utils.MustNewUUID().String(),
+				Kind: "collect",
+				Time: now,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+
+	failures, err := s.State.MetricFailuresForUnit(s.meteredUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 1)
+	failures, err = s.State.MetricFailuresForUnit(s.mysqlUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
@@ -25,6 +25,12 @@ type metricsDebug interface {
 	// MetricBatchesForService returns metric batches for the given service.
 	MetricBatchesForService(service string) ([]state.MetricBatch, error)
 
+	// MetricFailuresForUnit returns metric failures for the given unit.
+	MetricFailuresForUnit(unit string) ([]state.MetricFailure, error)
+
+	// MetricFailuresForService returns metric failures for the given service.
+	MetricFailuresForService(service string) ([]state.MetricFailure, error)
+
 	// Unit returns the unit based on its name.
 	Unit(string) (*state.Unit, error)
 
@@ -37,6 +43,10 @@ type MetricsDebug interface {
 	// GetMetrics returns all metrics stored by the state server.
 	GetMetrics(arg params.Entities) (params.MetricResults, error)
 
+	// GetMetricFailures returns the recorded failures to collect, spool
+	// or send metrics.
+	GetMetricFailures(arg params.Entities) (params.MetricFailureResults, error)
+
 	// SetMeterStatus will set the meter status on the given entity tag.
 	SetMeterStatus(params.MeterStatusParams) (params.ErrorResults, error)
 }
@@ -119,6 +129,46 @@ func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResul
 	return results, nil
 }
 
+// GetMetricFailures returns the failures to collect, spool or send
+// metrics recorded for the given units or services, oldest first.
+func (api *MetricsDebugAPI) GetMetricFailures(args params.Entities) (params.MetricFailureResults, error) {
+	results := params.MetricFailureResults{
+		Results: make([]params.EntityMetricFailures, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		var failures []state.MetricFailure
+		switch tag.Kind() {
+		case names.UnitTagKind:
+			failures, err = api.state.MetricFailuresForUnit(tag.Id())
+		case names.ServiceTagKind:
+			failures, err = api.state.MetricFailuresForService(tag.Id())
+		default:
+			err = errors.Errorf("invalid tag %v", arg.Tag)
+		}
+		if err != nil {
+			err = errors.Annotate(err, "failed to get metric failures")
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := make([]params.MetricFailureResult, len(failures))
+		for j, f := range failures {
+			result[j] = params.MetricFailureResult{
+				Time:    f.Time(),
+				Unit:    f.Unit(),
+				Kind:    string(f.Kind()),
+				Message: f.Message(),
+			}
+		}
+		results.Results[i].Failures = result
+	}
+	return results, nil
+}
+
 // SetMeterStatus sets meter statuses for entities.
 func (api *MetricsDebugAPI) SetMeterStatus(args params.MeterStatusParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/metricsdebug"
@@ -275,3 +276,42 @@ func (s *metricsDebugSuite) TestGetMultipleMetricsNoMocksWithService(c *gc.C) {
 	c.Assert(metrics.Results[0].Metrics[1].Value, gc.Equals, metricUnit1.Metrics()[0].Value)
 	c.Assert(metrics.Results[0].Metrics[1].Time, jc.TimeBetween(metricUnit1.Metrics()[0].Time, metricUnit1.Metrics()[0].Time))
 }
+
+func (s *metricsDebugSuite) TestGetMetricFailures(c *gc.C) {
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	meteredService := s.Factory.MakeService(c, &factory.ServiceParams{Charm: meteredCharm})
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Service: meteredService, SetCharmURL: true})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Service: meteredService, SetCharmURL: true})
+	now := time.Now().UTC().Round(time.Second)
+	for i, unit := range []*state.Unit{unit0, unit1} {
+		_, err := s.State.AddMetricFailure(state.MetricFailureParam{
+			UUID:    utils.MustNewUUID().String(),
+			Unit:    unit.UnitTag(),
+			Kind:    state.MetricCollectFailure,
+			Message: "hook failed",
+			Time:    now.Add(time.Duration(i) * time.Minute),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := s.metricsdebug.GetMetricFailures(params.Entities{Entities: []params.Entity{
+		{"unit-metered/1"},
+		{"service-metered"},
+		{"machine-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0], jc.DeepEquals, params.EntityMetricFailures{
+		Failures: []params.MetricFailureResult{{
+			Time:    now.Add(time.Minute),
+			Unit:    "metered/1",
+			Kind:    "collect",
+			Message: "hook failed",
+		}},
+	})
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Failures, gc.HasLen, 2)
+	c.Assert(result.Results[1].Failures[0].Unit, gc.Equals, "metered/0")
+	c.Assert(result.Results[1].Failures[1].Unit, gc.Equals, "metered/1")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, "failed to get metric failures: invalid tag machine-0")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	wireformat "github.com/juju/romulus/wireformat/metrics"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -126,6 +127,38 @@ func DefaultMetricSender() MetricSender {
 	return defaultSender
 }
 
+// SenderForConfig returns the metric sender selected by the
+// metrics-sender setting of the given config. The "prometheus"
+// sender records the latest metric values in the given state.
+func SenderForConfig(st *state.State, cfg *config.Config) (MetricSender, error) {
+	switch sender := cfg.MetricsSender(); sender {
+	case config.MetricsSenderCharmStore:
+		return defaultSender, nil
+	case config.MetricsSenderPrometheus:
+		return &PrometheusSender{Store: st}, nil
+	case config.MetricsSenderHTTP:
+		return &PushSender{URL: cfg.MetricsPushURL()}, nil
+	case config.MetricsSenderNone:
+		return &NopSender{}, nil
+	default:
+		return nil, errors.NotValidf("metrics sender %q", sender)
+	}
+}
+
+// ControllerSender returns the metric sender selected by the
+// controller model's config.
+func ControllerSender(st *state.State) (MetricSender, error) {
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := controllerModel.Config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return SenderForConfig(st, cfg)
+}
+
 // ToWire converts the state.MetricBatch into a type
 // that can be sent over the wire to the collector.
 func ToWire(mb *state.MetricBatch) *wireformat.MetricBatch {
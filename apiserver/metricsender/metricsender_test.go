@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/metricsender/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -144,3 +145,38 @@ func (s *MetricSenderSuite) TestFailuresResetOnSuccessfulSend(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(mm.ConsecutiveErrors(), gc.Equals, 0)
 }
+
+func (s *MetricSenderSuite) TestSenderForConfig(c *gc.C) {
+	for i, test := range []struct {
+		attrs  coretesting.Attrs
+		sender interface{}
+	}{{
+		attrs:  coretesting.Attrs{},
+		sender: metricsender.DefaultMetricSender(),
+	}, {
+		attrs:  coretesting.Attrs{"metrics-sender": "prometheus"},
+		sender: &metricsender.PrometheusSender{},
+	}, {
+		attrs:  coretesting.Attrs{"metrics-sender": "http", "metrics-push-url": "https://metrics.example.com/push"},
+		sender: &metricsender.PushSender{URL: "https://metrics.example.com/push"},
+	}, {
+		attrs:  coretesting.Attrs{"metrics-sender": "none"},
+		sender: &metricsender.NopSender{},
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		sender, err := metricsender.SenderForConfig(s.State, coretesting.CustomModelConfig(c, test.attrs))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(sender, gc.FitsTypeOf, test.sender)
+		if push, ok := test.sender.(*metricsender.PushSender); ok {
+			c.Assert(sender, jc.DeepEquals, push)
+		}
+	}
+}
+
+func (s *MetricSenderSuite) TestControllerSender(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"metrics-sender": "none"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	sender, err := metricsender.ControllerSender(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender, gc.FitsTypeOf, &metricsender.NopSender{})
+}
@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+
+	"github.com/juju/juju/state"
+)
+
+// prometheusMetricName is the name of the metric family under which
+// charm metrics are exposed; the charm's metric key is a label, as keys
+// need not be valid Prometheus metric names.
+const prometheusMetricName = "juju_unit_metric"
+
+// PrometheusContentType is the content type of the Prometheus text
+// exposition format written by WritePrometheus.
+const PrometheusContentType = "text/plain; version=0.0.4"
+
+// WritePrometheus writes the given metric values in the Prometheus text
+// exposition format.
+func WritePrometheus(w io.Writer, metrics []state.LatestMetric) error {
+	lines := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		lines = append(lines, fmt.Sprintf("%s{model_uuid=%s,unit=%s,charm=%s,metric=%s} %s %d\n",
+			prometheusMetricName,
+			quoteLabel(m.ModelUUID),
+			quoteLabel(m.Unit),
+			quoteLabel(m.CharmURL),
+			quoteLabel(m.Key),
+			strconv.FormatFloat(m.Value, 'g', -1, 64),
+			m.Time.UnixNano()/int64(time.Millisecond),
+		))
+	}
+	sort.Strings(lines)
+
+	header := fmt.Sprintf("# HELP %s Latest value of a metric reported by a charm.\n# TYPE %s gauge\n",
+		prometheusMetricName, prometheusMetricName)
+	for _, line := range append([]string{header}, lines...) {
+		if _, err := io.WriteString(w, line); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func quoteLabel(value string) string {
+	return `"` + labelReplacer.Replace(value) + `"`
+}
+
+// LatestMetricsStore records the latest values of units' metrics.
+type LatestMetricsStore interface {
+	UpdateLatestMetrics([]state.LatestMetric) error
+}
+
+// PrometheusSender records the latest value of each metric in state,
+// for scraping from the controller's /metrics endpoint, rather than
+// sending metrics on elsewhere. Values which are not numbers are
+// ignored.
+type PrometheusSender struct {
+	Store LatestMetricsStore
+}
+
+// Send implements the MetricSender interface. Batches are acknowledged,
+// so that they are marked as sent, only once their values have been
+// recorded.
+func (s *PrometheusSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	var latest []state.LatestMetric
+	for _, batch := range batches {
+		for _, m := range batch.Metrics {
+			value, err := strconv.ParseFloat(m.Value, 64)
+			if err != nil {
+				logger.Debugf("ignoring metric %q of unit %q: %v", m.Key, batch.UnitName, err)
+				continue
+			}
+			latest = append(latest, state.LatestMetric{
+				ModelUUID: batch.ModelUUID,
+				Unit:      batch.UnitName,
+				CharmURL:  batch.CharmUrl,
+				Key:       m.Key,
+				Value:     value,
+				Time:      m.Time,
+			})
+		}
+	}
+	if err := s.Store.UpdateLatestMetrics(latest); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NopSender{}.Send(batches)
+}
@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/metricsender"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PrometheusSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&PrometheusSuite{})
+
+var _ metricsender.MetricSender = (*metricsender.PrometheusSender)(nil)
+
+type mockLatestMetricsStore struct {
+	gitjujutesting.Stub
+}
+
+func (s *mockLatestMetricsStore) UpdateLatestMetrics(metrics []state.LatestMetric) error {
+	s.MethodCall(s, "UpdateLatestMetrics", metrics)
+	return s.NextErr()
+}
+
+func makeBatch(uuid, unit string, t time.Time, metrics ...wireformat.Metric) *wireformat.MetricBatch {
+	for i := range metrics {
+		metrics[i].Time = t
+	}
+	return &wireformat.MetricBatch{
+		UUID:      uuid,
+		ModelUUID: "model-uuid",
+		UnitName:  unit,
+		CharmUrl:  "cs:quantal/metered",
+		Created:   t,
+		Metrics:   metrics,
+	}
+}
+
+func latestMetric(unit, key string, value float64, t time.Time) state.LatestMetric {
+	return state.LatestMetric{
+		ModelUUID: "model-uuid",
+		Unit:      unit,
+		CharmURL:  "cs:quantal/metered",
+		Key:       key,
+		Value:     value,
+		Time:      t,
+	}
+}
+
+func (s *PrometheusSuite) TestSendRecordsValuesAndAcknowledgesBatches(c *gc.C) {
+	now := time.Unix(1000, 0)
+	store := &mockLatestMetricsStore{}
+	sender := &metricsender.PrometheusSender{Store: store}
+	resp, err := sender.Send([]*wireformat.MetricBatch{
+		makeBatch("batch-1", "metered/0", now, wireformat.Metric{Key: "pings", Value: "5"}),
+		makeBatch("batch-2", "metered/1", now, wireformat.Metric{Key: "pings", Value: "6.5"}),
+		makeBatch("batch-3", "metered/0", now, wireformat.Metric{Key: "juju-units", Value: "not-a-number"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.EnvResponses["model-uuid"].AcknowledgedBatches, jc.SameContents, []string{"batch-1", "batch-2", "batch-3"})
+	store.CheckCall(c, 0, "UpdateLatestMetrics", []state.LatestMetric{
+		latestMetric("metered/0", "pings", 5, now),
+		latestMetric("metered/1", "pings", 6.5, now),
+	})
+}
+
+func (s *PrometheusSuite) TestSendErrorAcknowledgesNothing(c *gc.C) {
+	store := &mockLatestMetricsStore{}
+	store.SetErrors(errors.New("boom"))
+	sender := &metricsender.PrometheusSender{Store: store}
+	resp, err := sender.Send([]*wireformat.MetricBatch{
+		makeBatch("batch-1", "metered/0", time.Unix(1000, 0), wireformat.Metric{Key: "pings", Value: "5"}),
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(resp, gc.IsNil)
+}
+
+func (s *PrometheusSuite) TestWritePrometheus(c *gc.C) {
+	now := time.Unix(1000, 0)
+	var buf bytes.Buffer
+	err := metricsender.WritePrometheus(&buf, []state.LatestMetric{
+		latestMetric("metered/1", "pings", 7, now),
+		latestMetric("metered/0", "pings", 6.5, now.Add(time.Minute)),
+		latestMetric("metered/0", `quote"d`, 1, now),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `# HELP juju_unit_metric Latest value of a metric reported by a charm.
+# TYPE juju_unit_metric gauge
+juju_unit_metric{model_uuid="model-uuid",unit="metered/0",charm="cs:quantal/metered",metric="pings"} 6.5 1060000
+juju_unit_metric{model_uuid="model-uuid",unit="metered/0",charm="cs:quantal/metered",metric="quote\"d"} 1 1000000
+juju_unit_metric{model_uuid="model-uuid",unit="metered/1",charm="cs:quantal/metered",metric="pings"} 7 1000000
+`)
+}
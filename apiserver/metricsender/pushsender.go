@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+)
+
+// defaultPushTimeout bounds each push, so that an unresponsive
+// endpoint cannot stall the metrics sender.
+const defaultPushTimeout = 30 * time.Second
+
+// PushSender pushes metric batches as JSON to a generic HTTP endpoint.
+type PushSender struct {
+	URL string
+
+	// Timeout bounds the whole request, including reading the
+	// response. If it is zero, defaultPushTimeout is used.
+	Timeout time.Duration
+}
+
+// Send implements the MetricSender interface. The batches are posted as
+// a JSON array; any 2xx response acknowledges all of them.
+func (s *PushSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	b, err := json.Marshal(batches)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = defaultPushTimeout
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Post(s.URL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.Errorf("failed to push metrics http %v", resp.StatusCode)
+	}
+	return NopSender{}.Send(batches)
+}
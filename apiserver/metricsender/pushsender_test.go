@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/metricsender"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PushSenderSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&PushSenderSuite{})
+
+var _ metricsender.MetricSender = (*metricsender.PushSender)(nil)
+
+func (s *PushSenderSuite) TestSend(c *gc.C) {
+	var received []wireformat.MetricBatch
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(json.NewDecoder(r.Body).Decode(&received), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1000, 0).UTC()
+	sender := &metricsender.PushSender{URL: srv.URL}
+	resp, err := sender.Send([]*wireformat.MetricBatch{
+		makeBatch("batch-1", "metered/0", now, wireformat.Metric{Key: "pings", Value: "5"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.EnvResponses["model-uuid"].AcknowledgedBatches, jc.DeepEquals, []string{"batch-1"})
+	c.Assert(received, gc.HasLen, 1)
+	c.Assert(received[0].UUID, gc.Equals, "batch-1")
+	c.Assert(received[0].UnitName, gc.Equals, "metered/0")
+	c.Assert(received[0].Metrics, jc.DeepEquals, []wireformat.Metric{{Key: "pings", Value: "5", Time: now}})
+}
+
+func (s *PushSenderSuite) TestSendErrorStatus(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sender := &metricsender.PushSender{URL: srv.URL}
+	resp, err := sender.Send([]*wireformat.MetricBatch{
+		makeBatch("batch-1", "metered/0", time.Now(), wireformat.Metric{Key: "pings", Value: "5"}),
+	})
+	c.Assert(err, gc.ErrorMatches, "failed to push metrics http 503")
+	c.Assert(resp, gc.IsNil)
+}
+
+func (s *PushSenderSuite) TestSendTimeout(c *gc.C) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	sender := &metricsender.PushSender{URL: srv.URL, Timeout: coretesting.ShortWait}
+	resp, err := sender.Send([]*wireformat.MetricBatch{
+		makeBatch("batch-1", "metered/0", time.Now(), wireformat.Metric{Key: "pings", Value: "5"}),
+	})
+	c.Assert(err, gc.ErrorMatches, ".*(Client.Timeout exceeded|request canceled).*")
+	c.Assert(resp, gc.IsNil)
+}
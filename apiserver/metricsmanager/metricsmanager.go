@@ -20,7 +20,9 @@ var (
 	logger            = loggo.GetLogger("juju.apiserver.metricsmanager")
 	maxBatchesPerSend = metricsender.DefaultMaxBatchesPerSend()
 
-	sender = metricsender.DefaultMetricSender()
+	// sender overrides the metric sender selected by the controller's
+	// config when set; it is only set in tests.
+	sender metricsender.MetricSender
 )
 
 func init() {
@@ -125,7 +127,15 @@ func (api *MetricsManagerAPI) SendMetrics(args params.Entities) (params.ErrorRes
 			result.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		err = metricsender.SendMetrics(api.state, sender, maxBatchesPerSend)
+		modelSender := sender
+		if modelSender == nil {
+			modelSender, err = metricsender.ControllerSender(api.state)
+			if err != nil {
+				result.Results[i].Error = common.ServerError(err)
+				continue
+			}
+		}
+		err = metricsender.SendMetrics(api.state, modelSender, maxBatchesPerSend)
 		if err != nil {
 			err = errors.Annotate(err, "failed to send metrics")
 			logger.Warningf("%v", err)
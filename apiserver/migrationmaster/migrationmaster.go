@@ -115,6 +115,32 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 	return errors.Annotate(err, "failed to set phase")
 }
 
+// SetProgress records the counts of the work done so far by the
+// active model migration.
+func (api *API) SetProgress(args params.MigrationProgress) error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetProgress(state.MigrationProgress{
+		EntitiesExported: args.EntitiesExported,
+		EntitiesImported: args.EntitiesImported,
+		BinaryBytes:      args.BinaryBytes,
+	})
+	return errors.Annotate(err, "failed to set progress")
+}
+
+// MinionReports returns details of the reports made by the agents of
+// the model for the current phase of the latest model migration.
+func (api *API) MinionReports() (params.MinionReports, error) {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return params.MinionReports{}, errors.Annotate(err, "could not get migration")
+	}
+	reports, err := common.MigrationMinionReports(mig)
+	return reports, errors.Trace(err)
+}
+
 var exportModel = migration.ExportModel
 
 // Export serializes the model associated with the API connection.
@@ -127,6 +127,46 @@ func (s *Suite) TestSetPhaseError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set phase: blam")
 }
 
+func (s *Suite) TestSetProgress(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetProgress(params.MigrationProgress{
+		EntitiesExported: 5,
+		BinaryBytes:      100,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.migration.progressSet, gc.Equals, state.MigrationProgress{
+		EntitiesExported: 5,
+		BinaryBytes:      100,
+	})
+}
+
+func (s *Suite) TestSetProgressNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetProgress(params.MigrationProgress{})
+	c.Assert(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
+func (s *Suite) TestMinionReports(c *gc.C) {
+	s.backend.migration.minionReports = &state.MinionReports{
+		Succeeded: []names.Tag{names.NewMachineTag("0")},
+		Failed:    []names.Tag{names.NewUnitTag("foo/0")},
+		Unknown:   []names.Tag{names.NewMachineTag("1"), names.NewUnitTag("bar/1")},
+	}
+	api := s.mustMakeAPI(c)
+
+	reports, err := api.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, params.MinionReports{
+		Phase:     "READONLY",
+		Succeeded: []string{"machine-0"},
+		Failed:    []string{"unit-foo-0"},
+		Unknown:   []string{"machine-1", "unit-bar-1"},
+	})
+}
+
 func (s *Suite) TestExport(c *gc.C) {
 	exportModel := func(migration.StateExporter) ([]byte, error) {
 		return []byte("foo"), nil
@@ -176,8 +216,10 @@ func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
 
 type stubMigration struct {
 	state.ModelMigration
-	setPhaseErr error
-	phaseSet    coremigration.Phase
+	setPhaseErr   error
+	phaseSet      coremigration.Phase
+	progressSet   state.MigrationProgress
+	minionReports *state.MinionReports
 }
 
 func (m *stubMigration) Phase() (coremigration.Phase, error) {
@@ -210,6 +252,15 @@ func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (m *stubMigration) SetProgress(progress state.MigrationProgress) error {
+	m.progressSet = progress
+	return nil
+}
+
+func (m *stubMigration) MinionReports() (*state.MinionReports, error) {
+	return m.minionReports, nil
+}
+
 var modelUUID string
 var controllerUUID string
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -56,3 +57,18 @@ func (api *API) Watch() (params.NotifyWatchResult, error) {
 		NotifyWatcherId: api.resources.Register(w),
 	}, nil
 }
+
+// Report allows a migration minion to submit whether it succeeded or
+// failed to complete its actions for a specific migration phase.
+func (api *API) Report(info params.MinionReport) error {
+	phase, ok := coremigration.ParsePhase(info.Phase)
+	if !ok {
+		return errors.Errorf("invalid phase: %q", info.Phase)
+	}
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SubmitMinionReport(api.authorizer.GetAuthTag(), phase, info.Success)
+	return errors.Trace(err)
+}
@@ -11,7 +11,9 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationminion"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -74,6 +76,33 @@ func (s *Suite) TestWatch(c *gc.C) {
 	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
 }
 
+func (s *Suite) TestReport(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		Phase:   "QUIESCE",
+		Success: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.migration.reports, jc.DeepEquals, []minionReport{{
+		tag:     names.NewMachineTag("99"),
+		phase:   coremigration.QUIESCE,
+		success: true,
+	}})
+}
+
+func (s *Suite) TestReportBadPhase(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{Phase: "WAT"})
+	c.Assert(err, gc.ErrorMatches, `invalid phase: "WAT"`)
+}
+
+func (s *Suite) TestReportNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{Phase: "QUIESCE"})
+	c.Assert(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
 func (s *Suite) makeAPI() (*migrationminion.API, error) {
 	return migrationminion.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -87,6 +116,8 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationminion.API {
 type stubBackend struct {
 	migrationminion.Backend
 	watchError error
+	getErr     error
+	migration  stubMigration
 }
 
 func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
@@ -95,3 +126,26 @@ func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
 	}
 	return apiservertesting.NewFakeNotifyWatcher(), nil
 }
+
+func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
+	if b.getErr != nil {
+		return nil, b.getErr
+	}
+	return &b.migration, nil
+}
+
+type minionReport struct {
+	tag     names.Tag
+	phase   coremigration.Phase
+	success bool
+}
+
+type stubMigration struct {
+	state.ModelMigration
+	reports []minionReport
+}
+
+func (m *stubMigration) SubmitMinionReport(tag names.Tag, phase coremigration.Phase, success bool) error {
+	m.reports = append(m.reports, minionReport{tag, phase, success})
+	return nil
+}
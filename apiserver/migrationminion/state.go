@@ -9,6 +9,7 @@ import "github.com/juju/juju/state"
 // MigrationMinion facade.
 type Backend interface {
 	WatchMigrationStatus() (state.NotifyWatcher, error)
+	GetModelMigration() (state.ModelMigration, error)
 }
 
 var getBackend = func(st *state.State) Backend {
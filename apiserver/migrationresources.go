@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// migrationResourcesHandler transfers resource content between
+// controllers during a model migration. The model is identified by the
+// URL, but as the migration is driven by the controllers rather than by
+// users of the model, the caller is authenticated against the controller
+// model. Downloading (GET) is allowed for controller machine agents and
+// controller administrators, uploading (POST) only for controller
+// administrators.
+type migrationResourcesHandler struct {
+	ctxt httpContext
+}
+
+func (h *migrationResourcesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	st, entity, err := h.stateForRequest(r)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	serviceID := query.Get("service")
+	name := query.Get("name")
+	if serviceID == "" || name == "" {
+		sendError(w, errors.BadRequestf("missing service or resource name"))
+		return
+	}
+	resources, err := st.Resources()
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		logger.Infof("handling migration download request for resource %s/%s", serviceID, name)
+		if err := h.processGet(w, resources, serviceID, name); err != nil {
+			sendError(w, err)
+			return
+		}
+	case "POST":
+		if _, ok := entity.Tag().(names.UserTag); !ok {
+			sendError(w, common.ErrPerm)
+			return
+		}
+		logger.Infof("handling migration upload request for resource %s/%s", serviceID, name)
+		defer r.Body.Close()
+		if err := resources.ImportResourceBlob(serviceID, name, r.Body); err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.ErrorResult{})
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// stateForRequest authenticates the request against the controller
+// model, and returns the state for the model identified by the request
+// along with the authenticated entity.
+func (h *migrationResourcesHandler) stateForRequest(r *http.Request) (*state.State, state.Entity, error) {
+	systemState := h.ctxt.srv.statePool.SystemState()
+	req, err := h.ctxt.loginRequest(r)
+	if err != nil {
+		return nil, nil, errors.NewUnauthorized(err, "")
+	}
+	entity, _, err := checkCreds(systemState, req, true, h.ctxt.srv.authCtxt)
+	if err != nil {
+		if !common.IsDischargeRequiredError(err) {
+			err = errors.NewUnauthorized(err, "")
+		}
+		return nil, nil, errors.Trace(err)
+	}
+
+	switch tag := entity.Tag().(type) {
+	case names.UserTag:
+		isAdmin, err := systemState.IsControllerAdministrator(tag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if !isAdmin {
+			return nil, nil, errors.Trace(common.ErrPerm)
+		}
+	case names.MachineTag:
+		machine, ok := entity.(*state.Machine)
+		if !ok || !machine.IsManager() {
+			return nil, nil, errors.Trace(common.ErrPerm)
+		}
+	default:
+		return nil, nil, errors.Trace(common.ErrPerm)
+	}
+
+	st, err := h.ctxt.stateForRequestUnauthenticated(r)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return st, entity, nil
+}
+
+func (h *migrationResourcesHandler) processGet(w http.ResponseWriter, resources state.Resources, serviceID, name string) error {
+	res, reader, err := resources.OpenResource(serviceID, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", params.ContentTypeRaw)
+	w.Header().Set("Content-Length", fmt.Sprint(res.Size))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, reader); err != nil {
+		// The headers have already been sent, so all we can do is log.
+		logger.Errorf("failed to send resource %s/%s: %v", serviceID, name, err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -58,15 +59,62 @@ func checkAuth(authorizer common.Authorizer, st *state.State) error {
 }
 
 // Import takes a serialized Juju model, deserializes it, and
-// recreates it in the receiving controller.
-func (api *API) Import(serialized params.SerializedModel) error {
+// recreates it in the receiving controller. The number of entities
+// recreated is returned, so that the migration master can report it.
+func (api *API) Import(serialized params.SerializedModel) (params.ImportResult, error) {
+	var result params.ImportResult
 	_, st, err := migration.ImportModel(api.state, serialized.Bytes)
 	if err != nil {
-		return err
+		return result, err
 	}
 	defer st.Close()
 	// TODO(mjs) - post import checks
-	return err
+	result.EntitiesImported, err = countEntities(st)
+	return result, errors.Trace(err)
+}
+
+// countEntities returns the number of machines (including
+// containers), services, units and relations in the model, the same
+// entities that the migration master counts when exporting.
+func countEntities(st *state.State) (int, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	relations, err := st.AllRelations()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	services, err := st.AllServices()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	count := len(machines) + len(relations) + len(services)
+	for _, service := range services {
+		units, err := service.AllUnits()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		count += len(units)
+	}
+	return count, nil
+}
+
+// DryRun checks whether a serialized Juju model could be imported into
+// the receiving controller. The model is imported into a scratch model
+// which is removed again, and every problem found is reported.
+func (api *API) DryRun(serialized params.SerializedModel) (params.MigrationIssues, error) {
+	var result params.MigrationIssues
+	model, err := description.Deserialize(serialized.Bytes)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	issues, err := migration.DryRunImport(api.state, model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Issues = migration.IssuesToParams(issues)
+	return result, nil
 }
 
 func (api *API) getModel(args params.ModelArgs) (*state.Model, error) {
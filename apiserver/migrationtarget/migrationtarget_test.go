@@ -82,7 +82,7 @@ func (s *Suite) TestNotControllerAdmin(c *gc.C) {
 
 func (s *Suite) importModel(c *gc.C, api *migrationtarget.API) names.ModelTag {
 	uuid, bytes := s.makeExportedModel(c)
-	err := api.Import(params.SerializedModel{Bytes: bytes})
+	_, err := api.Import(params.SerializedModel{Bytes: bytes})
 	c.Assert(err, jc.ErrorIsNil)
 	return names.NewModelTag(uuid)
 }
@@ -97,6 +97,59 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportReportsEntities(c *gc.C) {
+	// A unit, and the service and machine it needs.
+	s.Factory.MakeUnit(c, nil)
+	api := s.mustNewAPI(c)
+	_, bytes := s.makeExportedModel(c)
+
+	result, err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.EntitiesImported, gc.Equals, 3)
+}
+
+func (s *Suite) TestDryRun(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.DryRun(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, gc.HasLen, 0)
+
+	// Nothing is left behind.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+}
+
+func (s *Suite) TestDryRunExistingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+	_, bytes := s.makeExportedModel(c)
+	model, err := description.Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{"uuid": tag.Id()})
+	bytes, err = description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.DryRun(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, gc.HasLen, 2)
+	c.Check(result.Issues[0], jc.DeepEquals, params.MigrationIssue{
+		Phase:   "PRECHECK",
+		Message: "model with UUID " + tag.Id() + " already exists",
+	})
+	c.Check(result.Issues[1].Phase, gc.Equals, "PRECHECK")
+	c.Check(result.Issues[1].Message, gc.Matches, `owner .* already has a model called "some-model"`)
+}
+
+func (s *Suite) TestDryRunBadBytes(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.DryRun(params.SerializedModel{Bytes: []byte("not a model")})
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -92,7 +92,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		Users: []params.ModelUserInfo{{
 			UserName:       "admin",
 			LastConnection: &time.Time{},
-			Access:         params.ModelWriteAccess,
+			Access:         params.ModelAdminAccess,
 		}, {
 			UserName:       "bob@local",
 			DisplayName:    "Bob",
@@ -134,6 +134,36 @@ func (s *modelInfoSuite) TestModelInfoNonOwner(c *gc.C) {
 	c.Assert(info.Users[0].UserName, gc.Equals, "charlotte@local")
 }
 
+func (s *modelInfoSuite) TestModelInfoV2ReportsAdminAsWrite(c *gc.C) {
+	api, err := modelmanager.NewModelManagerAPIV2(s.st, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.ModelInfo(params.Entities{
+		Entities: []params.Entity{{
+			names.NewModelTag(s.st.model.cfg.UUID()).String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	users := results.Results[0].Result.Users
+	c.Assert(users, gc.HasLen, 3)
+	c.Assert(users[0].Access, gc.Equals, params.ModelWriteAccess)
+	c.Assert(users[1].Access, gc.Equals, params.ModelReadAccess)
+}
+
+func (s *modelInfoSuite) TestCreateModelWithoutControllerAccess(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob@local"))
+	_, err := s.modelmanager.CreateModel(params.ModelCreateArgs{
+		OwnerTag: names.NewUserTag("bob@local").String(),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.st.CheckCallNames(c,
+		"IsControllerAdministrator", "ModelUUID",
+		"IsControllerAdministrator", "ModelUUID",
+		"ControllerModel", "ControllerAccess",
+	)
+}
+
 func (s *modelInfoSuite) getModelInfo(c *gc.C) params.ModelInfo {
 	results, err := s.modelmanager.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -193,10 +223,11 @@ type mockState struct {
 	common.ModelConfigGetter
 	common.ToolsStorageGetter
 
-	uuid  string
-	model *mockModel
-	owner names.UserTag
-	users []*state.ModelUser
+	uuid             string
+	model            *mockModel
+	owner            names.UserTag
+	users            []*state.ModelUser
+	controllerAccess state.ControllerAccess
 }
 
 func (st *mockState) ModelUUID() string {
@@ -214,6 +245,17 @@ func (st *mockState) IsControllerAdministrator(user names.UserTag) (bool, error)
 	return user.Canonical() == "admin@local", st.NextErr()
 }
 
+func (st *mockState) ControllerAccess(user names.UserTag) (state.ControllerAccess, error) {
+	st.MethodCall(st, "ControllerAccess", user)
+	if err := st.NextErr(); err != nil {
+		return "", err
+	}
+	if st.controllerAccess == "" {
+		return "", errors.NotFoundf("controller access for %q", user.Canonical())
+	}
+	return st.controllerAccess, nil
+}
+
 func (st *mockState) NewModel(args state.ModelArgs) (*state.Model, *state.State, error) {
 	st.MethodCall(st, "NewModel", args)
 	return nil, nil, st.NextErr()
@@ -28,7 +28,13 @@ import (
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
 func init() {
-	common.RegisterStandardFacade("ModelManager", 2, newFacade)
+	common.RegisterStandardFacade("ModelManager", 2, newFacadeV2)
+
+	// Version 3 has the same set of methods as 2, with the same
+	// signatures, but distinguishes model write access from admin
+	// access. Version 2 clients know only "read" and "write", where
+	// "write" means what is now "admin".
+	common.RegisterStandardFacade("ModelManager", 3, newFacade)
 }
 
 // ModelManager defines the methods on the modelmanager API endpoint.
@@ -54,6 +60,14 @@ func newFacade(st *state.State, resources *common.Resources, auth common.Authori
 	return NewModelManagerAPI(NewStateBackend(st), auth)
 }
 
+func newFacadeV2(st *state.State, resources *common.Resources, auth common.Authorizer) (*ModelManagerAPIV2, error) {
+	api, err := newFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV2{api}, nil
+}
+
 // NewModelManagerAPI creates a new api server endpoint for managing
 // models.
 func NewModelManagerAPI(st Backend, authorizer common.Authorizer) (*ModelManagerAPI, error) {
@@ -96,6 +110,24 @@ func (m *ModelManagerAPI) authCheck(user names.UserTag) error {
 	return common.ErrPerm
 }
 
+// checkCanAddModel checks that the user is allowed to add models to the
+// controller.
+func (m *ModelManagerAPI) checkCanAddModel() error {
+	if m.isAdmin {
+		return nil
+	}
+	access, err := m.state.ControllerAccess(m.apiUser)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !access.EqualOrGreaterThan(state.ControllerAddModelAccess) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // ConfigSource describes a type that is able to provide config.
 // Abstracted primarily for testing.
 type ConfigSource interface {
@@ -200,13 +232,16 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		return result, errors.Trace(err)
 	}
 
-	// Any user is able to create themselves an model (until real fine
-	// grain permissions are available), and admins (the creator of the state
+	// Users granted add-model access to the controller are able to
+	// create themselves a model, and admins (the creator of the state
 	// server model) are able to create models for other people.
 	err = mm.authCheck(ownerTag)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
+	if err := mm.checkCanAddModel(); err != nil {
+		return result, errors.Trace(err)
+	}
 
 	newConfig, err := mm.newModelConfig(args, controllerModel)
 	if err != nil {
@@ -402,9 +437,8 @@ func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error
 	case permission.ModelReadAccess:
 		return state.ModelReadAccess, nil
 	case permission.ModelWriteAccess:
-		// TODO: Initially, we'll map "write" access to admin-level access.
-		// Post Juju-2.0, support for more nuanced access will be added to the
-		// permission business logic and state model.
+		return state.ModelWriteAccess, nil
+	case permission.ModelAdminAccess:
 		return state.ModelAdminAccess, nil
 	}
 	logger.Errorf("invalid access permission: %+v", access)
@@ -413,15 +447,8 @@ func resolveStateAccess(access permission.ModelAccess) (state.ModelAccess, error
 
 // isGreaterAccess returns whether the new access provides more permissions
 // than the current access.
-// TODO(cmars): If/when more access types are implemented in state,
-//   the implementation of this function will certainly need to change, and it
-//   should be abstracted away to juju/permission as pure business logic
-//   instead of operating on state values.
 func isGreaterAccess(currentAccess, newAccess state.ModelAccess) bool {
-	if currentAccess == state.ModelReadAccess && newAccess == state.ModelAdminAccess {
-		return true
-	}
-	return false
+	return !currentAccess.EqualOrGreaterThan(newAccess)
 }
 
 func userAuthorizedToChangeAccess(st Backend, userIsAdmin bool, userTag names.UserTag) error {
@@ -495,23 +522,30 @@ func ChangeModelAccess(accessor Backend, modelTag names.ModelTag, apiUser, targe
 		return errors.Annotate(err, "could not grant model access")
 
 	case params.RevokeModelAccess:
-		if stateAccess == state.ModelReadAccess {
+		var remainingAccess state.ModelAccess
+		switch stateAccess {
+		case state.ModelReadAccess:
 			// Revoking read access removes all access.
 			err := st.RemoveModelUser(targetUserTag)
 			return errors.Annotate(err, "could not revoke model access")
-
-		} else if stateAccess == state.ModelAdminAccess {
-			// Revoking admin access sets read-only.
-			modelUser, err := st.ModelUser(targetUserTag)
-			if err != nil {
-				return errors.Annotate(err, "could not look up model access for user")
-			}
-			err = modelUser.SetAccess(state.ModelReadAccess)
-			return errors.Annotate(err, "could not set model access to read-only")
-
-		} else {
+		case state.ModelWriteAccess:
+			// Revoking write access sets read-only.
+			remainingAccess = state.ModelReadAccess
+		case state.ModelAdminAccess:
+			// Revoking admin access leaves write access.
+			remainingAccess = state.ModelWriteAccess
+		default:
 			return errors.Errorf("don't know how to revoke %q access", stateAccess)
 		}
+		modelUser, err := st.ModelUser(targetUserTag)
+		if err != nil {
+			return errors.Annotate(err, "could not look up model access for user")
+		}
+		if !modelUser.Access().EqualOrGreaterThan(stateAccess) {
+			return errors.Errorf("user does not have %q access", stateAccess)
+		}
+		err = modelUser.SetAccess(remainingAccess)
+		return errors.Annotatef(err, "could not set model access to %q", remainingAccess)
 
 	default:
 		return errors.Errorf("unknown action %q", action)
@@ -526,6 +560,58 @@ func FromModelAccessParam(paramAccess params.ModelAccessPermission) (permission.
 		return permission.ModelReadAccess, nil
 	case params.ModelWriteAccess:
 		return permission.ModelWriteAccess, nil
+	case params.ModelAdminAccess:
+		return permission.ModelAdminAccess, nil
 	}
 	return fail, errors.Errorf("invalid model access permission %q", paramAccess)
 }
+
+// ModelManagerAPIV2 implements version 2 of the model manager API,
+// which predates the split of model write and admin access.
+type ModelManagerAPIV2 struct {
+	*ModelManagerAPI
+}
+
+// NewModelManagerAPIV2 creates a new api server endpoint for managing
+// models, for clients that only know read and write model access.
+func NewModelManagerAPIV2(st Backend, authorizer common.Authorizer) (*ModelManagerAPIV2, error) {
+	api, err := NewModelManagerAPI(st, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV2{api}, nil
+}
+
+// ModelInfo returns information about the specified models, reporting
+// admin access as write access.
+func (m *ModelManagerAPIV2) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
+	results, err := m.ModelManagerAPI.ModelInfo(args)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for _, result := range results.Results {
+		if result.Result == nil {
+			continue
+		}
+		for i, user := range result.Result.Users {
+			if user.Access == params.ModelAdminAccess {
+				result.Result.Users[i].Access = params.ModelWriteAccess
+			}
+		}
+	}
+	return results, nil
+}
+
+// ModifyModelAccess changes the model access granted to users. Write
+// access is granted as admin access, as it was before write and admin
+// access were distinguished.
+func (m *ModelManagerAPIV2) ModifyModelAccess(args params.ModifyModelAccessRequest) (params.ErrorResults, error) {
+	changes := make([]params.ModifyModelAccess, len(args.Changes))
+	for i, arg := range args.Changes {
+		if arg.Access == params.ModelWriteAccess {
+			arg.Access = params.ModelAdminAccess
+		}
+		changes[i] = arg
+	}
+	return m.ModelManagerAPI.ModifyModelAccess(params.ModifyModelAccessRequest{Changes: changes})
+}
@@ -101,6 +101,8 @@ func (s *modelManagerSuite) createArgsForVersion(c *gc.C, owner names.UserTag, v
 
 func (s *modelManagerSuite) TestUserCanCreateModel(c *gc.C) {
 	owner := names.NewUserTag("external@remote")
+	err := s.State.SetControllerAccess(owner, s.AdminUserTag(c), state.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
 	s.setAPIUser(c, owner)
 	model, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
 	c.Assert(err, jc.ErrorIsNil)
@@ -108,6 +110,13 @@ func (s *modelManagerSuite) TestUserCanCreateModel(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "test-model")
 }
 
+func (s *modelManagerSuite) TestUserWithoutAddModelCannotCreateModel(c *gc.C) {
+	owner := names.NewUserTag("external@remote")
+	s.setAPIUser(c, owner)
+	_, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerSuite) TestAdminCanCreateModelForSomeoneElse(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	owner := names.NewUserTag("external@remote")
@@ -363,10 +372,22 @@ func (s *modelManagerSuite) TestGrantMissingModelFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, expectedErr)
 }
 
-func (s *modelManagerSuite) TestRevokeAdminLeavesReadAccess(c *gc.C) {
+func (s *modelManagerSuite) TestRevokeAdminLeavesWriteAccess(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
 
+	err := s.revoke(c, user.UserTag(), params.ModelAdminAccess, user.ModelTag())
+	c.Assert(err, gc.IsNil)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+}
+
+func (s *modelManagerSuite) TestRevokeWriteLeavesReadAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelWriteAccess})
+
 	err := s.revoke(c, user.UserTag(), params.ModelWriteAccess, user.ModelTag())
 	c.Assert(err, gc.IsNil)
 
@@ -375,6 +396,26 @@ func (s *modelManagerSuite) TestRevokeAdminLeavesReadAccess(c *gc.C) {
 	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
 }
 
+func (s *modelManagerSuite) TestRevokeWriteFromAdminLeavesReadAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelAdminAccess})
+
+	err := s.revoke(c, user.UserTag(), params.ModelWriteAccess, user.ModelTag())
+	c.Assert(err, gc.IsNil)
+
+	modelUser, err := s.State.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.ReadOnly(), jc.IsTrue)
+}
+
+func (s *modelManagerSuite) TestRevokeAccessNotHeld(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	user := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ModelReadAccess})
+
+	err := s.revoke(c, user.UserTag(), params.ModelAdminAccess, user.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `user does not have "admin" access`)
+}
+
 func (s *modelManagerSuite) TestRevokeReadRemovesModelUser(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	user := s.Factory.MakeModelUser(c, nil)
@@ -459,11 +500,28 @@ func (s *modelManagerSuite) TestGrantModelAddAdminUser(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
 
+	err := s.grant(c, user.UserTag(), params.ModelAdminAccess, st.ModelTag())
+
+	modelUser, err := st.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNewUser(c, modelUser, user.UserTag(), apiUser)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
+}
+
+func (s *modelManagerSuite) TestGrantModelAddWriteUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", NoModelUser: true})
+	apiUser := s.AdminUserTag(c)
+	s.setAPIUser(c, apiUser)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
 	err := s.grant(c, user.UserTag(), params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
 
 	modelUser, err := st.ModelUser(user.UserTag())
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertNewUser(c, modelUser, user.UserTag(), apiUser)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
 	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
 }
 
@@ -479,6 +537,13 @@ func (s *modelManagerSuite) TestGrantModelIncreaseAccess(c *gc.C) {
 
 	modelUser, err := st.ModelUser(user.UserTag())
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+
+	err = s.grant(c, user.UserTag(), params.ModelAdminAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err = st.ModelUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(modelUser.Access(), gc.Equals, state.ModelAdminAccess)
 }
 
@@ -509,6 +574,21 @@ func (s *modelManagerSuite) TestGrantToModelReadAccess(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *modelManagerSuite) TestGrantToModelWriteOnlyAccess(c *gc.C) {
+	apiUser := names.NewUserTag("bob@remote")
+	s.setAPIUser(c, apiUser)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stFactory := factory.NewFactory(st)
+	stFactory.MakeModelUser(c, &factory.ModelUserParams{
+		User: apiUser.Canonical(), Access: state.ModelWriteAccess})
+
+	other := names.NewUserTag("other@remote")
+	err := s.grant(c, other, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerSuite) TestGrantToModelWriteAccess(c *gc.C) {
 	apiUser := names.NewUserTag("bob@remote")
 	s.setAPIUser(c, apiUser)
@@ -20,6 +20,7 @@ type Backend interface {
 	ModelUUID() string
 	ModelsForUser(names.UserTag) ([]*state.UserModel, error)
 	IsControllerAdministrator(user names.UserTag) (bool, error)
+	ControllerAccess(user names.UserTag) (state.ControllerAccess, error)
 	NewModel(state.ModelArgs) (*state.Model, *state.State, error)
 	ControllerModel() (*state.Model, error)
 	ForModel(tag names.ModelTag) (Backend, error)
@@ -43,6 +43,30 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timeout is how long the action may run before it is failed;
+	// zero means the action may run indefinitely.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// ServiceActions is a slice of ServiceAction for bulk requests.
+type ServiceActions struct {
+	Actions []ServiceAction `json:"actions,omitempty"`
+}
+
+// ServiceAction describes an Action to be queued up on the units of a
+// service.
+type ServiceAction struct {
+	ServiceTag string                 `json:"service-tag"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Timeout is how long each action may run before it is failed;
+	// zero means the actions may run indefinitely.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// LeaderOnly restricts the action to the service's leader unit.
+	LeaderOnly bool `json:"leader-only,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -60,6 +84,25 @@ type ActionResult struct {
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
+
+	// CancelRequested is set when the action was cancelled while it
+	// was running, and the receiver has not yet stopped it.
+	CancelRequested bool `json:"cancel-requested,omitempty"`
+
+	// Log holds the progress messages logged by the action, oldest
+	// first.
+	Log []ActionMessage `json:"log,omitempty"`
+}
+
+// ActionMessage is a timestamped progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// ActionMessageParams holds the progress messages to log to actions.
+type ActionMessageParams struct {
+	Messages []EntityString `json:"messages"`
 }
 
 // ActionsByReceivers wrap a slice of Actions for API calls.
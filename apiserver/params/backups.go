@@ -12,6 +12,11 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string
+
+	// EncryptionKey and EncryptionPublicKey are used to encrypt the
+	// backup archive. At most one of them may be set.
+	EncryptionKey       string
+	EncryptionPublicKey string
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -59,13 +64,15 @@ type BackupsMetadataResult struct {
 	Size           int64
 	Stored         time.Time // May be zero...
 
-	Started  time.Time
-	Finished time.Time // May be zero...
-	Notes    string
-	Model    string
-	Machine  string
-	Hostname string
-	Version  version.Number
+	Started   time.Time
+	Finished  time.Time // May be zero...
+	Notes     string
+	Scheduled bool
+	Encrypted bool
+	Model     string
+	Machine   string
+	Hostname  string
+	Version   version.Number
 
 	CACert       string
 	CAPrivateKey string
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -46,3 +48,32 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// AuditLogFilter holds the criteria used to select entries from the
+// controller's audit log. Empty fields do not restrict the results.
+type AuditLogFilter struct {
+	UserTag  string     `json:"user-tag,omitempty"`
+	ModelTag string     `json:"model-tag,omitempty"`
+	Facade   string     `json:"facade,omitempty"`
+	From     *time.Time `json:"from,omitempty"`
+	To       *time.Time `json:"to,omitempty"`
+	Limit    int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single entry from the controller's audit log.
+type AuditLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	UserTag   string    `json:"user-tag"`
+	ModelTag  string    `json:"model-tag"`
+	Facade    string    `json:"facade"`
+	Version   int       `json:"version"`
+	Method    string    `json:"method"`
+	Args      string    `json:"args,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditLogResults holds the entries returned from the controller's
+// audit log.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -620,6 +620,26 @@ type MetricBatchParams struct {
 	Batches []MetricBatchParam
 }
 
+// MetricFailure describes a unit's failure to collect, spool or
+// send metrics.
+type MetricFailure struct {
+	UUID    string
+	Kind    string
+	Message string
+	Time    time.Time
+}
+
+// MetricFailureParam contains a single metric failure.
+type MetricFailureParam struct {
+	Tag     string
+	Failure MetricFailure
+}
+
+// MetricFailureParams contains multiple metric failures.
+type MetricFailureParams struct {
+	Failures []MetricFailureParam
+}
+
 // MeterStatusResult holds unit meter status or error.
 type MeterStatusResult struct {
 	Code  string
@@ -36,3 +36,35 @@ type MetricResult struct {
 	Key   string    `json:"key"`
 	Value string    `json:"value"`
 }
+
+// MetricFailureResults contains results from a GetMetricFailures call,
+// with one item per Entity given as an argument to the command.
+type MetricFailureResults struct {
+	Results []EntityMetricFailures `json:"results"`
+}
+
+// OneError returns the first error
+func (m *MetricFailureResults) OneError() error {
+	for _, r := range m.Results {
+		if err := r.Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EntityMetricFailures contains the results of a GetMetricFailures call
+// for a single entity.
+type EntityMetricFailures struct {
+	Failures []MetricFailureResult `json:"failures,omitempty"`
+	Error    *Error                `json:"error,omitempty"`
+}
+
+// MetricFailureResult contains a single failure to collect, spool or
+// send metrics.
+type MetricFailureResult struct {
+	Time    time.Time `json:"time"`
+	Unit    string    `json:"unit"`
+	Kind    string    `json:"kind"`
+	Message string    `json:"message"`
+}
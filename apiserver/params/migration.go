@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // InitiateModelMigrationArgs holds the details required to start one
 // or more model migrations.
 type InitiateModelMigrationArgs struct {
@@ -51,6 +53,64 @@ type SerializedModel struct {
 	Bytes []byte `json:"bytes"`
 }
 
+// SerializedModelResult holds the serialized form of a single model,
+// or the error encountered while exporting it.
+type SerializedModelResult struct {
+	Bytes []byte `json:"bytes"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// SerializedModelResults holds the result of exporting one or more
+// models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
+// ImportResult holds the result of importing a serialized model
+// during a migration.
+type ImportResult struct {
+	// EntitiesImported holds the number of machines, services, units
+	// and relations created in the target controller.
+	EntitiesImported int `json:"entities-imported"`
+}
+
+// ImportModelArgs holds a serialized model to be created in a
+// controller. If Name is set the model is renamed, and if NewUUID is
+// true the model is given a new UUID, allowing a copy of a model to be
+// created alongside the original. NewUUID is refused for models with
+// provisioned machines, as their agents are not redirected to the copy.
+type ImportModelArgs struct {
+	Bytes   []byte `json:"bytes"`
+	Name    string `json:"name,omitempty"`
+	NewUUID bool   `json:"new-uuid,omitempty"`
+}
+
+// MigrationIssue describes a problem that would cause a model
+// migration to fail, and the migration phase that would fail.
+type MigrationIssue struct {
+	Phase   string `json:"phase"`
+	Message string `json:"message"`
+}
+
+// MigrationIssues holds the problems found when checking whether a
+// model can be migrated.
+type MigrationIssues struct {
+	Issues []MigrationIssue `json:"issues"`
+}
+
+// MigrationIssuesResult holds the problems found when checking whether
+// a model can be migrated, or an error if the checks could not be run.
+type MigrationIssuesResult struct {
+	Issues []MigrationIssue `json:"issues"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// MigrationIssuesResults holds the results of checking whether a
+// number of models can be migrated.
+type MigrationIssuesResults struct {
+	Results []MigrationIssuesResult `json:"results"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
@@ -86,3 +146,55 @@ type PhaseResult struct {
 type PhaseResults struct {
 	Results []PhaseResult `json:"Results"`
 }
+
+// MigrationProgress holds counts of the work done by a model
+// migration.
+type MigrationProgress struct {
+	EntitiesExported int   `json:"entities-exported"`
+	EntitiesImported int   `json:"entities-imported"`
+	BinaryBytes      int64 `json:"binary-bytes"`
+}
+
+// MinionReport holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReport struct {
+	Phase   string `json:"phase"`
+	Success bool   `json:"success"`
+}
+
+// MinionReports holds the tags of the agents which have reported
+// success or failure for a migration phase, and of those which are
+// yet to report.
+type MinionReports struct {
+	Phase     string   `json:"phase"`
+	Succeeded []string `json:"succeeded"`
+	Failed    []string `json:"failed"`
+	Unknown   []string `json:"unknown"`
+}
+
+// ModelMigrationStatus describes the latest migration attempt for a
+// model, and how far it has got.
+type ModelMigrationStatus struct {
+	Id               string            `json:"id"`
+	Attempt          int               `json:"attempt"`
+	Phase            string            `json:"phase"`
+	StatusMessage    string            `json:"status-message,omitempty"`
+	StartTime        time.Time         `json:"start-time"`
+	PhaseChangedTime time.Time         `json:"phase-changed-time"`
+	EndTime          *time.Time        `json:"end-time,omitempty"`
+	Progress         MigrationProgress `json:"progress"`
+	MinionReports    MinionReports     `json:"minion-reports"`
+}
+
+// ModelMigrationStatusResult holds the status of the latest migration
+// attempt for a model, or an error.
+type ModelMigrationStatusResult struct {
+	Status *ModelMigrationStatus `json:"status,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// ModelMigrationStatusResults holds the results of requesting the
+// migration status of a number of models.
+type ModelMigrationStatusResults struct {
+	Results []ModelMigrationStatusResult `json:"results"`
+}
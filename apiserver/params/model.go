@@ -138,4 +138,37 @@ type ModelAccessPermission string
 const (
 	ModelReadAccess  ModelAccessPermission = "read"
 	ModelWriteAccess ModelAccessPermission = "write"
+	ModelAdminAccess ModelAccessPermission = "admin"
+)
+
+// ModifyControllerAccessRequest holds the parameters for granting and
+// revoking controller access.
+type ModifyControllerAccessRequest struct {
+	Changes []ModifyControllerAccess `json:"changes"`
+}
+
+type ModifyControllerAccess struct {
+	UserTag string                     `json:"user-tag"`
+	Action  ControllerAction           `json:"action"`
+	Access  ControllerAccessPermission `json:"access"`
+}
+
+// ControllerAction is an action that can be performed on a user's
+// controller access.
+type ControllerAction string
+
+// Actions that can be performed on a user's controller access.
+const (
+	GrantControllerAccess  ControllerAction = "grant"
+	RevokeControllerAccess ControllerAction = "revoke"
+)
+
+// ControllerAccessPermission is the type of permission that a user has
+// on a controller.
+type ControllerAccessPermission string
+
+// Controller access permissions that may be set on a user.
+const (
+	ControllerAddModelAccess  ControllerAccessPermission = "add-model"
+	ControllerSuperuserAccess ControllerAccessPermission = "superuser"
 )
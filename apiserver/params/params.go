@@ -48,6 +48,12 @@ type Entities struct {
 	Entities []Entity
 }
 
+// EntityString holds a string value for the entity with the given tag.
+type EntityString struct {
+	Tag   string
+	Value string
+}
+
 // EntityPasswords holds the parameters for making a SetPasswords call.
 type EntityPasswords struct {
 	Changes []EntityPassword
@@ -213,6 +219,7 @@ type ServiceDeploy struct {
 	Constraints      constraints.Value
 	Placement        []*instance.Placement
 	Storage          map[string]storage.Constraints
+	AttachStorage    []string // Storage tags of existing storage to attach.
 	EndpointBindings map[string]string
 	Resources        map[string]string
 }
@@ -250,6 +257,10 @@ type ServiceSetCharm struct {
 // ServiceExpose holds the parameters for making the service Expose call.
 type ServiceExpose struct {
 	ServiceName string
+	// ToCIDRs and ToSpaces, if set, restrict access to the service's
+	// open ports to the given CIDRs and the subnets of the given spaces.
+	ToCIDRs  []string
+	ToSpaces []string
 }
 
 // ServiceSet holds the parameters for a service Set
@@ -348,9 +359,10 @@ type AddServiceUnitsResults struct {
 
 // AddServiceUnits holds parameters for the AddUnits call.
 type AddServiceUnits struct {
-	ServiceName string
-	NumUnits    int
-	Placement   []*instance.Placement
+	ServiceName   string
+	NumUnits      int
+	Placement     []*instance.Placement
+	AttachStorage []string // Storage tags of existing storage to attach.
 }
 
 // DestroyServiceUnits holds parameters for the DestroyUnits call.
@@ -761,6 +773,19 @@ type LogRecord struct {
 	Message  string      `json:"x"`
 }
 
+// DebugLogRecord is a log message sent by the debug-log API endpoint
+// when JSON output is requested. Unlike LogRecord, it has readable
+// field names so that it is easy to process with other tools.
+type DebugLogRecord struct {
+	ModelUUID string    `json:"model-uuid"`
+	Time      time.Time `json:"timestamp"`
+	Entity    string    `json:"entity"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+}
+
 // GetBundleChangesParams holds parameters for making GetBundleChanges calls.
 type GetBundleChangesParams struct {
 	// BundleDataYAML is the YAML-encoded charm bundle data
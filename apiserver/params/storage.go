@@ -210,6 +210,7 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+	SnapshotId string                  `json:"snapshotid,omitempty"`
 }
 
 // VolumeAttachmentParams holds the parameters for creating a volume
@@ -283,6 +284,55 @@ type VolumeAttachmentParamsResults struct {
 	Results []VolumeAttachmentParamsResult `json:"results,omitempty"`
 }
 
+// VolumeSnapshot identifies and describes a snapshot of a storage
+// volume.
+type VolumeSnapshot struct {
+	Snapshot  string             `json:"snapshot"`
+	VolumeTag string             `json:"volumetag"`
+	Info      VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshotInfo describes a snapshot of a storage volume.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `json:"snapshotid"`
+	// Size is the size of the snapshotted volume in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshots describes a set of storage volume snapshots.
+type VolumeSnapshots struct {
+	VolumeSnapshots []VolumeSnapshot `json:"volumesnapshots"`
+}
+
+// VolumeSnapshotIds holds the IDs of a set of storage volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for creating a snapshot of
+// a storage volume.
+type VolumeSnapshotParams struct {
+	Snapshot  string            `json:"snapshot"`
+	VolumeTag string            `json:"volumetag"`
+	VolumeId  string            `json:"volumeid"`
+	Size      uint64            `json:"size"`
+	Provider  string            `json:"provider"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds provisioning parameters for a volume
+// snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds provisioning parameters for multiple
+// volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
 // Filesystem identifies and describes a storage filesystem in the model.
 type Filesystem struct {
 	FilesystemTag string         `json:"filesystemtag"`
@@ -662,6 +712,10 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// Snapshot is the ID of the volume snapshot from which the
+	// storage is to be created, if any.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
@@ -43,11 +43,21 @@ var readOnlyCalls = set.NewStrings(
 	// Status is so old it shouldn't be used.
 	"Client.StatusHistory",
 	"Client.WatchAll",
-	// TODO: add controller work.
+	"Controller.AllModels",
+	"Controller.AuditLog",
+	"Controller.ExportModel",
+	"Controller.ListBlockedModels",
+	"Controller.ModelConfig",
+	"Controller.ModelMigrationStatus",
+	"Controller.ModelStatus",
+	"Controller.PrecheckModelMigration",
+	"Controller.WatchAllModels",
 	"KeyManager.ListKeys",
+	"MetricsDebug.GetMetricFailures",
 	"ModelManager.ModelInfo",
 	"Service.GetConstraints",
 	"Service.CharmRelations",
+	"Service.ExportBundle",
 	"Service.Get",
 	"Spaces.ListSpaces",
 	"Storage.ListStorageDetails",
@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// ExportBundle returns the services, machines and relations of the
+// model as bundle YAML which can be deployed again.
+func (api *API) ExportBundle() (params.StringResult, error) {
+	data, err := exportBundle(api.state)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	out, err := goyaml.Marshal(data)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: string(out)}, nil
+}
+
+// exportBundle builds bundle data describing the current model.
+func exportBundle(st *state.State) (*charm.BundleData, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := &charm.BundleData{
+		Services: make(map[string]*charm.ServiceSpec),
+		Machines: make(map[string]*charm.MachineSpec),
+	}
+	if series, ok := cfg.DefaultSeries(); ok {
+		data.Series = series
+	}
+
+	services, err := st.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineIds := make(map[string]bool)
+	for _, service := range services {
+		spec, err := exportService(st, service)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exporting service %q", service.Name())
+		}
+		if service.IsPrincipal() {
+			units, err := service.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unit := range units {
+				machineId, err := unit.AssignedMachineId()
+				if errors.IsNotAssigned(err) {
+					continue
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+				spec.To = append(spec.To, placementFor(machineId))
+				machineIds[state.TopParentId(machineId)] = true
+			}
+			spec.NumUnits = len(units)
+		}
+		data.Services[service.Name()] = spec
+	}
+
+	for id := range machineIds {
+		machine, err := st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec, err := exportMachine(st, machine)
+		if err != nil {
+			return nil, errors.Annotatef(err, "exporting machine %q", id)
+		}
+		data.Machines[id] = spec
+	}
+
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		endpoints := relation.Endpoints()
+		if len(endpoints) != 2 {
+			// Peer relations are established implicitly.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			endpoints[0].String(),
+			endpoints[1].String(),
+		})
+	}
+
+	// Make sure the bundle we hand back can actually be deployed.
+	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
+		return nil, errors.Annotate(err, "exported bundle is not valid")
+	}
+	return data, nil
+}
+
+func verifyConstraints(s string) error {
+	_, err := constraints.Parse(s)
+	return err
+}
+
+func verifyStorage(s string) error {
+	_, err := storage.ParseConstraints(s)
+	return err
+}
+
+// exportService returns the bundle service spec for the given service,
+// without its units.
+func exportService(st *state.State, service *state.Service) (*charm.ServiceSpec, error) {
+	curl, _ := service.CharmURL()
+	spec := &charm.ServiceSpec{
+		Charm:  curl.String(),
+		Series: service.Series(),
+		Expose: service.IsExposed(),
+	}
+
+	settings, err := service.ConfigSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(settings) > 0 {
+		spec.Options = settings
+	}
+
+	if service.IsPrincipal() {
+		cons, err := service.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec.Constraints = cons.String()
+	}
+
+	bindings, err := service.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if spec.EndpointBindings == nil {
+			spec.EndpointBindings = make(map[string]string)
+		}
+		spec.EndpointBindings[endpoint] = space
+	}
+
+	storage, err := service.StorageConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, cons := range storage {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		spec.Storage[name] = fmt.Sprintf("%s,%d,%dM", cons.Pool, cons.Count, cons.Size)
+	}
+
+	annotations, err := st.Annotations(service)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	return spec, nil
+}
+
+// exportMachine returns the bundle machine spec for the given top level
+// machine.
+func exportMachine(st *state.State, machine *state.Machine) (*charm.MachineSpec, error) {
+	cons, err := machine.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	annotations, err := st.Annotations(machine)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec := &charm.MachineSpec{
+		Series:      machine.Series(),
+		Constraints: cons.String(),
+	}
+	if len(annotations) > 0 {
+		spec.Annotations = annotations
+	}
+	return spec, nil
+}
+
+// placementFor returns the bundle placement directive for a unit assigned
+// to the given machine. Units in containers are placed in a new container
+// of the same type on the top level machine.
+func placementFor(machineId string) string {
+	if containerType := state.ContainerTypeFromId(machineId); containerType != "" {
+		return fmt.Sprintf("%s:%s", containerType, state.TopParentId(machineId))
+	}
+	return machineId
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/service"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type exportBundleSuite struct {
+	jujutesting.JujuConnSuite
+
+	serviceApi *service.API
+}
+
+var _ = gc.Suite(&exportBundleSuite{})
+
+func (s *exportBundleSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+
+	var err error
+	s.serviceApi, err = service.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportBundleSuite) exportBundle(c *gc.C) *charm.BundleData {
+	result, err := s.serviceApi.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	var data charm.BundleData
+	err = goyaml.Unmarshal([]byte(result.Result), &data)
+	c.Assert(err, jc.ErrorIsNil)
+	return &data
+}
+
+func (s *exportBundleSuite) TestExportBundleEmpty(c *gc.C) {
+	data := s.exportBundle(c)
+	c.Assert(data.Services, gc.HasLen, 0)
+	c.Assert(data.Machines, gc.HasLen, 0)
+	c.Assert(data.Relations, gc.HasLen, 0)
+}
+
+func (s *exportBundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "My Blog"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetConstraints(constraints.MustParse("cpu-cores=2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAnnotations(wordpress, map[string]string{"gui-x": "100"})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	err = s.State.SetAnnotations(machine, map[string]string{"owner": "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: machine})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: mysql, Machine: machine})
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := s.exportBundle(c)
+	wordpressURL, _ := wordpress.CharmURL()
+	mysqlURL, _ := mysql.CharmURL()
+	c.Check(data.Services, jc.DeepEquals, map[string]*charm.ServiceSpec{
+		"wordpress": {
+			Charm:       wordpressURL.String(),
+			Series:      "quantal",
+			NumUnits:    1,
+			To:          []string{machine.Id()},
+			Expose:      true,
+			Options:     map[string]interface{}{"blog-title": "My Blog"},
+			Constraints: "cpu-cores=2",
+			Annotations: map[string]string{"gui-x": "100"},
+		},
+		"mysql": {
+			Charm:    mysqlURL.String(),
+			Series:   "quantal",
+			NumUnits: 1,
+			To:       []string{machine.Id()},
+		},
+	})
+	c.Check(data.Machines, jc.DeepEquals, map[string]*charm.MachineSpec{
+		machine.Id(): {
+			Series:      "quantal",
+			Constraints: "mem=4096M",
+			Annotations: map[string]string{"owner": "ops"},
+		},
+	})
+	endpoints := rel.Endpoints()
+	c.Check(data.Relations, jc.DeepEquals, [][]string{{
+		endpoints[0].String(), endpoints[1].String(),
+	}})
+}
+
+func (s *exportBundleSuite) TestExportBundleContainers(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	host := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: container})
+
+	data := s.exportBundle(c)
+	c.Check(data.Services["wordpress"].To, jc.DeepEquals, []string{"lxd:" + host.Id()})
+	c.Check(data.Machines, gc.HasLen, 1)
+	c.Check(data.Machines[host.Id()], gc.NotNil)
+}
+
+func (s *exportBundleSuite) TestExportBundleRoundTrip(c *gc.C) {
+	wordpress := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.SetConstraints(constraints.MustParse("cpu-cores=2"))
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	host := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: wordpress, Machine: host})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Service: mysql, Machine: container})
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.serviceApi.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	// The exported YAML must read back as a bundle which passes the
+	// same verification as one given to deploy.
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	err = data.Verify(func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}, func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, jc.DeepEquals, s.exportBundle(c))
+}
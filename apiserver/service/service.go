@@ -9,6 +9,7 @@ package service
 import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	goyaml "gopkg.in/yaml.v2"
@@ -29,6 +30,12 @@ var (
 
 func init() {
 	common.RegisterStandardFacade("Service", 3, NewAPI)
+
+	// Version 4 has the same set of methods as 3, with the same
+	// signatures, but its Expose honours the ToCIDRs and ToSpaces
+	// arguments. Clients must require version 4 to restrict exposure,
+	// as older servers silently ignore them and expose to everyone.
+	common.RegisterStandardFacade("Service", 4, NewAPI)
 }
 
 // Service defines the methods on the service API end point.
@@ -156,6 +163,11 @@ func deployService(st *state.State, owner string, args params.ServiceDeploy) err
 		return errors.Trace(err)
 	}
 
+	attachStorage, err := parseStorageTags(args.AttachStorage)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	channel := csparams.Channel(args.Channel)
 
 	_, err = jjj.DeployService(st,
@@ -171,12 +183,30 @@ func deployService(st *state.State, owner string, args params.ServiceDeploy) err
 			Constraints:      args.Constraints,
 			Placement:        args.Placement,
 			Storage:          args.Storage,
+			AttachStorage:    attachStorage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		})
 	return errors.Trace(err)
 }
 
+// parseStorageTags parses the given storage tag strings, as supplied
+// for attaching existing storage to new units.
+func parseStorageTags(tags []string) ([]names.StorageTag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	storageTags := make([]names.StorageTag, len(tags))
+	for i, tag := range tags {
+		storageTag, err := names.ParseStorageTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageTags[i] = storageTag
+	}
+	return storageTags, nil
+}
+
 // ServiceSetSettingsStrings updates the settings for the given service,
 // taking the configuration from a map of strings.
 func ServiceSetSettingsStrings(service *state.Service, settings map[string]string) error {
@@ -434,7 +464,8 @@ func (api *API) CharmRelations(p params.ServiceCharmRelations) (params.ServiceCh
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. Access may be
+// restricted to particular CIDRs and spaces.
 func (api *API) Expose(args params.ServiceExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
@@ -443,7 +474,7 @@ func (api *API) Expose(args params.ServiceExpose) error {
 	if err != nil {
 		return err
 	}
-	return svc.SetExposed()
+	return svc.SetExposedTo(args.ToCIDRs, args.ToSpaces)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -468,7 +499,11 @@ func addServiceUnits(st *state.State, args params.AddServiceUnits) ([]*state.Uni
 	if args.NumUnits < 1 {
 		return nil, errors.New("must add at least one unit")
 	}
-	return jjj.AddUnits(st, service, args.NumUnits, args.Placement)
+	attachStorage, err := parseStorageTags(args.AttachStorage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jjj.AddUnits(st, service, args.NumUnits, args.Placement, attachStorage)
 }
 
 // AddUnits adds a given number of units to a service.
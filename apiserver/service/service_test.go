@@ -243,6 +243,26 @@ func (s *serviceSuite) TestServiceDeployWithStorage(c *gc.C) {
 	})
 }
 
+func (s *serviceSuite) TestServiceDeployWithInvalidAttachStorage(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "utopic/storage-block-10", "storage-block")
+	err := service.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.ServiceDeploy{
+		ServiceName:   "service",
+		CharmUrl:      curl.String(),
+		NumUnits:      1,
+		AttachStorage: []string{"volume-0"},
+	}
+	results, err := s.serviceApi.Deploy(params.ServicesDeploy{
+		Services: []params.ServiceDeploy{args}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+}
+
 func (s *serviceSuite) TestMinJujuVersionTooHigh(c *gc.C) {
 	curl, _ := s.UploadCharm(c, "quantal/minjujuversion-0", "minjujuversion")
 	err := service.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
@@ -1887,7 +1907,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err = s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1899,6 +1919,26 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeTo(c *gc.C) {
+	s.AddTestingService(c, "dummy-service", s.AddTestingCharm(c, "dummy"))
+
+	err := s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		ToCIDRs:     []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	service, err := s.State.Service("dummy-service")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(service.IsExposed(), jc.IsTrue)
+	c.Assert(service.ExposedToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.serviceApi.Expose(params.ServiceExpose{
+		ServiceName: "dummy-service",
+		ToSpaces:    []string{"missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "dummy-service": space "missing" not found`)
+}
+
 func (s *serviceSuite) setupServiceExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	serviceNames := []string{"dummy-service", "exposed-service"}
@@ -1939,7 +1979,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1954,7 +1994,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.serviceApi.Expose(params.ServiceExpose{t.service})
+		err := s.serviceApi.Expose(params.ServiceExpose{ServiceName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -82,6 +82,10 @@ const (
 	filesystemAttachmentsCall               = "filesystemAttachments"
 	allFilesystemsCall                      = "allFilesystems"
 	addStorageForUnitCall                   = "addStorageForUnit"
+	addStorageForUnitFromSnapshotCall       = "addStorageForUnitFromSnapshot"
+	createVolumeSnapshotCall                = "createVolumeSnapshot"
+	attachStorageCall                       = "attachStorage"
+	detachStorageCall                       = "detachStorage"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 )
@@ -222,6 +226,22 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.calls = append(s.calls, addStorageForUnitCall)
 			return nil
 		},
+		addStorageForUnitFromSnapshot: func(u names.UnitTag, name string, cons state.StorageConstraints, snapshot string) error {
+			s.calls = append(s.calls, addStorageForUnitFromSnapshotCall)
+			return nil
+		},
+		createVolumeSnapshot: func(tag names.VolumeTag) (string, error) {
+			s.calls = append(s.calls, createVolumeSnapshotCall)
+			return "0", nil
+		},
+		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, attachStorageCall)
+			return nil
+		},
+		detachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.calls = append(s.calls, detachStorageCall)
+			return nil
+		},
 		getBlockForType: func(t state.BlockType) (state.Block, bool, error) {
 			s.calls = append(s.calls, getBlockForTypeCall)
 			val, found := s.blocks[t]
@@ -59,6 +59,10 @@ type mockState struct {
 	filesystemAttachments               func(filesystem names.FilesystemTag) ([]state.FilesystemAttachment, error)
 	allFilesystems                      func() ([]state.Filesystem, error)
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
+	addStorageForUnitFromSnapshot       func(u names.UnitTag, name string, cons state.StorageConstraints, snapshot string) error
+	createVolumeSnapshot                func(names.VolumeTag) (string, error)
+	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 }
@@ -151,6 +155,22 @@ func (st *mockState) AddStorageForUnit(u names.UnitTag, name string, cons state.
 	return st.addStorageForUnit(u, name, cons)
 }
 
+func (st *mockState) AddStorageForUnitFromSnapshot(u names.UnitTag, name string, cons state.StorageConstraints, snapshot string) error {
+	return st.addStorageForUnitFromSnapshot(u, name, cons, snapshot)
+}
+
+func (st *mockState) CreateVolumeSnapshot(v names.VolumeTag) (string, error) {
+	return st.createVolumeSnapshot(v)
+}
+
+func (st *mockState) AttachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.attachStorage(s, u)
+}
+
+func (st *mockState) DetachStorage(s names.StorageTag, u names.UnitTag) error {
+	return st.detachStorage(s, u)
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return st.getBlockForType(t)
 }
@@ -277,8 +297,8 @@ func (m *mockStorageInstance) Kind() state.StorageKind {
 	return m.kind
 }
 
-func (m *mockStorageInstance) Owner() names.Tag {
-	return m.owner
+func (m *mockStorageInstance) Owner() (names.Tag, bool) {
+	return m.owner, m.owner != nil
 }
 
 func (m *mockStorageInstance) Tag() names.Tag {
@@ -303,7 +323,7 @@ func (m *mockStorageAttachment) StorageInstance() names.StorageTag {
 }
 
 func (m *mockStorageAttachment) Unit() names.UnitTag {
-	return m.storage.Owner().(names.UnitTag)
+	return m.storage.owner.(names.UnitTag)
 }
 
 type mockVolumeAttachment struct {
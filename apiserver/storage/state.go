@@ -80,6 +80,18 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// AddStorageForUnitFromSnapshot is required for storage add functionality.
+	AddStorageForUnitFromSnapshot(tag names.UnitTag, name string, cons state.StorageConstraints, snapshot string) error
+
+	// CreateVolumeSnapshot is required for volume snapshot functionality.
+	CreateVolumeSnapshot(names.VolumeTag) (string, error)
+
+	// AttachStorage is required for storage attach functionality.
+	AttachStorage(names.StorageTag, names.UnitTag) error
+
+	// DetachStorage is required for storage detach functionality.
+	DetachStorage(names.StorageTag, names.UnitTag) error
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
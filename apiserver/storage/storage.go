@@ -22,6 +22,11 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Storage", 2, NewAPI)
+
+	// Version 3 adds Attach, Detach and CreateSnapshot, and AddToUnit
+	// accepts a snapshot. Clients must require version 3 to use them,
+	// as older servers lack the methods and ignore the snapshot.
+	common.RegisterStandardFacade("Storage", 3, NewAPI)
 }
 
 // API implements the storage interface and is the concrete
@@ -181,9 +186,14 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 	}
 
+	var ownerTag string
+	if owner, ok := si.Owner(); ok {
+		ownerTag = owner.String()
+	}
+
 	return &params.StorageDetails{
 		StorageTag:  si.Tag().String(),
-		OwnerTag:    si.Owner().String(),
+		OwnerTag:    ownerTag,
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
@@ -695,9 +705,16 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 			continue
 		}
 
-		err = a.storage.AddStorageForUnit(u,
-			one.StorageName,
-			paramsToState(one.Constraints))
+		if one.Snapshot != "" {
+			err = a.storage.AddStorageForUnitFromSnapshot(u,
+				one.StorageName,
+				paramsToState(one.Constraints),
+				one.Snapshot)
+		} else {
+			err = a.storage.AddStorageForUnit(u,
+				one.StorageName,
+				paramsToState(one.Constraints))
+		}
 		if err != nil {
 			result[i] = serverErr(
 				errors.Annotatef(err, "adding storage %v for %v", one.StorageName, one.UnitTag))
@@ -705,3 +722,105 @@ func (a *API) AddToUnit(args params.StoragesAddParams) (params.ErrorResults, err
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// CreateSnapshot requests snapshots of the specified volumes, returning
+// the ID of each snapshot. Snapshots are taken asynchronously by the
+// storage provisioner.
+// This method handles bulk snapshot operations and a failure to
+// snapshot one volume does not block the remaining volumes from
+// being processed.
+// A "CHANGE" block can block this operation.
+func (a *API) CreateSnapshot(args params.Entities) (params.StringResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.StringResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		volumeTag, err := names.ParseVolumeTag(entity.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		id, err := a.storage.CreateVolumeSnapshot(volumeTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Result = id
+	}
+	return params.StringResults{Results: result}, nil
+}
+
+// Attach attaches existing, detached storage instances to units.
+// This method handles bulk attach operations and a failure to attach
+// one storage instance does not block the remaining instances from
+// being processed.
+// A "CHANGE" block can block this operation.
+func (a *API) Attach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Ids))
+	for i, id := range args.Ids {
+		storageTag, err := names.ParseStorageTag(id.StorageTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		unitTag, err := names.ParseUnitTag(id.UnitTag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := a.storage.AttachStorage(storageTag, unitTag); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// Detach detaches storage instances from the units they are attached
+// to, leaving the storage in the model so that it may be attached to
+// other units.
+// This method handles bulk detach operations and a failure to detach
+// one storage instance does not block the remaining instances from
+// being processed.
+// A "CHANGE" block can block this operation.
+func (a *API) Detach(args params.Entities) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		if err := a.detachStorage(entity.Tag); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *API) detachStorage(tag string) error {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attachments, err := a.storage.StorageAttachments(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(attachments) == 0 {
+		return errors.Errorf("%s is not attached to any unit", names.ReadableString(storageTag))
+	}
+	for _, attachment := range attachments {
+		if err := a.storage.DetachStorage(storageTag, attachment.Unit()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -50,6 +50,23 @@ func (s *storageAddSuite) TestStorageAddUnit(c *gc.C) {
 	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitCall})
 }
 
+func (s *storageAddSuite) TestStorageAddUnitFromSnapshot(c *gc.C) {
+	var snapshots []string
+	s.state.addStorageForUnitFromSnapshot = func(u names.UnitTag, name string, cons state.StorageConstraints, snapshot string) error {
+		s.calls = append(s.calls, addStorageForUnitFromSnapshotCall)
+		snapshots = append(snapshots, snapshot)
+		return nil
+	}
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Snapshot:    "0/1",
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	s.assertCalls(c, []string{getBlockForTypeCall, addStorageForUnitFromSnapshotCall})
+	c.Assert(snapshots, jc.DeepEquals, []string{"0/1"})
+}
+
 func (s *storageAddSuite) TestStorageAddUnitBlocked(c *gc.C) {
 	s.blockAllChanges(c, "TestStorageAddUnitBlocked")
 
@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type storageAttachSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageAttachSuite{})
+
+func (s *storageAttachSuite) TestAttach(c *gc.C) {
+	var attached []names.Tag
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		attached = append(attached, storage, unit)
+		return nil
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: "storage-data-0",
+		UnitTag:    "unit-mysql-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, attachStorageCall})
+	c.Assert(attached, jc.DeepEquals, []names.Tag{
+		names.NewStorageTag("data/0"),
+		names.NewUnitTag("mysql/1"),
+	})
+}
+
+func (s *storageAttachSuite) TestAttachErrors(c *gc.C) {
+	s.state.attachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, attachStorageCall)
+		return errors.New("boom")
+	}
+	results, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: "volume-0",
+		UnitTag:    "unit-mysql-1",
+	}, {
+		StorageTag: "storage-data-0",
+		UnitTag:    "machine-0",
+	}, {
+		StorageTag: "storage-data-0",
+		UnitTag:    "unit-mysql-1",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"volume-0" is not a valid storage tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *storageAttachSuite) TestAttachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAttachBlocked")
+	_, err := s.api.Attach(params.StorageAttachmentIds{[]params.StorageAttachmentId{{
+		StorageTag: "storage-data-0",
+		UnitTag:    "unit-mysql-1",
+	}}})
+	s.assertBlocked(c, err, "TestAttachBlocked")
+}
+
+func (s *storageAttachSuite) TestDetach(c *gc.C) {
+	var detached []names.Tag
+	s.state.detachStorage = func(storage names.StorageTag, unit names.UnitTag) error {
+		s.calls = append(s.calls, detachStorageCall)
+		detached = append(detached, storage, unit)
+		return nil
+	}
+	results, err := s.api.Detach(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.assertCalls(c, []string{
+		getBlockForTypeCall,
+		storageInstanceAttachmentsCall,
+		detachStorageCall,
+	})
+	c.Assert(detached, jc.DeepEquals, []names.Tag{s.storageTag, s.unitTag})
+}
+
+func (s *storageAttachSuite) TestDetachNotAttached(c *gc.C) {
+	s.state.storageInstanceAttachments = func(names.StorageTag) ([]state.StorageAttachment, error) {
+		s.calls = append(s.calls, storageInstanceAttachmentsCall)
+		return nil, nil
+	}
+	results, err := s.api.Detach(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "storage data/0 is not attached to any unit")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid storage tag`)
+	s.assertCalls(c, []string{getBlockForTypeCall, storageInstanceAttachmentsCall})
+}
+
+func (s *storageAttachSuite) TestDetachBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestDetachBlocked")
+	_, err := s.api.Detach(params.Entities{[]params.Entity{
+		{Tag: s.storageTag.String()},
+	}})
+	s.assertBlocked(c, err, "TestDetachBlocked")
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type storageSnapshotSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&storageSnapshotSuite{})
+
+func (s *storageSnapshotSuite) TestCreateSnapshot(c *gc.C) {
+	var snapshotted []names.VolumeTag
+	s.state.createVolumeSnapshot = func(tag names.VolumeTag) (string, error) {
+		s.calls = append(s.calls, createVolumeSnapshotCall)
+		snapshotted = append(snapshotted, tag)
+		return "0/1", nil
+	}
+	results, err := s.api.CreateSnapshot(params.Entities{[]params.Entity{
+		{Tag: "volume-0-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{{Result: "0/1"}},
+	})
+	s.assertCalls(c, []string{getBlockForTypeCall, createVolumeSnapshotCall})
+	c.Assert(snapshotted, jc.DeepEquals, []names.VolumeTag{names.NewVolumeTag("0/0")})
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotErrors(c *gc.C) {
+	s.state.createVolumeSnapshot = func(tag names.VolumeTag) (string, error) {
+		s.calls = append(s.calls, createVolumeSnapshotCall)
+		return "", errors.New("boom")
+	}
+	results, err := s.api.CreateSnapshot(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"storage-data-0" is not a valid volume tag`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *storageSnapshotSuite) TestCreateSnapshotBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotBlocked")
+	_, err := s.api.CreateSnapshot(params.Entities{[]params.Entity{
+		{Tag: "volume-0"},
+	}})
+	s.assertBlocked(c, err, "TestCreateSnapshotBlocked")
+}
@@ -267,6 +267,12 @@ func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.S
 	return s.watchStorageEntities(args, s.st.WatchModelFilesystems, s.st.WatchMachineFilesystems)
 }
 
+// WatchVolumeSnapshots watches for changes to snapshots of volumes
+// scoped to the entity with the tag passed to NewState.
+func (s *StorageProvisionerAPI) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeSnapshots, s.st.WatchMachineVolumeSnapshots)
+}
+
 func (s *StorageProvisionerAPI) watchStorageEntities(
 	args params.Entities,
 	watchEnvironStorage func() state.StringsWatcher,
@@ -623,6 +629,56 @@ func (s *StorageProvisionerAPI) VolumeParams(args params.Entities) (params.Volum
 	return results, nil
 }
 
+// VolumeSnapshotParams returns the parameters for taking the volume
+// snapshots with the specified IDs. If a snapshot has already been
+// taken, an error satisfying params.IsCodeAlreadyExists is returned.
+func (s *StorageProvisionerAPI) VolumeSnapshotParams(args params.VolumeSnapshotIds) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	envConfig, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Ids)),
+	}
+	poolManager := poolmanager.New(s.settings)
+	one := func(id string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if !canAccess(snapshot.Volume()) {
+			return params.VolumeSnapshotParams{}, common.ErrPerm
+		}
+		if _, err := snapshot.Info(); err == nil {
+			return params.VolumeSnapshotParams{}, errors.AlreadyExistsf("volume snapshot %q", id)
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		volume, err := s.st.Volume(snapshot.Volume())
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		return storagecommon.VolumeSnapshotParams(snapshot, volume, envConfig, poolManager)
+	}
+	for i, id := range args.Ids {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPI) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -955,6 +1011,39 @@ func (s *StorageProvisionerAPI) SetVolumeInfo(args params.Volumes) (params.Error
 	return results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of newly taken volume
+// snapshots.
+func (s *StorageProvisionerAPI) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.VolumeSnapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		id, info, err := storagecommon.VolumeSnapshotToState(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		snapshot, err := s.st.VolumeSnapshot(id)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !canAccess(snapshot.Volume()) {
+			return common.ErrPerm
+		}
+		return errors.Trace(s.st.SetVolumeSnapshotInfo(id, info))
+	}
+	for i, arg := range args.VolumeSnapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (s *StorageProvisionerAPI) SetFilesystemInfo(args params.Filesystems) (params.ErrorResults, error) {
 	canAccessFilesystem, err := s.getStorageEntityAuthFunc()
@@ -420,6 +420,75 @@ func (s *provisionerSuite) TestVolumeParamsEmptyArgs(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 0)
 }
 
+func (s *provisionerSuite) setupVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	for _, id := range []string{"0/0", "2"} {
+		_, err := s.State.CreateVolumeSnapshot(names.NewVolumeTag(id))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *provisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	err := s.State.SetVolumeSnapshotInfo("1", state.VolumeSnapshotInfo{SnapshotId: "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{"0/0", "1", "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Snapshot:  "0/0",
+				VolumeTag: "volume-0-0",
+				VolumeId:  "abc",
+				Size:      1024,
+				Provider:  "machinescoped",
+				Tags: map[string]string{
+					tags.JujuController: testing.ModelTag.Id(),
+					tags.JujuModel:      testing.ModelTag.Id(),
+				},
+			}},
+			{Error: &params.Error{Message: `volume snapshot "1" already exists`, Code: "already exists"}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	results, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		VolumeSnapshots: []params.VolumeSnapshot{{
+			Snapshot:  "0/0",
+			VolumeTag: "volume-0-0",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024},
+		}, {
+			Snapshot:  "1",
+			VolumeTag: "volume-2",
+			Info:      params.VolumeSnapshotInfo{Size: 4096},
+		}, {
+			Snapshot:  "42",
+			VolumeTag: "volume-42",
+			Info:      params.VolumeSnapshotInfo{SnapshotId: "snap-42"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot set info for volume snapshot "1": snapshot ID not set`}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	snapshot, err := s.State.VolumeSnapshot("0/0")
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-0", Size: 1024})
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -722,6 +791,40 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumeSnapshots(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.State.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	w0 := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w0)
+	w1 := s.resources.Get("2")
+	defer statetesting.AssertStop(c, w1)
+
+	// Check that the Watch has consumed the initial events ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w0.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, w1.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
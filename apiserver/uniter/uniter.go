@@ -28,6 +28,22 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 3, NewUniterAPIV3)
+	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+}
+
+// UniterAPIV4 implements the API version 4, used by the uniter worker.
+// It adds LogActionsMessages to version 3.
+type UniterAPIV4 struct {
+	*UniterAPIV3
+}
+
+// NewUniterAPIV4 creates a new instance of the Uniter API, version 4.
+func NewUniterAPIV4(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV4, error) {
+	api, err := NewUniterAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UniterAPIV4{api}, nil
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -889,6 +905,18 @@ func (u *UniterAPIV3) Actions(args params.Entities) (params.ActionResults, error
 	return common.Actions(args, actionFn), nil
 }
 
+// ActionsCancelRequested reports whether each of the actions represented
+// by the passed in Tags was cancelled while it was running.
+func (u *UniterAPIV3) ActionsCancelRequested(args params.Entities) (params.BoolResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.ActionsCancelRequested(args, actionFn), nil
+}
+
 // BeginActions marks the actions represented by the passed in Tags as running.
 func (u *UniterAPIV3) BeginActions(args params.Entities) (params.ErrorResults, error) {
 	canAccess, err := u.accessUnit()
@@ -900,6 +928,18 @@ func (u *UniterAPIV3) BeginActions(args params.Entities) (params.ErrorResults, e
 	return common.BeginActions(args, actionFn), nil
 }
 
+// LogActionsMessages appends the given progress messages to the running
+// actions represented by the passed in Tags.
+func (u *UniterAPIV4) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // FinishActions saves the result of a completed Action
 func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.ErrorResults, error) {
 	canAccess, err := u.accessUnit()
@@ -1158,6 +1158,68 @@ func (s *uniterSuite) TestActionsPermissionDenied(c *gc.C) {
 	c.Assert(actions.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
 }
 
+func (s *uniterSuite) TestActionsCancelRequested(c *gc.C) {
+	running, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	cancelled, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cancelled, err = cancelled.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cancelled.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: running.Tag().String()},
+			{Tag: cancelled.Tag().String()},
+			{Tag: other.Tag().String()},
+		},
+	}
+	results, err := s.uniter.ActionsCancelRequested(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.BoolResult{Result: false})
+	c.Assert(results.Results[1], jc.DeepEquals, params.BoolResult{Result: true})
+	c.Assert(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	running, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: running.Tag().String(), Value: "halfway there"},
+			{Tag: pending.Tag().String(), Value: "halfway there"},
+			{Tag: other.Tag().String(), Value: "halfway there"},
+		},
+	}
+	uniterAPIV4, err := uniter.NewUniterAPIV4(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := uniterAPIV4.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+	c.Assert(results.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+
+	running, err = s.State.Action(running.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := running.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "halfway there")
+}
+
 func (s *uniterSuite) TestFinishActionsSuccess(c *gc.C) {
 	testName := "fakeaction"
 	testOutput := map[string]interface{}{"output": "completed fakeaction successfully"}
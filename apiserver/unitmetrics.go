@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/metricsender"
+)
+
+// unitMetricsHandler serves the latest metric values reported by
+// charms, as recorded in state by the "prometheus" metrics sender, to
+// controller administrators.
+type unitMetricsHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *unitMetricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if !isAdmin {
+		sendError(w, common.ErrPerm)
+		return
+	}
+	metrics, err := st.LatestMetrics()
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", metricsender.PrometheusContentType)
+	if err := metricsender.WritePrometheus(w, metrics); err != nil {
+		logger.Errorf("cannot write unit metrics: %v", err)
+	}
+}
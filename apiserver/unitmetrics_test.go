@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type unitMetricsSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&unitMetricsSuite{})
+
+func (s *unitMetricsSuite) metricsURL(c *gc.C) string {
+	return s.makeURL(c, "https", "/metrics", nil).String()
+}
+
+func (s *unitMetricsSuite) assertErrorResponse(c *gc.C, resp *http.Response, statusCode int, msg string) {
+	body := assertResponse(c, resp, statusCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.ErrorMatches, msg)
+}
+
+func (s *unitMetricsSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *unitMetricsSuite) TestRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "hunter2",
+		Access:   state.ModelReadAccess,
+	})
+	resp := s.sendRequest(c, httpRequestParams{
+		method:   "GET",
+		url:      s.metricsURL(c),
+		tag:      user.Tag().String(),
+		password: "hunter2",
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *unitMetricsSuite) TestRequiresGET(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.metricsURL(c)})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *unitMetricsSuite) TestServesLatestMetrics(c *gc.C) {
+	err := s.State.UpdateLatestMetrics([]state.LatestMetric{{
+		ModelUUID: s.State.ModelUUID(),
+		Unit:      "metered/0",
+		CharmURL:  "cs:quantal/metered",
+		Key:       "pings",
+		Value:     5,
+		Time:      time.Unix(1000, 0),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.metricsURL(c)})
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(resp.Header.Get("Content-Type"), gc.Equals, "text/plain; version=0.0.4")
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(body), jc.Contains, "# TYPE juju_unit_metric gauge\n")
+	c.Check(string(body), jc.Contains, `juju_unit_metric{model_uuid="`+s.State.ModelUUID()+`",unit="metered/0",charm="cs:quantal/metered",metric="pings"} 5 1000000`)
+}
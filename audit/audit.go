@@ -1,7 +1,9 @@
 // Copyright 2013, 2014 Canonical Ltd.
 // Licensed under the AGPLv3, see LICENCE file for details.
 
-// Package audit records auditable events
+// Package audit records auditable events. Structured records of
+// API calls made by users are described by AuditEntry and persisted
+// by the state package.
 package audit
 
 import (
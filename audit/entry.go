@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+)
+
+// AuditEntry represents a single API call made by a user which
+// modified, or attempted to modify, the state of a model or
+// controller.
+type AuditEntry struct {
+	// Timestamp is when the call was made.
+	Timestamp time.Time
+
+	// UserTag identifies the user who made the call.
+	UserTag names.UserTag
+
+	// ModelUUID identifies the model the call was made against.
+	ModelUUID string
+
+	// Facade is the name of the API facade that was called.
+	Facade string
+
+	// Version is the version of the API facade that was called.
+	Version int
+
+	// Method is the name of the facade method that was called.
+	Method string
+
+	// Args holds a summary of the arguments supplied with the call.
+	// It may be truncated, or omitted entirely when the arguments are
+	// known to contain sensitive information.
+	Args string
+
+	// Error holds the error returned by the call. An empty Error
+	// indicates that the call succeeded.
+	Error string
+}
+
+// Succeeded reports whether the audited call completed without
+// error.
+func (e AuditEntry) Succeeded() bool {
+	return e.Error == ""
+}
+
+// Validate ensures that the entry has all the information required to
+// be recorded.
+func (e AuditEntry) Validate() error {
+	if e.Timestamp.IsZero() {
+		return errors.NotValidf("zero Timestamp")
+	}
+	if e.UserTag.Id() == "" {
+		return errors.NotValidf("empty UserTag")
+	}
+	if !names.IsValidModel(e.ModelUUID) {
+		return errors.NotValidf("ModelUUID %q", e.ModelUUID)
+	}
+	if e.Facade == "" {
+		return errors.NotValidf("empty Facade")
+	}
+	if e.Method == "" {
+		return errors.NotValidf("empty Method")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type entrySuite struct{}
+
+var _ = gc.Suite(&entrySuite{})
+
+func validEntry() audit.AuditEntry {
+	return audit.AuditEntry{
+		Timestamp: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+		UserTag:   names.NewUserTag("bob"),
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:    "Service",
+		Version:   3,
+		Method:    "Set",
+		Args:      `{"servicename":"mysql"}`,
+	}
+}
+
+func (*entrySuite) TestValidate(c *gc.C) {
+	c.Assert(validEntry().Validate(), jc.ErrorIsNil)
+}
+
+func (*entrySuite) TestValidateFailures(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*audit.AuditEntry)
+		err    string
+	}{{
+		mutate: func(e *audit.AuditEntry) { e.Timestamp = time.Time{} },
+		err:    "zero Timestamp not valid",
+	}, {
+		mutate: func(e *audit.AuditEntry) { e.UserTag = names.UserTag{} },
+		err:    "empty UserTag not valid",
+	}, {
+		mutate: func(e *audit.AuditEntry) { e.ModelUUID = "foo" },
+		err:    `ModelUUID "foo" not valid`,
+	}, {
+		mutate: func(e *audit.AuditEntry) { e.Facade = "" },
+		err:    "empty Facade not valid",
+	}, {
+		mutate: func(e *audit.AuditEntry) { e.Method = "" },
+		err:    "empty Method not valid",
+	}} {
+		c.Logf("test %d", i)
+		entry := validEntry()
+		test.mutate(&entry)
+		c.Check(entry.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (*entrySuite) TestSucceeded(c *gc.C) {
+	entry := validEntry()
+	c.Check(entry.Succeeded(), jc.IsTrue)
+	entry.Error = "permission denied"
+	c.Check(entry.Succeeded(), jc.IsFalse)
+}
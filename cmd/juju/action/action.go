@@ -24,6 +24,11 @@ type APIClient interface {
 	// Action.
 	Enqueue(params.Actions) (params.ActionResults, error)
 
+	// EnqueueOnServices takes a list of ServiceActions and queues each
+	// one up on every unit of the designated service, or on its leader
+	// only, returning the queued Actions grouped by service.
+	EnqueueOnServices(params.ServiceActions) (params.ActionsByReceivers, error)
+
 	// ListAll takes a list of Tags representing ActionReceivers and returns
 	// all of the Actions that have been queued or run by each of those
 	// Entities.
@@ -39,8 +44,10 @@ type APIClient interface {
 	// Entities.
 	ListCompleted(params.Entities) (params.ActionsByReceivers, error)
 
-	// Cancel attempts to cancel a queued up Action from running.
-	Cancel(params.Actions) (params.ActionResults, error)
+	// Cancel cancels the Actions represented by the given Entities.
+	// Pending Actions are cancelled immediately; running Actions are
+	// stopped by their receivers.
+	Cancel(params.Entities) (params.ActionResults, error)
 
 	// ServiceCharmActions is a single query which uses ServicesCharmActions to
 	// get the charm.Actions for a single Service by tag.
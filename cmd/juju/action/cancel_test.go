@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/testing"
+)
+
+type CancelSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&CancelSuite{})
+
+func (s *CancelSuite) TestInit(c *gc.C) {
+	for _, modelFlag := range s.modelFlags {
+		wrappedCommand, command := action.NewCancelCommandForTest(s.store)
+		err := testing.InitCommand(wrappedCommand, []string{modelFlag, "admin"})
+		c.Check(err, gc.ErrorMatches, "no action ID specified")
+
+		wrappedCommand, command = action.NewCancelCommandForTest(s.store)
+		err = testing.InitCommand(wrappedCommand, []string{modelFlag, "admin", "dead", "beef"})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(command.RequestedIds(), jc.DeepEquals, []string{"dead", "beef"})
+	}
+}
+
+func (s *CancelSuite) TestRun(c *gc.C) {
+	prefix := "deadbeef"
+	fakeid := prefix + "-0000-4000-8000-feedfacebeef"
+	faketag := "action-" + fakeid
+
+	tests := []struct {
+		should      string
+		tags        params.FindTagsResults
+		results     []params.ActionResult
+		apiErr      string
+		expectError string
+	}{{
+		should:      "fail with no matching actions",
+		expectError: `actions for identifier "` + prefix + `" not found`,
+	}, {
+		should:      "fail with several matching actions",
+		tags:        tagsForIdPrefix(prefix, faketag, "action-"+prefix+"-0001-4000-8000-feedfacebeef"),
+		expectError: `identifier "` + prefix + `" matched multiple actions .*`,
+	}, {
+		should:      "fail with API error",
+		tags:        tagsForIdPrefix(prefix, faketag),
+		apiErr:      "bam",
+		expectError: "bam",
+	}, {
+		should:      "fail with the wrong number of results",
+		tags:        tagsForIdPrefix(prefix, faketag),
+		expectError: "expected 1 results, got 0",
+	}, {
+		should: "cancel a pending action",
+		tags:   tagsForIdPrefix(prefix, faketag),
+		results: []params.ActionResult{{
+			Action: &params.Action{Tag: faketag, Receiver: "unit-mysql-0"},
+			Status: params.ActionCancelled,
+		}},
+	}, {
+		should: "cancel a running action",
+		tags:   tagsForIdPrefix(prefix, faketag),
+		results: []params.ActionResult{{
+			Action:          &params.Action{Tag: faketag, Receiver: "unit-mysql-0"},
+			Status:          params.ActionRunning,
+			CancelRequested: true,
+		}},
+	}}
+
+	for i, t := range tests {
+		for _, modelFlag := range s.modelFlags {
+			c.Logf("test %d: should %s", i, t.should)
+			fakeClient := makeFakeClient(0, 5*time.Second, t.tags, t.results, params.ActionsByNames{}, t.apiErr)
+			restore := s.patchAPIClient(fakeClient)
+			defer restore()
+
+			wrappedCommand, _ := action.NewCancelCommandForTest(s.store)
+			ctx, err := testing.RunCommand(c, wrappedCommand, modelFlag, "admin", prefix)
+			if t.expectError != "" {
+				c.Check(err, gc.ErrorMatches, t.expectError)
+				continue
+			}
+			c.Assert(err, jc.ErrorIsNil)
+			c.Check(fakeClient.cancelledActions, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: faketag}},
+			})
+			buf, err := cmd.DefaultFormatters["yaml"](action.ActionResultsToMap(t.results))
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, string(buf)+"\n")
+		}
+	}
+}
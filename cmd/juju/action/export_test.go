@@ -4,6 +4,8 @@
 package action
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/names"
 
@@ -49,6 +51,26 @@ func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *RunCommand) ServiceTag() names.ServiceTag {
+	return c.serviceTag
+}
+
+func (c *RunCommand) LeaderOnly() bool {
+	return c.leaderOnly
+}
+
+type CancelCommand struct {
+	*cancelCommand
+}
+
+func (c *CancelCommand) RequestedIds() []string {
+	return c.requestedIds
+}
+
 type ListCommand struct {
 	*listCommand
 }
@@ -79,6 +101,12 @@ func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListComm
 	return modelcmd.Wrap(c, modelcmd.ModelSkipDefault), &ListCommand{c}
 }
 
+func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
+	c := &cancelCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &CancelCommand{c}
+}
+
 func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunCommand) {
 	c := &runCommand{}
 	c.SetClientStore(store)
@@ -128,6 +128,8 @@ type fakeAPIClient struct {
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
 	enqueuedActions    params.Actions
+	serviceActions     params.ServiceActions
+	cancelledActions   params.Entities
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
@@ -152,6 +154,13 @@ func (c *fakeAPIClient) Enqueue(args params.Actions) (params.ActionResults, erro
 	return params.ActionResults{Results: c.actionResults}, c.apiErr
 }
 
+func (c *fakeAPIClient) EnqueueOnServices(args params.ServiceActions) (params.ActionsByReceivers, error) {
+	c.serviceActions = args
+	return params.ActionsByReceivers{
+		Actions: c.actionsByReceivers,
+	}, c.apiErr
+}
+
 func (c *fakeAPIClient) ListAll(args params.Entities) (params.ActionsByReceivers, error) {
 	return params.ActionsByReceivers{
 		Actions: c.actionsByReceivers,
@@ -170,7 +179,8 @@ func (c *fakeAPIClient) ListCompleted(args params.Entities) (params.ActionsByRec
 	}, c.apiErr
 }
 
-func (c *fakeAPIClient) Cancel(args params.Actions) (params.ActionResults, error) {
+func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
+	c.cancelledActions = args
 	return params.ActionResults{
 		Results: c.actionResults,
 	}, c.apiErr
@@ -4,9 +4,13 @@
 package action
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -30,9 +34,14 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	ActionCommandBase
 	unitTag      names.UnitTag
+	serviceTag   names.ServiceTag
+	allUnits     bool
+	leaderOnly   bool
+	wait         string
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	timeout      time.Duration
 	out          cmd.Output
 	args         [][]string
 }
@@ -53,6 +62,17 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+If --timeout is given, the unit kills the action and marks it failed if it
+is still running once the timeout has elapsed. A queued action can be
+cancelled with 'juju cancel-action'.
+
+To run the Action on every unit of a service, give the service name and the
+--all flag; to run it on the service's leader only, use --leader instead. The
+command then waits for the Actions to finish, writing any progress messages
+to stderr, and shows the outcome for each unit. It exits with an error unless
+every Action completed. Use --wait with a duration, as in --wait 10m, to stop
+waiting after that long; the default of 0 waits indefinitely.
+
 Examples:
 
 $ juju run-action mysql/3 backup 
@@ -103,6 +123,17 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/3 backup --timeout 30m
+...
+The action will be failed if it has not finished within 30 minutes.
+
+$ juju run-action mysql backup --all
+UNIT     ID                                    STATUS     MESSAGE
+mysql/0  b5ab93a5-7a5d-4f2b-8a6c-a84ebd6c3ae4  completed
+mysql/1  e39e9f39-5b5e-4a7c-8d54-3e0e6b06cb42  failed     disk full
+
+1 of 2 actions completed
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -110,15 +141,23 @@ var ActionNameRule = regexp.MustCompile("^[a-z](?:[a-z-]*[a-z])?$")
 
 // SetFlags offers an option for YAML output.
 func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	c.out.AddFlags(f, "smart", map[string]cmd.Formatter{
+		"smart": formatRunSmart,
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+	})
 	f.Var(&c.paramsYAML, "params", "path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "use raw string values of CLI args")
+	f.DurationVar(&c.timeout, "timeout", 0, "fail the action if it runs for longer than this (0 means no limit)")
+	f.BoolVar(&c.allUnits, "all", false, "run the action on all units of the given service")
+	f.BoolVar(&c.leaderOnly, "leader", false, "run the action on the leader of the given service")
+	f.StringVar(&c.wait, "wait", "0", "with --all or --leader, how long to wait for the actions to finish (0 means no limit)")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit>|<service> <action name> [key.key.key...=value]",
 		Purpose: "queue an action for execution",
 		Doc:     runDoc,
 	}
@@ -126,22 +165,43 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if c.timeout < 0 {
+		return errors.Errorf("timeout must not be negative, got %v", c.timeout)
+	}
+	if c.allUnits && c.leaderOnly {
+		return errors.New("cannot specify both --all and --leader")
+	}
+	if _, err := parseWait(c.wait); err != nil {
+		return errors.Trace(err)
+	}
+	onService := c.allUnits || c.leaderOnly
 	switch len(args) {
 	case 0:
+		if onService {
+			return errors.New("no service specified")
+		}
 		return errors.New("no unit specified")
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the unit or service, and action names.
+		if onService {
+			serviceName := args[0]
+			if !names.IsValidService(serviceName) {
+				return errors.Errorf("invalid service name %q", serviceName)
+			}
+			c.serviceTag = names.NewServiceTag(serviceName)
+		} else {
+			unitName := args[0]
+			if !names.IsValidUnit(unitName) {
+				return errors.Errorf("invalid unit name %q", unitName)
+			}
+			c.unitTag = names.NewUnitTag(unitName)
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return fmt.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
@@ -227,11 +287,16 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.allUnits || c.leaderOnly {
+		return c.runOnService(ctx, api, actionParams)
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
 			Name:       c.actionName,
 			Parameters: actionParams,
+			Timeout:    c.timeout,
 		}},
 	}
 
@@ -261,3 +326,144 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output := map[string]string{"Action queued with id": tag.Id()}
 	return c.out.Write(ctx, output)
 }
+
+// runOnService enqueues the action on the units of the target service,
+// waits for the actions to finish and writes the outcome for each unit.
+// It returns cmd.ErrSilent if any of the actions did not complete.
+func (c *runCommand) runOnService(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	wait, _, err := newWaitTimer(c.wait)
+	if err != nil {
+		return err
+	}
+
+	results, err := api.EnqueueOnServices(params.ServiceActions{
+		Actions: []params.ServiceAction{{
+			ServiceTag: c.serviceTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			Timeout:    c.timeout,
+			LeaderOnly: c.leaderOnly,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Actions) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Actions[0]
+	if result.Error != nil {
+		return result.Error
+	}
+
+	entities := []params.Entity{}
+	for _, enqueued := range result.Actions {
+		if enqueued.Error == nil && enqueued.Action != nil {
+			entities = append(entities, params.Entity{Tag: enqueued.Action.Tag})
+		}
+	}
+	finished := map[string]params.ActionResult{}
+	if len(entities) > 0 {
+		latest, err := watchActions(ctx, api, entities, wait)
+		if err != nil {
+			return err
+		}
+		for _, r := range latest {
+			if r.Action != nil {
+				finished[r.Action.Tag] = r
+			}
+		}
+	}
+
+	output := serviceActionResults{Units: map[string]unitActionResult{}}
+	completed := 0
+	for _, enqueued := range result.Actions {
+		if enqueued.Action == nil {
+			continue
+		}
+		unit := enqueued.Action.Receiver
+		if tag, err := names.ParseUnitTag(unit); err == nil {
+			unit = tag.Id()
+		}
+		unitResult := unitActionResult{}
+		if enqueued.Error != nil {
+			unitResult.Status = params.ActionFailed
+			unitResult.Message = enqueued.Error.Error()
+			output.Units[unit] = unitResult
+			continue
+		}
+		if tag, err := names.ParseActionTag(enqueued.Action.Tag); err == nil {
+			unitResult.Id = tag.Id()
+		}
+		latest, ok := finished[enqueued.Action.Tag]
+		if !ok {
+			latest = enqueued
+		}
+		unitResult.Status = latest.Status
+		unitResult.Message = latest.Message
+		if latest.Error != nil {
+			unitResult.Message = latest.Error.Error()
+		}
+		if unitResult.Status == params.ActionCompleted {
+			completed++
+		}
+		output.Units[unit] = unitResult
+	}
+	output.Completed = completed
+	output.Total = len(output.Units)
+
+	if err := c.out.Write(ctx, output); err != nil {
+		return err
+	}
+	if completed != output.Total {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// serviceActionResults holds the outcome of an action run on the units
+// of a service.
+type serviceActionResults struct {
+	Units     map[string]unitActionResult `yaml:"units" json:"units"`
+	Completed int                         `yaml:"completed" json:"completed"`
+	Total     int                         `yaml:"total" json:"total"`
+}
+
+// unitActionResult holds the outcome of an action run on one unit.
+type unitActionResult struct {
+	Id      string `yaml:"id,omitempty" json:"id,omitempty"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// formatRunSmart formats the outcome of a service-level run as a table,
+// and anything else with the default smart formatter.
+func formatRunSmart(value interface{}) ([]byte, error) {
+	results, ok := value.(serviceActionResults)
+	if !ok {
+		return cmd.DefaultFormatters["smart"](value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "UNIT\tID\tSTATUS\tMESSAGE\n")
+	units := make([]string, 0, len(results.Units))
+	for unit := range results.Units {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	for _, unit := range units {
+		r := results.Units[unit]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", unit, r.Id, r.Status, r.Message)
+	}
+	tw.Flush()
+	fmt.Fprintf(&out, "\n%d of %d actions completed", results.Completed, results.Total)
+	return out.Bytes(), nil
+}
@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	"github.com/juju/cmd"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -60,9 +62,12 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnit           names.UnitTag
+		expectService        names.ServiceTag
+		expectLeaderOnly     bool
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
+		expectTimeout        time.Duration
 		expectKVArgs         [][]string
 		expectOutput         string
 		expectError          string
@@ -106,6 +111,47 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnit:         names.NewUnitTag(validUnitId),
 		expectAction:       "valid-action-name",
 		expectParseStrings: true,
+	}, {
+		should:        "handle --timeout",
+		args:          []string{validUnitId, "valid-action-name", "--timeout", "5m"},
+		expectUnit:    names.NewUnitTag(validUnitId),
+		expectAction:  "valid-action-name",
+		expectTimeout: 5 * time.Minute,
+	}, {
+		should:      "fail with negative --timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout=-5m"},
+		expectError: "timeout must not be negative, got -5m0s",
+	}, {
+		should:      "fail with empty --wait",
+		args:        []string{"mysql", "valid-action-name", "--all", "--wait="},
+		expectError: "--wait must not be empty",
+	}, {
+		should:      "fail with unparsable --wait",
+		args:        []string{"mysql", "valid-action-name", "--all", "--wait=soon"},
+		expectError: "invalid --wait: time: invalid duration soon",
+	}, {
+		should:        "handle --all",
+		args:          []string{"mysql", "valid-action-name", "--all"},
+		expectService: names.NewServiceTag("mysql"),
+		expectAction:  "valid-action-name",
+	}, {
+		should:           "handle --leader",
+		args:             []string{"mysql", "valid-action-name", "--leader"},
+		expectService:    names.NewServiceTag("mysql"),
+		expectLeaderOnly: true,
+		expectAction:     "valid-action-name",
+	}, {
+		should:      "fail with --all and --leader",
+		args:        []string{"mysql", "valid-action-name", "--all", "--leader"},
+		expectError: "cannot specify both --all and --leader",
+	}, {
+		should:      "fail with --all and no service",
+		args:        []string{"--all"},
+		expectError: "no service specified",
+	}, {
+		should:      "fail with --all and a unit",
+		args:        []string{validUnitId, "valid-action-name", "--all"},
+		expectError: "invalid service name \"" + validUnitId + "\"",
 	}, {
 		// cf. worker/uniter/runner/jujuc/action-set_test.go per @fwereade
 		should:       "work with multiple '=' signs",
@@ -169,10 +215,13 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			err := testing.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTag(), gc.Equals, t.expectUnit)
+				c.Check(command.ServiceTag(), gc.Equals, t.expectService)
+				c.Check(command.LeaderOnly(), gc.Equals, t.expectLeaderOnly)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
+				c.Check(command.Timeout(), gc.Equals, t.expectTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -252,6 +301,18 @@ func (s *RunSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		},
+	}, {
+		should:   "enqueue an action with a timeout",
+		withArgs: []string{validUnitId, "some-action", "--timeout", "90s"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+			Timeout:    90 * time.Second,
+		},
 	}, {
 		should: "enqueue an action with some explicit params",
 		withArgs: []string{validUnitId, "some-action",
@@ -399,3 +460,106 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunOnService(c *gc.C) {
+	tag0 := "action-" + validActionId
+	tag1 := "action-" + validActionId[:len(validActionId)-1] + "0"
+	id1 := validActionId[:len(validActionId)-1] + "0"
+	enqueued := []params.ActionsByReceiver{{
+		Receiver: "service-mysql",
+		Actions: []params.ActionResult{{
+			Action: &params.Action{Tag: tag0, Receiver: "unit-mysql-0"},
+			Status: params.ActionPending,
+		}, {
+			Action: &params.Action{Tag: tag1, Receiver: "unit-mysql-1"},
+			Status: params.ActionPending,
+		}},
+	}}
+
+	tests := []struct {
+		should         string
+		withArgs       []string
+		withEnqueued   []params.ActionsByReceiver
+		withResults    []params.ActionResult
+		expectedErr    string
+		expectedOutput string
+	}{{
+		should:      "fail with an error for the service",
+		withArgs:    []string{"mysql", "backup", "--all"},
+		expectedErr: "service \"mysql\" has no units",
+		withEnqueued: []params.ActionsByReceiver{{
+			Error: common.ServerError(errors.New(`service "mysql" has no units`)),
+		}},
+	}, {
+		should:       "show the results for each unit",
+		withArgs:     []string{"mysql", "backup", "--all"},
+		withEnqueued: enqueued,
+		withResults: []params.ActionResult{{
+			Action:  &params.Action{Tag: tag0, Receiver: "unit-mysql-0"},
+			Status:  params.ActionCompleted,
+			Message: "ok",
+		}, {
+			Action:  &params.Action{Tag: tag1, Receiver: "unit-mysql-1"},
+			Status:  params.ActionCompleted,
+			Message: "ok",
+		}},
+		expectedOutput: "" +
+			"UNIT     ID                                    STATUS     MESSAGE\n" +
+			"mysql/0  " + validActionId + "  completed  ok\n" +
+			"mysql/1  " + id1 + "  completed  ok\n" +
+			"\n" +
+			"2 of 2 actions completed\n",
+	}, {
+		should:       "fail silently if any action did not complete",
+		withArgs:     []string{"mysql", "backup", "--all", "--format", "yaml"},
+		withEnqueued: enqueued,
+		withResults: []params.ActionResult{{
+			Action: &params.Action{Tag: tag0, Receiver: "unit-mysql-0"},
+			Status: params.ActionCompleted,
+		}, {
+			Action:  &params.Action{Tag: tag1, Receiver: "unit-mysql-1"},
+			Status:  params.ActionFailed,
+			Message: "disk full",
+		}},
+		expectedErr: cmd.ErrSilent.Error(),
+		expectedOutput: `
+units:
+  mysql/0:
+    id: ` + validActionId + `
+    status: completed
+  mysql/1:
+    id: ` + id1 + `
+    status: failed
+    message: disk full
+completed: 1
+total: 2
+`[1:],
+	}}
+
+	for i, t := range tests {
+		for _, modelFlag := range s.modelFlags {
+			c.Logf("test %d: should %s", i, t.should)
+			fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, t.withResults, params.ActionsByNames{}, "")
+			fakeClient.actionsByReceivers = t.withEnqueued
+			restore := s.patchAPIClient(fakeClient)
+			defer restore()
+
+			wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+			args := append([]string{modelFlag, "admin"}, t.withArgs...)
+			ctx, err := testing.RunCommand(c, wrappedCommand, args...)
+			if t.expectedErr != "" {
+				c.Check(err, gc.ErrorMatches, t.expectedErr)
+			} else {
+				c.Check(err, jc.ErrorIsNil)
+			}
+			c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, t.expectedOutput)
+			c.Check(fakeClient.serviceActions, jc.DeepEquals, params.ServiceActions{
+				Actions: []params.ServiceAction{{
+					ServiceTag: "service-mysql",
+					Name:       "backup",
+					Parameters: map[string]interface{}{},
+				}},
+			})
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package action
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -61,7 +62,8 @@ func (c *showOutputCommand) Init(args []string) error {
 		return errors.New("no action ID specified")
 	case 1:
 		c.requestedId = args[0]
-		return nil
+		_, err := parseWait(c.wait)
+		return errors.Trace(err)
 	default:
 		return cmd.CheckEmpty(args[1:])
 	}
@@ -69,45 +71,70 @@ func (c *showOutputCommand) Init(args []string) error {
 
 // Run issues the API call to get Actions by ID.
 func (c *showOutputCommand) Run(ctx *cmd.Context) error {
+	wait, _, err := newWaitTimer(c.wait)
+	if err != nil {
+		return err
+	}
+
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	result, err := GetActionResult(api, c.requestedId, wait)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	return c.out.Write(ctx, FormatActionResult(result))
+}
+
+// parseWait parses a --wait value, assuming seconds if no units are
+// given.
+func parseWait(wait string) (time.Duration, error) {
+	if wait == "" {
+		return 0, errors.New("--wait must not be empty")
+	}
 	// Check whether units were left off our time string.
 	r := regexp.MustCompile("[a-zA-Z]")
-	matches := r.FindStringSubmatch(c.wait[len(c.wait)-1:])
+	matches := r.FindStringSubmatch(wait[len(wait)-1:])
 	// If any match, we have units.  Otherwise, we don't; assume seconds.
 	if len(matches) == 0 {
-		c.wait = c.wait + "s"
+		wait = wait + "s"
 	}
 
-	waitDur, err := time.ParseDuration(c.wait)
+	waitDur, err := time.ParseDuration(wait)
 	if err != nil {
-		return err
+		return 0, errors.Annotate(err, "invalid --wait")
 	}
+	return waitDur, nil
+}
 
-	api, err := c.NewActionAPIClient()
+// newWaitTimer parses a --wait value with parseWait, and returns a
+// timer which fires when the wait is over. A negative wait returns a
+// timer which has already fired, and a zero wait returns a timer which
+// never fires. It also reports whether the caller should wait at all.
+func newWaitTimer(wait string) (*time.Timer, bool, error) {
+	waitDur, err := parseWait(wait)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
-	defer api.Close()
 
-	wait := time.NewTimer(0 * time.Second)
+	timer := time.NewTimer(0 * time.Second)
 
 	switch {
 	case waitDur.Nanoseconds() < 0:
 		// Negative duration signals immediate return.  All is well.
+		return timer, false, nil
 	case waitDur.Nanoseconds() == 0:
 		// Zero duration signals indefinite wait.  Discard the tick.
-		wait = time.NewTimer(0 * time.Second)
-		_ = <-wait.C
+		_ = <-timer.C
 	default:
 		// Otherwise, start an ordinary timer.
-		wait = time.NewTimer(waitDur)
-	}
-
-	result, err := GetActionResult(api, c.requestedId, wait)
-	if err != nil {
-		return errors.Trace(err)
+		timer = time.NewTimer(waitDur)
 	}
-
-	return c.out.Write(ctx, FormatActionResult(result))
+	return timer, true, nil
 }
 
 // GetActionResult tries to repeatedly fetch an action until it is
@@ -191,6 +218,11 @@ func fetchResult(api APIClient, requestedId string) (params.ActionResult, error)
 	return result, nil
 }
 
+// formatActionMessage formats a progress message logged by an action.
+func formatActionMessage(m params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", m.Timestamp, m.Message)
+}
+
 // FormatActionResult removes empty values from the given ActionResult and
 // inserts the remaining ones in a map[string]interface{} for cmd.Output to
 // write in an easy-to-read format.
@@ -202,6 +234,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		logs := make([]string, len(result.Log))
+		for i, m := range result.Log {
+			logs[i] = formatActionMessage(m)
+		}
+		response["log"] = logs
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
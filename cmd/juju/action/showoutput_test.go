@@ -71,7 +71,7 @@ func (s *ShowOutputSuite) TestRun(c *gc.C) {
 	}{{
 		should:         "handle wait-time formatting errors",
 		withClientWait: "not-a-duration-at-all",
-		expectedErr:    "time: invalid duration not-a-duration-at-all",
+		expectedErr:    "invalid --wait: time: invalid duration not-a-duration-at-all",
 	}, {
 		should:            "timeout if result never comes",
 		withClientWait:    "3s",
@@ -167,6 +167,28 @@ timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
   started: 2015-02-14 08:15:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print action log messages",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status: "running",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "dumped 3 of 10 tables",
+			}},
+			Enqueued: time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:  time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		expectedOutput: `
+log:
+- 2015-02-14 08:15:10 +0000 UTC dumped 3 of 10 tables
+status: running
+timing:
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
 `[1:],
 	}, {
 		should:            "pretty-print action output with no completed time",
@@ -4,6 +4,9 @@
 package action
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
 	"github.com/juju/names"
@@ -23,17 +26,24 @@ type statusCommand struct {
 	out         cmd.Output
 	requestedId string
 	name        string
+	wait        string
 }
 
 const statusDoc = `
 Show the status of Actions matching given ID, partial ID prefix, or all Actions if no ID is supplied.
 If --name <name> is provided the search will be done by name rather than by ID.
+
+To watch the Actions until they have all finished, use the --wait flag with
+a duration, as in --wait 5s or --wait 1h.  Use --wait 0 to wait indefinitely.
+If units are left off, seconds are assumed.  While waiting, progress messages
+logged by the Actions with action-log are written to stderr as they arrive.
 `
 
 // Set up the output.
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.name, "name", "", "an action name")
+	f.StringVar(&c.wait, "wait", "-1s", "wait for the actions to finish")
 }
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -59,6 +69,11 @@ func (c *statusCommand) Init(args []string) error {
 }
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
+	wait, watching, err := newWaitTimer(c.wait)
+	if err != nil {
+		return err
+	}
+
 	api, err := c.NewActionAPIClient()
 	if err != nil {
 		return err
@@ -70,6 +85,17 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if watching {
+			entities := []params.Entity{}
+			for _, action := range actions {
+				if action.Action != nil {
+					entities = append(entities, params.Entity{Tag: action.Action.Tag})
+				}
+			}
+			if actions, err = watchActions(ctx, api, entities, wait); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		return c.out.Write(ctx, resultsToMap(actions))
 	}
 
@@ -91,16 +117,72 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		entities = append(entities, params.Entity{tag.String()})
 	}
 
-	actions, err := api.Actions(params.Entities{Entities: entities})
-	if err != nil {
-		return err
+	var results []params.ActionResult
+	if watching {
+		results, err = watchActions(ctx, api, entities, wait)
+		if err != nil {
+			return err
+		}
+	} else {
+		actions, err := api.Actions(params.Entities{Entities: entities})
+		if err != nil {
+			return err
+		}
+		results = actions.Results
 	}
 
-	if len(actions.Results) < 1 {
+	if len(results) < 1 {
 		return errors.Errorf("identifier %q matched action(s) %v, but found no results", c.requestedId, actionTags)
 	}
 
-	return c.out.Write(ctx, resultsToMap(actions.Results))
+	return c.out.Write(ctx, resultsToMap(results))
+}
+
+// watchActions repeatedly fetches the given actions until they have all
+// finished or "wait" fires, writing any new progress messages to stderr
+// as they arrive. It returns the latest results.
+func watchActions(ctx *cmd.Context, api APIClient, entities []params.Entity, wait *time.Timer) ([]params.ActionResult, error) {
+	// tick every two seconds, to delay the loop timer.
+	tick := time.NewTimer(2 * time.Second)
+	defer tick.Stop()
+
+	seen := make(map[string]int)
+	for {
+		actions, err := api.Actions(params.Entities{Entities: entities})
+		if err != nil {
+			return nil, err
+		}
+
+		finished := true
+		for _, result := range actions.Results {
+			switch result.Status {
+			case params.ActionRunning, params.ActionPending:
+				finished = false
+			}
+			if result.Action == nil {
+				continue
+			}
+			id := result.Action.Tag
+			if tag, err := names.ParseActionTag(id); err == nil {
+				id = tag.Id()
+			}
+			for _, m := range result.Log[seen[id]:] {
+				fmt.Fprintf(ctx.Stderr, "%s %s\n", id, formatActionMessage(m))
+			}
+			seen[id] = len(result.Log)
+		}
+		if finished {
+			return actions.Results, nil
+		}
+
+		// Block until a tick happens, or the timeout arrives.
+		select {
+		case <-wait.C:
+			return actions.Results, nil
+		case <-tick.C:
+			tick.Reset(2 * time.Second)
+		}
+	}
 }
 
 // resultsToMap is a helper function that takes in a []params.ActionResult
@@ -136,6 +218,9 @@ func resultToMap(result params.ActionResult) map[string]interface{} {
 
 	}
 	item["status"] = result.Status
+	if result.CancelRequested {
+		item["cancel-requested"] = true
+	}
 	return item
 }
 
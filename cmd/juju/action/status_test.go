@@ -90,6 +90,46 @@ func (s *StatusSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *StatusSuite) TestRunWait(c *gc.C) {
+	prefix := "deadbeef"
+	fakeid := prefix + "-0000-4000-8000-feedfacebeef"
+	faketag := "action-" + fakeid
+	results := []params.ActionResult{{
+		Action: &params.Action{Tag: faketag, Receiver: "unit-mysql-0"},
+		Status: params.ActionCompleted,
+		Log: []params.ActionMessage{{
+			Timestamp: time.Date(2015, time.February, 14, 8, 14, 0, 0, time.UTC),
+			Message:   "dumped 1 of 2 tables",
+		}, {
+			Timestamp: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Message:   "dumped 2 of 2 tables",
+		}},
+	}}
+
+	for _, modelFlag := range s.modelFlags {
+		fakeClient := makeFakeClient(0, 5*time.Second, tagsForIdPrefix(prefix, faketag), results, params.ActionsByNames{}, "")
+		restore := s.patchAPIClient(fakeClient)
+		defer restore()
+
+		s.subcommand, _ = action.NewStatusCommandForTest(s.store)
+		ctx, err := testing.RunCommand(c, s.subcommand, modelFlag, "admin", "--wait", "10s", prefix)
+		c.Assert(err, jc.ErrorIsNil)
+		buf, err := cmd.DefaultFormatters["yaml"](action.ActionResultsToMap(results))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, string(buf)+"\n")
+		c.Check(ctx.Stderr.(*bytes.Buffer).String(), gc.Equals, ""+
+			fakeid+" 2015-02-14 08:14:00 +0000 UTC dumped 1 of 2 tables\n"+
+			fakeid+" 2015-02-14 08:15:00 +0000 UTC dumped 2 of 2 tables\n",
+		)
+	}
+}
+
+func (s *StatusSuite) TestRunWaitBadDuration(c *gc.C) {
+	s.subcommand, _ = action.NewStatusCommandForTest(s.store)
+	_, err := testing.RunCommand(c, s.subcommand, s.modelFlags[0], "admin", "--wait", "not-a-duration")
+	c.Assert(err, gc.ErrorMatches, "time: invalid duration not-a-duration")
+}
+
 func (s *StatusSuite) runTestCase(c *gc.C, tc statusTestCase) {
 	for _, modelFlag := range s.modelFlags {
 		fakeClient := makeFakeClient(
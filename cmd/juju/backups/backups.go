@@ -6,7 +6,9 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -24,7 +26,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -79,6 +81,8 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	fmt.Fprintf(ctx.Stdout, "scheduled:       %v\n", result.Scheduled)
+	fmt.Fprintf(ctx.Stdout, "encrypted:       %v\n", result.Encrypted)
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -104,6 +108,14 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 		return nil, nil, errors.Trace(err)
 	}
 
+	encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if encrypted {
+		return nil, nil, errors.Errorf("backup archive %q is encrypted; decrypt it with verify-backup --output first", filename)
+	}
+
 	// Extract the metadata.
 	ad, err := statebackups.NewArchiveDataReader(archive)
 	if err != nil {
@@ -155,3 +167,17 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 
 	return archive, metaResult, nil
 }
+
+// readKeyFile returns the contents of a file holding an encryption key,
+// without any surrounding whitespace.
+func readKeyFile(ctx *cmd.Context, filename string) (string, error) {
+	data, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return "", errors.Annotate(err, "cannot read key file")
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", errors.Errorf("key file %q is empty", filename)
+	}
+	return key, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -28,6 +29,11 @@ create-backup requests that juju create a backup of its state and print the
 backup's unique ID.  You may provide a note to associate with the backup.
 
 The backup archive and associated metadata are stored remotely by juju.
+By default the archive is kept in the controller's database; the
+"backup-storage" model config setting of the controller model may be
+used to keep archives off the controller instead, either in a directory
+such as an NFS mount ("directory", with "backup-directory") or in an
+S3-compatible object store ("s3", with the "backup-s3-*" settings).
 
 The --download option may be used without the --filename option.  In
 that case, the backup archive will be stored in the current working
@@ -44,6 +50,19 @@ Therefore, you should use the --download or --filename options, or use:
 to get a local copy of the backup archive.
 This local copy can then be used to restore an model even if that
 model was already destroyed or is otherwise unavailable.
+
+The archive may be encrypted with --encrypt-key-file, which names a
+file holding a secret key, or with --encrypt-public-key-file, which
+names a file holding a PEM-encoded RSA public key. The same key, or the
+matching private key, is then needed to verify or restore the backup:
+
+    juju create-backup --encrypt-public-key-file backup-key.pub
+    juju verify-backup --private-key-file backup-key.pem \
+        --output decrypted.tar.gz juju-backup-<date>-<time>.tar.gz
+    juju restore-backup --file decrypted.tar.gz
+
+Scheduled backups are encrypted when the "backup-public-key" model config
+setting of the controller model holds a public key.
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -60,6 +79,12 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// KeyFile is the path to a file holding the key with which the
+	// archive is encrypted.
+	KeyFile string
+	// PublicKeyFile is the path to a file holding the public key with
+	// which the archive is encrypted.
+	PublicKeyFile string
 }
 
 // Info implements Command.Info.
@@ -77,6 +102,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "download to this file")
+	f.StringVar(&c.KeyFile, "encrypt-key-file", "", "encrypt the archive with the key in this file")
+	f.StringVar(&c.PublicKeyFile, "encrypt-public-key-file", "", "encrypt the archive with the RSA public key in this file")
 }
 
 // Init implements Command.Init.
@@ -93,6 +120,9 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+	if c.KeyFile != "" && c.PublicKeyFile != "" {
+		return errors.Errorf("cannot mix --encrypt-key-file and --encrypt-public-key-file")
+	}
 
 	return nil
 }
@@ -104,13 +134,29 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	args := params.BackupsCreateArgs{Notes: c.Notes}
+	if c.KeyFile != "" {
+		key, err := readKeyFile(ctx, c.KeyFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		args.EncryptionKey = key
+	}
+	if c.PublicKeyFile != "" {
+		key, err := readKeyFile(ctx, c.PublicKeyFile)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		args.EncryptionPublicKey = key
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	result, err := client.Create(args)
 	if err != nil {
 		return errors.Trace(err)
 	}
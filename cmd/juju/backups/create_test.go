@@ -5,6 +5,8 @@ package backups_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -12,6 +14,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/testing"
 )
@@ -139,3 +142,44 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) TestEncryptKeyFile(c *gc.C) {
+	client := s.setSuccess()
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--encrypt-key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs, gc.Equals, params.BackupsCreateArgs{EncryptionKey: "secret"})
+}
+
+func (s *createSuite) TestEncryptPublicKeyFile(c *gc.C) {
+	client := s.setSuccess()
+	keyFile := filepath.Join(c.MkDir(), "backup.pub")
+	err := ioutil.WriteFile(keyFile, []byte("<public key>\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--encrypt-public-key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs, gc.Equals, params.BackupsCreateArgs{EncryptionPublicKey: "<public key>"})
+}
+
+func (s *createSuite) TestEncryptKeyFileMissing(c *gc.C) {
+	client := s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--no-download", "--encrypt-key-file", "/no/such/file")
+
+	c.Check(err, gc.ErrorMatches, "cannot read key file: .*")
+	client.Check(c, "", "")
+}
+
+func (s *createSuite) TestEncryptBothKeyFiles(c *gc.C) {
+	s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--encrypt-key-file", "a", "--encrypt-public-key-file", "b")
+
+	c.Check(err, gc.ErrorMatches, "cannot mix --encrypt-key-file and --encrypt-public-key-file")
+}
@@ -5,6 +5,7 @@ package backups
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/version"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -65,6 +66,17 @@ func NewRemoveCommandForTest() cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewVerifyCommandForTest(store jujuclient.ClientStore, serverVersion version.Number) cmd.Command {
+	c := &verifyCommand{
+		serverVersionFunc: func() (version.Number, error) {
+			return serverVersion, nil
+		},
+	}
+	c.Log = &cmd.Log{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func NewRestoreCommandForTest(
 	store jujuclient.ClientStore,
 	api RestoreAPI,
@@ -9,11 +9,19 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 list-backups provides the metadata associated with all backups.
+
+Backups made by the controller's backup schedule (see the
+"backup-schedule" model config setting of the controller model) are
+marked as scheduled. Old scheduled backups are removed according to
+the "backup-keep-last", "backup-keep-daily" and "backup-keep-weekly"
+settings; backups made with "juju create-backup" are never removed
+automatically.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -72,15 +80,23 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	if verbose {
 		c.dumpMetadata(ctx, &result.List[0])
 	} else {
-		fmt.Fprintln(ctx.Stdout, result.List[0].ID)
+		printBrief(ctx, &result.List[0])
 	}
 	for _, resultItem := range result.List[1:] {
 		if verbose {
 			fmt.Fprintln(ctx.Stdout)
 			c.dumpMetadata(ctx, &resultItem)
 		} else {
-			fmt.Fprintln(ctx.Stdout, resultItem.ID)
+			printBrief(ctx, &resultItem)
 		}
 	}
 	return nil
 }
+
+func printBrief(ctx *cmd.Context, result *params.BackupsMetadataResult) {
+	if result.Scheduled {
+		fmt.Fprintf(ctx.Stdout, "%s (scheduled)\n", result.ID)
+	} else {
+		fmt.Fprintln(ctx.Stdout, result.ID)
+	}
+}
@@ -32,6 +32,8 @@ stored:          0001-01-01 00:00:00 +0000 UTC
 started:         0001-01-01 00:00:00 +0000 UTC
 finished:        0001-01-01 00:00:00 +0000 UTC
 notes:           ""
+scheduled:       false
+encrypted:       false
 model ID:        ""
 machine ID:      ""
 created on host: ""
@@ -123,6 +125,8 @@ type fakeAPIClient struct {
 	args  []string
 	idArg string
 	notes string
+
+	createArgs params.BackupsCreateArgs
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -131,10 +135,11 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, "notes")
-	c.notes = notes
+	c.notes = args.Notes
+	c.createArgs = args
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -81,6 +81,10 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+Downloaded archives should be checked with "juju verify-backup" before
+they are restored. Encrypted archives must first be decrypted with
+"juju verify-backup --output".
 `
 
 var BootstrapFunc = bootstrap.Bootstrap
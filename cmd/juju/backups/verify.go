@@ -0,0 +1,226 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks a downloaded backup archive before it is restored.
+The archive's contents are checked against the manifest stored in it,
+the manifest's signature is checked against the CA certificate of the
+current controller, and the juju version which made the backup is
+checked against the version the controller is running, since backups
+may only be restored by the same major and minor version of juju.
+
+Encrypted archives are decrypted with --key-file, naming a file holding
+the key given to create-backup, or with --private-key-file, naming a
+file holding the RSA private key matching the public key the archive was
+encrypted with. Encrypted archives cannot be restored directly; use
+--output to write the decrypted archive to a file which can be given to
+restore-backup:
+
+    juju verify-backup juju-backup-<date>-<time>.tar.gz
+    juju verify-backup --private-key-file backup-key.pem \
+        --output decrypted.tar.gz juju-backup-<date>-<time>.tar.gz
+    juju restore-backup --file decrypted.tar.gz
+`
+
+// NewVerifyCommand returns a command used to verify backup archives.
+func NewVerifyCommand() cmd.Command {
+	c := &verifyCommand{}
+	c.serverVersionFunc = c.serverVersion
+	return modelcmd.Wrap(c)
+}
+
+// verifyCommand is the sub-command for verifying a downloaded backup
+// archive.
+type verifyCommand struct {
+	CommandBase
+	// Filename is the path to the backup archive.
+	Filename string
+	// KeyFile is the path to a file holding the key with which the
+	// archive was encrypted.
+	KeyFile string
+	// PrivateKeyFile is the path to a file holding the private key
+	// matching the public key with which the archive was encrypted.
+	PrivateKeyFile string
+	// Output is where the decrypted archive is written.
+	Output string
+
+	serverVersionFunc func() (version.Number, error)
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify-backup",
+		Args:    "<filename>",
+		Purpose: "check a backup archive before restoring it",
+		Doc:     verifyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.KeyFile, "key-file", "", "decrypt the archive with the key in this file")
+	f.StringVar(&c.PrivateKeyFile, "private-key-file", "", "decrypt the archive with the RSA private key in this file")
+	f.StringVar(&c.Output, "output", "", "write the decrypted archive to this file")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	c.Filename, args = args[0], args[1:]
+	if c.KeyFile != "" && c.PrivateKeyFile != "" {
+		return errors.New("cannot mix --key-file and --private-key-file")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) (err error) {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+	archive, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !encrypted && c.Output != "" {
+		return errors.Errorf("backup archive %q is not encrypted, so there is nothing to write to --output", c.Filename)
+	}
+	var source io.Reader = archive
+	if encrypted {
+		decrypted, derr := c.decrypt(ctx, archive)
+		if derr != nil {
+			return errors.Trace(derr)
+		}
+		defer func() {
+			decrypted.Close()
+			if err != nil || c.Output == "" {
+				os.Remove(decrypted.Name())
+			}
+		}()
+		source = decrypted
+	}
+
+	ws, err := statebackups.NewArchiveWorkspaceReader(source)
+	if err != nil {
+		return errors.Annotate(err, "cannot unpack backup archive")
+	}
+	defer ws.Close()
+
+	caCert, err := c.caCert()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manifest, err := ws.Verify(caCert)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := c.serverVersionFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := statebackups.CheckRestoreCompatible(manifest.Version, current); err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "backup archive verified: %d files from model %s, made by juju %s\n",
+		len(manifest.Files), manifest.Model, manifest.Version)
+	if c.Output != "" {
+		fmt.Fprintf(ctx.Stdout, "decrypted archive written to %s\n", c.Output)
+	}
+	return nil
+}
+
+// decrypt decrypts the archive to --output if it was given, or else to
+// a temporary file. The returned file is positioned at its start.
+func (c *verifyCommand) decrypt(ctx *cmd.Context, archive io.ReadSeeker) (_ *os.File, err error) {
+	var dec statebackups.Decryption
+	switch {
+	case c.KeyFile != "":
+		if dec.Key, err = readKeyFile(ctx, c.KeyFile); err != nil {
+			return nil, errors.Trace(err)
+		}
+	case c.PrivateKeyFile != "":
+		if dec.PrivateKey, err = readKeyFile(ctx, c.PrivateKeyFile); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.Errorf("backup archive %q is encrypted; use --key-file or --private-key-file to decrypt it", c.Filename)
+	}
+
+	var out *os.File
+	if c.Output != "" {
+		out, err = os.OpenFile(ctx.AbsPath(c.Output), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	} else {
+		out, err = ioutil.TempFile("", "juju-backup-")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			out.Close()
+			os.Remove(out.Name())
+		}
+	}()
+	if err := statebackups.DecryptArchive(out, archive, dec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := out.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out, nil
+}
+
+// caCert returns the CA certificate of the current controller, with
+// which the archive's manifest must have been signed.
+func (c *verifyCommand) caCert() (string, error) {
+	details, err := c.ClientStore().ControllerByName(c.ControllerName())
+	if err != nil {
+		return "", errors.Annotate(err, "cannot get controller details")
+	}
+	return details.CACert, nil
+}
+
+// serverVersion returns the version of juju the current controller is
+// running.
+func (c *verifyCommand) serverVersion() (version.Number, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return version.Number{}, errors.Trace(err)
+	}
+	defer root.Close()
+	v, ok := root.ServerVersion()
+	if !ok {
+		return version.Number{}, errors.New("controller did not report its version")
+	}
+	return v, nil
+}
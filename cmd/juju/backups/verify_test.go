@@ -0,0 +1,185 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	store *jujuclienttesting.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{
+		ControllerUUID: testing.ModelTag.Id(),
+		CACert:         testing.CACert,
+	}
+	s.store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+	s.dir = c.MkDir()
+}
+
+// writeArchive writes a backup archive signed with the given CA key,
+// encrypted with key if it is not empty, and returns its path.
+func (s *verifySuite) writeArchive(c *gc.C, caCert, caKey, key string) string {
+	meta := backupstesting.NewMetadataStarted()
+	meta.CACert = caCert
+	meta.CAPrivateKey = caKey
+	archive, err := backupstesting.NewSignedArchive(meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	if key != "" {
+		var encrypted bytes.Buffer
+		w, err := statebackups.NewEncryptingWriter(&encrypted, statebackups.Encryption{Key: key})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = archive.WriteTo(w)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(w.Close(), jc.ErrorIsNil)
+		archive = &encrypted
+	}
+
+	filename := filepath.Join(s.dir, "backup.tar.gz")
+	err = ioutil.WriteFile(filename, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *verifySuite) writeKeyFile(c *gc.C, key string) string {
+	filename := filepath.Join(s.dir, "backup.key")
+	err := ioutil.WriteFile(filename, []byte(key+"\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *verifySuite) run(c *gc.C, serverVersion version.Number, args ...string) (*cmd.Context, error) {
+	command := backups.NewVerifyCommandForTest(s.store, serverVersion)
+	return testing.RunCommand(c, command, append([]string{"-m", "testing:admin"}, args...)...)
+}
+
+func (s *verifySuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, jujuversion.Current)
+	c.Check(err, gc.ErrorMatches, "missing filename")
+
+	_, err = s.run(c, jujuversion.Current, "a", "b")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+
+	_, err = s.run(c, jujuversion.Current, "--key-file", "a", "--private-key-file", "b", "c")
+	c.Check(err, gc.ErrorMatches, "cannot mix --key-file and --private-key-file")
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "")
+
+	ctx, err := s.run(c, jujuversion.Current, filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Matches, "backup archive verified: 4 files from model .*, made by juju .*\n")
+}
+
+func (s *verifySuite) TestVerifyOtherController(c *gc.C) {
+	filename := s.writeArchive(c, testing.OtherCACert, testing.OtherCAKey, "")
+
+	_, err := s.run(c, jujuversion.Current, filename)
+	c.Check(err, gc.ErrorMatches, "backup manifest was not signed by this controller")
+}
+
+func (s *verifySuite) TestVerifyUnsigned(c *gc.C) {
+	filename := s.writeArchive(c, "", "", "")
+
+	_, err := s.run(c, jujuversion.Current, filename)
+	c.Check(err, gc.ErrorMatches, "backup manifest is not signed")
+}
+
+func (s *verifySuite) TestVerifyIncompatibleVersion(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "")
+	current := jujuversion.Current
+	current.Minor++
+
+	_, err := s.run(c, current, filename)
+	c.Check(err, gc.ErrorMatches, `backup made by juju .* cannot be restored by juju .* \(major and minor versions must match\)`)
+}
+
+func (s *verifySuite) TestVerifyNoManifest(c *gc.C) {
+	archive, err := backupstesting.NewArchiveBasic(backupstesting.NewMetadataStarted())
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(s.dir, "backup.tar.gz")
+	err = ioutil.WriteFile(filename, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, jujuversion.Current, filename)
+	c.Check(err, gc.ErrorMatches, "backup manifest not found")
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "secret")
+	keyFile := s.writeKeyFile(c, "secret")
+	output := filepath.Join(s.dir, "decrypted.tar.gz")
+
+	ctx, err := s.run(c, jujuversion.Current, filename, "--key-file", keyFile, "--output", output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Matches, "backup archive verified: .*\ndecrypted archive written to .*\n")
+
+	// The decrypted archive can itself be verified.
+	_, err = s.run(c, jujuversion.Current, output)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *verifySuite) TestVerifyEncryptedWithoutOutput(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "secret")
+	keyFile := s.writeKeyFile(c, "secret")
+
+	_, err := s.run(c, jujuversion.Current, filename, "--key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *verifySuite) TestVerifyEncryptedWrongKey(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "secret")
+	keyFile := s.writeKeyFile(c, "guess")
+	output := filepath.Join(s.dir, "decrypted.tar.gz")
+
+	_, err := s.run(c, jujuversion.Current, filename, "--key-file", keyFile, "--output", output)
+	c.Check(err, gc.ErrorMatches, `backup archive failed integrity check \(wrong key, or the archive is corrupt\)`)
+	_, err = os.Stat(output)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *verifySuite) TestVerifyEncryptedNoKey(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "secret")
+
+	_, err := s.run(c, jujuversion.Current, filename)
+	c.Check(err, gc.ErrorMatches, `backup archive ".*" is encrypted; use --key-file or --private-key-file to decrypt it`)
+}
+
+func (s *verifySuite) TestOutputNotEncrypted(c *gc.C) {
+	filename := s.writeArchive(c, testing.CACert, testing.CAKey, "")
+
+	_, err := s.run(c, jujuversion.Current, filename, "--output", filepath.Join(s.dir, "out.tar.gz"))
+	c.Check(err, gc.ErrorMatches, `backup archive ".*" is not encrypted, so there is nothing to write to --output`)
+}
@@ -6,6 +6,8 @@ package commands
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/loggo"
@@ -37,8 +39,19 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name, which can be truncated.
 A combination of machine and unit filtering uses a logical OR whereas a
 combination of module and machine/unit filtering uses a logical AND.
+The '--include-location' and '--exclude-location' options filter by the
+source location of the message: a file name, optionally followed by ':'
+and a line number, which may contain '*' wildcards.
+The '--message' option shows only messages matching a regular expression.
 Log levels are cumulative; each lower level (more verbose) contains the
 preceding higher level (less verbose).
+The '--since' and '--until' options restrict the messages shown to a time
+window. Each takes either an RFC3339 timestamp or a duration such as "90m",
+meaning that long before now. Either option implies '--replay', and
+'--until' also stops once the window has been shown.
+With '--format json', each message is written as a JSON object on its own
+line, with "model-uuid", "timestamp", "entity", "module", "location",
+"level" and "message" fields, ready for processing by other tools.
 
 Examples:
 Exclude all machine 0 messages; show a maximum of 100 lines; and continue
@@ -65,6 +78,15 @@ To see all WARNING and ERROR messages:
 
     juju debug-log --replay --level WARNING
 
+To see the messages from the last hour containing "hook failed", as JSON:
+
+    juju debug-log -T --since 1h --message "hook failed" --format json
+
+To see messages written by the uniter on a given day:
+
+    juju debug-log --since 2016-06-19T00:00:00Z --until 2016-06-20T00:00:00Z \
+        --include-location "uniter*.go"
+
 See also: 
     status`
 
@@ -84,9 +106,15 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	since  string
+	until  string
 	params api.DebugLogParams
 }
 
+// debugLogNow returns the time which durations given to --since and
+// --until are relative to.
+var debugLogNow = time.Now
+
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeEntity), "i", "Only show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeEntity), "include", "Only show log messages for these entities")
@@ -94,6 +122,9 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLocation), "include-location", "Only show log messages from these source locations")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLocation), "exclude-location", "Do not show log messages from these source locations")
+	f.StringVar(&c.params.Message, "message", "", "Only show log messages matching this regular expression")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -104,6 +135,9 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 	f.BoolVar(&c.params.NoTail, "T", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.params.NoTail, "no-tail", false, "")
+	f.StringVar(&c.since, "since", "", "Only show log messages written since this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages written before this time or duration ago, then exit")
+	f.StringVar(&c.params.Format, "format", "text", "Output format, one of [text, json]")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -115,9 +149,54 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	if c.params.Message != "" {
+		if _, err := regexp.Compile(c.params.Message); err != nil {
+			return fmt.Errorf("message value %q is not a valid regular expression: %v", c.params.Message, err)
+		}
+	}
+	if c.params.Format != "text" && c.params.Format != "json" {
+		return fmt.Errorf("format value %q is not one of %q, %q", c.params.Format, "text", "json")
+	}
+	if c.params.Format == "text" {
+		// The server writes text by default, so there is no need to
+		// ask for it.
+		c.params.Format = ""
+	}
+
+	now := debugLogNow()
+	var err error
+	if c.params.StartTime, err = parseDebugLogTime("since", c.since, now); err != nil {
+		return err
+	}
+	if c.params.EndTime, err = parseDebugLogTime("until", c.until, now); err != nil {
+		return err
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && !c.params.StartTime.Before(c.params.EndTime) {
+		return fmt.Errorf("--since must be before --until")
+	}
+	if !c.params.StartTime.IsZero() || !c.params.EndTime.IsZero() {
+		c.params.Replay = true
+	}
 	return cmd.CheckEmpty(args)
 }
 
+// parseDebugLogTime parses the value of the named time flag, which is
+// either an RFC3339 timestamp or a duration before now. An empty value
+// gives the zero time.
+func parseDebugLogTime(name, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("%s value %q is not an RFC3339 time or a positive duration", name, value)
+	}
+	return now.Add(-d), nil
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params api.DebugLogParams) (io.ReadCloser, error)
 	Close() error
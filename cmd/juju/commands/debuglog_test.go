@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -24,6 +25,8 @@ type DebugLogSuite struct {
 var _ = gc.Suite(&DebugLogSuite{})
 
 func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
+	now := time.Date(2016, 6, 19, 16, 0, 0, 0, time.UTC)
+	s.PatchValue(&debugLogNow, func() time.Time { return now })
 	for i, test := range []struct {
 		args     []string
 		expected api.DebugLogParams
@@ -91,6 +94,65 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--include-location", "uniter.go", "--include-location", "*_test.go:42"},
+			expected: api.DebugLogParams{
+				IncludeLocation: []string{"uniter.go", "*_test.go:42"},
+				Backlog:         10,
+			},
+		}, {
+			args: []string{"--exclude-location", "uniter.go", "--exclude-location", "*_test.go"},
+			expected: api.DebugLogParams{
+				ExcludeLocation: []string{"uniter.go", "*_test.go"},
+				Backlog:         10,
+			},
+		}, {
+			args: []string{"--message", "^hook .* failed$"},
+			expected: api.DebugLogParams{
+				Message: "^hook .* failed$",
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--message", "(unclosed"},
+			errMatch: `message value "\(unclosed" is not a valid regular expression: .*`,
+		}, {
+			args: []string{"--format", "json"},
+			expected: api.DebugLogParams{
+				Format:  "json",
+				Backlog: 10,
+			},
+		}, {
+			args: []string{"--format", "text"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
+		}, {
+			args: []string{"--since", "2016-06-19T12:00:00Z"},
+			expected: api.DebugLogParams{
+				StartTime: time.Date(2016, 6, 19, 12, 0, 0, 0, time.UTC),
+				Backlog:   10,
+				Replay:    true,
+			},
+		}, {
+			args: []string{"--since", "2h", "--until", "90m"},
+			expected: api.DebugLogParams{
+				StartTime: now.Add(-2 * time.Hour),
+				EndTime:   now.Add(-90 * time.Minute),
+				Backlog:   10,
+				Replay:    true,
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `since value "yesterday" is not an RFC3339 time or a positive duration`,
+		}, {
+			args:     []string{"--until", "-1h"},
+			errMatch: `until value "-1h" is not an RFC3339 time or a positive duration`,
+		}, {
+			args:     []string{"--since", "1h", "--until", "2h"},
+			errMatch: `--since must be before --until`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -203,6 +203,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(newWaitCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newRunCommand())
@@ -234,6 +235,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewRestoreCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -279,6 +281,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
 	r.Register(action.NewRunCommand())
+	r.Register(action.NewCancelCommand())
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 
@@ -290,6 +293,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(service.NewGetCommand())
 	r.Register(service.NewSetCommand())
 	r.Register(service.NewDeployCommand())
+	r.Register(service.NewDiffBundleCommand())
+	r.Register(service.NewExportBundleCommand())
 	r.Register(service.NewExposeCommand())
 	r.Register(service.NewUnexposeCommand())
 	r.Register(service.NewServiceGetConstraintsCommand())
@@ -301,6 +306,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage storage
 	r.Register(storage.NewAddCommand())
+	r.Register(storage.NewAttachStorageCommand())
+	r.Register(storage.NewCreateSnapshotCommand())
+	r.Register(storage.NewDetachStorageCommand())
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
@@ -333,6 +341,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewRemoveBlocksCommand())
 	r.Register(controller.NewShowControllerCommand())
+	r.Register(controller.NewShowAuditLogCommand())
+	r.Register(controller.NewExportModelCommand())
+	r.Register(controller.NewImportModelCommand())
+	r.Register(controller.NewShowMigrationCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -342,17 +342,20 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"allocate",
+	"attach-storage",
 	"autoload-credentials",
 	"backups",
 	"block",
 	"bootstrap",
 	"cached-images",
+	"cancel-action",
 	"change-user-password",
 	"charm",
 	"collect-metrics",
 	"create-backup",
 	"create-budget",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"debug-hooks",
 	"debug-log",
 	"debug-metrics",
@@ -362,10 +365,14 @@ var commandNames = []string{
 	"destroy-relation",
 	"destroy-service",
 	"destroy-unit",
+	"detach-storage",
+	"diff-bundle",
 	"disable-user",
 	"download-backup",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
+	"export-model",
 	"expose",
 	"get-config",
 	"get-configs",
@@ -376,6 +383,7 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"import-model",
 	"import-ssh-key",
 	"import-ssh-keys",
 	"kill-controller",
@@ -438,6 +446,7 @@ var commandNames = []string{
 	"ssh-keys",
 	"show-action-output",
 	"show-action-status",
+	"show-audit-log",
 	"show-backup",
 	"show-budget",
 	"show-cloud",
@@ -445,6 +454,7 @@ var commandNames = []string{
 	"show-controllers",
 	"show-machine",
 	"show-machines",
+	"show-migration",
 	"show-model",
 	"show-status",
 	"show-storage",
@@ -466,7 +476,9 @@ var commandNames = []string{
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"verify-backup",
 	"version",
+	"wait",
 }
 
 // devFeatures are feature flags that impact registration of commands.
@@ -4,10 +4,18 @@
 package commands
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -18,14 +26,26 @@ func newMigrateCommand() cmd.Command {
 // migrateCommand initiates a model migration.
 type migrateCommand struct {
 	modelcmd.ControllerCommandBase
-	api migrateAPI
+	api       migrateAPI
+	targetAPI migrateTargetAPI
+	out       cmd.Output
 
 	model            string
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error)
+	PrecheckModelMigration(modelUUID string) ([]params.MigrationIssue, error)
+	ExportModel(modelUUID string) ([]byte, error)
+}
+
+// migrateTargetAPI defines the methods on the target controller's API
+// that a dry run migration calls.
+type migrateTargetAPI interface {
+	DryRun(bytes []byte) ([]params.MigrationIssue, error)
+	Close() error
 }
 
 const migrateDoc = `
@@ -51,6 +71,14 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, no migration is started. Instead the checks made by
+the source controller are run, and the model is imported into a
+scratch model on the target controller, checked and then removed
+again. Every problem that would cause the migration to fail is
+reported, such as agents running a different version to the model,
+charms which are missing, or a target controller on a different
+provider.
+
 See Also:
    juju help login
    juju help controllers
@@ -67,6 +95,16 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the migration would succeed without migrating the model")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationIssuesTabular,
+	})
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -84,6 +122,21 @@ func (c *migrateCommand) Init(args []string) error {
 	return nil
 }
 
+// targetAccount returns the names of the target controller and of the
+// account used to access it.
+func (c *migrateCommand) targetAccount() (controllerName, accountName string, err error) {
+	store := c.ClientStore()
+	controllerName, err = modelcmd.ResolveControllerName(store, c.targetController)
+	if err != nil {
+		return "", "", err
+	}
+	accountName, err = store.CurrentAccount(controllerName)
+	if err != nil {
+		return "", "", err
+	}
+	return controllerName, accountName, nil
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.ModelMigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -92,7 +145,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.ModelMigrationSpec, err
 		return nil, err
 	}
 
-	controllerName, err := modelcmd.ResolveControllerName(store, c.targetController)
+	controllerName, accountName, err := c.targetAccount()
 	if err != nil {
 		return nil, err
 	}
@@ -102,11 +155,6 @@ func (c *migrateCommand) getMigrationSpec() (*controller.ModelMigrationSpec, err
 		return nil, err
 	}
 
-	accountName, err := store.CurrentAccount(controllerName)
-	if err != nil {
-		return nil, err
-	}
-
 	accountInfo, err := store.AccountByName(controllerName, accountName)
 	if err != nil {
 		return nil, err
@@ -132,6 +180,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, api, spec.ModelUUID)
+	}
 	id, err := api.InitiateModelMigration(*spec)
 	if err != nil {
 		return err
@@ -140,9 +191,106 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// MigrationIssue describes a problem found by a dry run migration, and
+// the controller which found it.
+type MigrationIssue struct {
+	Phase      string `yaml:"phase" json:"phase"`
+	Controller string `yaml:"controller" json:"controller"`
+	Message    string `yaml:"message" json:"message"`
+}
+
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, modelUUID string) error {
+	var report []MigrationIssue
+	addIssues := func(controllerName string, issues []params.MigrationIssue) {
+		for _, issue := range issues {
+			report = append(report, MigrationIssue{
+				Phase:      issue.Phase,
+				Controller: controllerName,
+				Message:    issue.Message,
+			})
+		}
+	}
+
+	sourceIssues, err := api.PrecheckModelMigration(modelUUID)
+	if err != nil {
+		return errors.Annotate(err, "source controller precheck")
+	}
+	addIssues(c.ControllerName(), sourceIssues)
+
+	serialized, err := api.ExportModel(modelUUID)
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	targetName, accountName, err := c.targetAccount()
+	if err != nil {
+		return err
+	}
+	targetAPI, err := c.getTargetAPI(targetName, accountName)
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to target controller")
+	}
+	defer targetAPI.Close()
+	targetIssues, err := targetAPI.DryRun(serialized)
+	if err != nil {
+		return errors.Annotate(err, "target controller dry run")
+	}
+	addIssues(targetName, targetIssues)
+
+	if len(report) == 0 {
+		ctx.Infof("Dry run found no problems migrating %q to %q", c.model, targetName)
+		return nil
+	}
+	if err := c.out.Write(ctx, report); err != nil {
+		return err
+	}
+	return errors.Errorf("migration would fail: %d problem(s) found", len(report))
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
 	}
 	return c.NewControllerAPIClient()
 }
+
+// targetClient adapts a connection to the target controller to
+// migrateTargetAPI.
+type targetClient struct {
+	migrationtarget.Client
+	io.Closer
+}
+
+func (c *migrateCommand) getTargetAPI(controllerName, accountName string) (migrateTargetAPI, error) {
+	if c.targetAPI != nil {
+		return c.targetAPI, nil
+	}
+	conn, err := c.JujuCommandBase.NewAPIRoot(c.ClientStore(), controllerName, accountName, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &targetClient{migrationtarget.NewClient(conn), conn}, nil
+}
+
+func formatMigrationIssuesTabular(value interface{}) ([]byte, error) {
+	issues, ok := value.([]MigrationIssue)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", issues, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "PHASE\tCONTROLLER\tPROBLEM\n")
+	for _, issue := range issues {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", issue.Phase, issue.Controller, issue.Message)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
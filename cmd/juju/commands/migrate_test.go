@@ -5,10 +5,12 @@ package commands
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/jujuclient"
@@ -18,8 +20,9 @@ import (
 
 type MigrateSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
-	api   *fakeMigrateAPI
-	store *jujuclienttesting.MemStore
+	api       *fakeMigrateAPI
+	targetAPI *fakeMigrateTargetAPI
+	store     *jujuclienttesting.MemStore
 }
 
 var _ = gc.Suite(&MigrateSuite{})
@@ -74,6 +77,7 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.api = &fakeMigrateAPI{}
+	s.targetAPI = &fakeMigrateTargetAPI{}
 }
 
 func (s *MigrateSuite) TestMissingModel(c *gc.C) {
@@ -118,19 +122,106 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRunNoProblems(c *gc.C) {
+	ctx, err := s.runCommand(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Dry run found no problems migrating \"model\" to \"target\"\n")
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // No migration is started.
+	c.Check(s.api.prechecked, gc.Equals, modelUUID)
+	c.Check(s.api.exported, gc.Equals, modelUUID)
+	c.Check(s.targetAPI.bytesSeen, gc.DeepEquals, []byte("model-bytes"))
+	c.Check(s.targetAPI.closed, jc.IsTrue)
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.issues = []params.MigrationIssue{{
+		Phase:   "PRECHECK",
+		Message: "charm cs:trusty/mysql-1 not found",
+	}}
+	s.targetAPI.issues = []params.MigrationIssue{{
+		Phase:   "PRECHECK",
+		Message: `model provider type "ec2" does not match controller provider type "maas"`,
+	}, {
+		Phase:   "VALIDATION",
+		Message: "expected 3 machines, found 2",
+	}}
+	ctx, err := s.runCommand(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `migration would fail: 3 problem\(s\) found`)
+
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"PHASE       CONTROLLER  PROBLEM\n"+
+		"PRECHECK    source      charm cs:trusty/mysql-1 not found\n"+
+		"PRECHECK    target      model provider type \"ec2\" does not match controller provider type \"maas\"\n"+
+		"VALIDATION  target      expected 3 machines, found 2\n")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunYAML(c *gc.C) {
+	s.targetAPI.issues = []params.MigrationIssue{{
+		Phase:   "IMPORT",
+		Message: "boom",
+	}}
+	ctx, err := s.runCommand(c, "--dry-run", "--format", "yaml", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `migration would fail: 1 problem\(s\) found`)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"- phase: IMPORT\n"+
+		"  controller: target\n"+
+		"  message: boom\n")
+}
+
+func (s *MigrateSuite) TestDryRunTargetError(c *gc.C) {
+	s.targetAPI.err = errors.New("boom")
+	_, err := s.runCommand(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "target controller dry run: boom")
+	c.Check(s.targetAPI.closed, jc.IsTrue)
+}
+
 func (s *MigrateSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
 	cmd := &migrateCommand{
-		api: s.api,
+		api:       s.api,
+		targetAPI: s.targetAPI,
 	}
 	cmd.SetClientStore(s.store)
 	return testing.RunCommand(c, modelcmd.WrapController(cmd), args...)
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.ModelMigrationSpec
+	specSeen   *controller.ModelMigrationSpec
+	prechecked string
+	exported   string
+	issues     []params.MigrationIssue
 }
 
 func (a *fakeMigrateAPI) InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error) {
 	a.specSeen = &spec
 	return "uuid:0", nil
 }
+
+func (a *fakeMigrateAPI) PrecheckModelMigration(modelUUID string) ([]params.MigrationIssue, error) {
+	a.prechecked = modelUUID
+	return a.issues, nil
+}
+
+func (a *fakeMigrateAPI) ExportModel(modelUUID string) ([]byte, error) {
+	a.exported = modelUUID
+	return []byte("model-bytes"), nil
+}
+
+type fakeMigrateTargetAPI struct {
+	bytesSeen []byte
+	issues    []params.MigrationIssue
+	err       error
+	closed    bool
+}
+
+func (a *fakeMigrateTargetAPI) DryRun(bytes []byte) ([]params.MigrationIssue, error) {
+	a.bytesSeen = bytes
+	return a.issues, a.err
+}
+
+func (a *fakeMigrateTargetAPI) Close() error {
+	a.closed = true
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+func newWaitCommand() cmd.Command {
+	return modelcmd.Wrap(&waitCommand{})
+}
+
+const waitDoc = `
+Wait until the model reaches a steady state, in which every unit of the
+given services, or of all services if none are given, is idle and reports
+one of the required workload statuses.
+
+By default the required workload status is "active"; use --workload-status
+to accept others, as in --workload-status active,blocked. Services given
+with --exclude are ignored.
+
+With --fail-fast, the command fails as soon as any unit it is waiting for
+enters an error state. With --timeout, it fails if the model has not become
+steady within the given duration.
+
+Examples:
+
+    juju wait
+    juju wait mysql wordpress --timeout 30m
+    juju wait --exclude nagios --fail-fast
+`
+
+// waitCommand blocks until the units of a model reach a steady state.
+type waitCommand struct {
+	modelcmd.ModelCommandBase
+
+	services         []string
+	exclude          string
+	workloadStatuses string
+	timeout          time.Duration
+	failFast         bool
+
+	excluded set.Strings
+	required set.Strings
+}
+
+func (c *waitCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait",
+		Args:    "[<service> ...]",
+		Purpose: "wait until the units of a model are idle and active",
+		Doc:     waitDoc,
+	}
+}
+
+func (c *waitCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.exclude, "exclude", "", "comma separated services to ignore")
+	f.StringVar(&c.workloadStatuses, "workload-status", string(status.StatusActive), "comma separated workload statuses to accept")
+	f.DurationVar(&c.timeout, "timeout", 0, "fail if the model is not steady after this long (0 means no limit)")
+	f.BoolVar(&c.failFast, "fail-fast", false, "fail as soon as a unit enters an error state")
+}
+
+func (c *waitCommand) Init(args []string) error {
+	for _, service := range args {
+		if !names.IsValidService(service) {
+			return errors.Errorf("invalid service name %q", service)
+		}
+	}
+	c.services = args
+	c.excluded = set.NewStrings()
+	for _, service := range splitCommaList(c.exclude) {
+		if !names.IsValidService(service) {
+			return errors.Errorf("invalid service name %q", service)
+		}
+		c.excluded.Add(service)
+	}
+	c.required = set.NewStrings()
+	for _, s := range splitCommaList(c.workloadStatuses) {
+		if !status.ValidWorkloadStatus(status.Status(s)) {
+			return errors.Errorf("invalid workload status %q", s)
+		}
+		c.required.Add(s)
+	}
+	if c.required.IsEmpty() {
+		return errors.New("no workload status specified")
+	}
+	if c.timeout < 0 {
+		return errors.Errorf("timeout must not be negative, got %v", c.timeout)
+	}
+	return nil
+}
+
+// splitCommaList splits a comma separated list, ignoring empty items.
+func splitCommaList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// waitWatcher is the part of the allwatcher used by the wait command.
+type waitWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// clientAllWatcher stops an allwatcher and closes the client it came from.
+type clientAllWatcher struct {
+	*api.AllWatcher
+	client *api.Client
+}
+
+// Stop is part of the waitWatcher interface.
+func (w *clientAllWatcher) Stop() error {
+	err := w.AllWatcher.Stop()
+	if closeErr := w.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+var getWaitWatcher = func(c *waitCommand) (waitWatcher, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		client.Close()
+		return nil, errors.Trace(err)
+	}
+	return &clientAllWatcher{AllWatcher: watcher, client: client}, nil
+}
+
+// Run watches the model until it is steady.
+func (c *waitCommand) Run(ctx *cmd.Context) error {
+	watcher, err := getWaitWatcher(c)
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	type next struct {
+		deltas []multiwatcher.Delta
+		err    error
+	}
+	nextc := make(chan next)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case nextc <- next{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timeout = time.After(c.timeout)
+	}
+	m := newWaitModel()
+	for {
+		select {
+		case n := <-nextc:
+			if n.err != nil {
+				return errors.Annotate(n.err, "watching model")
+			}
+			m.update(n.deltas)
+		case <-timeout:
+			return errors.Errorf("timed out after %v waiting for %s", c.timeout, strings.Join(m.pending(c), ", "))
+		}
+		if c.failFast {
+			if failed := m.failed(c); len(failed) > 0 {
+				return errors.Errorf("units in error state: %s", strings.Join(failed, ", "))
+			}
+		}
+		pending := m.pending(c)
+		if len(pending) == 0 {
+			return nil
+		}
+		logger.Debugf("waiting for %s", strings.Join(pending, ", "))
+	}
+}
+
+// wants reports whether the command waits for the units of the named
+// service.
+func (c *waitCommand) wants(service string) bool {
+	if c.excluded.Contains(service) {
+		return false
+	}
+	if len(c.services) == 0 {
+		return true
+	}
+	for _, s := range c.services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// waitModel tracks the services and units reported by the allwatcher.
+type waitModel struct {
+	services set.Strings
+	units    map[string]multiwatcher.UnitInfo
+}
+
+func newWaitModel() *waitModel {
+	return &waitModel{
+		services: set.NewStrings(),
+		units:    make(map[string]multiwatcher.UnitInfo),
+	}
+}
+
+// update applies the given deltas to the model.
+func (m *waitModel) update(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch entity := delta.Entity.(type) {
+		case *multiwatcher.ServiceInfo:
+			if delta.Removed {
+				m.services.Remove(entity.Name)
+			} else {
+				m.services.Add(entity.Name)
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(m.units, entity.Name)
+			} else {
+				m.units[entity.Name] = *entity
+			}
+		}
+	}
+}
+
+// pending returns descriptions of the services and units which are not
+// yet steady, sorted by name.
+func (m *waitModel) pending(c *waitCommand) []string {
+	var pending []string
+	for _, service := range c.services {
+		if !m.services.Contains(service) && !c.excluded.Contains(service) {
+			pending = append(pending, fmt.Sprintf("service %s", service))
+		}
+	}
+	for name, unit := range m.units {
+		if !c.wants(unit.Service) {
+			continue
+		}
+		workload := unit.WorkloadStatus.Current
+		agent := unit.JujuStatus.Current
+		if agent != status.StatusIdle || !c.required.Contains(string(workload)) {
+			pending = append(pending, fmt.Sprintf("%s (%s, %s)", name, agent, workload))
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// failed returns the names of the units in an error state, sorted by
+// name.
+func (m *waitModel) failed(c *waitCommand) []string {
+	var failed []string
+	for name, unit := range m.units {
+		if !c.wants(unit.Service) {
+			continue
+		}
+		if unit.WorkloadStatus.Current == status.StatusError || unit.JujuStatus.Current == status.StatusError {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
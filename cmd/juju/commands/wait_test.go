@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type WaitSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&WaitSuite{})
+
+func (s *WaitSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		args: []string{},
+	}, {
+		args: []string{"mysql", "wordpress", "--exclude", "nagios,ntp", "--workload-status", "active,blocked"},
+	}, {
+		args:     []string{"mysql/0"},
+		errMatch: `invalid service name "mysql/0"`,
+	}, {
+		args:     []string{"--exclude", "mysql,ntp/1"},
+		errMatch: `invalid service name "ntp/1"`,
+	}, {
+		args:     []string{"--workload-status", "active,happy"},
+		errMatch: `invalid workload status "happy"`,
+	}, {
+		args:     []string{"--workload-status", ","},
+		errMatch: "no workload status specified",
+	}, {
+		args:     []string{"--timeout=-1m"},
+		errMatch: "timeout must not be negative, got -1m0s",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(newWaitCommand(), test.args)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *WaitSuite) TestRun(c *gc.C) {
+	for i, test := range []struct {
+		about    string
+		args     []string
+		deltas   [][]multiwatcher.Delta
+		nextErr  error
+		errMatch string
+	}{{
+		about: "steady immediately",
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+		}},
+	}, {
+		about: "steady once the units settle",
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			unitDelta("mysql/0", status.StatusExecuting, status.StatusMaintenance),
+		}, {
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+		}},
+	}, {
+		about: "other workload statuses can be accepted",
+		args:  []string{"--workload-status", "active,blocked"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusBlocked),
+			unitDelta("mysql/1", status.StatusIdle, status.StatusActive),
+		}},
+	}, {
+		about: "only the given services are waited for",
+		args:  []string{"mysql", "--timeout", "50ms"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			serviceDelta("wordpress"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+			unitDelta("wordpress/0", status.StatusExecuting, status.StatusMaintenance),
+		}},
+	}, {
+		about: "excluded services are ignored",
+		args:  []string{"--exclude", "wordpress", "--fail-fast", "--timeout", "50ms"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			serviceDelta("wordpress"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+			unitDelta("wordpress/0", status.StatusError, status.StatusError),
+		}},
+	}, {
+		about: "removed units are forgotten",
+		args:  []string{"--timeout", "50ms"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+			unitDelta("mysql/1", status.StatusExecuting, status.StatusMaintenance),
+		}, {{
+			Removed: true,
+			Entity:  &multiwatcher.UnitInfo{Name: "mysql/1", Service: "mysql"},
+		}}},
+	}, {
+		about: "fail fast on errors",
+		args:  []string{"--fail-fast"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+			unitDelta("mysql/1", status.StatusError, status.StatusError),
+		}},
+		errMatch: "units in error state: mysql/1",
+	}, {
+		about: "time out waiting for units",
+		args:  []string{"--timeout", "50ms"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+			unitDelta("mysql/0", status.StatusIdle, status.StatusActive),
+			unitDelta("mysql/1", status.StatusExecuting, status.StatusMaintenance),
+		}},
+		errMatch: `timed out after 50ms waiting for mysql/1 \(executing, maintenance\)`,
+	}, {
+		about: "time out waiting for a service",
+		args:  []string{"wordpress", "--timeout", "50ms"},
+		deltas: [][]multiwatcher.Delta{{
+			serviceDelta("mysql"),
+		}},
+		errMatch: "timed out after 50ms waiting for service wordpress",
+	}, {
+		about:    "watcher errors are returned",
+		nextErr:  errors.New("boom"),
+		errMatch: "watching model: boom",
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		watcher := newFakeWaitWatcher(test.deltas, test.nextErr)
+		s.PatchValue(&getWaitWatcher, func(*waitCommand) (waitWatcher, error) {
+			return watcher, nil
+		})
+		_, err := testing.RunCommand(c, newWaitCommand(), test.args...)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+		c.Check(watcher.stopped, jc.IsTrue)
+	}
+}
+
+func serviceDelta(name string) multiwatcher.Delta {
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.ServiceInfo{Name: name},
+	}
+}
+
+func unitDelta(name string, agent, workload status.Status) multiwatcher.Delta {
+	service := name[:len(name)-2]
+	return multiwatcher.Delta{
+		Entity: &multiwatcher.UnitInfo{
+			Name:           name,
+			Service:        service,
+			JujuStatus:     multiwatcher.StatusInfo{Current: agent},
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		},
+	}
+}
+
+// fakeWaitWatcher returns each batch of deltas in turn, then the given
+// error if any; otherwise it blocks until it is stopped.
+type fakeWaitWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	err     error
+	stop    chan struct{}
+	stopped bool
+}
+
+func newFakeWaitWatcher(deltas [][]multiwatcher.Delta, err error) *fakeWaitWatcher {
+	return &fakeWaitWatcher{
+		deltas: deltas,
+		err:    err,
+		stop:   make(chan struct{}),
+	}
+}
+
+func (w *fakeWaitWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) > 0 {
+		next := w.deltas[0]
+		w.deltas = w.deltas[1:]
+		return next, nil
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	<-w.stop
+	return nil, errors.New("watcher stopped")
+}
+
+func (w *fakeWaitWatcher) Stop() error {
+	if !w.stopped {
+		w.stopped = true
+		close(w.stop)
+	}
+	return nil
+}
@@ -129,6 +129,51 @@ func NewListBlocksCommandForTest(api listBlocksAPI, apierr error, store jujuclie
 	return modelcmd.WrapController(c)
 }
 
+// NewShowAuditLogCommandForTest returns a showAuditLogCommand with the
+// controller endpoint mocked out.
+func NewShowAuditLogCommandForTest(api showAuditLogAPI, apierr error, store jujuclient.ClientStore) cmd.Command {
+	c := &showAuditLogCommand{
+		api:    api,
+		apierr: apierr,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewExportModelCommandForTest returns an exportModelCommand with the
+// controller endpoint mocked out.
+func NewExportModelCommandForTest(api exportModelAPI, apierr error, store jujuclient.ClientStore) cmd.Command {
+	c := &exportModelCommand{
+		api:    api,
+		apierr: apierr,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewImportModelCommandForTest returns an importModelCommand with the
+// controller endpoint mocked out.
+func NewImportModelCommandForTest(api importModelAPI, apierr error, store jujuclient.ClientStore) cmd.Command {
+	c := &importModelCommand{
+		api:    api,
+		apierr: apierr,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewShowMigrationCommandForTest returns a showMigrationCommand with
+// the controller endpoint and clock mocked out.
+func NewShowMigrationCommandForTest(api showMigrationAPI, apierr error, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &showMigrationCommand{
+		api:    api,
+		apierr: apierr,
+		clock:  clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
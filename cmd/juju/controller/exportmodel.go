@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportModelCommand returns a command to download the description
+// of a model.
+func NewExportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&exportModelCommand{})
+}
+
+// exportModelCommand downloads the serialized description of a model
+// hosted by a controller.
+type exportModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api    exportModelAPI
+	apierr error
+
+	model    string
+	filename string
+}
+
+var exportModelDoc = `
+Downloads a description of a model in YAML format. The description
+includes the model's configuration, machines, services, units,
+relations, storage and networking, and may be reviewed, compared with
+other descriptions, or used to recreate the model with "juju
+import-model" on this or any other controller.
+
+The model may be given by name, owner-qualified name (owner/name) or
+UUID. The description is written to standard output unless a file is
+specified.
+
+Resource content and charm archives are not part of the description.
+
+Examples:
+
+    juju export-model mymodel
+    juju export-model -o mymodel.yaml bob/mymodel
+
+See also: import-model
+          list-models
+`
+
+// exportModelAPI defines the methods on the controller API endpoint
+// that the export-model command calls.
+type exportModelAPI interface {
+	Close() error
+	AllModels() ([]base.UserModel, error)
+	ExportModel(modelUUID string) ([]byte, error)
+}
+
+// Info implements Command.Info.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<model name>",
+		Purpose: "Downloads the description of a model.",
+		Doc:     exportModelDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *exportModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.filename, "o", "", "Write the description to this file")
+	f.StringVar(&c.filename, "output", "", "")
+}
+
+// Init implements Command.Init.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model specified")
+	}
+	c.model, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *exportModelCommand) getAPI() (exportModelAPI, error) {
+	if c.api != nil {
+		return c.api, c.apierr
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	models, err := api.AllModels()
+	if err != nil {
+		return errors.Annotate(err, "cannot list models")
+	}
+	uuid, err := resolveModelUUID(models, c.model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	bytes, err := api.ExportModel(uuid)
+	if err != nil {
+		return errors.Annotate(err, "cannot export model")
+	}
+	if c.filename == "" {
+		_, err := ctx.Stdout.Write(bytes)
+		return errors.Trace(err)
+	}
+	filename := ctx.AbsPath(c.filename)
+	if err := ioutil.WriteFile(filename, bytes, 0600); err != nil {
+		return errors.Annotate(err, "cannot write model description")
+	}
+	ctx.Infof("exported model %q to %s", c.model, c.filename)
+	return nil
+}
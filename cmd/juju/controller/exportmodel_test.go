@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+const exportModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type ExportModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeExportModelAPI
+	apierror error
+	store    *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ExportModelSuite{})
+
+type fakeExportModelAPI struct {
+	err       error
+	models    []base.UserModel
+	modelUUID string
+}
+
+func (f *fakeExportModelAPI) Close() error { return nil }
+
+func (f *fakeExportModelAPI) AllModels() ([]base.UserModel, error) {
+	return f.models, nil
+}
+
+func (f *fakeExportModelAPI) ExportModel(modelUUID string) ([]byte, error) {
+	f.modelUUID = modelUUID
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte("version: 1\n"), nil
+}
+
+func (s *ExportModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apierror = nil
+	s.api = &fakeExportModelAPI{
+		models: []base.UserModel{{
+			Name:  "mymodel",
+			UUID:  exportModelUUID,
+			Owner: "admin@local",
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["dummysys"] = jujuclient.ControllerDetails{}
+}
+
+func (s *ExportModelSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewExportModelCommandForTest(s.api, s.apierror, s.store)
+	args = append(args, "-c", "dummysys")
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ExportModelSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Check(err, gc.ErrorMatches, "no model specified")
+	_, err = s.run(c, "mymodel", "extra")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ExportModelSuite) TestCannotConnectToAPI(c *gc.C) {
+	s.apierror = errors.New("connection refused")
+	_, err := s.run(c, "mymodel")
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: connection refused")
+}
+
+func (s *ExportModelSuite) TestUnknownModel(c *gc.C) {
+	_, err := s.run(c, "bob/mymodel")
+	c.Assert(err, gc.ErrorMatches, `model "bob/mymodel" not found`)
+}
+
+func (s *ExportModelSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c, "mymodel")
+	c.Assert(err, gc.ErrorMatches, "cannot export model: boom")
+}
+
+func (s *ExportModelSuite) TestStdout(c *gc.C) {
+	ctx, err := s.run(c, "admin/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.modelUUID, gc.Equals, exportModelUUID)
+	c.Check(testing.Stdout(ctx), gc.Equals, "version: 1\n")
+}
+
+func (s *ExportModelSuite) TestFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "model.yaml")
+	ctx, err := s.run(c, "-o", filename, exportModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.modelUUID, gc.Equals, exportModelUUID)
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+
+	content, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "version: 1\n")
+}
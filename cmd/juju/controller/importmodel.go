@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewImportModelCommand returns a command to create a model from a
+// model description.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+// importModelCommand creates a model in a controller from a description
+// produced by export-model.
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api    importModelAPI
+	apierr error
+
+	filename string
+	name     string
+	newUUID  bool
+}
+
+var importModelDoc = `
+Creates a new model in the controller from a description downloaded with
+"juju export-model". The model keeps the name and UUID it was exported
+with unless told otherwise, so a copy of a model can only be imported
+alongside the original by giving it both a new name and a new UUID.
+
+Machines in the imported model refer to the same cloud instances as in
+the exported model. Resource content is not part of the description and
+must be uploaded again for any services that use resources.
+
+The agents running on those instances are not told about the import:
+they keep reporting to the model with the exported UUID. For that reason
+--new-uuid is refused for models with provisioned machines; it is only
+useful for copying models which have no machines yet.
+
+Examples:
+
+    juju import-model mymodel.yaml
+    juju import-model --name mymodel-copy --new-uuid mymodel.yaml
+
+See also: export-model
+          list-models
+`
+
+// importModelAPI defines the methods on the controller API endpoint
+// that the import-model command calls.
+type importModelAPI interface {
+	Close() error
+	ImportModel(bytes []byte, name string, newUUID bool) (params.Model, error)
+}
+
+// Info implements Command.Info.
+func (c *importModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<filename>",
+		Purpose: "Creates a model from a model description.",
+		Doc:     importModelDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.name, "name", "", "Give the imported model this name")
+	f.BoolVar(&c.newUUID, "new-uuid", false, "Give the imported model a new UUID")
+}
+
+// Init implements Command.Init.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model description file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *importModelCommand) getAPI() (importModelAPI, error) {
+	if c.api != nil {
+		return c.api, c.apierr
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	bytes, err := ioutil.ReadFile(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "cannot read model description")
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	model, err := api.ImportModel(bytes, c.name, c.newUUID)
+	if err != nil {
+		return errors.Annotate(err, "cannot import model")
+	}
+
+	// Make the model known to the client if it belongs to the current
+	// user, as add-model does.
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	accountName, err := store.CurrentAccount(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	account, err := store.AccountByName(controllerName, accountName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if owner.Canonical() == account.User {
+		details := jujuclient.ModelDetails{ModelUUID: model.UUID}
+		if err := store.UpdateModel(controllerName, accountName, model.Name, details); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	ctx.Infof("imported model %q (%s)", model.Name, model.UUID)
+	return nil
+}
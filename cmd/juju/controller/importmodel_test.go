@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ImportModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeImportModelAPI
+	apierror error
+	store    *jujuclienttesting.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&ImportModelSuite{})
+
+type fakeImportModelAPI struct {
+	err     error
+	owner   string
+	bytes   []byte
+	name    string
+	newUUID bool
+}
+
+func (f *fakeImportModelAPI) Close() error { return nil }
+
+func (f *fakeImportModelAPI) ImportModel(bytes []byte, name string, newUUID bool) (params.Model, error) {
+	f.bytes, f.name, f.newUUID = bytes, name, newUUID
+	if f.err != nil {
+		return params.Model{}, f.err
+	}
+	if name == "" {
+		name = "mymodel"
+	}
+	return params.Model{
+		Name:     name,
+		UUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		OwnerTag: f.owner,
+	}, nil
+}
+
+func (s *ImportModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apierror = nil
+	s.api = &fakeImportModelAPI{owner: "user-bob@local"}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["dummysys"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["dummysys"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"bob@local": {User: "bob@local"},
+		},
+		CurrentAccount: "bob@local",
+	}
+	s.filename = filepath.Join(c.MkDir(), "model.yaml")
+	err := ioutil.WriteFile(s.filename, []byte("version: 1\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportModelSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewImportModelCommandForTest(s.api, s.apierror, s.store)
+	args = append(args, "-c", "dummysys")
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ImportModelSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Check(err, gc.ErrorMatches, "no model description file specified")
+	_, err = s.run(c, s.filename, "extra")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ImportModelSuite) TestMissingFile(c *gc.C) {
+	_, err := s.run(c, filepath.Join(c.MkDir(), "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, "cannot read model description: .*")
+}
+
+func (s *ImportModelSuite) TestCannotConnectToAPI(c *gc.C) {
+	s.apierror = errors.New("connection refused")
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: connection refused")
+}
+
+func (s *ImportModelSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "cannot import model: boom")
+}
+
+func (s *ImportModelSuite) TestImport(c *gc.C) {
+	ctx, err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(s.api.bytes), gc.Equals, "version: 1\n")
+	c.Check(s.api.name, gc.Equals, "")
+	c.Check(s.api.newUUID, jc.IsFalse)
+	c.Check(testing.Stderr(ctx), gc.Equals, "imported model \"mymodel\" (deadbeef-0bad-400d-8000-4b1d0d06f00d)\n")
+
+	details, err := s.store.ModelByName("dummysys", "bob@local", "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(details.ModelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *ImportModelSuite) TestImportCopy(c *gc.C) {
+	_, err := s.run(c, "--name", "copy", "--new-uuid", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.name, gc.Equals, "copy")
+	c.Check(s.api.newUUID, jc.IsTrue)
+
+	_, err = s.store.ModelByName("dummysys", "bob@local", "copy")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportModelSuite) TestImportOtherOwner(c *gc.C) {
+	s.api.owner = "user-mary@local"
+	_, err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.store.ModelByName("dummysys", "bob@local", "mymodel")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
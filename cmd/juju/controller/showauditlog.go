@@ -0,0 +1,280 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewShowAuditLogCommand returns a command to show the audit log of a
+// controller.
+func NewShowAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&showAuditLogCommand{})
+}
+
+// showAuditLogCommand shows the API calls which have changed, or
+// attempted to change, the models hosted by a controller.
+type showAuditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out    cmd.Output
+	api    showAuditLogAPI
+	apierr error
+
+	user   string
+	model  string
+	facade string
+	from   string
+	to     string
+	limit  int
+}
+
+var showAuditLogDoc = `
+Shows the calls made by users which changed, or attempted to change, the
+models within a controller. Calls which only read information, such as
+"juju status", are not recorded.
+
+The results may be filtered by user, by model (name or UUID), by the API
+facade that was called and by a time range. Times are given either as a
+date (YYYY-MM-DD) or in RFC3339 format (YYYY-MM-DDTHH:MM:SSZ). The most
+recent entries are shown, up to the given limit.
+
+Examples:
+
+    juju show-audit-log
+    juju show-audit-log --user bob --model mymodel
+    juju show-audit-log --facade Service --from 2016-06-01 --to 2016-06-08
+    juju show-audit-log --limit 0 --format json
+
+See also: list-models
+          list-users
+`
+
+// showAuditLogAPI defines the methods on the controller API endpoint
+// that the show-audit-log command calls.
+type showAuditLogAPI interface {
+	Close() error
+	AllModels() ([]base.UserModel, error)
+	AuditLog(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *showAuditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-audit-log",
+		Purpose: "Shows the changes made to models within a controller.",
+		Doc:     showAuditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showAuditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "Only show calls made by this user")
+	f.StringVar(&c.model, "model", "", "Only show calls made against this model")
+	f.StringVar(&c.facade, "facade", "", "Only show calls made to this API facade")
+	f.StringVar(&c.from, "from", "", "Only show calls made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show calls made before this time")
+	f.IntVar(&c.limit, "limit", 100, "The maximum number of entries to show (0 means no limit)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showAuditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit")
+	}
+	if _, err := parseAuditTime(c.from); err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	if _, err := parseAuditTime(c.to); err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showAuditLogCommand) getAPI() (showAuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, c.apierr
+	}
+	return c.NewControllerAPIClient()
+}
+
+// AuditLogEntry holds a single audit log entry for display.
+type AuditLogEntry struct {
+	Time    string `yaml:"time" json:"time"`
+	User    string `yaml:"user" json:"user"`
+	Model   string `yaml:"model" json:"model"`
+	Facade  string `yaml:"facade" json:"facade"`
+	Version int    `yaml:"version" json:"version"`
+	Method  string `yaml:"method" json:"method"`
+	Args    string `yaml:"args,omitempty" json:"args,omitempty"`
+	Error   string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *showAuditLogCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	models, err := api.AllModels()
+	if err != nil {
+		return errors.Annotate(err, "cannot list models")
+	}
+
+	filter := params.AuditLogFilter{
+		Facade: c.facade,
+		Limit:  c.limit,
+	}
+	if c.user != "" {
+		filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	if c.model != "" {
+		uuid, err := resolveModelUUID(models, c.model)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.ModelTag = names.NewModelTag(uuid).String()
+	}
+	if from, _ := parseAuditTime(c.from); !from.IsZero() {
+		filter.From = &from
+	}
+	if to, _ := parseAuditTime(c.to); !to.IsZero() {
+		filter.To = &to
+	}
+
+	entries, err := api.AuditLog(filter)
+	if err != nil {
+		return errors.Annotate(err, "cannot read audit log")
+	}
+
+	modelNames := make(map[string]string)
+	for _, model := range models {
+		modelNames[model.UUID] = fmt.Sprintf("%s/%s", model.Owner, model.Name)
+	}
+	result := make([]AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = AuditLogEntry{
+			Time:    entry.Timestamp.UTC().Format(time.RFC3339),
+			User:    entry.UserTag,
+			Model:   entry.ModelTag,
+			Facade:  entry.Facade,
+			Version: entry.Version,
+			Method:  entry.Method,
+			Args:    entry.Args,
+			Error:   entry.Error,
+		}
+		if user, err := names.ParseUserTag(entry.UserTag); err == nil {
+			result[i].User = user.Canonical()
+		}
+		if model, err := names.ParseModelTag(entry.ModelTag); err == nil {
+			result[i].Model = model.Id()
+			if name, ok := modelNames[model.Id()]; ok {
+				result[i].Model = name
+			}
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// resolveModelUUID returns the UUID of the model identified by the
+// given UUID, name, or owner-qualified name.
+func resolveModelUUID(models []base.UserModel, model string) (string, error) {
+	if names.IsValidModel(model) {
+		return model, nil
+	}
+	owner, name := "", model
+	if i := strings.Index(model, "/"); i >= 0 {
+		if !names.IsValidUser(model[:i]) {
+			return "", errors.NotValidf("model owner %q", model[:i])
+		}
+		owner = names.NewUserTag(model[:i]).Canonical()
+		name = model[i+1:]
+	}
+	var uuids []string
+	for _, m := range models {
+		if m.Name == name && (owner == "" || m.Owner == owner) {
+			uuids = append(uuids, m.UUID)
+		}
+	}
+	switch len(uuids) {
+	case 0:
+		return "", errors.NotFoundf("model %q", model)
+	case 1:
+		return uuids[0], nil
+	}
+	return "", errors.Errorf("model name %q is ambiguous, please specify the owner or UUID", model)
+}
+
+// parseAuditTime parses a time given either as a date or in RFC3339
+// format. An empty string results in the zero time.
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is neither a date (YYYY-MM-DD) nor an RFC3339 time", value)
+	}
+	return t.UTC(), nil
+}
+
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]AuditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tMODEL\tCALL\tOUTCOME\n")
+	for _, entry := range entries {
+		outcome := "ok"
+		if entry.Error != "" {
+			outcome = entry.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s.%s\t%s\n",
+			entry.Time,
+			entry.User,
+			entry.Model,
+			entry.Facade,
+			entry.Method,
+			outcome,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
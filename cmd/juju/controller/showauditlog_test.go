@@ -0,0 +1,189 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+const auditModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type ShowAuditLogSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeShowAuditLogAPI
+	apierror error
+	store    *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ShowAuditLogSuite{})
+
+type fakeShowAuditLogAPI struct {
+	err     error
+	models  []base.UserModel
+	entries []params.AuditLogEntry
+	filter  params.AuditLogFilter
+}
+
+func (f *fakeShowAuditLogAPI) Close() error { return nil }
+
+func (f *fakeShowAuditLogAPI) AllModels() ([]base.UserModel, error) {
+	return f.models, nil
+}
+
+func (f *fakeShowAuditLogAPI) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.filter = filter
+	return f.entries, f.err
+}
+
+func (s *ShowAuditLogSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apierror = nil
+	s.api = &fakeShowAuditLogAPI{
+		models: []base.UserModel{{
+			Name:  "mymodel",
+			UUID:  auditModelUUID,
+			Owner: "admin@local",
+		}},
+		entries: []params.AuditLogEntry{{
+			Timestamp: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC),
+			UserTag:   "user-bob",
+			ModelTag:  "model-" + auditModelUUID,
+			Facade:    "Service",
+			Version:   3,
+			Method:    "Expose",
+			Args:      `{"servicename":"mysql"}`,
+		}, {
+			Timestamp: time.Date(2016, 6, 1, 12, 5, 0, 0, time.UTC),
+			UserTag:   "user-mary",
+			ModelTag:  "model-" + auditModelUUID,
+			Facade:    "Client",
+			Version:   1,
+			Method:    "DestroyMachines",
+			Error:     "permission denied",
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["dummysys"] = jujuclient.ControllerDetails{}
+}
+
+func (s *ShowAuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewShowAuditLogCommandForTest(s.api, s.apierror, s.store)
+	args = append(args, "-c", "dummysys")
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ShowAuditLogSuite) TestCannotConnectToAPI(c *gc.C) {
+	s.apierror = errors.New("connection refused")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: connection refused")
+}
+
+func (s *ShowAuditLogSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "cannot read audit log: boom")
+}
+
+func (s *ShowAuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  USER        MODEL                CALL                    OUTCOME\n"+
+		"2016-06-01T12:00:00Z  bob@local   admin@local/mymodel  Service.Expose          ok\n"+
+		"2016-06-01T12:05:00Z  mary@local  admin@local/mymodel  Client.DestroyMachines  permission denied\n"+
+		"\n")
+}
+
+func (s *ShowAuditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml", "--limit", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"- time: 2016-06-01T12:00:00Z\n"+
+		"  user: bob@local\n"+
+		"  model: admin@local/mymodel\n"+
+		"  facade: Service\n"+
+		"  version: 3\n"+
+		"  method: Expose\n"+
+		"  args: '{\"servicename\":\"mysql\"}'\n"+
+		"- time: 2016-06-01T12:05:00Z\n"+
+		"  user: mary@local\n"+
+		"  model: admin@local/mymodel\n"+
+		"  facade: Client\n"+
+		"  version: 1\n"+
+		"  method: DestroyMachines\n"+
+		"  error: permission denied\n")
+	c.Check(s.api.filter, jc.DeepEquals, params.AuditLogFilter{Limit: 1})
+}
+
+func (s *ShowAuditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "mymodel",
+		"--facade", "Service",
+		"--from", "2016-06-01",
+		"--to", "2016-06-02T10:00:00+02:00",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2016, 6, 2, 8, 0, 0, 0, time.UTC)
+	c.Check(s.api.filter, jc.DeepEquals, params.AuditLogFilter{
+		UserTag:  "user-bob",
+		ModelTag: "model-" + auditModelUUID,
+		Facade:   "Service",
+		From:     &from,
+		To:       &to,
+		Limit:    100,
+	})
+}
+
+func (s *ShowAuditLogSuite) TestModelByUUIDAndOwner(c *gc.C) {
+	for _, model := range []string{auditModelUUID, "admin/mymodel"} {
+		_, err := s.run(c, "--model", model)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.api.filter.ModelTag, gc.Equals, "model-"+auditModelUUID)
+	}
+}
+
+func (s *ShowAuditLogSuite) TestUnknownModel(c *gc.C) {
+	_, err := s.run(c, "--model", "bob/mymodel")
+	c.Assert(err, gc.ErrorMatches, `model "bob/mymodel" not found`)
+}
+
+func (s *ShowAuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--user", "not/valid"},
+		err:  `user name "not/valid" not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "negative limit not valid",
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: "yesterday" is neither a date \(YYYY-MM-DD\) nor an RFC3339 time`,
+	}, {
+		args: []string{"--to", "soon"},
+		err:  `invalid --to: "soon" is neither a date \(YYYY-MM-DD\) nor an RFC3339 time`,
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// showMigrationPollInterval is how often show-migration checks on a
+// migration when watching it.
+const showMigrationPollInterval = 5 * time.Second
+
+// NewShowMigrationCommand returns a command to show the progress of a
+// model migration.
+func NewShowMigrationCommand() cmd.Command {
+	return modelcmd.WrapController(&showMigrationCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// showMigrationCommand shows the status and progress of the most
+// recent migration of a model.
+type showMigrationCommand struct {
+	modelcmd.ControllerCommandBase
+	out    cmd.Output
+	api    showMigrationAPI
+	apierr error
+	clock  clock.Clock
+
+	model string
+	watch bool
+}
+
+var showMigrationDoc = `
+Shows the status of the most recent migration of a model: the phase it
+has reached, how long it has been in that phase, how much of the model
+has been exported, imported and transferred, and which of the model's
+agents have and have not yet reported back for the current phase.
+
+With --watch the migration is checked every 5 seconds and its status
+shown again whenever it changes, until the migration completes or is
+aborted. A migration whose progress and agent reports stop changing
+for a long time is likely to be stuck rather than slow.
+
+The model may be given by name, owner-qualified name (owner/name) or
+UUID.
+
+Examples:
+
+    juju show-migration mymodel
+    juju show-migration --watch bob/mymodel
+    juju show-migration --format yaml mymodel
+
+See also: migrate
+          list-models
+`
+
+// showMigrationAPI defines the methods on the controller API endpoint
+// that the show-migration command calls.
+type showMigrationAPI interface {
+	Close() error
+	AllModels() ([]base.UserModel, error)
+	ModelMigrationStatus(modelUUID string) (params.ModelMigrationStatus, error)
+}
+
+// Info implements Command.Info.
+func (c *showMigrationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-migration",
+		Args:    "<model name>",
+		Purpose: "Shows the progress of a model migration.",
+		Doc:     showMigrationDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showMigrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.watch, "watch", false, "Keep showing the migration's status until it completes")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showMigrationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model specified")
+	}
+	c.model, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showMigrationCommand) getAPI() (showMigrationAPI, error) {
+	if c.api != nil {
+		return c.api, c.apierr
+	}
+	return c.NewControllerAPIClient()
+}
+
+// MigrationStatus holds the status of a model migration for display.
+type MigrationStatus struct {
+	Id            string            `yaml:"id" json:"id"`
+	Attempt       int               `yaml:"attempt" json:"attempt"`
+	Phase         string            `yaml:"phase" json:"phase"`
+	Message       string            `yaml:"message,omitempty" json:"message,omitempty"`
+	Started       string            `yaml:"started" json:"started"`
+	PhaseChanged  string            `yaml:"phase-changed" json:"phase-changed"`
+	Ended         string            `yaml:"ended,omitempty" json:"ended,omitempty"`
+	Progress      MigrationProgress `yaml:"progress" json:"progress"`
+	AgentsPhase   string            `yaml:"agents-phase" json:"agents-phase"`
+	AgentsReady   []string          `yaml:"agents-ready,omitempty" json:"agents-ready,omitempty"`
+	AgentsFailed  []string          `yaml:"agents-failed,omitempty" json:"agents-failed,omitempty"`
+	AgentsWaiting []string          `yaml:"agents-waiting,omitempty" json:"agents-waiting,omitempty"`
+}
+
+// MigrationProgress holds the amount of a model which has been
+// migrated, for display.
+type MigrationProgress struct {
+	EntitiesExported int   `yaml:"entities-exported" json:"entities-exported"`
+	EntitiesImported int   `yaml:"entities-imported" json:"entities-imported"`
+	BinaryBytes      int64 `yaml:"binary-bytes" json:"binary-bytes"`
+}
+
+// Run implements Command.Run.
+func (c *showMigrationCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	models, err := api.AllModels()
+	if err != nil {
+		return errors.Annotate(err, "cannot list models")
+	}
+	uuid, err := resolveModelUUID(models, c.model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var last *MigrationStatus
+	for {
+		status, err := api.ModelMigrationStatus(uuid)
+		if params.IsCodeNotFound(err) {
+			return errors.Errorf("model %q has not been migrated", c.model)
+		} else if err != nil {
+			return errors.Annotate(err, "cannot get migration status")
+		}
+		current := migrationStatusFromParams(status)
+		if last == nil || !reflect.DeepEqual(*last, current) {
+			if last != nil {
+				fmt.Fprintln(ctx.Stdout)
+			}
+			if err := c.out.Write(ctx, current); err != nil {
+				return errors.Trace(err)
+			}
+			last = &current
+		}
+		if !c.watch || isFinished(current.Phase) {
+			return nil
+		}
+		<-c.clock.After(showMigrationPollInterval)
+	}
+}
+
+func migrationStatusFromParams(status params.ModelMigrationStatus) MigrationStatus {
+	result := MigrationStatus{
+		Id:           status.Id,
+		Attempt:      status.Attempt,
+		Phase:        status.Phase,
+		Message:      status.StatusMessage,
+		Started:      status.StartTime.UTC().Format(time.RFC3339),
+		PhaseChanged: status.PhaseChangedTime.UTC().Format(time.RFC3339),
+		Progress: MigrationProgress{
+			EntitiesExported: status.Progress.EntitiesExported,
+			EntitiesImported: status.Progress.EntitiesImported,
+			BinaryBytes:      status.Progress.BinaryBytes,
+		},
+		AgentsPhase:   status.MinionReports.Phase,
+		AgentsReady:   status.MinionReports.Succeeded,
+		AgentsFailed:  status.MinionReports.Failed,
+		AgentsWaiting: status.MinionReports.Unknown,
+	}
+	if status.EndTime != nil {
+		result.Ended = status.EndTime.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// isFinished returns true if a migration in the named phase has
+// completed or been aborted.
+func isFinished(phaseName string) bool {
+	phase, ok := coremigration.ParsePhase(phaseName)
+	return !ok || phase.IsTerminal()
+}
+
+func (c *showMigrationCommand) formatTabular(value interface{}) ([]byte, error) {
+	status, ok := value.(MigrationStatus)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", status, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "Migration:\t%s (attempt %d)\n", status.Id, status.Attempt)
+	fmt.Fprintf(tw, "Started:\t%s\n", status.Started)
+	if status.Ended != "" {
+		fmt.Fprintf(tw, "Phase:\t%s\n", status.Phase)
+		fmt.Fprintf(tw, "Ended:\t%s\n", status.Ended)
+	} else if changed, err := time.Parse(time.RFC3339, status.PhaseChanged); err == nil {
+		elapsed := c.clock.Now().Sub(changed) / time.Second * time.Second
+		fmt.Fprintf(tw, "Phase:\t%s (for %s)\n", status.Phase, elapsed)
+	} else {
+		fmt.Fprintf(tw, "Phase:\t%s\n", status.Phase)
+	}
+	if status.Message != "" {
+		fmt.Fprintf(tw, "Message:\t%s\n", status.Message)
+	}
+	progress := status.Progress
+	fmt.Fprintf(tw, "Entities:\t%d exported, %d imported\n", progress.EntitiesExported, progress.EntitiesImported)
+	fmt.Fprintf(tw, "Transferred:\t%d bytes of resources\n", progress.BinaryBytes)
+	total := len(status.AgentsReady) + len(status.AgentsFailed) + len(status.AgentsWaiting)
+	fmt.Fprintf(tw, "Agents:\t%d of %d ready for %s\n", len(status.AgentsReady), total, status.AgentsPhase)
+	if len(status.AgentsFailed) > 0 {
+		fmt.Fprintf(tw, "Failed:\t%s\n", strings.Join(status.AgentsFailed, ", "))
+	}
+	if len(status.AgentsWaiting) > 0 {
+		fmt.Fprintf(tw, "Waiting on:\t%s\n", strings.Join(status.AgentsWaiting, ", "))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
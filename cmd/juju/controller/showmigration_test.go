@@ -0,0 +1,230 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+const showMigrationUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type ShowMigrationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeShowMigrationAPI
+	apierror error
+	store    *jujuclienttesting.MemStore
+	clock    *fakeShowMigrationClock
+}
+
+var _ = gc.Suite(&ShowMigrationSuite{})
+
+type fakeShowMigrationAPI struct {
+	err      error
+	models   []base.UserModel
+	statuses []params.ModelMigrationStatus
+	calls    int
+}
+
+func (f *fakeShowMigrationAPI) Close() error { return nil }
+
+func (f *fakeShowMigrationAPI) AllModels() ([]base.UserModel, error) {
+	return f.models, nil
+}
+
+func (f *fakeShowMigrationAPI) ModelMigrationStatus(modelUUID string) (params.ModelMigrationStatus, error) {
+	if f.err != nil {
+		return params.ModelMigrationStatus{}, f.err
+	}
+	if modelUUID != showMigrationUUID {
+		return params.ModelMigrationStatus{}, errors.Errorf("unexpected model %q", modelUUID)
+	}
+	status := f.statuses[f.calls]
+	if f.calls < len(f.statuses)-1 {
+		f.calls++
+	}
+	return status, nil
+}
+
+// fakeShowMigrationClock reports a fixed time, and never makes the
+// command wait.
+type fakeShowMigrationClock struct {
+	clock.Clock
+	now   time.Time
+	waits []time.Duration
+}
+
+func (f *fakeShowMigrationClock) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeShowMigrationClock) After(d time.Duration) <-chan time.Time {
+	f.waits = append(f.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- f.now
+	return ch
+}
+
+var migrationStartTime = time.Date(2016, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func makeMigrationStatus(phase string) params.ModelMigrationStatus {
+	return params.ModelMigrationStatus{
+		Id:               showMigrationUUID + ":0",
+		Phase:            phase,
+		StartTime:        migrationStartTime,
+		PhaseChangedTime: migrationStartTime.Add(time.Minute),
+		Progress: params.MigrationProgress{
+			EntitiesExported: 12,
+			EntitiesImported: 10,
+			BinaryBytes:      2048,
+		},
+		MinionReports: params.MinionReports{
+			Phase:     phase,
+			Succeeded: []string{"machine-0"},
+			Unknown:   []string{"machine-1", "unit-mysql-0"},
+		},
+	}
+}
+
+func (s *ShowMigrationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apierror = nil
+	s.api = &fakeShowMigrationAPI{
+		models: []base.UserModel{{
+			Name:  "mymodel",
+			UUID:  showMigrationUUID,
+			Owner: "admin@local",
+		}},
+		statuses: []params.ModelMigrationStatus{makeMigrationStatus("IMPORT")},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["dummysys"] = jujuclient.ControllerDetails{}
+	s.clock = &fakeShowMigrationClock{
+		now: migrationStartTime.Add(4*time.Minute + 500*time.Millisecond),
+	}
+}
+
+func (s *ShowMigrationSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewShowMigrationCommandForTest(s.api, s.apierror, s.store, s.clock)
+	args = append(args, "-c", "dummysys")
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ShowMigrationSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c)
+	c.Check(err, gc.ErrorMatches, "no model specified")
+	_, err = s.run(c, "mymodel", "extra")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ShowMigrationSuite) TestCannotConnectToAPI(c *gc.C) {
+	s.apierror = errors.New("connection refused")
+	_, err := s.run(c, "mymodel")
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: connection refused")
+}
+
+func (s *ShowMigrationSuite) TestNeverMigrated(c *gc.C) {
+	s.api.err = &params.Error{Code: params.CodeNotFound, Message: "migration not found"}
+	_, err := s.run(c, "mymodel")
+	c.Assert(err, gc.ErrorMatches, `model "mymodel" has not been migrated`)
+}
+
+func (s *ShowMigrationSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c, "mymodel")
+	c.Assert(err, gc.ErrorMatches, "cannot get migration status: boom")
+}
+
+func (s *ShowMigrationSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "admin/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"Migration:    "+showMigrationUUID+":0 (attempt 0)\n"+
+		"Started:      2016-06-01T10:00:00Z\n"+
+		"Phase:        IMPORT (for 3m0s)\n"+
+		"Entities:     12 exported, 10 imported\n"+
+		"Transferred:  2048 bytes of resources\n"+
+		"Agents:       1 of 3 ready for IMPORT\n"+
+		"Waiting on:   machine-1, unit-mysql-0\n",
+	)
+	c.Check(s.clock.waits, gc.HasLen, 0)
+}
+
+func (s *ShowMigrationSuite) TestTabularEnded(c *gc.C) {
+	status := makeMigrationStatus("ABORTDONE")
+	status.StatusMessage = "aborted: target unreachable"
+	ended := migrationStartTime.Add(2 * time.Minute)
+	status.EndTime = &ended
+	status.MinionReports.Failed = []string{"machine-1"}
+	status.MinionReports.Unknown = nil
+	s.api.statuses = []params.ModelMigrationStatus{status}
+
+	ctx, err := s.run(c, "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"Migration:    "+showMigrationUUID+":0 (attempt 0)\n"+
+		"Started:      2016-06-01T10:00:00Z\n"+
+		"Phase:        ABORTDONE\n"+
+		"Ended:        2016-06-01T10:02:00Z\n"+
+		"Message:      aborted: target unreachable\n"+
+		"Entities:     12 exported, 10 imported\n"+
+		"Transferred:  2048 bytes of resources\n"+
+		"Agents:       1 of 2 ready for ABORTDONE\n"+
+		"Failed:       machine-1\n",
+	)
+}
+
+func (s *ShowMigrationSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml", showMigrationUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, ""+
+		"id: "+showMigrationUUID+":0\n"+
+		"attempt: 0\n"+
+		"phase: IMPORT\n"+
+		"started: 2016-06-01T10:00:00Z\n"+
+		"phase-changed: 2016-06-01T10:01:00Z\n"+
+		"progress:\n"+
+		"  entities-exported: 12\n"+
+		"  entities-imported: 10\n"+
+		"  binary-bytes: 2048\n"+
+		"agents-phase: IMPORT\n"+
+		"agents-ready:\n"+
+		"- machine-0\n"+
+		"agents-waiting:\n"+
+		"- machine-1\n"+
+		"- unit-mysql-0\n",
+	)
+}
+
+func (s *ShowMigrationSuite) TestWatch(c *gc.C) {
+	importing := makeMigrationStatus("IMPORT")
+	imported := makeMigrationStatus("IMPORT")
+	imported.Progress.EntitiesImported = 12
+	done := makeMigrationStatus("DONE")
+	s.api.statuses = []params.ModelMigrationStatus{
+		importing, importing, imported, done,
+	}
+
+	ctx, err := s.run(c, "--watch", "--format", "json", "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.clock.waits, jc.DeepEquals, []time.Duration{
+		5 * time.Second, 5 * time.Second, 5 * time.Second,
+	})
+	// The unchanged status is only shown once.
+	c.Check(testing.Stdout(ctx), gc.Matches, `(?s)\{"id".*"phase":"IMPORT".*"entities-imported":10.*\}\n\n`+
+		`\{"id".*"phase":"IMPORT".*"entities-imported":12.*\}\n\n`+
+		`\{"id".*"phase":"DONE".*\}\n`)
+}
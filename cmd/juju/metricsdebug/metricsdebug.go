@@ -23,14 +23,20 @@ import (
 const debugMetricsDoc = `
 debug-metrics
 display recently collected metrics and exit
+
+With --failures, display instead the history of failures to collect, spool
+or send metrics reported by the unit or the units of the service, oldest
+first. -n then limits the output to the most recent failures. Failures are
+kept for the duration of the model's "metrics-history" setting.
 `
 
 // DebugMetricsCommand retrieves metrics stored in the juju controller.
 type DebugMetricsCommand struct {
 	modelcmd.ModelCommandBase
-	Json  bool
-	Tag   names.Tag
-	Count int
+	Json     bool
+	Tag      names.Tag
+	Count    int
+	Failures bool
 }
 
 // New creates a new DebugMetricsCommand.
@@ -71,10 +77,12 @@ func (c *DebugMetricsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.IntVar(&c.Count, "n", 0, "number of metrics to retrieve")
 	f.BoolVar(&c.Json, "json", false, "output metrics as json")
+	f.BoolVar(&c.Failures, "failures", false, "show the history of metric collection failures")
 }
 
 type GetMetricsClient interface {
 	GetMetrics(tag string) ([]params.MetricResult, error)
+	GetMetricFailures(tag string) ([]params.MetricFailureResult, error)
 	Close() error
 }
 
@@ -92,6 +100,10 @@ func (c *DebugMetricsCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.Failures {
+		defer client.Close()
+		return c.runFailures(ctx, client)
+	}
 	metrics, err := client.GetMetrics(c.Tag.String())
 	if err != nil {
 		return errors.Trace(err)
@@ -119,3 +131,32 @@ func (c *DebugMetricsCommand) Run(ctx *cmd.Context) error {
 	tw.Flush()
 	return nil
 }
+
+// runFailures writes the history of metric failures.
+func (c *DebugMetricsCommand) runFailures(ctx *cmd.Context, client GetMetricsClient) error {
+	failures, err := client.GetMetricFailures(c.Tag.String())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	if c.Count > 0 && len(failures) > c.Count {
+		failures = failures[len(failures)-c.Count:]
+	}
+	if c.Json {
+		b, err := json.MarshalIndent(failures, "", "    ")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintf(ctx.Stdout, string(b))
+		return nil
+	}
+	tw := tabwriter.NewWriter(ctx.Stdout, 0, 1, 1, ' ', 0)
+	fmt.Fprintf(tw, "TIME\tUNIT\tKIND\tMESSAGE\n")
+	for _, f := range failures {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", f.Time.Format(time.RFC3339), f.Unit, f.Kind, f.Message)
+	}
+	tw.Flush()
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -36,6 +37,10 @@ func (m *MockGetMetricsClient) GetMetrics(tag string) ([]params.MetricResult, er
 	m.Stub.MethodCall(m, "GetMetrics", tag)
 	return nil, nil
 }
+func (m *MockGetMetricsClient) GetMetricFailures(tag string) ([]params.MetricFailureResult, error) {
+	m.Stub.MethodCall(m, "GetMetricFailures", tag)
+	return nil, nil
+}
 func (m *MockGetMetricsClient) Close() error {
 	m.Stub.MethodCall(m, "Close")
 	return nil
@@ -67,6 +72,17 @@ func (s *DebugMetricsMockSuite) TestService(c *gc.C) {
 	client.CheckCall(c, 0, "GetMetrics", "service-metered")
 }
 
+func (s *DebugMetricsMockSuite) TestFailures(c *gc.C) {
+	client := MockGetMetricsClient{testing.Stub{}}
+	s.PatchValue(metricsdebug.NewClient, func(_ modelcmd.ModelCommandBase) (metricsdebug.GetMetricsClient, error) {
+		return &client, nil
+	})
+	_, err := coretesting.RunCommand(c, metricsdebug.New(), "metered", "--failures")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCallNames(c, "GetMetricFailures", "Close")
+	client.CheckCall(c, 0, "GetMetricFailures", "service-metered")
+}
+
 func (s *DebugMetricsMockSuite) TestNotValidServiceOrUnit(c *gc.C) {
 	client := MockGetMetricsClient{testing.Stub{}}
 	s.PatchValue(metricsdebug.NewClient, func(_ modelcmd.ModelCommandBase) (metricsdebug.GetMetricsClient, error) {
@@ -194,3 +210,29 @@ func (s *DebugMetricsCommandSuite) TestUnitJsonOutput(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, expectedOutput)
 }
+
+func (s *DebugMetricsCommandSuite) TestFailures(c *gc.C) {
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	meteredService := s.Factory.MakeService(c, &factory.ServiceParams{Charm: meteredCharm})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: meteredService, SetCharmURL: true})
+	newTime := time.Now().Round(time.Second)
+	for i, kind := range []state.MetricFailureKind{state.MetricCollectFailure, state.MetricSendFailure, state.MetricSpoolFailure} {
+		_, err := s.State.AddMetricFailure(state.MetricFailureParam{
+			UUID:    utils.MustNewUUID().String(),
+			Unit:    unit.UnitTag(),
+			Kind:    kind,
+			Message: fmt.Sprintf("failure %d", i),
+			Time:    newTime.Add(time.Duration(i) * time.Minute),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	expectedOutput := bytes.Buffer{}
+	tw := tabwriter.NewWriter(&expectedOutput, 0, 1, 1, ' ', 0)
+	fmt.Fprintf(tw, "TIME\tUNIT\tKIND\tMESSAGE\n")
+	fmt.Fprintf(tw, "%v\tmetered/0\tsend\tfailure 1\n", newTime.Add(time.Minute).Format(time.RFC3339))
+	fmt.Fprintf(tw, "%v\tmetered/0\tspool\tfailure 2\n", newTime.Add(2*time.Minute).Format(time.RFC3339))
+	tw.Flush()
+	ctx, err := coretesting.RunCommand(c, metricsdebug.New(), "metered", "--failures", "-n", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, expectedOutput.String())
+}
@@ -14,12 +14,20 @@ import (
 )
 
 var usageGrantSummary = `
-Grants access to a Juju user for a model.`[1:]
+Grants access to a Juju user for a model or the controller.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
 Model access can also be granted at user-addition time with the `[1:] + "`juju add-\nuser`" + ` command.
 Users with read access are limited in what they can do with models: ` + "`juju \nlist-models`, `juju list-machines`, and `juju status`" + `.
+Users with write access may also deploy, scale and configure applications
+and run actions, but only users with admin access may destroy the model or
+grant access to it to other users.
+
+The 'add-model' and 'superuser' permissions apply to the controller rather
+than to any one model, so no models are specified when granting them.
+Users with add-model access may create models of their own; superusers
+have full control over the controller and all of its models.
 
 Examples:
 Grant user 'joe' default (read) access to model 'mymodel':
@@ -34,18 +42,26 @@ Grant user 'sam' default (read) access to models 'model1' and 'model2':
 
     juju grant sam model1 model2
 
+Grant user 'maria' permission to add models to the controller:
+
+    juju grant --acl=add-model maria
+
 See also: 
     revoke
     add-user`
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model.`[1:]
+Revokes access from a Juju user for a model or the controller.`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
-Revoking write access, from a user who has that permission, will leave
-that user with read access. Revoking read access, however, also revokes
-write access.
+Revoking admin access, from a user who has that permission, will leave
+that user with write access, and revoking write access will leave them
+with read access. Revoking read access, however, revokes all access to
+the model.
+
+Similarly, revoking superuser access from a user leaves them with
+add-model access to the controller.
 
 Examples:
 Revoke read (and write) access from user 'joe' for model 'mymodel':
@@ -56,6 +72,10 @@ Revoke write access from user 'sam' for models 'model1' and 'model2':
 
     juju revoke --acl=write sam model1 model2
 
+Revoke add-model access from user 'maria':
+
+    juju revoke --acl=add-model maria
+
 See also: 
     grant`[1:]
 
@@ -69,7 +89,7 @@ type accessCommand struct {
 
 // SetFlags implements cmd.Command.
 func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
-	f.StringVar(&c.ModelAccess, "acl", "read", "Access control ('read' or 'write')")
+	f.StringVar(&c.ModelAccess, "acl", "read", "Access control ('read', 'write', 'admin', 'add-model' or 'superuser')")
 }
 
 // Init implements cmd.Command.
@@ -78,6 +98,14 @@ func (c *accessCommand) Init(args []string) error {
 		return errors.New("no user specified")
 	}
 
+	if c.isControllerAccess() {
+		if len(args) > 1 {
+			return errors.Errorf("%q access applies to the controller, no models may be specified", c.ModelAccess)
+		}
+		c.User = args[0]
+		return nil
+	}
+
 	if len(args) < 2 {
 		return errors.New("no model specified")
 	}
@@ -92,6 +120,13 @@ func (c *accessCommand) Init(args []string) error {
 	return nil
 }
 
+// isControllerAccess returns whether the requested access is granted on
+// the controller rather than on models.
+func (c *accessCommand) isControllerAccess() bool {
+	_, err := permission.ParseControllerAccess(c.ModelAccess)
+	return err == nil
+}
+
 // NewGrantCommand returns a new grant command.
 func NewGrantCommand() cmd.Command {
 	return modelcmd.WrapController(&grantCommand{})
@@ -100,14 +135,15 @@ func NewGrantCommand() cmd.Command {
 // grantCommand represents the command to grant a user access to one or more models.
 type grantCommand struct {
 	accessCommand
-	api GrantModelAPI
+	api           GrantModelAPI
+	controllerAPI GrantControllerAPI
 }
 
 // Info implements Command.Info.
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <model name> ...",
+		Args:    "<user name> [<model name> ...]",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -120,14 +156,37 @@ func (c *grantCommand) getAPI() (GrantModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
 	GrantModel(user, access string, modelUUIDs ...string) error
 }
 
+// GrantControllerAPI defines the API functions used by the grant command
+// to grant access to the controller.
+type GrantControllerAPI interface {
+	Close() error
+	GrantController(user, access string) error
+}
+
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if c.isControllerAccess() {
+		client, err := c.getControllerAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return block.ProcessBlockedError(client.GrantController(c.User, c.ModelAccess), block.BlockChange)
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -149,14 +208,15 @@ func NewRevokeCommand() cmd.Command {
 // revokeCommand revokes a user's access to models.
 type revokeCommand struct {
 	accessCommand
-	api RevokeModelAPI
+	api           RevokeModelAPI
+	controllerAPI RevokeControllerAPI
 }
 
 // Info implements cmd.Command.
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <model name> ...",
+		Args:    "<user> [<model name> ...]",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -169,14 +229,37 @@ func (c *revokeCommand) getAPI() (RevokeModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
 	RevokeModel(user, access string, modelUUIDs ...string) error
 }
 
+// RevokeControllerAPI defines the API functions used by the revoke
+// command to revoke access to the controller.
+type RevokeControllerAPI interface {
+	Close() error
+	RevokeController(user, access string) error
+}
+
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if c.isControllerAccess() {
+		client, err := c.getControllerAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return block.ProcessBlockedError(client.RevokeController(c.User, c.ModelAccess), block.BlockChange)
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -90,6 +90,28 @@ func (s *grantRevokeSuite) TestAccess(c *gc.C) {
 	c.Assert(s.fake.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestAdminAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "admin", "sam", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID})
+	c.Assert(s.fake.access, gc.Equals, "admin")
+}
+
+func (s *grantRevokeSuite) TestControllerAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "add-model", "sam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.modelUUIDs, gc.HasLen, 0)
+	c.Assert(s.fake.access, gc.Equals, "add-model")
+	c.Assert(s.fake.controller, jc.IsTrue)
+}
+
+func (s *grantRevokeSuite) TestInvalidAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "root", "sam", "model1")
+	c.Assert(err, gc.ErrorMatches, `invalid model access permission "root"`)
+}
+
 func (s *grantRevokeSuite) TestBlockGrant(c *gc.C) {
 	s.fake.err = &params.Error{Code: params.CodeOperationBlocked}
 	_, err := s.run(c, "sam", "foo")
@@ -106,13 +128,13 @@ var _ = gc.Suite(&grantSuite{})
 func (s *grantSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewGrantCommandForTest(fake, s.store)
+		c, _ := model.NewGrantCommandForTest(fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *grantSuite) TestInit(c *gc.C) {
-	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.store)
+	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -127,6 +149,9 @@ func (s *grantSuite) TestInit(c *gc.C) {
 
 	err = testing.InitCommand(wrappedCmd, []string{"nomodel"})
 	c.Assert(err, gc.ErrorMatches, `no model specified`)
+
+	err = testing.InitCommand(wrappedCmd, []string{"--acl", "superuser", "bob", "model1"})
+	c.Assert(err, gc.ErrorMatches, `"superuser" access applies to the controller, no models may be specified`)
 }
 
 type revokeSuite struct {
@@ -138,13 +163,13 @@ var _ = gc.Suite(&revokeSuite{})
 func (s *revokeSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewRevokeCommandForTest(fake, s.store)
+		c, _ := model.NewRevokeCommandForTest(fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *revokeSuite) TestInit(c *gc.C) {
-	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.store)
+	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -159,6 +184,9 @@ func (s *revokeSuite) TestInit(c *gc.C) {
 
 	err = testing.InitCommand(wrappedCmd, []string{"nomodel"})
 	c.Assert(err, gc.ErrorMatches, `no model specified`)
+
+	err = testing.InitCommand(wrappedCmd, []string{"--acl", "superuser", "bob", "model1"})
+	c.Assert(err, gc.ErrorMatches, `"superuser" access applies to the controller, no models may be specified`)
 }
 
 type fakeGrantRevokeAPI struct {
@@ -166,6 +194,7 @@ type fakeGrantRevokeAPI struct {
 	user       string
 	access     string
 	modelUUIDs []string
+	controller bool
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
@@ -178,6 +207,16 @@ func (f *fakeGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ...stri
 	return f.fake(user, access, modelUUIDs...)
 }
 
+func (f *fakeGrantRevokeAPI) GrantController(user, access string) error {
+	f.controller = true
+	return f.fake(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeController(user, access string) error {
+	f.controller = true
+	return f.fake(user, access)
+}
+
 func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
 	f.user = user
 	f.access = access
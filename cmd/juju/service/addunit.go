@@ -50,6 +50,11 @@ Add a unit of mariadb to LXC container number 3 on machine 24:
 
     juju add-unit mariadb --to 24/lxc/3
 
+Add a unit of postgresql, attaching the existing, detached storage
+pgdata/0 to it:
+
+    juju add-unit postgresql --attach-storage pgdata/0
+
 See also: 
     remove-unit`[1:]
 
@@ -61,17 +66,29 @@ type UnitCommandBase struct {
 	// Placement is the result of parsing the PlacementSpec arg value.
 	Placement []*instance.Placement
 	NumUnits  int
+	// AttachStorage is a list of storage IDs, identifying storage to
+	// attach to the unit created by deploy or add-unit.
+	AttachStorage []string
 }
 
 func (c *UnitCommandBase) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumUnits, "num-units", 1, "")
 	f.StringVar(&c.PlacementSpec, "to", "", "The machine and/or container to deploy the unit in (bypasses constraints)")
+	f.Var(cmd.NewStringsValue(nil, &c.AttachStorage), "attach-storage", "Existing storage to attach to the deployed unit")
 }
 
 func (c *UnitCommandBase) Init(args []string) error {
 	if c.NumUnits < 1 {
 		return errors.New("--num-units must be a positive integer")
 	}
+	if len(c.AttachStorage) > 0 && c.NumUnits != 1 {
+		return errors.New("--attach-storage cannot be used with -n")
+	}
+	for _, id := range c.AttachStorage {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
 	if c.PlacementSpec != "" {
 		placementSpecs := strings.Split(c.PlacementSpec, ",")
 		c.Placement = make([]*instance.Placement, len(placementSpecs))
@@ -150,7 +167,7 @@ func (c *addUnitCommand) Init(args []string) error {
 type serviceAddUnitAPI interface {
 	Close() error
 	ModelUUID() string
-	AddUnits(service string, numUnits int, placement []*instance.Placement) ([]string, error)
+	AddUnits(apiservice.AddUnitsParams) ([]string, error)
 }
 
 func (c *addUnitCommand) getAPI() (serviceAddUnitAPI, error) {
@@ -179,7 +196,12 @@ func (c *addUnitCommand) Run(_ *cmd.Context) error {
 		}
 		c.Placement[i] = p
 	}
-	_, err = apiclient.AddUnits(c.ServiceName, c.NumUnits, c.Placement)
+	_, err = apiclient.AddUnits(apiservice.AddUnitsParams{
+		ServiceName:   c.ServiceName,
+		NumUnits:      c.NumUnits,
+		Placement:     c.Placement,
+		AttachStorage: c.AttachStorage,
+	})
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
 
@@ -60,6 +60,7 @@ func deployBundle(
 	resolver *charmURLResolver,
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
+	incremental bool,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -119,6 +120,15 @@ func deployBundle(
 		return nil, errors.Annotate(err, "cannot get annotations client")
 	}
 
+	// In incremental mode, compare the bundle with the current model so that
+	// changes which the model already reflects can be skipped.
+	var model *charm.BundleData
+	if incremental {
+		if model, err = modelBundleData(serviceClient); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	// Instantiate the bundle handler.
 	h := &bundleHandler{
 		bundleDir:         bundleFilePath,
@@ -133,6 +143,7 @@ func deployBundle(
 		resolver:          resolver,
 		log:               log,
 		data:              data,
+		model:             model,
 		unitStatus:        unitStatus,
 		ignoredMachines:   make(map[string]bool, len(data.Services)),
 		ignoredUnits:      make(map[string]bool, len(data.Services)),
@@ -143,6 +154,9 @@ func deployBundle(
 	csMacs := make(map[*charm.URL]*macaroon.Macaroon)
 	channels := make(map[*charm.URL]csparams.Channel)
 	for _, change := range changes {
+		if h.unchanged(change) {
+			continue
+		}
 		switch change := change.(type) {
 		case *bundlechanges.AddCharmChange:
 			cURL, channel, csMac, err2 := h.addCharm(change.Id(), change.Params)
@@ -233,6 +247,10 @@ type bundleHandler struct {
 	// data is the original bundle data that we want to deploy.
 	data *charm.BundleData
 
+	// model holds the current model as bundle data when deploying
+	// incrementally, and is nil otherwise.
+	model *charm.BundleData
+
 	// unitStatus reflects the environment status and maps unit names to their
 	// corresponding machine identifiers. This is kept updated by both change
 	// handlers (addCharm, addService etc.) and by updateUnitStatus.
@@ -536,7 +554,11 @@ func (h *bundleHandler) addUnit(id string, p bundlechanges.AddUnitParams) error
 		}
 		placementArg = append(placementArg, placement)
 	}
-	r, err := h.serviceClient.AddUnits(service, 1, placementArg)
+	r, err := h.serviceClient.AddUnits(apiservice.AddUnitsParams{
+		ServiceName: service,
+		NumUnits:    1,
+		Placement:   placementArg,
+	})
 	if err != nil {
 		return errors.Annotatef(err, "cannot add unit for service %q", service)
 	}
@@ -591,6 +613,88 @@ func (h *bundleHandler) setAnnotations(id string, p bundlechanges.SetAnnotations
 	return nil
 }
 
+// unchanged reports whether the given change can be skipped because the
+// model already reflects it, which is only ever the case when deploying
+// incrementally. The results of skipped changes are recorded so that later
+// changes can still refer to them.
+func (h *bundleHandler) unchanged(change bundlechanges.Change) bool {
+	if h.model == nil {
+		return false
+	}
+	switch change := change.(type) {
+	case *bundlechanges.AddCharmChange:
+		// The charm is only needed if a service using it must be deployed
+		// or updated.
+		var curl string
+		for _, other := range h.changes {
+			deploy, ok := other.(*bundlechanges.AddServiceChange)
+			if !ok || deploy.Params.Charm != "$"+change.Id() {
+				continue
+			}
+			if !h.serviceUnchanged(deploy.Params.Service) {
+				return false
+			}
+			curl = h.model.Services[deploy.Params.Service].Charm
+		}
+		if curl == "" {
+			return false
+		}
+		h.results[change.Id()] = curl
+		return true
+	case *bundlechanges.AddServiceChange:
+		service := change.Params.Service
+		if !h.serviceUnchanged(service) {
+			return false
+		}
+		h.results[change.Id()] = service
+		h.log.Infof("reusing unchanged service %s (charm: %s)", service, h.model.Services[service].Charm)
+		return true
+	case *bundlechanges.AddRelationChange:
+		ep1 := resolveRelation(change.Params.Endpoint1, h.results)
+		ep2 := resolveRelation(change.Params.Endpoint2, h.results)
+		for _, relation := range h.model.Relations {
+			if relationMatches([]string{ep1, ep2}, relation) {
+				h.log.Infof("%s and %s are already related", ep1, ep2)
+				return true
+			}
+		}
+	case *bundlechanges.ExposeChange:
+		service := resolve(change.Params.Service, h.results)
+		if spec := h.model.Services[service]; spec != nil && spec.Expose {
+			h.log.Infof("service %s already exposed", service)
+			return true
+		}
+	case *bundlechanges.SetAnnotationsChange:
+		if change.Params.EntityType != bundlechanges.ServiceType {
+			return false
+		}
+		service := resolve(change.Params.Id, h.results)
+		spec := h.model.Services[service]
+		if spec == nil {
+			return false
+		}
+		for key, value := range change.Params.Annotations {
+			if spec.Annotations[key] != value {
+				return false
+			}
+		}
+		h.log.Infof("annotations already set for service %s", service)
+		return true
+	}
+	return false
+}
+
+// serviceUnchanged reports whether the named bundle service is already
+// deployed with the charm, options and constraints given in the bundle.
+func (h *bundleHandler) serviceUnchanged(service string) bool {
+	spec := h.model.Services[service]
+	if spec == nil {
+		return false
+	}
+	diff := diffService(h.data.Services[service], spec)
+	return diff == nil || diff.Charm == nil && diff.Options == nil && diff.Constraints == nil
+}
+
 // servicesForMachineChange returns the names of the services for which an
 // "addMachine" change is required, as adding machines is required to place
 // units, and units belong to services.
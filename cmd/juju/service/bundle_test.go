@@ -238,6 +238,71 @@ deployment of bundle "cs:bundle/wordpress-simple-1" completed`
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleIncremental(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	_, err := runDeployCommand(c, "bundle/wordpress-simple")
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := runDeployCommand(c, "bundle/wordpress-simple", "--incremental")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput := `
+reusing unchanged service mysql (charm: cs:trusty/mysql-42)
+reusing unchanged service wordpress (charm: cs:trusty/wordpress-47)
+wordpress:db and mysql:server are already related
+avoid adding new units to service mysql: 1 unit already present
+avoid adding new units to service wordpress: 1 unit already present
+deployment of bundle "cs:bundle/wordpress-simple-1" completed`
+	c.Assert(output, gc.Equals, strings.TrimSpace(expectedOutput))
+	s.assertServicesDeployed(c, map[string]serviceInfo{
+		"mysql":     {charm: "cs:trusty/mysql-42"},
+		"wordpress": {charm: "cs:trusty/wordpress-47"},
+	})
+	s.assertRelationsEstablished(c, "wordpress:db mysql:server")
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleIncrementalChanges(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	_, err := s.DeployBundleYAML(c, `
+        services:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	output, err := s.DeployBundleYAML(c, `
+        services:
+            wordpress:
+                charm: wordpress
+                num_units: 1
+                expose: true
+                options:
+                    blog-title: these are the voyages
+    `, "--incremental")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedOutput := `
+added charm cs:trusty/wordpress-42
+reusing service wordpress (charm: cs:trusty/wordpress-42)
+configuration updated for service wordpress
+service wordpress exposed
+avoid adding new units to service wordpress: 1 unit already present
+deployment of bundle "local:bundle/example-0" completed`
+	c.Assert(output, gc.Equals, strings.TrimSpace(expectedOutput))
+	s.assertServicesDeployed(c, map[string]serviceInfo{
+		"wordpress": {
+			charm:   "cs:trusty/wordpress-42",
+			config:  charm.Settings{"blog-title": "these are the voyages"},
+			exposed: true,
+		},
+	})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployCharmIncremental(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "trusty/wordpress-42", "wordpress")
+	_, err := runDeployCommand(c, "trusty/wordpress", "--incremental")
+	c.Assert(err, gc.ErrorMatches, "Flags provided but not supported when deploying a charm: --incremental.")
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleGatedCharm(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "trusty/mysql-42", "mysql")
 	url, _ := testcharms.UploadCharm(c, s.client, "trusty/wordpress-47", "wordpress")
@@ -333,7 +398,7 @@ func (s *BundleDeployCharmStoreSuite) Client() *csclient.Client {
 // DeployBundleYAML uses the given bundle content to create a bundle in the
 // local repository and then deploy it. It returns the bundle deployment output
 // and error.
-func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string) (string, error) {
+func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string, args ...string) (string, error) {
 	bundlePath := filepath.Join(c.MkDir(), "example")
 	c.Assert(os.Mkdir(bundlePath, 0777), jc.ErrorIsNil)
 	defer os.RemoveAll(bundlePath)
@@ -341,7 +406,7 @@ func (s *BundleDeployCharmStoreSuite) DeployBundleYAML(c *gc.C, content string)
 	c.Assert(err, jc.ErrorIsNil)
 	err = ioutil.WriteFile(filepath.Join(bundlePath, "README.md"), []byte("README"), 0644)
 	c.Assert(err, jc.ErrorIsNil)
-	return runDeployCommand(c, bundlePath)
+	return runDeployCommand(c, bundlePath, args...)
 }
 
 var deployBundleErrorsTests = []struct {
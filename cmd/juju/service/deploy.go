@@ -86,6 +86,10 @@ type DeployCommand struct {
 	Bindings map[string]string
 	Steps    []DeployStep
 
+	// Incremental is used to deploy only the parts of a bundle which
+	// differ from the current model.
+	Incremental bool
+
 	flagSet *gnuflag.FlagSet
 }
 
@@ -127,6 +131,10 @@ For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+When a bundle is deployed with --incremental, the bundle is first compared with
+the current model, and only the changes the model does not already reflect are
+applied. Use diff-bundle to review those differences beforehand.
+
 <service name>, if omitted, will be derived from <charm name>.
 
 Constraints can be specified when using deploy by specifying the --constraints
@@ -142,6 +150,13 @@ repeated more than once to upload more than one resource.
 
 Where bar and baz are resources named in the metadata for the foo charm.
 
+Existing storage that has been detached from another unit may be attached
+to the deployed unit by specifying the --attach-storage flag with a
+comma-separated list of storage IDs. Only a single unit may be deployed
+when --attach-storage is used.
+
+  juju deploy postgresql --attach-storage pgdata/0
+
 Charms can be deployed to a specific machine using the --to argument.
 If the destination is an LXC container the default is to use lxc-clone
 to create the container where possible. For Ubuntu deployments, lxc-clone
@@ -225,8 +240,8 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// charmOnlyFlags and bundleOnlyFlags are used to validate flags based on
 	// whether we are deploying a charm or a bundle.
-	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource"}
-	bundleOnlyFlags = []string{}
+	charmOnlyFlags  = []string{"bind", "config", "constraints", "force", "n", "num-units", "series", "to", "resource", "attach-storage"}
+	bundleOnlyFlags = []string{"incremental"}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -242,6 +257,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure service endpoint bindings to spaces")
+	f.BoolVar(&c.Incremental, "incremental", false, "only apply the bundle changes the model does not already reflect")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -418,7 +434,7 @@ func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client
 		}
 		// TODO(ericsnow) Do something with the CS macaroons that were returned?
 		if _, err := deployBundle(
-			bundleFilePath, bundleData, c.Channel, client, &deployer, resolver, ctx, c.BundleStorage,
+			bundleFilePath, bundleData, c.Channel, client, &deployer, resolver, ctx, c.BundleStorage, c.Incremental,
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -557,6 +573,9 @@ func (c *DeployCommand) deployCharm(args deployCharmArgs) (rErr error) {
 		if !constraints.IsEmpty(&c.Constraints) {
 			return errors.New("cannot use --constraints with subordinate service")
 		}
+		if len(c.AttachStorage) > 0 {
+			return errors.New("cannot use --attach-storage with subordinate service")
+		}
 		if numUnits == 1 && c.PlacementSpec == "" {
 			numUnits = 0
 		} else {
@@ -626,6 +645,7 @@ func (c *DeployCommand) deployCharm(args deployCharmArgs) (rErr error) {
 		constraints:   c.Constraints,
 		placement:     c.Placement,
 		storage:       c.Storage,
+		attachStorage: c.AttachStorage,
 		spaceBindings: c.Bindings,
 		resources:     ids,
 	}
@@ -708,6 +728,7 @@ type serviceDeployParams struct {
 	constraints   constraints.Value
 	placement     []*instance.Placement
 	storage       map[string]storage.Constraints
+	attachStorage []string
 	spaceBindings map[string]string
 	resources     map[string]string
 }
@@ -755,6 +776,7 @@ func (c *serviceDeployer) serviceDeploy(args serviceDeployParams) error {
 		Cons:             args.constraints,
 		Placement:        args.placement,
 		Storage:          args.storage,
+		AttachStorage:    args.attachStorage,
 		EndpointBindings: args.spaceBindings,
 		Resources:        args.resources,
 	}
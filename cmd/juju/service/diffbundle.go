@@ -0,0 +1,295 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	goyaml "gopkg.in/yaml.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+)
+
+var usageDiffBundleSummary = `
+Compares a bundle with the current model.`[1:]
+
+var usageDiffBundleDetails = `
+Reports the differences between a local bundle and the current model: services
+missing from either, charm revisions, unit counts, configuration options,
+constraints and exposure of the services in both, and relations present in only
+one of them.
+
+Only the options and constraints set by the bundle are compared, as those are
+the ones a bundle deployment changes. A bundle charm without a revision matches
+any revision of the same charm.
+
+Examples:
+    juju diff-bundle mymodel.yaml
+    juju diff-bundle /path/to/bundle/directory
+
+See also:
+    export-bundle
+    deploy`
+
+// NewDiffBundleCommand returns a command used to compare a bundle with
+// the current model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	BundlePath string
+	out        cmd.Output
+	api        exportBundleAPI
+}
+
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: usageDiffBundleSummary,
+		Doc:     usageDiffBundleDetails,
+	}
+}
+
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.BundlePath = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *diffBundleCommand) getAPI() (exportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// Run compares the bundle with the model and writes the differences.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	path := ctx.AbsPath(c.BundlePath)
+	data, err := charmrepo.ReadBundleFile(path)
+	if err != nil {
+		bundle, _, pathErr := charmrepo.NewBundleAtPath(path)
+		if pathErr != nil {
+			return errors.Annotatef(err, "cannot read bundle %q", c.BundlePath)
+		}
+		data = bundle.Data()
+	}
+
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	model, err := modelBundleData(apiclient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, diffBundle(data, model))
+}
+
+// modelBundleData returns the current model as bundle data.
+func modelBundleData(api exportBundleAPI) (*charm.BundleData, error) {
+	out, err := api.ExportBundle()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot export model")
+	}
+	var data charm.BundleData
+	if err := goyaml.Unmarshal([]byte(out), &data); err != nil {
+		return nil, errors.Annotate(err, "cannot parse exported model")
+	}
+	return &data, nil
+}
+
+// bundleDiff describes how a bundle differs from a model.
+type bundleDiff struct {
+	Services  map[string]*serviceDiff `yaml:"services,omitempty" json:"services,omitempty"`
+	Relations *relationsDiff          `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// serviceDiff describes how a bundle service differs from the model
+// service of the same name. Missing is "model" for services only in the
+// bundle and "bundle" for services only in the model.
+type serviceDiff struct {
+	Missing     string                `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *valueDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	NumUnits    *valueDiff            `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Options     map[string]*valueDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Constraints *valueDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Expose      *valueDiff            `yaml:"expose,omitempty" json:"expose,omitempty"`
+}
+
+// valueDiff holds a value as found in the bundle and in the model.
+type valueDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// relationsDiff holds the relations found in only one of the bundle and
+// the model.
+type relationsDiff struct {
+	BundleOnly [][]string `yaml:"bundle-only,omitempty" json:"bundle-only,omitempty"`
+	ModelOnly  [][]string `yaml:"model-only,omitempty" json:"model-only,omitempty"`
+}
+
+// diffBundle returns the differences between the given bundle and model.
+func diffBundle(bundle, model *charm.BundleData) *bundleDiff {
+	diff := &bundleDiff{
+		Services: make(map[string]*serviceDiff),
+	}
+	for name, spec := range bundle.Services {
+		if d := diffService(spec, model.Services[name]); d != nil {
+			diff.Services[name] = d
+		}
+	}
+	for name := range model.Services {
+		if _, ok := bundle.Services[name]; !ok {
+			diff.Services[name] = &serviceDiff{Missing: "bundle"}
+		}
+	}
+
+	relations := &relationsDiff{
+		BundleOnly: unmatchedRelations(bundle.Relations, model.Relations),
+		ModelOnly:  unmatchedRelations(model.Relations, bundle.Relations),
+	}
+	if len(relations.BundleOnly) > 0 || len(relations.ModelOnly) > 0 {
+		diff.Relations = relations
+	}
+	return diff
+}
+
+// diffService returns the differences between the given bundle and
+// model services, or nil if there are none.
+func diffService(bundle, model *charm.ServiceSpec) *serviceDiff {
+	if model == nil {
+		return &serviceDiff{Missing: "model"}
+	}
+	var diff serviceDiff
+	if !charmMatches(bundle.Charm, model.Charm) {
+		diff.Charm = &valueDiff{bundle.Charm, model.Charm}
+	}
+	if bundle.NumUnits != model.NumUnits {
+		diff.NumUnits = &valueDiff{bundle.NumUnits, model.NumUnits}
+	}
+	for name, value := range bundle.Options {
+		current, ok := model.Options[name]
+		if !ok || fmt.Sprint(value) != fmt.Sprint(current) {
+			if diff.Options == nil {
+				diff.Options = make(map[string]*valueDiff)
+			}
+			diff.Options[name] = &valueDiff{value, current}
+		}
+	}
+	if bundle.Constraints != "" && !constraintsMatch(bundle.Constraints, model.Constraints) {
+		diff.Constraints = &valueDiff{bundle.Constraints, model.Constraints}
+	}
+	if bundle.Expose != model.Expose {
+		diff.Expose = &valueDiff{bundle.Expose, model.Expose}
+	}
+	if diff.Charm == nil && diff.NumUnits == nil && diff.Options == nil && diff.Constraints == nil && diff.Expose == nil {
+		return nil
+	}
+	return &diff
+}
+
+// charmMatches reports whether the charm referenced by a bundle is the
+// charm the model is running. A bundle charm without a series or revision
+// matches any series or revision, and local charm paths are compared by
+// name only.
+func charmMatches(bundleCharm, modelCharm string) bool {
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(bundleCharm, ".") || filepath.IsAbs(bundleCharm) {
+		return modelURL.Schema == "local" && filepath.Base(bundleCharm) == modelURL.Name
+	}
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return false
+	}
+	if bundleURL.Schema != modelURL.Schema || bundleURL.User != modelURL.User || bundleURL.Name != modelURL.Name {
+		return false
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return false
+	}
+	return bundleURL.Revision < 0 || bundleURL.Revision == modelURL.Revision
+}
+
+// constraintsMatch reports whether the given constraints strings describe
+// the same constraints.
+func constraintsMatch(a, b string) bool {
+	aCons, err := constraints.Parse(a)
+	if err != nil {
+		return false
+	}
+	bCons, err := constraints.Parse(b)
+	if err != nil {
+		return false
+	}
+	return aCons.String() == bCons.String()
+}
+
+// unmatchedRelations returns the relations in from which match none of
+// the relations in other.
+func unmatchedRelations(from, other [][]string) [][]string {
+	var unmatched [][]string
+outer:
+	for _, relation := range from {
+		for _, candidate := range other {
+			if relationMatches(relation, candidate) {
+				continue outer
+			}
+		}
+		unmatched = append(unmatched, relation)
+	}
+	return unmatched
+}
+
+// relationMatches reports whether two relations join the same endpoints,
+// in either order. An endpoint given as a bare service name matches any
+// endpoint of that service.
+func relationMatches(a, b []string) bool {
+	if len(a) != 2 || len(b) != 2 {
+		return false
+	}
+	return endpointMatches(a[0], b[0]) && endpointMatches(a[1], b[1]) ||
+		endpointMatches(a[0], b[1]) && endpointMatches(a[1], b[0])
+}
+
+func endpointMatches(a, b string) bool {
+	aParts := strings.SplitN(a, ":", 2)
+	bParts := strings.SplitN(b, ":", 2)
+	if aParts[0] != bParts[0] {
+		return false
+	}
+	return len(aParts) == 1 || len(bParts) == 1 || aParts[1] == bParts[1]
+}
@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DiffBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+var diffBundleTests = []struct {
+	about    string
+	bundle   *charm.BundleData
+	model    *charm.BundleData
+	expected *bundleDiff
+}{{
+	about: "no differences",
+	bundle: &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       "wordpress",
+				NumUnits:    1,
+				Options:     map[string]interface{}{"blog-title": "voyages"},
+				Constraints: "mem=4G",
+			},
+			"mysql": {Charm: "cs:trusty/mysql-42", NumUnits: 1},
+		},
+		Relations: [][]string{{"wordpress", "mysql:server"}},
+	},
+	model: &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       "cs:trusty/wordpress-47",
+				NumUnits:    1,
+				Options:     map[string]interface{}{"blog-title": "voyages", "debug": true},
+				Constraints: "mem=4096M",
+			},
+			"mysql": {Charm: "cs:trusty/mysql-42", NumUnits: 1},
+		},
+		Relations: [][]string{{"mysql:server", "wordpress:db"}},
+	},
+	expected: &bundleDiff{
+		Services: map[string]*serviceDiff{},
+	},
+}, {
+	about: "service differences",
+	bundle: &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       "cs:trusty/wordpress-48",
+				NumUnits:    3,
+				Options:     map[string]interface{}{"blog-title": "voyages", "skill-level": 47},
+				Constraints: "mem=8G",
+				Expose:      true,
+			},
+			"haproxy": {Charm: "haproxy", NumUnits: 1},
+		},
+	},
+	model: &charm.BundleData{
+		Services: map[string]*charm.ServiceSpec{
+			"wordpress": {
+				Charm:       "cs:trusty/wordpress-47",
+				NumUnits:    1,
+				Options:     map[string]interface{}{"blog-title": "trek"},
+				Constraints: "mem=4096M",
+			},
+			"mysql": {Charm: "cs:trusty/mysql-42", NumUnits: 1},
+		},
+	},
+	expected: &bundleDiff{
+		Services: map[string]*serviceDiff{
+			"wordpress": {
+				Charm:    &valueDiff{"cs:trusty/wordpress-48", "cs:trusty/wordpress-47"},
+				NumUnits: &valueDiff{3, 1},
+				Options: map[string]*valueDiff{
+					"blog-title":  {"voyages", "trek"},
+					"skill-level": {47, nil},
+				},
+				Constraints: &valueDiff{"mem=8G", "mem=4096M"},
+				Expose:      &valueDiff{true, false},
+			},
+			"haproxy": {Missing: "model"},
+			"mysql":   {Missing: "bundle"},
+		},
+	},
+}, {
+	about: "relation differences",
+	bundle: &charm.BundleData{
+		Relations: [][]string{
+			{"wordpress:db", "mysql:server"},
+			{"haproxy", "wordpress"},
+		},
+	},
+	model: &charm.BundleData{
+		Relations: [][]string{
+			{"wordpress:db", "mysql:server"},
+			{"wordpress:cache", "memcached:cache"},
+		},
+	},
+	expected: &bundleDiff{
+		Services: map[string]*serviceDiff{},
+		Relations: &relationsDiff{
+			BundleOnly: [][]string{{"haproxy", "wordpress"}},
+			ModelOnly:  [][]string{{"wordpress:cache", "memcached:cache"}},
+		},
+	},
+}}
+
+func (s *DiffBundleSuite) TestDiffBundle(c *gc.C) {
+	for i, test := range diffBundleTests {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(diffBundle(test.bundle, test.model), jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *DiffBundleSuite) TestCharmMatches(c *gc.C) {
+	for i, test := range []struct {
+		bundle  string
+		model   string
+		matches bool
+	}{
+		{"wordpress", "cs:trusty/wordpress-47", true},
+		{"trusty/wordpress", "cs:trusty/wordpress-47", true},
+		{"cs:trusty/wordpress-47", "cs:trusty/wordpress-47", true},
+		{"cs:trusty/wordpress-46", "cs:trusty/wordpress-47", false},
+		{"xenial/wordpress", "cs:trusty/wordpress-47", false},
+		{"cs:~bob/wordpress", "cs:trusty/wordpress-47", false},
+		{"mysql", "cs:trusty/wordpress-47", false},
+		{"./charms/wordpress", "local:trusty/wordpress-0", true},
+		{"./charms/wordpress", "cs:trusty/wordpress-47", false},
+	} {
+		c.Logf("test %d: %s %s", i, test.bundle, test.model)
+		c.Check(charmMatches(test.bundle, test.model), gc.Equals, test.matches)
+	}
+}
+
+func (s *DiffBundleSuite) TestInit(c *gc.C) {
+	err := coretesting.InitCommand(NewDiffBundleCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+	err = coretesting.InitCommand(NewDiffBundleCommand(), []string{"bundle.yaml", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *DiffBundleSuite) TestRun(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+services:
+    wordpress:
+        charm: wordpress
+        num_units: 2
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	api := &fakeModelBundleAPI{bundle: `
+services:
+  wordpress:
+    charm: cs:trusty/wordpress-47
+    num_units: 1
+`}
+	ctx, err := coretesting.RunCommand(c, modelcmd.Wrap(&diffBundleCommand{api: api}), path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+services:
+  wordpress:
+    num_units:
+      bundle: 2
+      model: 1
+`[1:])
+	c.Assert(api.closed, jc.IsTrue)
+}
+
+func (s *DiffBundleSuite) TestRunExportError(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte("services: {}\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	api := &fakeModelBundleAPI{err: errors.New("boom")}
+	_, err = coretesting.RunCommand(c, modelcmd.Wrap(&diffBundleCommand{api: api}), path)
+	c.Assert(err, gc.ErrorMatches, "cannot export model: boom")
+}
+
+func (s *DiffBundleSuite) TestRunBundleNotFound(c *gc.C) {
+	api := &fakeModelBundleAPI{}
+	_, err := coretesting.RunCommand(c, modelcmd.Wrap(&diffBundleCommand{api: api}), filepath.Join(c.MkDir(), "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, `cannot read bundle ".*missing.yaml": .*`)
+}
+
+type fakeModelBundleAPI struct {
+	bundle string
+	err    error
+	closed bool
+}
+
+func (f *fakeModelBundleAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeModelBundleAPI) ExportBundle() (string, error) {
+	return f.bundle, f.err
+}
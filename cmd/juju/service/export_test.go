@@ -32,6 +32,13 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewExportBundleCommandForTest returns an ExportBundleCommand with the api provided as specified.
+func NewExportBundleCommandForTest(api exportBundleAPI) cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{
+		api: api,
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageExportBundleSummary = `
+Exports the current model as a bundle.`[1:]
+
+var usageExportBundleDetails = `
+Writes the services of the model, with their charms, configuration,
+constraints, placement, endpoint bindings, storage and annotations, together
+with the machines hosting them and the relations between them, as bundle
+YAML which can be deployed again with `[1:] + "`juju deploy`" + `.
+
+The bundle is written to standard output unless --filename is given.
+
+Examples:
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy`
+
+// NewExportBundleCommand returns a command used to export the current
+// model as a bundle.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+// exportBundleCommand writes the current model as bundle YAML.
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	Filename string
+	api      exportBundleAPI
+}
+
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: usageExportBundleSummary,
+		Doc:     usageExportBundleDetails,
+	}
+}
+
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Filename, "filename", "", "bundle file to write")
+}
+
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// exportBundleAPI defines the methods on the service API
+// that the export-bundle command calls.
+type exportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (exportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// Run exports the model and writes the resulting bundle.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	bundle, err := apiclient.ExportBundle()
+	if err != nil {
+		return errors.Annotate(err, "cannot export bundle")
+	}
+	if c.Filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, bundle)
+		return err
+	}
+	path := ctx.AbsPath(c.Filename)
+	if err := ioutil.WriteFile(path, []byte(bundle), 0644); err != nil {
+		return errors.Annotate(err, "cannot write bundle")
+	}
+	ctx.Infof("Bundle written to %s", path)
+	return nil
+}
@@ -6,6 +6,7 @@ package service
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/service"
 	"github.com/juju/juju/cmd/juju/block"
@@ -19,8 +20,15 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the service.
 
+Access may instead be restricted to particular CIDRs with --to-cidrs,
+and to the subnets of particular spaces with --to-spaces. Exposing the
+service again replaces any such restrictions. Controllers too old to
+support restrictions refuse them, rather than exposing to everyone.
+
 Examples:
     juju expose wordpress
+    juju expose --to-cidrs 10.0.0.0/8,192.168.0.0/16 mysql
+    juju expose --to-spaces internal mysql
 
 See also: 
     unexpose`[1:]
@@ -34,6 +42,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ServiceName string
+	ToCIDRs     []string
+	ToSpaces    []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,6 +55,11 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to-cidrs", "only allow access from these comma-separated CIDRs")
+	f.Var(cmd.NewStringsValue(nil, &c.ToSpaces), "to-spaces", "only allow access from the subnets of these comma-separated spaces")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no service name specified")
@@ -56,6 +71,7 @@ func (c *exposeCommand) Init(args []string) error {
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, cidrs, spaces []string) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +91,9 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ServiceName), block.BlockChange)
+	if len(c.ToCIDRs) == 0 && len(c.ToSpaces) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ServiceName), block.BlockChange)
+	}
+	err = client.ExposeTo(c.ServiceName, c.ToCIDRs, c.ToSpaces)
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -60,6 +60,30 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeTo(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
+	err := runDeploy(c, ch, "some-service-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "--to-cidrs", "10.0.0.0/8,192.168.0.0/16", "some-service-name")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-service-name")
+	svc, err := s.State.Service("some-service-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.ExposedToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Assert(svc.ExposedToSpaces(), gc.HasLen, 0)
+
+	err = runExpose(c, "--to-cidrs", "10.0.0.0", "some-service-name")
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "some-service-name": CIDR "10.0.0.0" not valid.*`)
+
+	// Exposing again without restrictions clears them.
+	err = runExpose(c, "some-service-name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.ExposedToCIDRs(), gc.HasLen, 0)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "dummy")
 	err := runDeploy(c, ch, "some-service-name", "--series", "trusty")
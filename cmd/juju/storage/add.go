@@ -10,6 +10,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -54,6 +55,12 @@ Storage constraints can be optionally ommitted.
 Model default values will be used for all ommitted constraint values.
 There is no need to comma-separate ommitted constraints. 
 
+Block storage may be created from a volume snapshot previously taken
+with "juju create-storage-snapshot", by specifying --from-snapshot
+and a single storage directive. The storage will be created in the
+same pool as the snapshotted volume, and will be at least as large
+as the snapshot.
+
 Example:
     Add 3 ebs storage instances for "data" storage to unit u/0:
 
@@ -70,6 +77,12 @@ Example:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+
+    Add 1 storage instance for "data" storage to unit u/0,
+    created from the volume snapshot with ID 4:
+
+      juju add-storage --from-snapshot 4 u/0 data
 `
 	addCommandAgs = `
 <unit name> <storage directive> ...
@@ -89,6 +102,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// snapshot is the ID of the volume snapshot from which
+	// to create the storage, if any.
+	snapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.snapshot, "from-snapshot", "", "create the storage from the specified volume snapshot")
 }
 
 // Init implements Command.Init.
@@ -104,7 +127,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u).String()
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.snapshot != "" && len(c.storageCons) != 1 {
+		return errors.New("--from-snapshot requires exactly one storage directive")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -173,6 +202,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 					&cons.Size,
 					&cons.Count,
 				},
+				Snapshot: c.snapshot,
 			})
 	}
 	return all
@@ -45,6 +45,7 @@ var errorTsts = []tstData{
 	{[]string{"tst/123", "data="}, `.*storage constraints require at least one.*`},
 	{[]string{"tst/123", "data=-676"}, `.*count must be greater than zero, got "-676".*`},
 	{[]string{"tst/123", "data=676", "data=676"}, `.*storage "data" specified more than once.*`},
+	{[]string{"--from-snapshot", "4", "tst/123", "data", "logs"}, `--from-snapshot requires exactly one storage directive`},
 }
 
 func (s *addSuite) TestAddArgs(c *gc.C) {
@@ -82,6 +83,16 @@ func (s *addSuite) TestAddSuccess(c *gc.C) {
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	s.args = []string{"--from-snapshot", "0/4", "tst/123", "data=ebs"}
+	s.assertAddOutput(c, "", "")
+	c.Assert(s.mockAPI.storages, gc.HasLen, 1)
+	c.Assert(s.mockAPI.storages[0].UnitTag, gc.Equals, "unit-tst-123")
+	c.Assert(s.mockAPI.storages[0].StorageName, gc.Equals, "data")
+	c.Assert(s.mockAPI.storages[0].Constraints.Pool, gc.Equals, "ebs")
+	c.Assert(s.mockAPI.storages[0].Snapshot, gc.Equals, "0/4")
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.abort = true
@@ -117,14 +128,16 @@ func (s *addSuite) assertAddOutput(c *gc.C, expectedValid, expectedErr string) {
 }
 
 type mockAddAPI struct {
-	abort bool
+	abort    bool
+	storages []params.StorageAddParams
 }
 
-func (s mockAddAPI) Close() error {
+func (s *mockAddAPI) Close() error {
 	return nil
 }
 
-func (s mockAddAPI) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+func (s *mockAddAPI) AddToUnit(storages []params.StorageAddParams) ([]params.ErrorResult, error) {
+	s.storages = append(s.storages, storages...)
 	if s.abort {
 		return nil, errors.New("aborted")
 	}
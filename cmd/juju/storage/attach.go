@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAttachStorageCommand returns a command used to attach storage
+// to a unit.
+func NewAttachStorageCommand() cmd.Command {
+	cmd := &attachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageAttachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	attachStorageCommandDoc = `
+Attach existing, detached storage to a unit. The storage must have
+previously been detached from a unit with "juju detach-storage", and
+the unit's charm must declare storage with the same name and kind.
+
+Once the storage is attached, the unit's charm will receive the
+storage-attached hook as it would for newly added storage.
+
+Examples:
+    juju attach-storage postgresql/1 pgdata/0
+`
+	attachStorageCommandArgs = `<unit> <storage> [<storage> ...]`
+)
+
+// attachStorageCommand attaches existing storage to a unit.
+type attachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageAttachAPI, error)
+	unitId     string
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *attachStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("attach-storage requires a unit ID and at least one storage ID")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.NotValidf("unit name %q", args[0])
+	}
+	for _, id := range args[1:] {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.unitId = args[0]
+	c.storageIds = args[1:]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *attachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "attach-storage",
+		Purpose: "attaches existing storage to a unit",
+		Doc:     attachStorageCommandDoc,
+		Args:    attachStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *attachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Attach(c.unitId, c.storageIds)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "failed to attach %s to %s: %v\n", c.storageIds[i], c.unitId, result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("attaching %s to %s", c.storageIds[i], c.unitId)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageAttachAPI defines the API methods that the attach-storage
+// command uses.
+type StorageAttachAPI interface {
+	Close() error
+	Attach(unitId string, storageIds []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type AttachStorageSuite struct {
+	SubStorageSuite
+}
+
+var _ = gc.Suite(&AttachStorageSuite{})
+
+func (s *AttachStorageSuite) TestAttach(c *gc.C) {
+	fake := fakeEntityAttacher{results: []params.ErrorResult{
+		{},
+		{},
+	}}
+	ctx, err := s.run(c, &fake, "foo/0", "bar/1", "baz/2")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCalls(c, []jujutesting.StubCall{
+		{"Attach", []interface{}{"foo/0", []string{"bar/1", "baz/2"}}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+attaching bar/1 to foo/0
+attaching baz/2 to foo/0
+`[1:])
+}
+
+func (s *AttachStorageSuite) TestAttachError(c *gc.C) {
+	fake := fakeEntityAttacher{results: []params.ErrorResult{
+		{Error: &params.Error{Message: "foo"}},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	ctx, err := s.run(c, &fake, "foo/0", "bar/1", "baz/2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+failed to attach bar/1 to foo/0: foo
+failed to attach baz/2 to foo/0: bar
+`[1:])
+}
+
+func (s *AttachStorageSuite) TestAttachAPIError(c *gc.C) {
+	fake := fakeEntityAttacher{}
+	fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, &fake, "foo/0", "bar/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *AttachStorageSuite) TestAttachInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{nil, "attach-storage requires a unit ID and at least one storage ID"},
+		{[]string{"foo/0"}, "attach-storage requires a unit ID and at least one storage ID"},
+		{[]string{"foo", "bar/1"}, `unit name "foo" not valid`},
+		{[]string{"foo/0", "bar"}, `storage ID "bar" not valid`},
+	} {
+		c.Logf("test %d: %q", i, t.args)
+		_, err := s.run(c, &fakeEntityAttacher{}, t.args...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *AttachStorageSuite) run(c *gc.C, fake *fakeEntityAttacher, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewAttachStorageCommandForTest(fake, s.store), args...)
+}
+
+type fakeEntityAttacher struct {
+	jujutesting.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeEntityAttacher) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "Attach", unitId, storageIds)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.results, nil
+}
+
+func (f *fakeEntityAttacher) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
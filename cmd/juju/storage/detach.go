@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewDetachStorageCommand returns a command used to detach storage
+// from the unit it is attached to.
+func NewDetachStorageCommand() cmd.Command {
+	cmd := &detachStorageCommand{}
+	cmd.newAPIFunc = func() (StorageDetachAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	detachStorageCommandDoc = `
+Detach storage from the unit it is attached to, leaving the storage
+in the model. The unit's charm will receive the storage-detaching
+hook, and then the storage will be detached from the unit's machine.
+
+Detached storage may later be attached to another unit with
+"juju attach-storage", or when adding a unit with "--attach-storage".
+Only storage that is not bound to a machine, such as persistent
+volumes, may be detached.
+
+Examples:
+    juju detach-storage pgdata/0
+`
+	detachStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// detachStorageCommand detaches storage from units.
+type detachStorageCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageDetachAPI, error)
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *detachStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("detach-storage requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *detachStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detach-storage",
+		Purpose: "detaches storage from units",
+		Doc:     detachStorageCommandDoc,
+		Args:    detachStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *detachStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.Detach(c.storageIds)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "failed to detach %s: %v\n", c.storageIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("detaching %s", c.storageIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageDetachAPI defines the API methods that the detach-storage
+// command uses.
+type StorageDetachAPI interface {
+	Close() error
+	Detach(storageIds []string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type DetachStorageSuite struct {
+	SubStorageSuite
+}
+
+var _ = gc.Suite(&DetachStorageSuite{})
+
+func (s *DetachStorageSuite) TestDetach(c *gc.C) {
+	fake := fakeEntityDetacher{results: []params.ErrorResult{
+		{},
+		{},
+	}}
+	ctx, err := s.run(c, &fake, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCalls(c, []jujutesting.StubCall{
+		{"Detach", []interface{}{[]string{"foo/0", "bar/1"}}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+detaching foo/0
+detaching bar/1
+`[1:])
+}
+
+func (s *DetachStorageSuite) TestDetachError(c *gc.C) {
+	fake := fakeEntityDetacher{results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	ctx, err := s.run(c, &fake, "foo/0", "bar/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stderr(ctx), gc.Equals, `
+detaching foo/0
+failed to detach bar/1: bar
+`[1:])
+}
+
+func (s *DetachStorageSuite) TestDetachAPIError(c *gc.C) {
+	fake := fakeEntityDetacher{}
+	fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, &fake, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DetachStorageSuite) TestDetachInitErrors(c *gc.C) {
+	_, err := s.run(c, &fakeEntityDetacher{})
+	c.Assert(err, gc.ErrorMatches, "detach-storage requires at least one storage ID")
+	_, err = s.run(c, &fakeEntityDetacher{}, "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *DetachStorageSuite) run(c *gc.C, fake *fakeEntityDetacher, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewDetachStorageCommandForTest(fake, s.store), args...)
+}
+
+type fakeEntityDetacher struct {
+	jujutesting.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeEntityDetacher) Detach(storageIds []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "Detach", storageIds)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.results, nil
+}
+
+func (f *fakeEntityDetacher) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -54,3 +54,27 @@ func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.C
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewAttachStorageCommandForTest(api StorageAttachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &attachStorageCommand{newAPIFunc: func() (StorageAttachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewDetachStorageCommandForTest(api StorageDetachAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachStorageCommand{newAPIFunc: func() (StorageDetachAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewCreateSnapshotCommandForTest(api StorageSnapshotAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createSnapshotCommand{newAPIFunc: func() (StorageSnapshotAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
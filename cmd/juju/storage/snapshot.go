@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCreateSnapshotCommand returns a command used to take snapshots
+// of volumes.
+func NewCreateSnapshotCommand() cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = func() (StorageSnapshotAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	createSnapshotCommandDoc = `
+Take point-in-time snapshots of volumes. The ID of each snapshot is
+printed, one per line, in the order the volumes were specified.
+Snapshots are taken asynchronously; a snapshot may be used once it
+has been taken by the storage provider.
+
+Storage may be created from a snapshot with "juju add-storage
+--from-snapshot". Snapshots of volumes bound to a machine, such as
+loop devices, may only be used to create storage on that machine.
+
+Only volumes whose storage provider supports snapshots, such as
+ebs, cinder and gce, may be snapshotted.
+
+Juju does not remove snapshots, even when the volume or the model is
+destroyed. Snapshots held by a cloud provider, such as ebs, cinder and
+gce snapshots, continue to be charged for until they are removed with
+the cloud's own tools. Volume snapshots are not carried over when a
+model is migrated.
+
+Examples:
+    juju create-storage-snapshot 0/1 2
+`
+	createSnapshotCommandArgs = `<volume> [<volume> ...]`
+)
+
+// createSnapshotCommand takes snapshots of volumes.
+type createSnapshotCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (StorageSnapshotAPI, error)
+	volumeIds  []string
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one volume ID")
+	}
+	for _, id := range args {
+		if !names.IsValidVolume(id) {
+			return errors.NotValidf("volume ID %q", id)
+		}
+	}
+	c.volumeIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "takes snapshots of volumes",
+		Doc:     createSnapshotCommandDoc,
+		Args:    createSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshot(c.volumeIds)
+	if err != nil {
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "failed to snapshot volume %s: %v\n", c.volumeIds[i], result.Error)
+			failed = true
+			continue
+		}
+		fmt.Fprintln(ctx.Stdout, result.Result)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// StorageSnapshotAPI defines the API methods that the
+// create-storage-snapshot command uses.
+type StorageSnapshotAPI interface {
+	Close() error
+	CreateSnapshot(volumeIds []string) ([]params.StringResult, error)
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type CreateSnapshotSuite struct {
+	SubStorageSuite
+}
+
+var _ = gc.Suite(&CreateSnapshotSuite{})
+
+func (s *CreateSnapshotSuite) TestCreateSnapshot(c *gc.C) {
+	fake := fakeVolumeSnapshotter{results: []params.StringResult{
+		{Result: "0/3"},
+		{Result: "4"},
+	}}
+	ctx, err := s.run(c, &fake, "0/1", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCalls(c, []jujutesting.StubCall{
+		{"CreateSnapshot", []interface{}{[]string{"0/1", "2"}}},
+		{"Close", nil},
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+0/3
+4
+`[1:])
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotError(c *gc.C) {
+	fake := fakeVolumeSnapshotter{results: []params.StringResult{
+		{Result: "0/3"},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	ctx, err := s.run(c, &fake, "0/1", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "0/3\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "failed to snapshot volume 2: bar\n")
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotAPIError(c *gc.C) {
+	fake := fakeVolumeSnapshotter{}
+	fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, &fake, "0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *CreateSnapshotSuite) TestCreateSnapshotInitErrors(c *gc.C) {
+	_, err := s.run(c, &fakeVolumeSnapshotter{})
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one volume ID")
+	_, err = s.run(c, &fakeVolumeSnapshotter{}, "data/0")
+	c.Assert(err, gc.ErrorMatches, `volume ID "data/0" not valid`)
+}
+
+func (s *CreateSnapshotSuite) run(c *gc.C, fake *fakeVolumeSnapshotter, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewCreateSnapshotCommandForTest(fake, s.store), args...)
+}
+
+type fakeVolumeSnapshotter struct {
+	jujutesting.Stub
+	results []params.StringResult
+}
+
+func (f *fakeVolumeSnapshotter) CreateSnapshot(volumeIds []string) ([]params.StringResult, error) {
+	f.MethodCall(f, "CreateSnapshot", volumeIds)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.results, nil
+}
+
+func (f *fakeVolumeSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
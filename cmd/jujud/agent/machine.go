@@ -59,6 +59,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage/looputil"
 	"github.com/juju/juju/upgrades"
@@ -66,6 +67,7 @@ import (
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -73,6 +75,7 @@ import (
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/imagemetadataworker"
+	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/mongoupgrader"
@@ -965,6 +968,26 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				backupPaths := backups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.New(backupscheduler.Config{
+					Backend: backupscheduler.NewStateBackend(st, backupPaths, a.machineId),
+					Clock:   clock.WallClock,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "logforwarder", func() (worker.Worker, error) {
+				return logforwarder.New(logforwarder.Config{
+					Backend:    logforwarder.NewStateBackend(st),
+					OpenSender: logforwarder.OpenSyslogSender,
+					Clock:      clock.WallClock,
+					RetryDelay: worker.RestartDelay,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
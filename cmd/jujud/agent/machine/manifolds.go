@@ -262,6 +262,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
 
+			APIOpen:   api.Open,
 			NewFacade: migrationminion.NewFacade,
 			NewWorker: migrationminion.NewWorker,
 		})),
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/utils/voyeur"
 
 	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	msapi "github.com/juju/juju/api/meterstatus"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
@@ -134,6 +135,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			FortressName:  migrationFortressName,
 
+			APIOpen:   api.Open,
 			NewFacade: migrationminion.NewFacade,
 			NewWorker: migrationminion.NewWorker,
 		}),
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+	"time"
+)
+
+// RetentionPolicy describes which scheduled backups are kept. A backup
+// is kept if any of the rules selects it; all other backups may be
+// removed. A policy with no rules set keeps every backup.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days, counting today, for which the
+	// most recent backup made on each day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of weeks, counting this one, for
+	// which the most recent backup made in each week is kept. Weeks
+	// start on Monday.
+	KeepWeekly int
+}
+
+// IsZero returns true if the policy has no rules, and so keeps every
+// backup.
+func (p RetentionPolicy) IsZero() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0
+}
+
+// Backup identifies a backup to which a retention policy is applied.
+type Backup struct {
+	ID      string
+	Started time.Time
+}
+
+// Expired returns the IDs of the backups which the policy does not
+// keep at the given time, oldest first.
+func (p RetentionPolicy) Expired(backups []Backup, now time.Time) []string {
+	if p.IsZero() {
+		return nil
+	}
+	sorted := make([]Backup, len(backups))
+	copy(sorted, backups)
+	sort.Sort(newestFirst(sorted))
+
+	keep := make(map[string]bool)
+	for i := 0; i < p.KeepLast && i < len(sorted); i++ {
+		keep[sorted[i].ID] = true
+	}
+	keepNewestInPeriods(keep, sorted, startOfDay(now), p.KeepDaily, func(t time.Time) time.Time {
+		return t.AddDate(0, 0, -1)
+	})
+	keepNewestInPeriods(keep, sorted, startOfWeek(now), p.KeepWeekly, func(t time.Time) time.Time {
+		return t.AddDate(0, 0, -7)
+	})
+
+	var expired []string
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !keep[sorted[i].ID] {
+			expired = append(expired, sorted[i].ID)
+		}
+	}
+	return expired
+}
+
+// keepNewestInPeriods marks the newest backup in each of count periods
+// as kept, where the most recent period starts at start and each
+// earlier one starts at previous(start).
+func keepNewestInPeriods(keep map[string]bool, sorted []Backup, start time.Time, count int, previous func(time.Time) time.Time) {
+	if count <= 0 {
+		return
+	}
+	end := start.AddDate(1000, 0, 0)
+	for ; count > 0; count-- {
+		for _, backup := range sorted {
+			started := backup.Started.UTC()
+			if !started.Before(start) && started.Before(end) {
+				keep[backup.ID] = true
+				break
+			}
+		}
+		start, end = previous(start), start
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	daysSinceMonday := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -daysSinceMonday)
+}
+
+type newestFirst []Backup
+
+func (b newestFirst) Len() int           { return len(b) }
+func (b newestFirst) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
+func (b newestFirst) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+)
+
+type RetentionSuite struct{}
+
+var _ = gc.Suite(&RetentionSuite{})
+
+// retentionNow is a Wednesday.
+var retentionNow = time.Date(2016, 6, 15, 12, 0, 0, 0, time.UTC)
+
+// backupsEvery returns count backups, made every interval before
+// retentionNow, newest first. Each backup's ID is its index.
+func backupsEvery(interval time.Duration, count int) []backups.Backup {
+	result := make([]backups.Backup, count)
+	for i := range result {
+		result[i] = backups.Backup{
+			ID:      string(rune('a' + i)),
+			Started: retentionNow.Add(-time.Duration(i) * interval),
+		}
+	}
+	return result
+}
+
+func (*RetentionSuite) TestZeroPolicyKeepsEverything(c *gc.C) {
+	var policy backups.RetentionPolicy
+	c.Check(policy.IsZero(), gc.Equals, true)
+	c.Check(policy.Expired(backupsEvery(time.Hour, 5), retentionNow), gc.HasLen, 0)
+}
+
+func (*RetentionSuite) TestKeepLast(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepLast: 2}
+	// Oldest first.
+	c.Check(policy.Expired(backupsEvery(time.Hour, 5), retentionNow), gc.DeepEquals, []string{
+		"e", "d", "c",
+	})
+}
+
+func (*RetentionSuite) TestKeepLastIgnoresOrder(c *gc.C) {
+	all := backupsEvery(time.Hour, 3)
+	all[0], all[2] = all[2], all[0]
+	policy := backups.RetentionPolicy{KeepLast: 1}
+	c.Check(policy.Expired(all, retentionNow), gc.DeepEquals, []string{"c", "b"})
+}
+
+func (*RetentionSuite) TestKeepDaily(c *gc.C) {
+	// Two backups a day, at 12:00 and 00:00, back to 06-12 00:00.
+	all := backupsEvery(12*time.Hour, 8)
+	policy := backups.RetentionPolicy{KeepDaily: 3}
+	// Kept: a (06-15 12:00), c (06-14 12:00), e (06-13 12:00).
+	c.Check(policy.Expired(all, retentionNow), gc.DeepEquals, []string{
+		"h", "g", "f", "d", "b",
+	})
+}
+
+func (*RetentionSuite) TestKeepWeekly(c *gc.C) {
+	// One backup a day, back to 05-27 (a Friday).
+	all := backupsEvery(24*time.Hour, 20)
+	policy := backups.RetentionPolicy{KeepWeekly: 3}
+	// Weeks start on Mondays 06-13, 06-06 and 05-30; the newest
+	// backups in each are a (06-15), d (06-12) and k (06-05).
+	expired := policy.Expired(all, retentionNow)
+	c.Check(expired, gc.HasLen, 17)
+	for _, id := range expired {
+		c.Check(id, gc.Not(gc.Matches), "[adk]")
+	}
+}
+
+func (*RetentionSuite) TestRulesCombine(c *gc.C) {
+	all := backupsEvery(24*time.Hour, 20)
+	policy := backups.RetentionPolicy{
+		KeepLast:   1,
+		KeepDaily:  2,
+		KeepWeekly: 3,
+	}
+	expired := policy.Expired(all, retentionNow)
+	c.Check(expired, gc.HasLen, 16)
+	for _, id := range expired {
+		c.Check(id, gc.Not(gc.Matches), "[abdk]")
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backups holds the rules which decide when the controller
+// backs itself up, and which of those backups it keeps.
+package backups
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule describes the times at which backups should be made. It is
+// parsed from a cron-style specification of five space-separated
+// fields:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field is "*", a number, a range ("1-5"), a step ("*/15" or
+// "0-30/10") or a comma-separated list of those. Day-of-week runs
+// from 0 (Sunday) to 6, with 7 also accepted for Sunday. As in cron,
+// when both day fields are restricted a day matching either of them
+// is used. The shortcuts "@hourly", "@daily" and "@weekly" are also
+// accepted. All times are in UTC.
+type Schedule struct {
+	minute     fieldSet
+	hour       fieldSet
+	dayOfMonth fieldSet
+	month      fieldSet
+	dayOfWeek  fieldSet
+
+	// anyDayOfMonth and anyDayOfWeek record whether the day fields
+	// are "*", in which case only the other one is used to match
+	// days.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+var scheduleShortcuts = map[string]string{
+	"@hourly": "0 * * * *",
+	"@daily":  "0 0 * * *",
+	"@weekly": "0 0 * * 0",
+}
+
+// fieldSet records which values of a schedule field match.
+type fieldSet map[int]bool
+
+type fieldRange struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = []fieldRange{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7},
+}
+
+// ParseSchedule parses a cron-style backup schedule.
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := scheduleShortcuts[spec]; ok {
+		spec = expanded
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(scheduleFields) {
+		return nil, errors.Errorf("schedule %q: expected %d fields, got %d", spec, len(scheduleFields), len(parts))
+	}
+	sets := make([]fieldSet, len(parts))
+	for i, part := range parts {
+		set, err := parseField(part, scheduleFields[i])
+		if err != nil {
+			return nil, errors.Annotatef(err, "schedule %q", spec)
+		}
+		sets[i] = set
+	}
+	// Sunday may be given as either 0 or 7.
+	if sets[4][7] {
+		sets[4][0] = true
+	}
+	return &Schedule{
+		minute:        sets[0],
+		hour:          sets[1],
+		dayOfMonth:    sets[2],
+		month:         sets[3],
+		dayOfWeek:     sets[4],
+		anyDayOfMonth: parts[2] == "*",
+		anyDayOfWeek:  parts[4] == "*",
+	}, nil
+}
+
+func parseField(field string, r fieldRange) (fieldSet, error) {
+	set := make(fieldSet)
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return nil, errors.Errorf("invalid step in %s field %q", r.name, field)
+			}
+			step = n
+			item = item[:i]
+		}
+		low, high := r.min, r.max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			n, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, errors.Errorf("invalid %s field %q", r.name, field)
+			}
+			low, high = n, n
+			if len(bounds) == 2 {
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, errors.Errorf("invalid %s field %q", r.name, field)
+				}
+			} else if step > 1 {
+				// "5/15" means every 15 starting at 5.
+				high = r.max
+			}
+		}
+		if low < r.min || high > r.max || low > high {
+			return nil, errors.Errorf("%s field %q out of range %d-%d", r.name, field, r.min, r.max)
+		}
+		for v := low; v <= high; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// scheduleSearchYears bounds how far ahead Next looks for a matching
+// time, so that schedules which can never match (such as the 30th of
+// February) don't search forever.
+const scheduleSearchYears = 5
+
+// Next returns the first time strictly after t which matches the
+// schedule, or the zero time if there is none.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(scheduleSearchYears, 0, 0)
+	for t.Before(limit) {
+		if !s.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := s.dayOfMonth[t.Day()]
+	dow := s.dayOfWeek[int(t.Weekday())]
+	switch {
+	case s.anyDayOfMonth:
+		return dow
+	case s.anyDayOfWeek:
+		return dom
+	}
+	return dom || dow
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+)
+
+type ScheduleSuite struct{}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+// 2016-06-01 is a Wednesday.
+var scheduleBase = time.Date(2016, 6, 1, 10, 30, 15, 0, time.UTC)
+
+func (*ScheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		expected time.Time
+	}{{
+		spec:     "* * * * *",
+		expected: time.Date(2016, 6, 1, 10, 31, 0, 0, time.UTC),
+	}, {
+		spec:     "@hourly",
+		expected: time.Date(2016, 6, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "@daily",
+		expected: time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "@weekly",
+		expected: time.Date(2016, 6, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "*/15 * * * *",
+		expected: time.Date(2016, 6, 1, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec:     "30 2 * * *",
+		expected: time.Date(2016, 6, 2, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:     "0 9-17/4 * * 1-5",
+		expected: time.Date(2016, 6, 1, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "0 3 * * 6,7",
+		expected: time.Date(2016, 6, 4, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "0 0 1 * *",
+		expected: time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Either day field may match when both are restricted.
+		spec:     "0 0 15 * 5",
+		expected: time.Date(2016, 6, 3, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "0 0 29 2 *",
+		expected: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:     "0 0 30 2 *",
+		expected: time.Time{},
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		schedule, err := backups.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(scheduleBase), gc.Equals, test.expected)
+	}
+}
+
+func (*ScheduleSuite) TestNextIsAfter(c *gc.C) {
+	schedule, err := backups.ParseSchedule("30 10 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	at := time.Date(2016, 6, 1, 10, 30, 0, 0, time.UTC)
+	c.Check(schedule.Next(at), gc.Equals, at.AddDate(0, 0, 1))
+}
+
+func (*ScheduleSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule .*: minute field "60" out of range 0-59`,
+	}, {
+		spec: "* 5-2 * * *",
+		err:  `schedule .*: hour field "5-2" out of range 0-23`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule .*: day-of-month field "0" out of range 1-31`,
+	}, {
+		spec: "* * * jan *",
+		err:  `schedule .*: invalid month field "jan"`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule .*: invalid step in minute field "\*/0"`,
+	}, {
+		spec: "@yearly",
+		err:  `schedule "@yearly": expected 5 fields, got 1`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := backups.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
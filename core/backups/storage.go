@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+)
+
+// The storage types name the places where backup archives may be
+// kept.
+const (
+	// StorageController keeps archives in the controller's own
+	// database.
+	StorageController = "controller"
+
+	// StorageDirectory keeps archives in a directory on the
+	// controller machine, which would usually be a mount of a remote
+	// file system such as NFS.
+	StorageDirectory = "directory"
+
+	// StorageS3 keeps archives in a bucket in an S3-compatible
+	// object store.
+	StorageS3 = "s3"
+)
+
+// StorageConfig describes where backup archives are kept. Backup
+// metadata is always kept in the controller's database.
+type StorageConfig struct {
+	// Type is one of the storage types above. The empty type is
+	// the same as StorageController.
+	Type string
+
+	// Directory is the absolute path of the directory in which
+	// archives are kept, for StorageDirectory.
+	Directory string
+
+	// S3 describes the bucket in which archives are kept, for
+	// StorageS3.
+	S3 S3Config
+}
+
+// S3Config describes a bucket in an S3-compatible object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If it is empty, the
+	// Amazon S3 endpoint for Region is used.
+	Endpoint string
+
+	// Region is the name of the store's region.
+	Region string
+
+	// Bucket is the name of the bucket, which is created if it does
+	// not already exist.
+	Bucket string
+
+	// AccessKey and SecretKey are the credentials used to access
+	// the bucket.
+	AccessKey string
+	SecretKey string
+}
+
+// Validate returns an error if the config does not describe a usable
+// place to keep backup archives.
+func (c StorageConfig) Validate() error {
+	switch c.Type {
+	case "", StorageController:
+	case StorageDirectory:
+		if c.Directory == "" {
+			return errors.NotValidf("directory storage with no directory")
+		}
+		if !filepath.IsAbs(c.Directory) {
+			return errors.NotValidf("relative directory %q", c.Directory)
+		}
+	case StorageS3:
+		if c.S3.Bucket == "" {
+			return errors.NotValidf("s3 storage with no bucket")
+		}
+		if c.S3.Endpoint == "" && c.S3.Region == "" {
+			return errors.NotValidf("s3 storage with no endpoint or region")
+		}
+	default:
+		return errors.NotValidf("storage type %q", c.Type)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+)
+
+type StorageConfigSuite struct{}
+
+var _ = gc.Suite(&StorageConfigSuite{})
+
+func (*StorageConfigSuite) TestValid(c *gc.C) {
+	for i, cfg := range []backups.StorageConfig{
+		{},
+		{Type: backups.StorageController},
+		{Type: backups.StorageDirectory, Directory: "/srv/backups"},
+		{Type: backups.StorageS3, S3: backups.S3Config{Region: "us-east-1", Bucket: "backups"}},
+		{Type: backups.StorageS3, S3: backups.S3Config{Endpoint: "http://10.0.0.1:9000", Bucket: "backups"}},
+	} {
+		c.Logf("test %d: %#v", i, cfg)
+		c.Check(cfg.Validate(), gc.IsNil)
+	}
+}
+
+func (*StorageConfigSuite) TestInvalid(c *gc.C) {
+	for i, test := range []struct {
+		cfg backups.StorageConfig
+		err string
+	}{{
+		cfg: backups.StorageConfig{Type: "tape"},
+		err: `storage type "tape" not valid`,
+	}, {
+		cfg: backups.StorageConfig{Type: backups.StorageDirectory},
+		err: "directory storage with no directory not valid",
+	}, {
+		cfg: backups.StorageConfig{Type: backups.StorageDirectory, Directory: "backups"},
+		err: `relative directory "backups" not valid`,
+	}, {
+		cfg: backups.StorageConfig{Type: backups.StorageS3, S3: backups.S3Config{Region: "us-east-1"}},
+		err: "s3 storage with no bucket not valid",
+	}, {
+		cfg: backups.StorageConfig{Type: backups.StorageS3, S3: backups.S3Config{Bucket: "backups"}},
+		err: "s3 storage with no endpoint or region not valid",
+	}} {
+		c.Logf("test %d: %#v", i, test.cfg)
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
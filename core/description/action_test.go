@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ActionSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ActionSerializationSuite{})
+
+func (s *ActionSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "actions"
+	s.sliceName = "actions"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importActions(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["actions"] = []interface{}{}
+	}
+}
+
+func testActionArgs() ActionArgs {
+	return ActionArgs{
+		Id:         "some-uuid",
+		Receiver:   "wordpress/0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{"outfile": "/tmp/out"},
+		Enqueued:   time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC),
+		Started:    time.Date(2016, 5, 6, 7, 8, 10, 0, time.UTC),
+		Completed:  time.Date(2016, 5, 6, 7, 8, 11, 0, time.UTC),
+		Status:     "completed",
+		Message:    "all good",
+		Results:    map[string]interface{}{"size": "big"},
+
+		Timeout:         5 * time.Minute,
+		CancelRequested: true,
+		Messages: []ActionMessageArgs{{
+			Timestamp: time.Date(2016, 5, 6, 7, 8, 10, 0, time.UTC),
+			Message:   "dumping tables",
+		}},
+	}
+}
+
+func (s *ActionSerializationSuite) TestNewAction(c *gc.C) {
+	action := newAction(testActionArgs())
+
+	c.Check(action.Id(), gc.Equals, "some-uuid")
+	c.Check(action.Receiver(), gc.Equals, "wordpress/0")
+	c.Check(action.Name(), gc.Equals, "backup")
+	c.Check(action.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "/tmp/out"})
+	c.Check(action.Enqueued(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC))
+	c.Check(action.Started(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 10, 0, time.UTC))
+	c.Check(action.Completed(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 11, 0, time.UTC))
+	c.Check(action.Status(), gc.Equals, "completed")
+	c.Check(action.Message(), gc.Equals, "all good")
+	c.Check(action.Results(), jc.DeepEquals, map[string]interface{}{"size": "big"})
+	c.Check(action.Timeout(), gc.Equals, 5*time.Minute)
+	c.Check(action.CancelRequested(), jc.IsTrue)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Timestamp(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 10, 0, time.UTC))
+	c.Check(messages[0].Message(), gc.Equals, "dumping tables")
+}
+
+func (s *ActionSerializationSuite) TestPendingAction(c *gc.C) {
+	action := newAction(ActionArgs{
+		Id:       "some-uuid",
+		Receiver: "wordpress/0",
+		Name:     "backup",
+		Enqueued: time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC),
+		Status:   "pending",
+	})
+	c.Check(action.Started().IsZero(), jc.IsTrue)
+	c.Check(action.Completed().IsZero(), jc.IsTrue)
+	c.Check(action.Timeout(), gc.Equals, time.Duration(0))
+	c.Check(action.CancelRequested(), jc.IsFalse)
+	c.Check(action.Messages(), gc.HasLen, 0)
+}
+
+func (s *ActionSerializationSuite) TestActionValidBadTimeout(c *gc.C) {
+	action := newAction(testActionArgs())
+	action.Timeout_ = "soon"
+	err := action.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `action "some-uuid" timeout "soon" not valid`)
+}
+
+func (s *ActionSerializationSuite) TestActionValidMissingStatus(c *gc.C) {
+	args := testActionArgs()
+	args.Status = ""
+	err := newAction(args).Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `action "some-uuid" missing status not valid`)
+}
+
+func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := actions{
+		Version: 1,
+		Actions_: []*action{
+			newAction(testActionArgs()),
+			newAction(ActionArgs{
+				Id:       "other-uuid",
+				Receiver: "mysql/1",
+				Name:     "snapshot",
+				Enqueued: time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC),
+				Status:   "pending",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := importActions(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(actions, jc.DeepEquals, initial.Actions_)
+}
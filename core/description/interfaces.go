@@ -63,11 +63,49 @@ type Model interface {
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
+	Spaces() []Space
+	AddSpace(SpaceArgs) Space
+
+	Subnets() []Subnet
+	AddSubnet(SubnetArgs) Subnet
+
+	LinkLayerDevices() []LinkLayerDevice
+	AddLinkLayerDevice(LinkLayerDeviceArgs) LinkLayerDevice
+
+	IPAddresses() []IPAddress
+	AddIPAddress(IPAddressArgs) IPAddress
+
+	Storages() []Storage
+	AddStorage(StorageArgs) Storage
+
+	StoragePools() []StoragePool
+	AddStoragePool(StoragePoolArgs) StoragePool
+
+	Volumes() []Volume
+	AddVolume(VolumeArgs) Volume
+
+	Filesystems() []Filesystem
+	AddFilesystem(FilesystemArgs) Filesystem
+
+	Resources() []Resource
+	AddResource(ResourceArgs) Resource
+
+	Payloads() []Payload
+	AddPayload(PayloadArgs) Payload
+
+	Actions() []Action
+	AddAction(ActionArgs) Action
+
+	MetricBatches() []MetricBatch
+	AddMetricBatch(MetricBatchArgs) MetricBatch
+
 	Validate() error
 }
 
 // User represents a user of the model. Users are able to connect to, and
-// depending on the read only flag, modify the model.
+// depending on the read only flag, modify the model. Access records the
+// user's access level to the model when it is more specific than the read
+// only flag; it is empty for models exported before it was recorded.
 type User interface {
 	Name() names.UserTag
 	DisplayName() string
@@ -75,6 +113,7 @@ type User interface {
 	DateCreated() time.Time
 	LastConnection() time.Time
 	ReadOnly() bool
+	Access() string
 }
 
 // Address represents an IP Address of some form.
@@ -138,6 +177,9 @@ type Machine interface {
 	OpenedPorts() []OpenedPorts
 	AddOpenedPorts(OpenedPortsArgs) OpenedPorts
 
+	BlockDevices() []BlockDevice
+	AddBlockDevice(BlockDeviceArgs) BlockDevice
+
 	// THINKING: Validate() error to make sure the machine has
 	// enough stuff set, like tools, and addresses etc.
 	Validate() error
@@ -155,6 +197,20 @@ type OpenedPorts interface {
 	OpenPorts() []PortRange
 }
 
+// BlockDevice represents a block device attached to a machine.
+type BlockDevice interface {
+	Name() string
+	Links() []string
+	Label() string
+	UUID() string
+	HardwareID() string
+	BusAddress() string
+	Size() uint64
+	FilesystemType() string
+	InUse() bool
+	MountPoint() string
+}
+
 // PortRange represents one or more contiguous ports opened by a particular
 // Unit.
 type PortRange interface {
@@ -216,6 +272,8 @@ type Service interface {
 	CharmModifiedVersion() int
 	ForceCharm() bool
 	Exposed() bool
+	ExposedToCIDRs() []string
+	ExposedToSpaces() []string
 	MinUnits() int
 
 	Settings() map[string]interface{}
@@ -225,6 +283,7 @@ type Service interface {
 	LeadershipSettings() map[string]interface{}
 
 	MetricsCredentials() []byte
+	StorageConstraints() map[string]StorageConstraint
 
 	Status() Status
 	SetStatus(StatusArgs)
@@ -235,6 +294,18 @@ type Service interface {
 	Validate() error
 }
 
+// StorageConstraint represents the user-specified constraints for
+// provisioning storage instances for a service unit.
+type StorageConstraint interface {
+	// Pool is the name of the storage pool from which to provision the
+	// storage instances.
+	Pool() string
+	// Size is the required size of the storage instances, in MiB.
+	Size() uint64
+	// Count is the required number of storage instances.
+	Count() uint64
+}
+
 // Unit represents an instance of a service in a model.
 type Unit interface {
 	HasAnnotations
@@ -304,3 +375,240 @@ type Endpoint interface {
 	Settings(unitName string) map[string]interface{}
 	SetUnitSettings(unitName string, settings map[string]interface{})
 }
+
+// Space represents a network space, which is a named collection of subnets.
+type Space interface {
+	Name() string
+	Public() bool
+	ProviderID() string
+}
+
+// Subnet represents a network subnet.
+type Subnet interface {
+	CIDR() string
+	ProviderId() string
+	VLANTag() int
+	AvailabilityZone() string
+	SpaceName() string
+	AllocatableIPHigh() string
+	AllocatableIPLow() string
+}
+
+// LinkLayerDevice represents a link-layer network device of a machine.
+type LinkLayerDevice interface {
+	Name() string
+	MTU() uint
+	ProviderID() string
+	MachineID() string
+	Type() string
+	MACAddress() string
+	IsAutoStart() bool
+	IsUp() bool
+	ParentName() string
+}
+
+// IPAddress represents an IP address assigned to a link-layer device of
+// a machine.
+type IPAddress interface {
+	ProviderID() string
+	DeviceName() string
+	MachineID() string
+	SubnetCIDR() string
+	ConfigMethod() string
+	Value() string
+	DNSServers() []string
+	DNSSearchDomains() []string
+	GatewayAddress() string
+}
+
+// Storage represents the state of a unit or service-wide storage instance
+// in the model.
+type Storage interface {
+	Tag() names.StorageTag
+	Kind() string
+	// Owner returns the tag of the service or unit that owns this storage
+	// instance, or nil if the storage is detached.
+	Owner() (names.Tag, error)
+	Name() string
+
+	// Attachments returns the tags of the units the storage is attached to.
+	Attachments() []names.UnitTag
+
+	Validate() error
+}
+
+// StoragePool represents a named storage pool and its settings.
+type StoragePool interface {
+	Name() string
+	Provider() string
+	Attributes() map[string]interface{}
+}
+
+// Volume represents a volume (disk, logical volume, etc.) in the model.
+type Volume interface {
+	HasStatusHistory
+
+	Tag() names.VolumeTag
+	Storage() names.StorageTag
+	// Binding returns the tag of the entity the volume's lifecycle is
+	// bound to, or nil if the volume is not bound.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	HardwareID() string
+	VolumeID() string
+	Persistent() bool
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []VolumeAttachment
+	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
+
+	Validate() error
+}
+
+// VolumeAttachment represents a volume attached to a machine.
+type VolumeAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	ReadOnly() bool
+	DeviceName() string
+	DeviceLink() string
+	BusAddress() string
+}
+
+// Filesystem represents a filesystem in the model.
+type Filesystem interface {
+	HasStatusHistory
+
+	Tag() names.FilesystemTag
+	Volume() names.VolumeTag
+	Storage() names.StorageTag
+	// Binding returns the tag of the entity the filesystem's lifecycle is
+	// bound to, or nil if the filesystem is not bound.
+	Binding() (names.Tag, error)
+
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	FilesystemID() string
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []FilesystemAttachment
+	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
+
+	Validate() error
+}
+
+// FilesystemAttachment represents a filesystem attached to a machine.
+type FilesystemAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	MountPoint() string
+	ReadOnly() bool
+}
+
+// Resource represents a charm resource used by a service, along with the
+// revisions of it in use by the service and its units.
+type Resource interface {
+	Service() string
+	Name() string
+
+	// Revision returns the revision of the resource used by the service.
+	Revision() ResourceRevision
+
+	// CharmStoreRevision returns the latest revision of the resource
+	// known to be available in the charm store, or nil if unknown.
+	CharmStoreRevision() ResourceRevision
+	SetCharmStoreRevision(ResourceRevisionArgs)
+
+	// UnitRevisions returns the revisions of the resource that the
+	// units of the service have, keyed by unit name.
+	UnitRevisions() map[string]ResourceRevision
+	SetUnitRevision(unitName string, args ResourceRevisionArgs)
+
+	Validate() error
+}
+
+// ResourceRevision represents a single revision of a resource. A revision
+// with no fingerprint is a placeholder for content yet to be provided.
+type ResourceRevision interface {
+	Revision() int
+	Type() string
+	Path() string
+	Description() string
+	Origin() string
+	FingerprintHex() string
+	Size() int64
+	Timestamp() time.Time
+	Username() string
+}
+
+// Payload represents a payload registered by the charm of a unit.
+type Payload interface {
+	Unit() names.UnitTag
+	Name() string
+	Type() string
+	RawID() string
+	State() string
+	Labels() []string
+
+	Validate() error
+}
+
+// Action represents an action queued for, running on, or run by a unit
+// or machine.
+type Action interface {
+	Id() string
+	Receiver() string
+	Name() string
+	Parameters() map[string]interface{}
+	Enqueued() time.Time
+	Started() time.Time
+	Completed() time.Time
+	Status() string
+	Message() string
+	Results() map[string]interface{}
+	Timeout() time.Duration
+	CancelRequested() bool
+	// Messages returns the progress messages logged by the action,
+	// oldest first.
+	Messages() []ActionMessage
+
+	Validate() error
+}
+
+// ActionMessage represents a progress message logged by an Action.
+type ActionMessage interface {
+	Timestamp() time.Time
+	Message() string
+}
+
+// MetricBatch represents a batch of metrics collected from a unit that
+// have not yet been sent to the collector.
+type MetricBatch interface {
+	UUID() string
+	Unit() names.UnitTag
+	CharmURL() string
+	Created() time.Time
+	Credentials() []byte
+	Metrics() []Metric
+
+	Validate() error
+}
+
+// Metric represents a single metric value in a MetricBatch.
+type Metric interface {
+	Key() string
+	Value() string
+	Time() time.Time
+}
@@ -0,0 +1,84 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type IPAddressSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&IPAddressSerializationSuite{})
+
+func (s *IPAddressSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "ip-addresses"
+	s.sliceName = "ip-addresses"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importIPAddresses(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["ip-addresses"] = []interface{}{}
+	}
+}
+
+func testIPAddressArgs() IPAddressArgs {
+	return IPAddressArgs{
+		ProviderID:       "magic",
+		DeviceName:       "eth0",
+		MachineID:        "42",
+		SubnetCIDR:       "10.0.0.0/24",
+		ConfigMethod:     "static",
+		Value:            "10.0.0.4",
+		DNSServers:       []string{"10.1.0.1", "10.2.0.1"},
+		DNSSearchDomains: []string{"bam", "mam"},
+		GatewayAddress:   "10.0.0.1",
+	}
+}
+
+func (s *IPAddressSerializationSuite) TestNewIPAddress(c *gc.C) {
+	args := testIPAddressArgs()
+	addr := newIPAddress(args)
+	c.Assert(addr.ProviderID(), gc.Equals, args.ProviderID)
+	c.Assert(addr.DeviceName(), gc.Equals, args.DeviceName)
+	c.Assert(addr.MachineID(), gc.Equals, args.MachineID)
+	c.Assert(addr.SubnetCIDR(), gc.Equals, args.SubnetCIDR)
+	c.Assert(addr.ConfigMethod(), gc.Equals, args.ConfigMethod)
+	c.Assert(addr.Value(), gc.Equals, args.Value)
+	c.Assert(addr.DNSServers(), jc.DeepEquals, args.DNSServers)
+	c.Assert(addr.DNSSearchDomains(), jc.DeepEquals, args.DNSSearchDomains)
+	c.Assert(addr.GatewayAddress(), gc.Equals, args.GatewayAddress)
+}
+
+func (s *IPAddressSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := ipaddresses{
+		Version: 1,
+		IPAddresses_: []*ipaddress{
+			newIPAddress(testIPAddressArgs()),
+			newIPAddress(IPAddressArgs{
+				DeviceName:   "eth1",
+				MachineID:    "42",
+				SubnetCIDR:   "10.0.1.0/24",
+				ConfigMethod: "dynamic",
+				Value:        "10.0.1.4",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses, err := importIPAddresses(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(addresses, jc.DeepEquals, initial.IPAddresses_)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
 	"github.com/juju/schema"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 )
 
@@ -42,6 +43,8 @@ type machine struct {
 
 	OpenedPorts_ *versionedOpenedPorts `yaml:"opened-ports,omitempty"`
 
+	BlockDevices_ *blockdevices `yaml:"block-devices,omitempty"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
@@ -253,6 +256,15 @@ func (m *machine) AddContainer(args MachineArgs) Machine {
 	return container
 }
 
+// machineIds returns the ids of the machine and all of its containers.
+func (m *machine) machineIds() set.Strings {
+	result := set.NewStrings(m.Id_)
+	for _, container := range m.Containers_ {
+		result = result.Union(container.machineIds())
+	}
+	return result
+}
+
 // OpenedPorts implements Machine.
 func (m *machine) OpenedPorts() []OpenedPorts {
 	if m.OpenedPorts_ == nil {
@@ -275,6 +287,33 @@ func (m *machine) AddOpenedPorts(args OpenedPortsArgs) OpenedPorts {
 	return ports
 }
 
+// BlockDevices implements Machine.
+func (m *machine) BlockDevices() []BlockDevice {
+	if m.BlockDevices_ == nil {
+		return nil
+	}
+	var result []BlockDevice
+	for _, device := range m.BlockDevices_.BlockDevices_ {
+		result = append(result, device)
+	}
+	return result
+}
+
+// AddBlockDevice implements Machine.
+func (m *machine) AddBlockDevice(args BlockDeviceArgs) BlockDevice {
+	if m.BlockDevices_ == nil {
+		m.BlockDevices_ = &blockdevices{Version: 1}
+	}
+	return m.BlockDevices_.add(args)
+}
+
+func (m *machine) setBlockDevices(devices []*blockdevice) {
+	m.BlockDevices_ = &blockdevices{
+		Version:       1,
+		BlockDevices_: devices,
+	}
+}
+
 func (m *machine) setOpenedPorts(portsList []*openedPorts) {
 	m.OpenedPorts_ = &versionedOpenedPorts{
 		Version:      1,
@@ -374,6 +413,7 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 		"tools":                schema.StringMap(schema.Any()),
 		"containers":           schema.List(schema.StringMap(schema.Any())),
 		"opened-ports":         schema.StringMap(schema.Any()),
+		"block-devices":        schema.StringMap(schema.Any()),
 
 		"provider-addresses":        schema.List(schema.StringMap(schema.Any())),
 		"machine-addresses":         schema.List(schema.StringMap(schema.Any())),
@@ -389,6 +429,7 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 		"instance":                  schema.Omit,
 		"supported-containers":      schema.Omit,
 		"opened-ports":              schema.Omit,
+		"block-devices":             schema.Omit,
 		"provider-addresses":        schema.Omit,
 		"machine-addresses":         schema.Omit,
 		"preferred-public-address":  schema.Omit,
@@ -510,6 +551,14 @@ func importMachineV1(source map[string]interface{}) (*machine, error) {
 		result.setOpenedPorts(portsList)
 	}
 
+	if devicesMap, ok := valid["block-devices"]; ok {
+		devices, err := importBlockDevices(devicesMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setBlockDevices(devices)
+	}
+
 	return result, nil
 
 }
@@ -230,6 +230,30 @@ func (s *MachineSerializationSuite) TestOpenedPorts(c *gc.C) {
 	s.AssertPortRange(c, opened[0], args[1].OpenedPorts[0])
 }
 
+func (s *MachineSerializationSuite) TestBlockDevices(c *gc.C) {
+	initial := minimalMachine("42")
+	args := BlockDeviceArgs{
+		Name:           "sda",
+		Links:          []string{"/dev/disk/by-id/foo"},
+		Label:          "root",
+		UUID:           "some-uuid",
+		HardwareID:     "hw-id",
+		BusAddress:     "scsi@0:0.0.0",
+		Size:           1024,
+		FilesystemType: "ext4",
+		InUse:          true,
+		MountPoint:     "/",
+	}
+	initial.AddBlockDevice(args)
+	initial.AddBlockDevice(BlockDeviceArgs{Name: "sdb", Size: 2048})
+
+	machine := s.exportImport(c, initial)
+	devices := machine.BlockDevices()
+	c.Assert(devices, gc.HasLen, 2)
+	c.Assert(devices[0], jc.DeepEquals, newBlockDevice(args))
+	c.Assert(devices[1], jc.DeepEquals, newBlockDevice(BlockDeviceArgs{Name: "sdb", Size: 2048}))
+}
+
 func (s *MachineSerializationSuite) TestAnnotations(c *gc.C) {
 	initial := minimalMachine("42")
 	annotations := map[string]string{
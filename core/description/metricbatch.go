@@ -0,0 +1,301 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/schema"
+)
+
+type metricbatches struct {
+	Version        int            `yaml:"version"`
+	MetricBatches_ []*metricbatch `yaml:"metric-batches"`
+}
+
+type metricbatch struct {
+	UUID_     string    `yaml:"uuid"`
+	Unit_     string    `yaml:"unit"`
+	CharmURL_ string    `yaml:"charm-url"`
+	Created_  time.Time `yaml:"created"`
+	// Credentials are opaque bytes, so they are stored base64 encoded
+	// to keep the serialized form readable.
+	Credentials_ string `yaml:"credentials,omitempty"`
+
+	Metrics_ metrics `yaml:"metrics"`
+}
+
+type metrics struct {
+	Version  int       `yaml:"version"`
+	Metrics_ []*metric `yaml:"metrics"`
+}
+
+type metric struct {
+	Key_   string    `yaml:"key"`
+	Value_ string    `yaml:"value"`
+	Time_  time.Time `yaml:"time"`
+}
+
+// MetricBatchArgs is an argument struct used to add a batch of unsent
+// metrics to the Model.
+type MetricBatchArgs struct {
+	UUID        string
+	Unit        names.UnitTag
+	CharmURL    string
+	Created     time.Time
+	Credentials []byte
+	Metrics     []MetricArgs
+}
+
+// MetricArgs is an argument struct used to describe a single metric
+// in a batch.
+type MetricArgs struct {
+	Key   string
+	Value string
+	Time  time.Time
+}
+
+func newMetricBatch(args MetricBatchArgs) *metricbatch {
+	b := &metricbatch{
+		UUID_:     args.UUID,
+		Unit_:     args.Unit.Id(),
+		CharmURL_: args.CharmURL,
+		Created_:  args.Created,
+	}
+	if len(args.Credentials) > 0 {
+		b.Credentials_ = base64.StdEncoding.EncodeToString(args.Credentials)
+	}
+	var metricList []*metric
+	for _, m := range args.Metrics {
+		metricList = append(metricList, &metric{
+			Key_:   m.Key,
+			Value_: m.Value,
+			Time_:  m.Time,
+		})
+	}
+	b.setMetrics(metricList)
+	return b
+}
+
+// UUID implements MetricBatch.
+func (b *metricbatch) UUID() string {
+	return b.UUID_
+}
+
+// Unit implements MetricBatch.
+func (b *metricbatch) Unit() names.UnitTag {
+	return names.NewUnitTag(b.Unit_)
+}
+
+// CharmURL implements MetricBatch.
+func (b *metricbatch) CharmURL() string {
+	return b.CharmURL_
+}
+
+// Created implements MetricBatch.
+func (b *metricbatch) Created() time.Time {
+	return b.Created_
+}
+
+// Credentials implements MetricBatch.
+func (b *metricbatch) Credentials() []byte {
+	if b.Credentials_ == "" {
+		return nil
+	}
+	// Validate has already checked that the credentials decode.
+	credentials, _ := base64.StdEncoding.DecodeString(b.Credentials_)
+	return credentials
+}
+
+// Metrics implements MetricBatch.
+func (b *metricbatch) Metrics() []Metric {
+	var result []Metric
+	for _, metric := range b.Metrics_.Metrics_ {
+		result = append(result, metric)
+	}
+	return result
+}
+
+func (b *metricbatch) setMetrics(metricList []*metric) {
+	b.Metrics_ = metrics{
+		Version:  1,
+		Metrics_: metricList,
+	}
+}
+
+// Validate implements MetricBatch.
+func (b *metricbatch) Validate() error {
+	if b.UUID_ == "" {
+		return errors.NotValidf("metric batch missing uuid")
+	}
+	if b.Unit_ == "" {
+		return errors.NotValidf("metric batch %q missing unit", b.UUID_)
+	}
+	if b.CharmURL_ == "" {
+		return errors.NotValidf("metric batch %q missing charm url", b.UUID_)
+	}
+	if _, err := base64.StdEncoding.DecodeString(b.Credentials_); err != nil {
+		return errors.Wrap(err, errors.NotValidf("metric batch %q credentials", b.UUID_))
+	}
+	return nil
+}
+
+// Key implements Metric.
+func (m *metric) Key() string {
+	return m.Key_
+}
+
+// Value implements Metric.
+func (m *metric) Value() string {
+	return m.Value_
+}
+
+// Time implements Metric.
+func (m *metric) Time() time.Time {
+	return m.Time_
+}
+
+func importMetricBatches(source map[string]interface{}) ([]*metricbatch, error) {
+	checker := versionedChecker("metric-batches")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "metric batches version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := metricBatchDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["metric-batches"].([]interface{})
+	return importMetricBatchList(sourceList, importFunc)
+}
+
+func importMetricBatchList(sourceList []interface{}, importFunc metricBatchDeserializationFunc) ([]*metricbatch, error) {
+	result := make([]*metricbatch, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for metric batch %d, %T", i, value)
+		}
+		batch, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "metric batch %d", i)
+		}
+		result = append(result, batch)
+	}
+	return result, nil
+}
+
+type metricBatchDeserializationFunc func(map[string]interface{}) (*metricbatch, error)
+
+var metricBatchDeserializationFuncs = map[int]metricBatchDeserializationFunc{
+	1: importMetricBatchV1,
+}
+
+func importMetricBatchV1(source map[string]interface{}) (*metricbatch, error) {
+	fields := schema.Fields{
+		"uuid":        schema.String(),
+		"unit":        schema.String(),
+		"charm-url":   schema.String(),
+		"created":     schema.Time(),
+		"credentials": schema.String(),
+		"metrics":     schema.StringMap(schema.Any()),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"credentials": "",
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "metric batch v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	result := &metricbatch{
+		UUID_:        valid["uuid"].(string),
+		Unit_:        valid["unit"].(string),
+		CharmURL_:    valid["charm-url"].(string),
+		Created_:     valid["created"].(time.Time),
+		Credentials_: valid["credentials"].(string),
+	}
+
+	metricList, err := importMetrics(valid["metrics"].(map[string]interface{}))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.setMetrics(metricList)
+
+	return result, nil
+}
+
+func importMetrics(source map[string]interface{}) ([]*metric, error) {
+	checker := versionedChecker("metrics")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "metrics version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := metricDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["metrics"].([]interface{})
+	return importMetricList(sourceList, importFunc)
+}
+
+func importMetricList(sourceList []interface{}, importFunc metricDeserializationFunc) ([]*metric, error) {
+	result := make([]*metric, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for metric %d, %T", i, value)
+		}
+		metric, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "metric %d", i)
+		}
+		result = append(result, metric)
+	}
+	return result, nil
+}
+
+type metricDeserializationFunc func(map[string]interface{}) (*metric, error)
+
+var metricDeserializationFuncs = map[int]metricDeserializationFunc{
+	1: importMetricV1,
+}
+
+func importMetricV1(source map[string]interface{}) (*metric, error) {
+	fields := schema.Fields{
+		"key":   schema.String(),
+		"value": schema.String(),
+		"time":  schema.Time(),
+	}
+	checker := schema.FieldMap(fields, nil) // no defaults
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "metric v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	return &metric{
+		Key_:   valid["key"].(string),
+		Value_: valid["value"].(string),
+		Time_:  valid["time"].(time.Time),
+	}, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type MetricBatchSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&MetricBatchSerializationSuite{})
+
+func (s *MetricBatchSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "metric batches"
+	s.sliceName = "metric-batches"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importMetricBatches(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["metric-batches"] = []interface{}{}
+	}
+}
+
+func testMetricBatchArgs() MetricBatchArgs {
+	return MetricBatchArgs{
+		UUID:        "some-uuid",
+		Unit:        names.NewUnitTag("wordpress/0"),
+		CharmURL:    "cs:trusty/wordpress-4",
+		Created:     time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC),
+		Credentials: []byte{0x00, 0xff, 's', 'e', 'c', 'r', 'e', 't'},
+		Metrics: []MetricArgs{{
+			Key:   "pings",
+			Value: "5",
+			Time:  time.Date(2016, 5, 6, 7, 8, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func (s *MetricBatchSerializationSuite) TestNewMetricBatch(c *gc.C) {
+	batch := newMetricBatch(testMetricBatchArgs())
+
+	c.Check(batch.UUID(), gc.Equals, "some-uuid")
+	c.Check(batch.Unit(), gc.Equals, names.NewUnitTag("wordpress/0"))
+	c.Check(batch.CharmURL(), gc.Equals, "cs:trusty/wordpress-4")
+	c.Check(batch.Created(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC))
+	c.Check(batch.Credentials(), jc.DeepEquals, []byte{0x00, 0xff, 's', 'e', 'c', 'r', 'e', 't'})
+	metrics := batch.Metrics()
+	c.Assert(metrics, gc.HasLen, 1)
+	c.Check(metrics[0].Key(), gc.Equals, "pings")
+	c.Check(metrics[0].Value(), gc.Equals, "5")
+	c.Check(metrics[0].Time(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 0, 0, time.UTC))
+}
+
+func (s *MetricBatchSerializationSuite) TestMetricBatchValidBadCredentials(c *gc.C) {
+	batch := newMetricBatch(testMetricBatchArgs())
+	batch.Credentials_ = "not base64!"
+	err := batch.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `metric batch "some-uuid" credentials not valid`)
+}
+
+func (s *MetricBatchSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := metricbatches{
+		Version: 1,
+		MetricBatches_: []*metricbatch{
+			newMetricBatch(testMetricBatchArgs()),
+			newMetricBatch(MetricBatchArgs{
+				UUID:     "other-uuid",
+				Unit:     names.NewUnitTag("mysql/1"),
+				CharmURL: "cs:trusty/mysql-1",
+				Created:  time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC),
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	batches, err := importMetricBatches(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(batches, jc.DeepEquals, initial.MetricBatches_)
+}
@@ -40,6 +40,18 @@ func NewModel(args ModelArgs) Model {
 	m.setMachines(nil)
 	m.setServices(nil)
 	m.setRelations(nil)
+	m.setSpaces(nil)
+	m.setSubnets(nil)
+	m.setLinkLayerDevices(nil)
+	m.setIPAddresses(nil)
+	m.setStorages(nil)
+	m.setStoragePools(nil)
+	m.setVolumes(nil)
+	m.setFilesystems(nil)
+	m.setResources(nil)
+	m.setPayloads(nil)
+	m.setActions(nil)
+	m.setMetricBatches(nil)
 	return m
 }
 
@@ -80,15 +92,26 @@ type model struct {
 	Services_  services  `yaml:"services"`
 	Relations_ relations `yaml:"relations"`
 
+	Spaces_           spaces           `yaml:"spaces"`
+	Subnets_          subnets          `yaml:"subnets"`
+	LinkLayerDevices_ linklayerdevices `yaml:"link-layer-devices"`
+	IPAddresses_      ipaddresses      `yaml:"ip-addresses"`
+
+	Storages_     storages     `yaml:"storages"`
+	StoragePools_ storagepools `yaml:"storage-pools"`
+	Volumes_      volumes      `yaml:"volumes"`
+	Filesystems_  filesystems  `yaml:"filesystems"`
+
+	Resources_     resources     `yaml:"resources"`
+	Payloads_      payloads      `yaml:"payloads"`
+	Actions_       actions       `yaml:"actions"`
+	MetricBatches_ metricbatches `yaml:"metric-batches"`
+
 	Sequences_ map[string]int `yaml:"sequences"`
 
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
-
-	// TODO:
-	// Spaces
-	// Storage
 }
 
 func (m *model) Tag() names.ModelTag {
@@ -237,6 +260,282 @@ func (m *model) setRelations(relationList []*relation) {
 	}
 }
 
+// Spaces implements Model.
+func (m *model) Spaces() []Space {
+	var result []Space
+	for _, space := range m.Spaces_.Spaces_ {
+		result = append(result, space)
+	}
+	return result
+}
+
+// AddSpace implements Model.
+func (m *model) AddSpace(args SpaceArgs) Space {
+	space := newSpace(args)
+	m.Spaces_.Spaces_ = append(m.Spaces_.Spaces_, space)
+	return space
+}
+
+func (m *model) setSpaces(spaceList []*space) {
+	m.Spaces_ = spaces{
+		Version: 1,
+		Spaces_: spaceList,
+	}
+}
+
+// Subnets implements Model.
+func (m *model) Subnets() []Subnet {
+	var result []Subnet
+	for _, subnet := range m.Subnets_.Subnets_ {
+		result = append(result, subnet)
+	}
+	return result
+}
+
+// AddSubnet implements Model.
+func (m *model) AddSubnet(args SubnetArgs) Subnet {
+	subnet := newSubnet(args)
+	m.Subnets_.Subnets_ = append(m.Subnets_.Subnets_, subnet)
+	return subnet
+}
+
+func (m *model) setSubnets(subnetList []*subnet) {
+	m.Subnets_ = subnets{
+		Version:  1,
+		Subnets_: subnetList,
+	}
+}
+
+// LinkLayerDevices implements Model.
+func (m *model) LinkLayerDevices() []LinkLayerDevice {
+	var result []LinkLayerDevice
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		result = append(result, device)
+	}
+	return result
+}
+
+// AddLinkLayerDevice implements Model.
+func (m *model) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	device := newLinkLayerDevice(args)
+	m.LinkLayerDevices_.LinkLayerDevices_ = append(m.LinkLayerDevices_.LinkLayerDevices_, device)
+	return device
+}
+
+func (m *model) setLinkLayerDevices(deviceList []*linklayerdevice) {
+	m.LinkLayerDevices_ = linklayerdevices{
+		Version:           1,
+		LinkLayerDevices_: deviceList,
+	}
+}
+
+// IPAddresses implements Model.
+func (m *model) IPAddresses() []IPAddress {
+	var result []IPAddress
+	for _, addr := range m.IPAddresses_.IPAddresses_ {
+		result = append(result, addr)
+	}
+	return result
+}
+
+// AddIPAddress implements Model.
+func (m *model) AddIPAddress(args IPAddressArgs) IPAddress {
+	addr := newIPAddress(args)
+	m.IPAddresses_.IPAddresses_ = append(m.IPAddresses_.IPAddresses_, addr)
+	return addr
+}
+
+func (m *model) setIPAddresses(addressesList []*ipaddress) {
+	m.IPAddresses_ = ipaddresses{
+		Version:      1,
+		IPAddresses_: addressesList,
+	}
+}
+
+// Storages implements Model.
+func (m *model) Storages() []Storage {
+	var result []Storage
+	for _, storage := range m.Storages_.Storages_ {
+		result = append(result, storage)
+	}
+	return result
+}
+
+// AddStorage implements Model.
+func (m *model) AddStorage(args StorageArgs) Storage {
+	storage := newStorage(args)
+	m.Storages_.Storages_ = append(m.Storages_.Storages_, storage)
+	return storage
+}
+
+func (m *model) setStorages(storageList []*storage) {
+	m.Storages_ = storages{
+		Version:   1,
+		Storages_: storageList,
+	}
+}
+
+// StoragePools implements Model.
+func (m *model) StoragePools() []StoragePool {
+	var result []StoragePool
+	for _, pool := range m.StoragePools_.Pools_ {
+		result = append(result, pool)
+	}
+	return result
+}
+
+// AddStoragePool implements Model.
+func (m *model) AddStoragePool(args StoragePoolArgs) StoragePool {
+	pool := newStoragePool(args)
+	m.StoragePools_.Pools_ = append(m.StoragePools_.Pools_, pool)
+	return pool
+}
+
+func (m *model) setStoragePools(poolList []*storagepool) {
+	m.StoragePools_ = storagepools{
+		Version: 1,
+		Pools_:  poolList,
+	}
+}
+
+// Volumes implements Model.
+func (m *model) Volumes() []Volume {
+	var result []Volume
+	for _, volume := range m.Volumes_.Volumes_ {
+		result = append(result, volume)
+	}
+	return result
+}
+
+// AddVolume implements Model.
+func (m *model) AddVolume(args VolumeArgs) Volume {
+	volume := newVolume(args)
+	m.Volumes_.Volumes_ = append(m.Volumes_.Volumes_, volume)
+	return volume
+}
+
+func (m *model) setVolumes(volumeList []*volume) {
+	m.Volumes_ = volumes{
+		Version:  1,
+		Volumes_: volumeList,
+	}
+}
+
+// Filesystems implements Model.
+func (m *model) Filesystems() []Filesystem {
+	var result []Filesystem
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		result = append(result, filesystem)
+	}
+	return result
+}
+
+// AddFilesystem implements Model.
+func (m *model) AddFilesystem(args FilesystemArgs) Filesystem {
+	filesystem := newFilesystem(args)
+	m.Filesystems_.Filesystems_ = append(m.Filesystems_.Filesystems_, filesystem)
+	return filesystem
+}
+
+func (m *model) setFilesystems(filesystemList []*filesystem) {
+	m.Filesystems_ = filesystems{
+		Version:      1,
+		Filesystems_: filesystemList,
+	}
+}
+
+// Resources implements Model.
+func (m *model) Resources() []Resource {
+	var result []Resource
+	for _, resource := range m.Resources_.Resources_ {
+		result = append(result, resource)
+	}
+	return result
+}
+
+// AddResource implements Model.
+func (m *model) AddResource(args ResourceArgs) Resource {
+	resource := newResource(args)
+	m.Resources_.Resources_ = append(m.Resources_.Resources_, resource)
+	return resource
+}
+
+func (m *model) setResources(resourceList []*resource) {
+	m.Resources_ = resources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
+// Payloads implements Model.
+func (m *model) Payloads() []Payload {
+	var result []Payload
+	for _, payload := range m.Payloads_.Payloads_ {
+		result = append(result, payload)
+	}
+	return result
+}
+
+// AddPayload implements Model.
+func (m *model) AddPayload(args PayloadArgs) Payload {
+	payload := newPayload(args)
+	m.Payloads_.Payloads_ = append(m.Payloads_.Payloads_, payload)
+	return payload
+}
+
+func (m *model) setPayloads(payloadList []*payload) {
+	m.Payloads_ = payloads{
+		Version:   1,
+		Payloads_: payloadList,
+	}
+}
+
+// Actions implements Model.
+func (m *model) Actions() []Action {
+	var result []Action
+	for _, action := range m.Actions_.Actions_ {
+		result = append(result, action)
+	}
+	return result
+}
+
+// AddAction implements Model.
+func (m *model) AddAction(args ActionArgs) Action {
+	action := newAction(args)
+	m.Actions_.Actions_ = append(m.Actions_.Actions_, action)
+	return action
+}
+
+func (m *model) setActions(actionList []*action) {
+	m.Actions_ = actions{
+		Version:  1,
+		Actions_: actionList,
+	}
+}
+
+// MetricBatches implements Model.
+func (m *model) MetricBatches() []MetricBatch {
+	var result []MetricBatch
+	for _, batch := range m.MetricBatches_.MetricBatches_ {
+		result = append(result, batch)
+	}
+	return result
+}
+
+// AddMetricBatch implements Model.
+func (m *model) AddMetricBatch(args MetricBatchArgs) MetricBatch {
+	batch := newMetricBatch(args)
+	m.MetricBatches_.MetricBatches_ = append(m.MetricBatches_.MetricBatches_, batch)
+	return batch
+}
+
+func (m *model) setMetricBatches(batchList []*metricbatch) {
+	m.MetricBatches_ = metricbatches{
+		Version:        1,
+		MetricBatches_: batchList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -268,6 +567,7 @@ func (m *model) Validate() error {
 	}
 
 	unitsWithOpenPorts := set.NewStrings()
+	allMachines := set.NewStrings()
 	for _, machine := range m.Machines_.Machines_ {
 		if err := machine.Validate(); err != nil {
 			return errors.Trace(err)
@@ -277,6 +577,7 @@ func (m *model) Validate() error {
 				unitsWithOpenPorts.Add(pr.UnitName())
 			}
 		}
+		allMachines = allMachines.Union(machine.machineIds())
 	}
 	allUnits := set.NewStrings()
 	for _, service := range m.Services_.Services_ {
@@ -292,9 +593,143 @@ func (m *model) Validate() error {
 		return errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())
 	}
 
+	if err := m.validateNetworking(allMachines); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validateStorage(allMachines, allUnits); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validateUnitEntities(allMachines, allUnits); err != nil {
+		return errors.Trace(err)
+	}
 	return m.validateRelations()
 }
 
+// validateNetworking makes sure that the subnets refer to known spaces, and
+// that the link-layer devices and IP addresses refer to known machines and
+// devices.
+func (m *model) validateNetworking(allMachines set.Strings) error {
+	allSpaces := set.NewStrings()
+	for _, space := range m.Spaces_.Spaces_ {
+		allSpaces.Add(space.Name())
+	}
+	for _, subnet := range m.Subnets_.Subnets_ {
+		if name := subnet.SpaceName(); name != "" && !allSpaces.Contains(name) {
+			return errors.Errorf("subnet %q references unknown space %q", subnet.CIDR(), name)
+		}
+	}
+	allDevices := set.NewStrings()
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		if !allMachines.Contains(device.MachineID()) {
+			return errors.Errorf("device %q references unknown machine %q", device.Name(), device.MachineID())
+		}
+		allDevices.Add(device.MachineID() + " " + device.Name())
+	}
+	for _, addr := range m.IPAddresses_.IPAddresses_ {
+		if !allMachines.Contains(addr.MachineID()) {
+			return errors.Errorf("ip address %q references unknown machine %q", addr.Value(), addr.MachineID())
+		}
+		if !allDevices.Contains(addr.MachineID() + " " + addr.DeviceName()) {
+			return errors.Errorf("ip address %q references unknown device %q on machine %q", addr.Value(), addr.DeviceName(), addr.MachineID())
+		}
+	}
+	return nil
+}
+
+// validateStorage makes sure that the storage instances are attached to
+// known units, and that the volumes and filesystems are attached to known
+// machines and assigned to known storage instances.
+func (m *model) validateStorage(allMachines, allUnits set.Strings) error {
+	allStorage := set.NewStrings()
+	for _, storage := range m.Storages_.Storages_ {
+		if err := storage.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allStorage.Add(storage.ID_)
+		for _, unit := range storage.Attachments_ {
+			if !allUnits.Contains(unit) {
+				return errors.Errorf("storage %q attached to unknown unit %q", storage.ID_, unit)
+			}
+		}
+	}
+	allVolumes := set.NewStrings()
+	for _, volume := range m.Volumes_.Volumes_ {
+		if err := volume.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allVolumes.Add(volume.ID_)
+		if volume.StorageID_ != "" && !allStorage.Contains(volume.StorageID_) {
+			return errors.Errorf("volume %q references unknown storage %q", volume.ID_, volume.StorageID_)
+		}
+		for _, attachment := range volume.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.Errorf("volume %q attached to unknown machine %q", volume.ID_, attachment.MachineID_)
+			}
+		}
+	}
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		if err := filesystem.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if filesystem.StorageID_ != "" && !allStorage.Contains(filesystem.StorageID_) {
+			return errors.Errorf("filesystem %q references unknown storage %q", filesystem.ID_, filesystem.StorageID_)
+		}
+		if filesystem.VolumeID_ != "" && !allVolumes.Contains(filesystem.VolumeID_) {
+			return errors.Errorf("filesystem %q references unknown volume %q", filesystem.ID_, filesystem.VolumeID_)
+		}
+		for _, attachment := range filesystem.Attachments_.Attachments_ {
+			if !allMachines.Contains(attachment.MachineID_) {
+				return errors.Errorf("filesystem %q attached to unknown machine %q", filesystem.ID_, attachment.MachineID_)
+			}
+		}
+	}
+	return nil
+}
+
+// validateUnitEntities makes sure that the resources refer to known
+// services and units, that the payloads and metric batches refer to known
+// units, and that the actions are for known units or machines.
+func (m *model) validateUnitEntities(allMachines, allUnits set.Strings) error {
+	for _, resource := range m.Resources_.Resources_ {
+		if err := resource.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if m.service(resource.Service_) == nil {
+			return errors.Errorf("resource %q references unknown service %q", resource.Name_, resource.Service_)
+		}
+		for unit := range resource.UnitRevisions_ {
+			if !allUnits.Contains(unit) {
+				return errors.Errorf("resource %q references unknown unit %q", resource.Name_, unit)
+			}
+		}
+	}
+	for _, payload := range m.Payloads_.Payloads_ {
+		if err := payload.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !allUnits.Contains(payload.Unit_) {
+			return errors.Errorf("payload %q references unknown unit %q", payload.Name_, payload.Unit_)
+		}
+	}
+	for _, action := range m.Actions_.Actions_ {
+		if err := action.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !allUnits.Contains(action.Receiver_) && !allMachines.Contains(action.Receiver_) {
+			return errors.Errorf("action %q references unknown receiver %q", action.Id_, action.Receiver_)
+		}
+	}
+	for _, batch := range m.MetricBatches_.MetricBatches_ {
+		if err := batch.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !allUnits.Contains(batch.Unit_) {
+			return errors.Errorf("metric batch %q references unknown unit %q", batch.UUID_, batch.Unit_)
+		}
+	}
+	return nil
+}
+
 // validateRelations makes sure that for each endpoint in each relation there
 // are settings for all units of that service for that endpoint.
 func (m *model) validateRelations() error {
@@ -354,11 +789,43 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 		"services":     schema.StringMap(schema.Any()),
 		"relations":    schema.StringMap(schema.Any()),
 		"sequences":    schema.StringMap(schema.Int()),
+
+		"spaces":             schema.StringMap(schema.Any()),
+		"subnets":            schema.StringMap(schema.Any()),
+		"link-layer-devices": schema.StringMap(schema.Any()),
+		"ip-addresses":       schema.StringMap(schema.Any()),
+		"storages":           schema.StringMap(schema.Any()),
+		"storage-pools":      schema.StringMap(schema.Any()),
+		"volumes":            schema.StringMap(schema.Any()),
+		"filesystems":        schema.StringMap(schema.Any()),
+
+		"resources":      schema.StringMap(schema.Any()),
+		"payloads":       schema.StringMap(schema.Any()),
+		"actions":        schema.StringMap(schema.Any()),
+		"metric-batches": schema.StringMap(schema.Any()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
 		"latest-tools": schema.Omit,
 		"blocks":       schema.Omit,
+
+		// Networking and storage were added to the v1 format after
+		// the initial release, so they may be missing from older
+		// serialized models.
+		"spaces":             schema.Omit,
+		"subnets":            schema.Omit,
+		"link-layer-devices": schema.Omit,
+		"ip-addresses":       schema.Omit,
+		"storages":           schema.Omit,
+		"storage-pools":      schema.Omit,
+		"volumes":            schema.Omit,
+		"filesystems":        schema.Omit,
+
+		// As are resources, payloads, actions and unsent metrics.
+		"resources":      schema.Omit,
+		"payloads":       schema.Omit,
+		"actions":        schema.Omit,
+		"metric-batches": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -429,5 +896,113 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setRelations(relations)
 
+	result.setSpaces(nil)
+	if spaceMap, ok := valid["spaces"]; ok {
+		spaces, err := importSpaces(spaceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "spaces")
+		}
+		result.setSpaces(spaces)
+	}
+
+	result.setSubnets(nil)
+	if subnetMap, ok := valid["subnets"]; ok {
+		subnets, err := importSubnets(subnetMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "subnets")
+		}
+		result.setSubnets(subnets)
+	}
+
+	result.setLinkLayerDevices(nil)
+	if deviceMap, ok := valid["link-layer-devices"]; ok {
+		devices, err := importLinkLayerDevices(deviceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "link-layer-devices")
+		}
+		result.setLinkLayerDevices(devices)
+	}
+
+	result.setIPAddresses(nil)
+	if addressMap, ok := valid["ip-addresses"]; ok {
+		addresses, err := importIPAddresses(addressMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "ip-addresses")
+		}
+		result.setIPAddresses(addresses)
+	}
+
+	result.setStorages(nil)
+	if storageMap, ok := valid["storages"]; ok {
+		storages, err := importStorages(storageMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storages")
+		}
+		result.setStorages(storages)
+	}
+
+	result.setStoragePools(nil)
+	if poolMap, ok := valid["storage-pools"]; ok {
+		pools, err := importStoragePools(poolMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storage-pools")
+		}
+		result.setStoragePools(pools)
+	}
+
+	result.setVolumes(nil)
+	if volumeMap, ok := valid["volumes"]; ok {
+		volumes, err := importVolumes(volumeMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volumes")
+		}
+		result.setVolumes(volumes)
+	}
+
+	result.setFilesystems(nil)
+	if filesystemMap, ok := valid["filesystems"]; ok {
+		filesystems, err := importFilesystems(filesystemMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "filesystems")
+		}
+		result.setFilesystems(filesystems)
+	}
+
+	result.setResources(nil)
+	if resourceMap, ok := valid["resources"]; ok {
+		resources, err := importResources(resourceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "resources")
+		}
+		result.setResources(resources)
+	}
+
+	result.setPayloads(nil)
+	if payloadMap, ok := valid["payloads"]; ok {
+		payloads, err := importPayloads(payloadMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "payloads")
+		}
+		result.setPayloads(payloads)
+	}
+
+	result.setActions(nil)
+	if actionMap, ok := valid["actions"]; ok {
+		actions, err := importActions(actionMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "actions")
+		}
+		result.setActions(actions)
+	}
+
+	result.setMetricBatches(nil)
+	if batchMap, ok := valid["metric-batches"]; ok {
+		batches, err := importMetricBatches(batchMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "metric-batches")
+		}
+		result.setMetricBatches(batches)
+	}
+
 	return result, nil
 }
@@ -326,3 +326,396 @@ func (s *ModelSerializationSuite) TestModelSerializationWithRelations(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model, jc.DeepEquals, initial)
 }
+
+func (s *ModelSerializationSuite) TestSpaces(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	space := initial.AddSpace(SpaceArgs{Name: "special"})
+	c.Assert(space.Name(), gc.Equals, "special")
+	spaces := initial.Spaces()
+	c.Assert(spaces, gc.HasLen, 1)
+	c.Assert(spaces[0], gc.Equals, space)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Spaces(), jc.DeepEquals, spaces)
+}
+
+func (s *ModelSerializationSuite) TestSubnets(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	subnet := initial.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24"})
+	c.Assert(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	subnets := initial.Subnets()
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Assert(subnets[0], jc.DeepEquals, subnet)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Subnets(), jc.DeepEquals, subnets)
+}
+
+func (s *ModelSerializationSuite) TestLinkLayerDevice(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	device := initial.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "foo"})
+	c.Assert(device.Name(), gc.Equals, "foo")
+	devices := initial.LinkLayerDevices()
+	c.Assert(devices, gc.HasLen, 1)
+	c.Assert(devices[0], jc.DeepEquals, device)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.LinkLayerDevices(), jc.DeepEquals, devices)
+}
+
+func (s *ModelSerializationSuite) TestIPAddress(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	addr := initial.AddIPAddress(IPAddressArgs{Value: "10.0.0.4"})
+	c.Assert(addr.Value(), gc.Equals, "10.0.0.4")
+	addresses := initial.IPAddresses()
+	c.Assert(addresses, gc.HasLen, 1)
+	c.Assert(addresses[0], jc.DeepEquals, addr)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.IPAddresses(), jc.DeepEquals, addresses)
+}
+
+func (s *ModelSerializationSuite) TestStorage(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	storage := initial.AddStorage(testStorageArgs())
+	storages := initial.Storages()
+	c.Assert(storages, gc.HasLen, 1)
+	c.Assert(storages[0], jc.DeepEquals, storage)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Storages(), jc.DeepEquals, storages)
+}
+
+func (s *ModelSerializationSuite) TestStoragePools(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	poolOne := map[string]interface{}{
+		"foo":   42,
+		"value": true,
+	}
+	poolTwo := map[string]interface{}{
+		"value": "spanner",
+	}
+	initial.AddStoragePool(StoragePoolArgs{
+		Name: "one", Provider: "sparkly", Attributes: poolOne})
+	initial.AddStoragePool(StoragePoolArgs{
+		Name: "two", Provider: "spiky", Attributes: poolTwo})
+
+	pools := initial.StoragePools()
+	c.Assert(pools, gc.HasLen, 2)
+	one, two := pools[0], pools[1]
+	c.Check(one.Name(), gc.Equals, "one")
+	c.Check(one.Provider(), gc.Equals, "sparkly")
+	c.Check(one.Attributes(), jc.DeepEquals, poolOne)
+	c.Check(two.Name(), gc.Equals, "two")
+	c.Check(two.Provider(), gc.Equals, "spiky")
+	c.Check(two.Attributes(), jc.DeepEquals, poolTwo)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools = model.StoragePools()
+	c.Assert(pools, gc.HasLen, 2)
+	one, two = pools[0], pools[1]
+	c.Check(one.Name(), gc.Equals, "one")
+	c.Check(one.Provider(), gc.Equals, "sparkly")
+	c.Check(one.Attributes(), jc.DeepEquals, poolOne)
+	c.Check(two.Name(), gc.Equals, "two")
+	c.Check(two.Provider(), gc.Equals, "spiky")
+	c.Check(two.Attributes(), jc.DeepEquals, poolTwo)
+}
+
+func (s *ModelSerializationSuite) TestVolumes(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	volume := initial.AddVolume(testVolumeArgs())
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(testVolumeAttachmentArgs())
+	volumes := initial.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	c.Assert(volumes[0], gc.Equals, volume)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Volumes(), jc.DeepEquals, volumes)
+}
+
+func (s *ModelSerializationSuite) TestFilesystems(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	filesystem := initial.AddFilesystem(testFilesystemArgs())
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(testFilesystemAttachmentArgs())
+	filesystems := initial.Filesystems()
+	c.Assert(filesystems, gc.HasLen, 1)
+	c.Assert(filesystems[0], gc.Equals, filesystem)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Filesystems(), jc.DeepEquals, filesystems)
+}
+
+func (s *ModelSerializationSuite) TestParsingWithoutNetworkingOrStorage(c *gc.C) {
+	// Models serialized before networking and storage were added
+	// to the format must still be readable.
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{
+		"spaces", "subnets", "link-layer-devices", "ip-addresses",
+		"storages", "storage-pools", "volumes", "filesystems",
+	} {
+		delete(source, key)
+	}
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Spaces(), gc.HasLen, 0)
+	c.Assert(model.Volumes(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetSpaces(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24", SpaceName: "missing"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `subnet "10.0.0.0/24" references unknown space "missing"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksLinkLayerDeviceMachines(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "eth0", MachineID: "42"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `device "eth0" references unknown machine "42"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksAddressDevices(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addMachineToModel(model, "0")
+	model.AddIPAddress(IPAddressArgs{Value: "10.0.0.4", MachineID: "0", DeviceName: "eth0"})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `ip address "10.0.0.4" references unknown device "eth0" on machine "0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksNetworkingGood(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	machine := s.addMachineToModel(model, "0")
+	container := machine.AddContainer(MachineArgs{Id: names.NewMachineTag("0/lxc/0")})
+	container.SetInstance(CloudInstanceArgs{InstanceId: "magic"})
+	container.SetTools(minimalAgentToolsArgs())
+	container.SetStatus(minimalStatusArgs())
+	model.AddSpace(SpaceArgs{Name: "db"})
+	model.AddSubnet(SubnetArgs{CIDR: "10.0.0.0/24", SpaceName: "db"})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{Name: "eth0", MachineID: "0/lxc/0"})
+	model.AddIPAddress(IPAddressArgs{Value: "10.0.0.4", MachineID: "0/lxc/0", DeviceName: "eth0"})
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageAttachments(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddStorage(testStorageArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `storage "db/0" attached to unknown unit "postgresql/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeStorage(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddVolume(testVolumeArgs()).SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume "1234" references unknown storage "test/1"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksFilesystemAttachments(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	args := testFilesystemArgs()
+	args.Storage = names.StorageTag{}
+	args.Volume = names.VolumeTag{}
+	filesystem := model.AddFilesystem(args)
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(testFilesystemAttachmentArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `filesystem "1234" attached to unknown machine "42"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageGood(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addServiceToModel(model, "postgresql", 2)
+	model.AddStorage(testStorageArgs())
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0"),
+		Storage: names.NewStorageTag("db/0"),
+		Size:    gig,
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{Machine: names.NewMachineTag("1")})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:     names.NewFilesystemTag("0"),
+		Storage: names.NewStorageTag("db/0"),
+		Volume:  names.NewVolumeTag("0"),
+		Size:    gig,
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{Machine: names.NewMachineTag("1")})
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestResources(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	resource := initial.AddResource(testResourceArgs())
+	resource.SetUnitRevision("wordpress/0", testResourceRevisionArgs())
+	resources := initial.Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	c.Assert(resources[0], gc.Equals, resource)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Resources(), jc.DeepEquals, resources)
+}
+
+func (s *ModelSerializationSuite) TestPayloads(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	payload := initial.AddPayload(testPayloadArgs())
+	payloads := initial.Payloads()
+	c.Assert(payloads, gc.HasLen, 1)
+	c.Assert(payloads[0], gc.Equals, payload)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Payloads(), jc.DeepEquals, payloads)
+}
+
+func (s *ModelSerializationSuite) TestActions(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	action := initial.AddAction(testActionArgs())
+	actions := initial.Actions()
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0], gc.Equals, action)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Actions(), jc.DeepEquals, actions)
+}
+
+func (s *ModelSerializationSuite) TestMetricBatches(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	batch := initial.AddMetricBatch(testMetricBatchArgs())
+	batches := initial.MetricBatches()
+	c.Assert(batches, gc.HasLen, 1)
+	c.Assert(batches[0], gc.Equals, batch)
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MetricBatches(), jc.DeepEquals, batches)
+}
+
+func (s *ModelSerializationSuite) TestParsingWithoutUnitEntities(c *gc.C) {
+	// Models serialized before resources, payloads, actions and metrics
+	// were added to the format must still be readable.
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{"resources", "payloads", "actions", "metric-batches"} {
+		delete(source, key)
+	}
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Resources(), gc.HasLen, 0)
+	c.Assert(model.MetricBatches(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksResourceServices(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddResource(testResourceArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `resource "blob" references unknown service "wordpress"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksResourceUnits(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addServiceToModel(model, "wordpress", 1)
+	resource := model.AddResource(testResourceArgs())
+	resource.SetUnitRevision("wordpress/3", testResourceRevisionArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `resource "blob" references unknown unit "wordpress/3"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksPayloadUnits(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddPayload(testPayloadArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `payload "spam" references unknown unit "wordpress/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksActionReceivers(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddAction(testActionArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `action "some-uuid" references unknown receiver "wordpress/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksMetricBatchUnits(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddMetricBatch(testMetricBatchArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `metric batch "some-uuid" references unknown unit "wordpress/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksUnitEntitiesGood(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addServiceToModel(model, "wordpress", 1)
+	resource := model.AddResource(testResourceArgs())
+	resource.SetUnitRevision("wordpress/0", testResourceRevisionArgs())
+	model.AddPayload(testPayloadArgs())
+	model.AddAction(testActionArgs())
+	model.AddMetricBatch(testMetricBatchArgs())
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type PayloadSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&PayloadSerializationSuite{})
+
+func (s *PayloadSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "payloads"
+	s.sliceName = "payloads"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importPayloads(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["payloads"] = []interface{}{}
+	}
+}
+
+func testPayloadArgs() PayloadArgs {
+	return PayloadArgs{
+		Unit:   names.NewUnitTag("wordpress/0"),
+		Name:   "spam",
+		Type:   "docker",
+		RawID:  "idspam",
+		State:  "running",
+		Labels: []string{"a-tag"},
+	}
+}
+
+func (s *PayloadSerializationSuite) TestNewPayload(c *gc.C) {
+	payload := newPayload(testPayloadArgs())
+
+	c.Check(payload.Unit(), gc.Equals, names.NewUnitTag("wordpress/0"))
+	c.Check(payload.Name(), gc.Equals, "spam")
+	c.Check(payload.Type(), gc.Equals, "docker")
+	c.Check(payload.RawID(), gc.Equals, "idspam")
+	c.Check(payload.State(), gc.Equals, "running")
+	c.Check(payload.Labels(), jc.DeepEquals, []string{"a-tag"})
+}
+
+func (s *PayloadSerializationSuite) TestPayloadValidMissingRawID(c *gc.C) {
+	args := testPayloadArgs()
+	args.RawID = ""
+	err := newPayload(args).Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `payload "spam" missing raw id not valid`)
+}
+
+func (s *PayloadSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := payloads{
+		Version: 1,
+		Payloads_: []*payload{
+			newPayload(testPayloadArgs()),
+			newPayload(PayloadArgs{
+				Unit:  names.NewUnitTag("mysql/1"),
+				Name:  "eggs",
+				Type:  "kvm",
+				RawID: "ideggs",
+				State: "stopped",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	payloads, err := importPayloads(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(payloads, jc.DeepEquals, initial.Payloads_)
+}
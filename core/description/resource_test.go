@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ResourceSerializationSuite{})
+
+func (s *ResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func testResourceRevisionArgs() ResourceRevisionArgs {
+	return ResourceRevisionArgs{
+		Revision:       3,
+		Type:           "file",
+		Path:           "blob.tgz",
+		Description:    "a big blob",
+		Origin:         "upload",
+		FingerprintHex: "abcdef0123456789",
+		Size:           1234,
+		Timestamp:      time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC),
+		Username:       "bob",
+	}
+}
+
+func testResourceArgs() ResourceArgs {
+	return ResourceArgs{
+		Service:  "wordpress",
+		Name:     "blob",
+		Revision: testResourceRevisionArgs(),
+	}
+}
+
+func (s *ResourceSerializationSuite) TestNewResource(c *gc.C) {
+	resource := newResource(testResourceArgs())
+
+	c.Check(resource.Service(), gc.Equals, "wordpress")
+	c.Check(resource.Name(), gc.Equals, "blob")
+	c.Check(resource.CharmStoreRevision(), gc.IsNil)
+	c.Check(resource.UnitRevisions(), gc.HasLen, 0)
+
+	revision := resource.Revision()
+	c.Check(revision.Revision(), gc.Equals, 3)
+	c.Check(revision.Type(), gc.Equals, "file")
+	c.Check(revision.Path(), gc.Equals, "blob.tgz")
+	c.Check(revision.Description(), gc.Equals, "a big blob")
+	c.Check(revision.Origin(), gc.Equals, "upload")
+	c.Check(revision.FingerprintHex(), gc.Equals, "abcdef0123456789")
+	c.Check(revision.Size(), gc.Equals, int64(1234))
+	c.Check(revision.Timestamp(), gc.Equals, time.Date(2016, 5, 6, 7, 8, 9, 0, time.UTC))
+	c.Check(revision.Username(), gc.Equals, "bob")
+}
+
+func (s *ResourceSerializationSuite) TestPlaceholderRevision(c *gc.C) {
+	resource := newResource(ResourceArgs{
+		Service: "wordpress",
+		Name:    "blob",
+		Revision: ResourceRevisionArgs{
+			Type:   "file",
+			Path:   "blob.tgz",
+			Origin: "upload",
+		},
+	})
+	revision := resource.Revision()
+	c.Check(revision.FingerprintHex(), gc.Equals, "")
+	c.Check(revision.Timestamp().IsZero(), jc.IsTrue)
+}
+
+func (s *ResourceSerializationSuite) TestResourceValidMissingService(c *gc.C) {
+	args := testResourceArgs()
+	args.Service = ""
+	err := newResource(args).Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `resource missing service not valid`)
+}
+
+func (s *ResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	withRevisions := newResource(testResourceArgs())
+	withRevisions.SetCharmStoreRevision(ResourceRevisionArgs{
+		Revision: 4,
+		Type:     "file",
+		Path:     "blob.tgz",
+		Origin:   "store",
+		Size:     4321,
+	})
+	withRevisions.SetUnitRevision("wordpress/0", testResourceRevisionArgs())
+	initial := resources{
+		Version: 1,
+		Resources_: []*resource{
+			withRevisions,
+			newResource(ResourceArgs{
+				Service:  "mysql",
+				Name:     "data",
+				Revision: ResourceRevisionArgs{Type: "file", Path: "data", Origin: "upload"},
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(resources, jc.DeepEquals, initial.Resources_)
+}
@@ -28,9 +28,11 @@ type service struct {
 
 	// ForceCharm is true if an upgrade charm is forced.
 	// It means upgrade even if the charm is in an error state.
-	ForceCharm_ bool `yaml:"force-charm,omitempty"`
-	Exposed_    bool `yaml:"exposed,omitempty"`
-	MinUnits_   int  `yaml:"min-units,omitempty"`
+	ForceCharm_      bool     `yaml:"force-charm,omitempty"`
+	Exposed_         bool     `yaml:"exposed,omitempty"`
+	ExposedToCIDRs_  []string `yaml:"exposed-to-cidrs,omitempty"`
+	ExposedToSpaces_ []string `yaml:"exposed-to-spaces,omitempty"`
+	MinUnits_        int      `yaml:"min-units,omitempty"`
 
 	Status_        *status `yaml:"status"`
 	StatusHistory_ `yaml:"status-history"`
@@ -50,7 +52,7 @@ type service struct {
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
-	// Storage Constraints
+	StorageConstraints_ map[string]*storageconstraint `yaml:"storage-constraints,omitempty"`
 }
 
 // ServiceArgs is an argument struct used to add a service to the Model.
@@ -63,12 +65,15 @@ type ServiceArgs struct {
 	CharmModifiedVersion int
 	ForceCharm           bool
 	Exposed              bool
+	ExposedToCIDRs       []string
+	ExposedToSpaces      []string
 	MinUnits             int
 	Settings             map[string]interface{}
 	SettingsRefCount     int
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	MetricsCredentials   []byte
+	StorageConstraints   map[string]StorageConstraintArgs
 }
 
 func newService(args ServiceArgs) *service {
@@ -82,6 +87,8 @@ func newService(args ServiceArgs) *service {
 		CharmModifiedVersion_: args.CharmModifiedVersion,
 		ForceCharm_:           args.ForceCharm,
 		Exposed_:              args.Exposed,
+		ExposedToCIDRs_:       args.ExposedToCIDRs,
+		ExposedToSpaces_:      args.ExposedToSpaces,
 		MinUnits_:             args.MinUnits,
 		Settings_:             args.Settings,
 		SettingsRefCount_:     args.SettingsRefCount,
@@ -91,6 +98,12 @@ func newService(args ServiceArgs) *service {
 		StatusHistory_:        newStatusHistory(),
 	}
 	svc.setUnits(nil)
+	if len(args.StorageConstraints) > 0 {
+		svc.StorageConstraints_ = make(map[string]*storageconstraint)
+		for key, value := range args.StorageConstraints {
+			svc.StorageConstraints_[key] = newStorageConstraint(value)
+		}
+	}
 	return svc
 }
 
@@ -139,6 +152,16 @@ func (s *service) Exposed() bool {
 	return s.Exposed_
 }
 
+// ExposedToCIDRs implements Service.
+func (s *service) ExposedToCIDRs() []string {
+	return s.ExposedToCIDRs_
+}
+
+// ExposedToSpaces implements Service.
+func (s *service) ExposedToSpaces() []string {
+	return s.ExposedToSpaces_
+}
+
 // MinUnits implements Service.
 func (s *service) MinUnits() int {
 	return s.MinUnits_
@@ -174,6 +197,15 @@ func (s *service) MetricsCredentials() []byte {
 	return creds
 }
 
+// StorageConstraints implements Service.
+func (s *service) StorageConstraints() map[string]StorageConstraint {
+	result := make(map[string]StorageConstraint)
+	for key, value := range s.StorageConstraints_ {
+		result[key] = value
+	}
+	return result
+}
+
 // Status implements Service.
 func (s *service) Status() Status {
 	// To avoid typed nils check nil here.
@@ -307,6 +339,8 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		"charm-mod-version":   schema.Int(),
 		"force-charm":         schema.Bool(),
 		"exposed":             schema.Bool(),
+		"exposed-to-cidrs":    schema.List(schema.String()),
+		"exposed-to-spaces":   schema.List(schema.String()),
 		"min-units":           schema.Int(),
 		"status":              schema.StringMap(schema.Any()),
 		"settings":            schema.StringMap(schema.Any()),
@@ -315,15 +349,19 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		"leadership-settings": schema.StringMap(schema.Any()),
 		"metrics-creds":       schema.String(),
 		"units":               schema.StringMap(schema.Any()),
+		"storage-constraints": schema.StringMap(schema.StringMap(schema.Any())),
 	}
 
 	defaults := schema.Defaults{
-		"subordinate":   false,
-		"force-charm":   false,
-		"exposed":       false,
-		"min-units":     int64(0),
-		"leader":        "",
-		"metrics-creds": "",
+		"subordinate":         false,
+		"force-charm":         false,
+		"exposed":             false,
+		"exposed-to-cidrs":    schema.Omit,
+		"exposed-to-spaces":   schema.Omit,
+		"min-units":           int64(0),
+		"leader":              "",
+		"metrics-creds":       "",
+		"storage-constraints": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -346,6 +384,8 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		CharmModifiedVersion_: int(valid["charm-mod-version"].(int64)),
 		ForceCharm_:           valid["force-charm"].(bool),
 		Exposed_:              valid["exposed"].(bool),
+		ExposedToCIDRs_:       convertToStringSlice(valid["exposed-to-cidrs"]),
+		ExposedToSpaces_:      convertToStringSlice(valid["exposed-to-spaces"]),
 		MinUnits_:             int(valid["min-units"].(int64)),
 		Settings_:             valid["settings"].(map[string]interface{}),
 		SettingsRefCount_:     int(valid["settings-refcount"].(int64)),
@@ -366,6 +406,14 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		result.Constraints_ = constraints
 	}
 
+	if constraintsMap, ok := valid["storage-constraints"]; ok {
+		constraints, err := importStorageConstraints(constraintsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.StorageConstraints_ = constraints
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
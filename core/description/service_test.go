@@ -110,6 +110,8 @@ func (s *ServiceSerializationSuite) TestNewService(c *gc.C) {
 		CharmModifiedVersion: 1,
 		ForceCharm:           true,
 		Exposed:              true,
+		ExposedToCIDRs:       []string{"10.0.0.0/8"},
+		ExposedToSpaces:      []string{"internal"},
 		MinUnits:             42, // no judgement is made by the migration code
 		Settings: map[string]interface{}{
 			"key": "value",
@@ -132,6 +134,8 @@ func (s *ServiceSerializationSuite) TestNewService(c *gc.C) {
 	c.Assert(service.CharmModifiedVersion(), gc.Equals, 1)
 	c.Assert(service.ForceCharm(), jc.IsTrue)
 	c.Assert(service.Exposed(), jc.IsTrue)
+	c.Assert(service.ExposedToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(service.ExposedToSpaces(), jc.DeepEquals, []string{"internal"})
 	c.Assert(service.MinUnits(), gc.Equals, 42)
 	c.Assert(service.Settings(), jc.DeepEquals, args.Settings)
 	c.Assert(service.SettingsRefCount(), gc.Equals, 1)
@@ -205,6 +209,28 @@ func (s *ServiceSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(service.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ServiceSerializationSuite) TestStorageConstraints(c *gc.C) {
+	args := minimalServiceArgs()
+	args.StorageConstraints = map[string]StorageConstraintArgs{
+		"data": {Pool: "ebs", Size: 1024, Count: 2},
+		"logs": {Pool: "loop", Size: 512, Count: 1},
+	}
+	initial := newService(args)
+	initial.SetStatus(minimalStatusArgs())
+
+	service := s.exportImport(c, initial)
+	constraints := service.StorageConstraints()
+	c.Assert(constraints, gc.HasLen, 2)
+	data := constraints["data"]
+	c.Check(data.Pool(), gc.Equals, "ebs")
+	c.Check(data.Size(), gc.Equals, uint64(1024))
+	c.Check(data.Count(), gc.Equals, uint64(2))
+	logs := constraints["logs"]
+	c.Check(logs.Pool(), gc.Equals, "loop")
+	c.Check(logs.Size(), gc.Equals, uint64(512))
+	c.Check(logs.Count(), gc.Equals, uint64(1))
+}
+
 func (s *ServiceSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalServiceArgs()
 	args.Leader = "ubuntu/1"
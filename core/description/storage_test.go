@@ -0,0 +1,127 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type StorageSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&StorageSerializationSuite{})
+
+func (s *StorageSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "storages"
+	s.sliceName = "storages"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importStorages(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["storages"] = []interface{}{}
+	}
+}
+
+func testStorageArgs() StorageArgs {
+	return StorageArgs{
+		Tag:   names.NewStorageTag("db/0"),
+		Kind:  "magic",
+		Owner: names.NewServiceTag("postgresql"),
+		Name:  "db",
+		Attachments: []names.UnitTag{
+			names.NewUnitTag("postgresql/0"),
+			names.NewUnitTag("postgresql/1"),
+		},
+	}
+}
+
+func (s *StorageSerializationSuite) TestNewStorage(c *gc.C) {
+	args := testStorageArgs()
+	storage := newStorage(args)
+	c.Assert(storage.Tag(), gc.Equals, args.Tag)
+	c.Assert(storage.Kind(), gc.Equals, args.Kind)
+	owner, err := storage.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.Equals, args.Owner)
+	c.Assert(storage.Name(), gc.Equals, args.Name)
+	c.Assert(storage.Attachments(), jc.DeepEquals, args.Attachments)
+}
+
+func (s *StorageSerializationSuite) TestStorageValid(c *gc.C) {
+	storage := newStorage(testStorageArgs())
+	c.Assert(storage.Validate(), jc.ErrorIsNil)
+}
+
+func (s *StorageSerializationSuite) TestStorageValidMissingID(c *gc.C) {
+	storage := newStorage(testStorageArgs())
+	storage.ID_ = ""
+	err := storage.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `storage missing id not valid`)
+}
+
+func (s *StorageSerializationSuite) TestStorageValidDetached(c *gc.C) {
+	args := testStorageArgs()
+	args.Owner = nil
+	args.Attachments = nil
+	storage := newStorage(args)
+	c.Assert(storage.Validate(), jc.ErrorIsNil)
+	owner, err := storage.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner, gc.IsNil)
+}
+
+func (s *StorageSerializationSuite) TestStorageValidBadOwner(c *gc.C) {
+	storage := newStorage(testStorageArgs())
+	storage.Owner_ = "bad"
+	err := storage.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `storage "db/0" invalid owner not valid`)
+}
+
+func (s *StorageSerializationSuite) TestStorageValidBadAttachment(c *gc.C) {
+	storage := newStorage(testStorageArgs())
+	storage.Attachments_ = []string{"bad"}
+	err := storage.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `storage "db/0" attachment "bad" not valid`)
+}
+
+func (s *StorageSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := storages{
+		Version: 1,
+		Storages_: []*storage{
+			newStorage(testStorageArgs()),
+			newStorage(StorageArgs{
+				Tag:   names.NewStorageTag("data/1"),
+				Kind:  "block",
+				Owner: names.NewUnitTag("mysql/0"),
+				Name:  "data",
+			}),
+			newStorage(StorageArgs{
+				Tag:  names.NewStorageTag("data/2"),
+				Kind: "block",
+				Name: "data",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storages, err := importStorages(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(storages, jc.DeepEquals, initial.Storages_)
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type StoragePoolSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&StoragePoolSerializationSuite{})
+
+func (s *StoragePoolSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "storagepools"
+	s.sliceName = "pools"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importStoragePools(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["pools"] = []interface{}{}
+	}
+}
+
+func testStoragePoolArgs() StoragePoolArgs {
+	return StoragePoolArgs{
+		Name:     "test",
+		Provider: "magic",
+		Attributes: map[string]interface{}{
+			"method": "madness",
+		},
+	}
+}
+
+func (s *StoragePoolSerializationSuite) TestNewStoragePool(c *gc.C) {
+	args := testStoragePoolArgs()
+	pool := newStoragePool(args)
+	c.Assert(pool.Name(), gc.Equals, args.Name)
+	c.Assert(pool.Provider(), gc.Equals, args.Provider)
+	c.Assert(pool.Attributes(), jc.DeepEquals, args.Attributes)
+}
+
+func (s *StoragePoolSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := storagepools{
+		Version: 1,
+		Pools_: []*storagepool{
+			newStoragePool(testStoragePoolArgs()),
+			newStoragePool(StoragePoolArgs{
+				Name:     "plain",
+				Provider: "loop",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools, err := importStoragePools(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(pools, jc.DeepEquals, initial.Pools_)
+}
@@ -71,6 +71,13 @@ func (*UserSerializationSuite) TestParsingSerializedData(c *gc.C) {
 				CreatedBy_:   "admin@local",
 				DateCreated_: time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
 				ReadOnly_:    true,
+				Access_:      "read",
+			},
+			&user{
+				Name_:        "write@local",
+				CreatedBy_:   "admin@local",
+				DateCreated_: time.Date(2015, 10, 9, 12, 34, 56, 0, time.UTC),
+				Access_:      "write",
 			},
 		},
 	}
@@ -30,8 +30,8 @@ var phaseNames = []string{
 	"QUIESCE",
 	"READONLY",
 	"PRECHECK",
-	"VALIDATION",
 	"IMPORT",
+	"VALIDATION",
 	"SUCCESS",
 	"LOGTRANSFER",
 	"REAP",
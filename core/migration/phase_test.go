@@ -25,6 +25,8 @@ func (s *PhaseSuite) TestUNKNOWN(c *gc.C) {
 
 func (s *PhaseSuite) TestStringValid(c *gc.C) {
 	c.Check(migration.PRECHECK.String(), gc.Equals, "PRECHECK")
+	c.Check(migration.IMPORT.String(), gc.Equals, "IMPORT")
+	c.Check(migration.VALIDATION.String(), gc.Equals, "VALIDATION")
 	c.Check(migration.UNKNOWN.String(), gc.Equals, "UNKNOWN")
 	c.Check(migration.ABORT.String(), gc.Equals, "ABORT")
 }
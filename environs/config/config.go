@@ -4,6 +4,7 @@
 package config
 
 import (
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -25,8 +26,10 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/backups"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -93,6 +96,31 @@ const (
 	// config setting. Only non-zero, positive integer values will
 	// have effect.
 	DefaultLXCDefaultMTU = 0
+
+	// DefaultBackupKeepLast is the default value for the
+	// "backup-keep-last" config setting.
+	DefaultBackupKeepLast = 7
+
+	// DefaultMetricsHistory is the default value for the
+	// "metrics-history" config setting.
+	DefaultMetricsHistory = 24 * time.Hour
+
+	// MetricsSenderCharmStore sends metrics to the charm store's
+	// metrics service; it is the default "metrics-sender".
+	MetricsSenderCharmStore = "charmstore"
+
+	// MetricsSenderPrometheus exposes the latest metric values on the
+	// controller's /metrics endpoint for Prometheus to scrape. The
+	// endpoint requires controller administrator credentials; the
+	// values are kept in state, so every API server serves them all.
+	MetricsSenderPrometheus = "prometheus"
+
+	// MetricsSenderHTTP pushes metric batches as JSON to the
+	// "metrics-push-url" endpoint.
+	MetricsSenderHTTP = "http"
+
+	// MetricsSenderNone discards metrics once they are collected.
+	MetricsSenderNone = "none"
 )
 
 // TODO(katco-): Please grow this over time.
@@ -202,6 +230,77 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// BackupScheduleKey is a cron-style schedule on which the
+	// controller backs itself up. Scheduled backups are only made
+	// when it is set in the controller model.
+	BackupScheduleKey = "backup-schedule"
+
+	// BackupKeepLastKey is the number of most recent scheduled
+	// backups to keep.
+	BackupKeepLastKey = "backup-keep-last"
+
+	// BackupKeepDailyKey is the number of days for which the last
+	// scheduled backup of each day is kept.
+	BackupKeepDailyKey = "backup-keep-daily"
+
+	// BackupKeepWeeklyKey is the number of weeks for which the last
+	// scheduled backup of each week is kept.
+	BackupKeepWeeklyKey = "backup-keep-weekly"
+
+	// BackupStorageKey names where the controller keeps backup
+	// archives: "controller" (the default), "directory" or "s3".
+	BackupStorageKey = "backup-storage"
+
+	// BackupDirectoryKey is the directory in which backup archives
+	// are kept when backup-storage is "directory".
+	BackupDirectoryKey = "backup-directory"
+
+	// BackupS3EndpointKey, BackupS3RegionKey and BackupS3BucketKey
+	// identify the bucket in which backup archives are kept when
+	// backup-storage is "s3".
+	BackupS3EndpointKey = "backup-s3-endpoint"
+	BackupS3RegionKey   = "backup-s3-region"
+	BackupS3BucketKey   = "backup-s3-bucket"
+
+	// BackupS3AccessKeyKey and BackupS3SecretKeyKey are the
+	// credentials used to access the backup bucket.
+	BackupS3AccessKeyKey = "backup-s3-access-key"
+	BackupS3SecretKeyKey = "backup-s3-secret-key"
+
+	// BackupPublicKeyKey is a PEM-encoded RSA public key with which
+	// scheduled backups are encrypted.
+	BackupPublicKeyKey = "backup-public-key"
+
+	// LogForwardSyslogHostsKey is a comma-separated list of the
+	// host:port addresses of the syslog collectors to which the
+	// controller forwards the logs of all models.
+	LogForwardSyslogHostsKey = "logforward-syslog-hosts"
+
+	// LogForwardSyslogCACertKey is the PEM-encoded certificate of the
+	// CA which signed the syslog collectors' certificates. Logs are
+	// forwarded over TLS when it is set, and over plain TCP otherwise.
+	LogForwardSyslogCACertKey = "logforward-syslog-ca-cert"
+
+	// LogForwardSyslogClientCertKey and LogForwardSyslogClientKeyKey
+	// are the PEM-encoded certificate and key with which the controller
+	// authenticates itself to the syslog collectors.
+	LogForwardSyslogClientCertKey = "logforward-syslog-client-cert"
+	LogForwardSyslogClientKeyKey  = "logforward-syslog-client-key"
+
+	// MetricsHistoryKey is how long sent metric batches and metric
+	// collection failures are kept for debugging, as a duration such
+	// as "24h".
+	MetricsHistoryKey = "metrics-history"
+
+	// MetricsSenderKey selects where the controller sends the metrics
+	// collected from charms. It is read from the controller model's
+	// config.
+	MetricsSenderKey = "metrics-sender"
+
+	// MetricsPushURLKey is the endpoint metric batches are pushed to
+	// by the "http" metrics sender.
+	MetricsPushURLKey = "metrics-push-url"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -679,6 +778,57 @@ func Validate(cfg, old *Config) error {
 		return errors.Errorf("%s: expected positive integer, got %v", LXCDefaultMTU, lxcDefaultMTU)
 	}
 
+	if v, ok := cfg.defined[BackupScheduleKey].(string); ok && v != "" {
+		if _, err := backups.ParseSchedule(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupScheduleKey)
+		}
+	}
+	for _, attr := range []string{BackupKeepLastKey, BackupKeepDailyKey, BackupKeepWeeklyKey} {
+		if v, ok := cfg.defined[attr].(int); ok && v < 0 {
+			return errors.Errorf("%s: expected non-negative integer, got %v", attr, v)
+		}
+	}
+	if err := cfg.BackupStorage().Validate(); err != nil {
+		return errors.Annotatef(err, "invalid %s", BackupStorageKey)
+	}
+	if v := cfg.BackupPublicKey(); v != "" {
+		if block, _ := pem.Decode([]byte(v)); block == nil || block.Type != "PUBLIC KEY" {
+			return errors.Errorf("%s: expected PEM-encoded public key", BackupPublicKeyKey)
+		}
+	}
+	if v, ok := cfg.defined[MetricsHistoryKey].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", MetricsHistoryKey)
+		} else if d < 0 {
+			return errors.Errorf("%s: expected non-negative duration, got %v", MetricsHistoryKey, v)
+		}
+	}
+	if v, ok := cfg.defined[MetricsSenderKey].(string); ok && v != "" {
+		switch v {
+		case MetricsSenderCharmStore, MetricsSenderPrometheus, MetricsSenderNone:
+		case MetricsSenderHTTP:
+			if cfg.MetricsPushURL() == "" {
+				return errors.Errorf("%s %q requires %s", MetricsSenderKey, v, MetricsPushURLKey)
+			}
+		default:
+			return errors.NotValidf("%s %q", MetricsSenderKey, v)
+		}
+	}
+	if v, ok := cfg.defined[MetricsPushURLKey].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", MetricsPushURLKey)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s: expected http or https URL, got %q", MetricsPushURLKey, v)
+		}
+	}
+	for _, syslogConfig := range cfg.LogForwardSyslogConfigs() {
+		if err := syslogConfig.Validate(); err != nil {
+			return errors.Annotatef(err, "invalid %s", LogForwardSyslogHostsKey)
+		}
+	}
+
 	cfg.defined = ProcessDeprecatedAttributes(cfg.defined)
 	return nil
 }
@@ -1091,6 +1241,108 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// BackupSchedule returns the schedule on which the controller backs
+// itself up, or nil if no schedule is set.
+func (c *Config) BackupSchedule() *backups.Schedule {
+	v, _ := c.defined[BackupScheduleKey].(string)
+	if v == "" {
+		return nil
+	}
+	schedule, err := backups.ParseSchedule(v)
+	if err != nil {
+		// This setting should have already been validated.
+		return nil
+	}
+	return schedule
+}
+
+// BackupRetentionPolicy returns the policy which decides which
+// scheduled backups are kept.
+func (c *Config) BackupRetentionPolicy() backups.RetentionPolicy {
+	keepLast, ok := c.defined[BackupKeepLastKey].(int)
+	if !ok {
+		keepLast = DefaultBackupKeepLast
+	}
+	keepDaily, _ := c.defined[BackupKeepDailyKey].(int)
+	keepWeekly, _ := c.defined[BackupKeepWeeklyKey].(int)
+	return backups.RetentionPolicy{
+		KeepLast:   keepLast,
+		KeepDaily:  keepDaily,
+		KeepWeekly: keepWeekly,
+	}
+}
+
+// BackupStorage returns where the controller keeps backup archives.
+func (c *Config) BackupStorage() backups.StorageConfig {
+	return backups.StorageConfig{
+		Type:      c.asString(BackupStorageKey),
+		Directory: c.asString(BackupDirectoryKey),
+		S3: backups.S3Config{
+			Endpoint:  c.asString(BackupS3EndpointKey),
+			Region:    c.asString(BackupS3RegionKey),
+			Bucket:    c.asString(BackupS3BucketKey),
+			AccessKey: c.asString(BackupS3AccessKeyKey),
+			SecretKey: c.asString(BackupS3SecretKeyKey),
+		},
+	}
+}
+
+// BackupPublicKey returns the public key with which scheduled backups
+// are encrypted, or "" if they are not encrypted.
+func (c *Config) BackupPublicKey() string {
+	return c.asString(BackupPublicKeyKey)
+}
+
+// LogForwardSyslogConfigs returns the configuration of each syslog
+// collector to which the controller forwards logs.
+func (c *Config) LogForwardSyslogConfigs() []syslog.ClientConfig {
+	var configs []syslog.ClientConfig
+	for _, host := range strings.Split(c.asString(LogForwardSyslogHostsKey), ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		configs = append(configs, syslog.ClientConfig{
+			Address:    host,
+			CACert:     c.asString(LogForwardSyslogCACertKey),
+			ClientCert: c.asString(LogForwardSyslogClientCertKey),
+			ClientKey:  c.asString(LogForwardSyslogClientKeyKey),
+		})
+	}
+	return configs
+}
+
+// MetricsHistory returns how long sent metric batches and metric
+// collection failures are kept.
+func (c *Config) MetricsHistory() time.Duration {
+	v, ok := c.defined[MetricsHistoryKey].(string)
+	if !ok || v == "" {
+		return DefaultMetricsHistory
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		// This setting should have already been validated.
+		return DefaultMetricsHistory
+	}
+	return d
+}
+
+// MetricsSender returns where the controller sends the metrics
+// collected from charms.
+func (c *Config) MetricsSender() string {
+	if v, ok := c.defined[MetricsSenderKey].(string); ok && v != "" {
+		return v
+	}
+	return MetricsSenderCharmStore
+}
+
+// MetricsPushURL returns the endpoint the "http" metrics sender pushes
+// metric batches to.
+func (c *Config) MetricsPushURL() string {
+	v, _ := c.defined[MetricsPushURLKey].(string)
+	return v
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -1329,6 +1581,31 @@ var alwaysOptional = schema.Defaults{
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	BackupScheduleKey:            schema.Omit,
+	BackupKeepLastKey:            schema.Omit,
+	BackupKeepDailyKey:           schema.Omit,
+	BackupKeepWeeklyKey:          schema.Omit,
+	BackupStorageKey:             schema.Omit,
+	BackupDirectoryKey:           schema.Omit,
+	BackupS3EndpointKey:          schema.Omit,
+	BackupS3RegionKey:            schema.Omit,
+	BackupS3BucketKey:            schema.Omit,
+	BackupS3AccessKeyKey:         schema.Omit,
+	BackupS3SecretKeyKey:         schema.Omit,
+	BackupPublicKeyKey:           schema.Omit,
+
+	// The metrics history defaults to DefaultMetricsHistory.
+	MetricsHistoryKey: schema.Omit,
+
+	// Metrics go to the charm store unless another sender is chosen.
+	MetricsSenderKey:  schema.Omit,
+	MetricsPushURLKey: schema.Omit,
+
+	// Log forwarding is disabled unless collectors are given.
+	LogForwardSyslogHostsKey:      schema.Omit,
+	LogForwardSyslogCACertKey:     schema.Omit,
+	LogForwardSyslogClientCertKey: schema.Omit,
+	LogForwardSyslogClientKeyKey:  schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
@@ -1919,4 +2196,102 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	BackupScheduleKey: {
+		Description: `A cron-style schedule ("minute hour day-of-month month day-of-week", in UTC) on which the controller backs itself up; only used in the controller model`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupKeepLastKey: {
+		Description: "The number of most recent scheduled backups to keep (default 7); when this and the other backup-keep settings are all 0, every scheduled backup is kept",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupKeepDailyKey: {
+		Description: "The number of days for which the last scheduled backup of each day is kept (default 0)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupKeepWeeklyKey: {
+		Description: "The number of weeks for which the last scheduled backup of each week is kept (default 0)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupStorageKey: {
+		Description: `Where the controller keeps backup archives: "controller", "directory" or "s3"; only used in the controller model`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"controller", "directory", "s3"},
+		Group:       environschema.EnvironGroup,
+	},
+	BackupDirectoryKey: {
+		Description: "The absolute path of the directory, such as an NFS mount, in which backup archives are kept when backup-storage is \"directory\"",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupS3EndpointKey: {
+		Description: "The URL of the S3-compatible object store in which backup archives are kept; if unset, the Amazon S3 endpoint for backup-s3-region is used",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupS3RegionKey: {
+		Description: "The region of the object store in which backup archives are kept",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupS3BucketKey: {
+		Description: "The bucket in which backup archives are kept when backup-storage is \"s3\"",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupS3AccessKeyKey: {
+		Description: "The access key used to store backup archives in the object store",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupS3SecretKeyKey: {
+		Description: "The secret key used to store backup archives in the object store",
+		Type:        environschema.Tstring,
+		Secret:      true,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupPublicKeyKey: {
+		Description: "A PEM-encoded RSA public key with which scheduled backups are encrypted",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MetricsHistoryKey: {
+		Description: `How long sent metric batches and metric collection failures are kept for debugging, as a duration such as "24h" (default 24h)`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MetricsSenderKey: {
+		Description: `Where the controller sends metrics collected from charms: "charmstore" (the default), "prometheus" to expose the latest values to controller administrators on the controller's /metrics endpoint, "http" to push them to metrics-push-url, or "none". Read from the controller model`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MetricsPushURLKey: {
+		Description: `The HTTP endpoint metric batches are pushed to as JSON when metrics-sender is "http"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardSyslogHostsKey: {
+		Description: "A comma-separated list of the host:port addresses of syslog collectors to which the controller forwards the logs of all models; only used in the controller model",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardSyslogCACertKey: {
+		Description: "The PEM-encoded certificate of the CA which signed the syslog collectors' certificates; logs are forwarded over TLS when it is set",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardSyslogClientCertKey: {
+		Description: "The PEM-encoded certificate with which the controller authenticates itself to the syslog collectors",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardSyslogClientKeyKey: {
+		Description: "The PEM-encoded private key of logforward-syslog-client-cert",
+		Type:        environschema.Tstring,
+		Secret:      true,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -23,8 +23,10 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/backups"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -222,6 +224,140 @@ var configTests = []configTest{
 			"lxc-default-mtu": -42,
 		}),
 		err: `lxc-default-mtu: expected positive integer, got -42`,
+	}, {
+		about:       "Backup schedule and retention set",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-schedule":    "30 2 * * *",
+			"backup-keep-last":   3,
+			"backup-keep-daily":  7,
+			"backup-keep-weekly": 4,
+		}),
+	}, {
+		about:       "Backup schedule invalid",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-schedule": "every day",
+		}),
+		err: `invalid backup-schedule: schedule "every day": expected 5 fields, got 2`,
+	}, {
+		about:       "Backup retention invalid (negative)",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-keep-daily": -1,
+		}),
+		err: `backup-keep-daily: expected non-negative integer, got -1`,
+	}, {
+		about:       "Backup storage in a directory",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-storage":   "directory",
+			"backup-directory": "/srv/backups",
+		}),
+	}, {
+		about:       "Backup storage in a directory with no directory",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-storage": "directory",
+		}),
+		err: `invalid backup-storage: directory storage with no directory not valid`,
+	}, {
+		about:       "Backup storage unknown",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-storage": "tape",
+		}),
+		err: `invalid backup-storage: storage type "tape" not valid`,
+	}, {
+		about:       "Backup public key",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-public-key": "-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n",
+		}),
+	}, {
+		about:       "Backup public key not PEM",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"backup-public-key": "ssh-rsa AAAA",
+		}),
+		err: `backup-public-key: expected PEM-encoded public key`,
+	}, {
+		about:       "Metrics history set",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-history": "72h",
+		}),
+	}, {
+		about:       "Metrics history invalid",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-history": "three days",
+		}),
+		err: `invalid metrics-history: time: invalid duration .*`,
+	}, {
+		about:       "Metrics history negative",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-history": "-1h",
+		}),
+		err: `metrics-history: expected non-negative duration, got -1h`,
+	}, {
+		about:       "Metrics sent to Prometheus",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender": "prometheus",
+		}),
+	}, {
+		about:       "Metrics pushed over HTTP",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":   "http",
+			"metrics-push-url": "https://metrics.example.com/push",
+		}),
+	}, {
+		about:       "Metrics pushed over HTTP with no URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender": "http",
+		}),
+		err: `metrics-sender "http" requires metrics-push-url`,
+	}, {
+		about:       "Metrics push URL not HTTP",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":   "http",
+			"metrics-push-url": "ftp://metrics.example.com/push",
+		}),
+		err: `metrics-push-url: expected http or https URL, got "ftp://metrics.example.com/push"`,
+	}, {
+		about:       "Metrics sender unknown",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender": "pigeon",
+		}),
+		err: `metrics-sender "pigeon" not valid`,
+	}, {
+		about:       "Log forwarding to syslog",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-syslog-hosts":   "logs1.example.com:6514, 10.0.0.2:6514",
+			"logforward-syslog-ca-cert": testing.CACert,
+		}),
+	}, {
+		about:       "Log forwarding to syslog with no port",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-syslog-hosts": "logs1.example.com",
+		}),
+		err: `invalid logforward-syslog-hosts: syslog address "logs1.example.com" \(expected host:port\) not valid`,
+	}, {
+		about:       "Log forwarding to syslog with invalid CA cert",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-syslog-hosts":   "logs1.example.com:6514",
+			"logforward-syslog-ca-cert": "foo",
+		}),
+		err: `invalid logforward-syslog-hosts: invalid syslog CA certificate: .*`,
 	}, {
 		about:       "CA cert & key from path",
 		useDefaults: config.UseDefaults,
@@ -1591,6 +1727,91 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestBackupDefaults(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.BackupSchedule(), gc.IsNil)
+	c.Assert(config.BackupRetentionPolicy(), gc.Equals, backups.RetentionPolicy{
+		KeepLast: 7,
+	})
+}
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"backup-schedule":    "@daily",
+		"backup-keep-last":   0,
+		"backup-keep-weekly": 4,
+	})
+	schedule := config.BackupSchedule()
+	c.Assert(schedule, gc.NotNil)
+	now := time.Date(2016, 6, 1, 10, 0, 0, 0, time.UTC)
+	c.Assert(schedule.Next(now), gc.Equals, time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC))
+	c.Assert(config.BackupRetentionPolicy(), gc.Equals, backups.RetentionPolicy{
+		KeepWeekly: 4,
+	})
+}
+
+func (s *ConfigSuite) TestBackupStorage(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.BackupStorage(), gc.Equals, backups.StorageConfig{})
+
+	config = newTestConfig(c, testing.Attrs{
+		"backup-storage":       "s3",
+		"backup-s3-endpoint":   "http://10.0.0.1:9000",
+		"backup-s3-region":     "local",
+		"backup-s3-bucket":     "juju-backups",
+		"backup-s3-access-key": "access",
+		"backup-s3-secret-key": "secret",
+	})
+	c.Assert(config.BackupStorage(), gc.Equals, backups.StorageConfig{
+		Type: backups.StorageS3,
+		S3: backups.S3Config{
+			Endpoint:  "http://10.0.0.1:9000",
+			Region:    "local",
+			Bucket:    "juju-backups",
+			AccessKey: "access",
+			SecretKey: "secret",
+		},
+	})
+}
+
+func (s *ConfigSuite) TestMetricsHistory(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MetricsHistory(), gc.Equals, 24*time.Hour)
+
+	config = newTestConfig(c, testing.Attrs{"metrics-history": "1h30m"})
+	c.Assert(config.MetricsHistory(), gc.Equals, 90*time.Minute)
+}
+
+func (s *ConfigSuite) TestMetricsSender(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.MetricsSender(), gc.Equals, "charmstore")
+	c.Assert(config.MetricsPushURL(), gc.Equals, "")
+
+	config = newTestConfig(c, testing.Attrs{
+		"metrics-sender":   "http",
+		"metrics-push-url": "http://10.0.0.1:8080/metrics",
+	})
+	c.Assert(config.MetricsSender(), gc.Equals, "http")
+	c.Assert(config.MetricsPushURL(), gc.Equals, "http://10.0.0.1:8080/metrics")
+}
+
+func (s *ConfigSuite) TestLogForwardSyslogConfigs(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.LogForwardSyslogConfigs(), gc.HasLen, 0)
+
+	config = newTestConfig(c, testing.Attrs{
+		"logforward-syslog-hosts":   "logs1.example.com:6514,logs2.example.com:6514",
+		"logforward-syslog-ca-cert": testing.CACert,
+	})
+	c.Assert(config.LogForwardSyslogConfigs(), jc.DeepEquals, []syslog.ClientConfig{{
+		Address: "logs1.example.com:6514",
+		CACert:  testing.CACert,
+	}, {
+		Address: "logs2.example.com:6514",
+		CACert:  testing.CACert,
+	}})
+}
+
 func (s *ConfigSuite) TestCloudImageBaseURL(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
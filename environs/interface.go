@@ -245,6 +245,27 @@ type Firewaller interface {
 	Ports() ([]network.PortRange, error)
 }
 
+// IngressFirewaller is implemented by environments whose firewall can
+// restrict the traffic reaching opened ports to particular source
+// CIDRs. Environments which do not implement it can only open ports to
+// traffic from anywhere.
+type IngressFirewaller interface {
+	// OpenIngressRules opens the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode.
+	IngressRules() ([]network.IngressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -111,7 +111,7 @@ models:
   users:
     admin@local:
       display-name: admin
-      access: write
+      access: admin
       last-connection: just now
 current-model: admin
 `[1:])
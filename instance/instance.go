@@ -54,6 +54,26 @@ type Instance interface {
 	Ports(machineId string) ([]network.PortRange, error)
 }
 
+// InstanceIngressFirewaller is implemented by instances whose firewall
+// can restrict the traffic reaching opened ports to particular source
+// CIDRs. Instances which do not implement it can only open ports to
+// traffic from anywhere.
+type InstanceIngressFirewaller interface {
+	// OpenIngressRules opens the given ingress rules on the instance,
+	// which should have been started with the given machine id.
+	OpenIngressRules(machineId string, rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules on the
+	// instance, which should have been started with the given
+	// machine id.
+	CloseIngressRules(machineId string, rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules open on the instance,
+	// which should have been started with the given machine id. The
+	// rules are returned as sorted by network.SortIngressRules().
+	IngressRules(machineId string) ([]network.IngressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
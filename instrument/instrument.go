@@ -0,0 +1,354 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package instrument provides the counters, gauges and histograms with
+// which juju reports on its own operation, and writes them in the
+// Prometheus text exposition format.
+package instrument
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the text written by
+// Registry.WriteTo.
+const ContentType = "text/plain; version=0.0.4"
+
+// DefaultBuckets holds the histogram bucket upper bounds, in seconds,
+// suitable for timing requests.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Default holds the metrics of the running process.
+var Default = NewRegistry()
+
+// Registry holds a set of named metrics.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry returns a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// register adds the family to the registry. Metrics are declared by
+// package variables, so a duplicate name is a programming error.
+func (r *Registry) register(f *family) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.families[f.name]; ok {
+		panic(fmt.Sprintf("metric %q already registered", f.name))
+	}
+	r.families[f.name] = f
+	return f
+}
+
+// WriteTo writes the current value of every metric in the registry in
+// the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	sort.Sort(byName(families))
+
+	var buf bytes.Buffer
+	for _, f := range families {
+		f.write(&buf)
+	}
+	return buf.WriteTo(w)
+}
+
+type byName []*family
+
+func (s byName) Len() int           { return len(s) }
+func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byName) Less(i, j int) bool { return s[i].name < s[j].name }
+
+// sample is implemented by Counter, Gauge and Histogram.
+type sample interface {
+	write(buf *bytes.Buffer, name, labels string)
+}
+
+// family holds the samples of a metric, one for each distinct set of
+// label values.
+type family struct {
+	name      string
+	help      string
+	kind      string
+	labels    []string
+	newSample func() sample
+
+	mu      sync.Mutex
+	samples map[string]sample
+}
+
+func (f *family) with(values []string) sample {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metric %q: expected %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	pairs := make([]string, len(values))
+	for i, value := range values {
+		pairs[i] = f.labels[i] + "=" + quoteLabel(value)
+	}
+	key := strings.Join(pairs, ",")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.samples[key]
+	if !ok {
+		s = f.newSample()
+		f.samples[key] = s
+	}
+	return s
+}
+
+func (f *family) write(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", f.name, f.kind)
+	f.mu.Lock()
+	keys := make([]string, 0, len(f.samples))
+	for key := range f.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		f.samples[key].write(buf, f.name, key)
+	}
+	f.mu.Unlock()
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func quoteLabel(value string) string {
+	return `"` + labelReplacer.Replace(value) + `"`
+}
+
+// writeSample writes a single sample line; labels holds the
+// comma-separated label pairs, and may be empty.
+func writeSample(buf *bytes.Buffer, name, labels string, value float64) {
+	buf.WriteString(name)
+	if labels != "" {
+		buf.WriteString("{" + labels + "}")
+	}
+	buf.WriteString(" " + formatFloat(value) + "\n")
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// CounterVec is a set of counters distinguished by label values.
+type CounterVec struct {
+	f *family
+}
+
+// NewCounterVec registers a new set of counters with the given name,
+// help text and label names.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{r.register(&family{
+		name:      name,
+		help:      help,
+		kind:      "counter",
+		labels:    labels,
+		newSample: func() sample { return &Counter{} },
+		samples:   make(map[string]sample),
+	})}
+}
+
+// With returns the counter with the given label values, which must be
+// given in the order of the label names.
+func (v *CounterVec) With(values ...string) *Counter {
+	return v.f.with(values).(*Counter)
+}
+
+// Counter is a value that only increases.
+type Counter struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add adds the given non-negative amount to the counter.
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic("counter cannot decrease")
+	}
+	c.mu.Lock()
+	c.value += delta
+	c.mu.Unlock()
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
+
+func (c *Counter) write(buf *bytes.Buffer, name, labels string) {
+	writeSample(buf, name, labels, c.Value())
+}
+
+// GaugeVec is a set of gauges distinguished by label values.
+type GaugeVec struct {
+	f *family
+}
+
+// NewGaugeVec registers a new set of gauges with the given name, help
+// text and label names.
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{r.register(&family{
+		name:      name,
+		help:      help,
+		kind:      "gauge",
+		labels:    labels,
+		newSample: func() sample { return &Gauge{} },
+		samples:   make(map[string]sample),
+	})}
+}
+
+// With returns the gauge with the given label values, which must be
+// given in the order of the label names.
+func (v *GaugeVec) With(values ...string) *Gauge {
+	return v.f.with(values).(*Gauge)
+}
+
+// Gauge is a value that may go up and down.
+type Gauge struct {
+	mu    sync.Mutex
+	value float64
+}
+
+// Set sets the gauge to the given value.
+func (g *Gauge) Set(value float64) {
+	g.mu.Lock()
+	g.value = value
+	g.mu.Unlock()
+}
+
+// Add adds the given amount, which may be negative, to the gauge.
+func (g *Gauge) Add(delta float64) {
+	g.mu.Lock()
+	g.value += delta
+	g.mu.Unlock()
+}
+
+// Inc adds one to the gauge.
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec subtracts one from the gauge.
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+func (g *Gauge) write(buf *bytes.Buffer, name, labels string) {
+	writeSample(buf, name, labels, g.Value())
+}
+
+// HistogramVec is a set of histograms distinguished by label values.
+type HistogramVec struct {
+	f *family
+}
+
+// NewHistogramVec registers a new set of histograms with the given
+// name, help text, bucket upper bounds and label names. The buckets
+// must be in increasing order.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if !sort.Float64sAreSorted(buckets) {
+		panic(fmt.Sprintf("metric %q: buckets not in increasing order", name))
+	}
+	return &HistogramVec{r.register(&family{
+		name:   name,
+		help:   help,
+		kind:   "histogram",
+		labels: labels,
+		newSample: func() sample {
+			return &Histogram{
+				buckets: buckets,
+				counts:  make([]uint64, len(buckets)),
+			}
+		},
+		samples: make(map[string]sample),
+	})}
+}
+
+// With returns the histogram with the given label values, which must be
+// given in the order of the label names.
+func (v *HistogramVec) With(values ...string) *Histogram {
+	return v.f.with(values).(*Histogram)
+}
+
+// Histogram counts observed values in buckets.
+type Histogram struct {
+	buckets []float64
+
+	mu     sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Observe adds the given value to the histogram.
+func (h *Histogram) Observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// Count returns the number of observed values.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+func (h *Histogram) write(buf *bytes.Buffer, name, labels string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	prefix := labels
+	if prefix != "" {
+		prefix += ","
+	}
+	var cumulative uint64
+	for i, bound := range h.buckets {
+		cumulative += h.counts[i]
+		writeSample(buf, name+"_bucket", prefix+"le="+quoteLabel(formatFloat(bound)), float64(cumulative))
+	}
+	writeSample(buf, name+"_bucket", prefix+`le="+Inf"`, float64(h.count))
+	writeSample(buf, name+"_sum", labels, h.sum)
+	writeSample(buf, name+"_count", labels, float64(h.count))
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instrument_test
+
+import (
+	"bytes"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instrument"
+)
+
+type instrumentSuite struct {
+	testing.IsolationSuite
+	registry *instrument.Registry
+}
+
+var _ = gc.Suite(&instrumentSuite{})
+
+func (s *instrumentSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.registry = instrument.NewRegistry()
+}
+
+func (s *instrumentSuite) assertOutput(c *gc.C, expect string) {
+	var buf bytes.Buffer
+	_, err := s.registry.WriteTo(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, expect)
+}
+
+func (s *instrumentSuite) TestCounter(c *gc.C) {
+	requests := s.registry.NewCounterVec("requests_total", "Requests handled.", "facade", "method")
+	requests.With("Client", "FullStatus").Inc()
+	requests.With("Client", "FullStatus").Add(2)
+	requests.With("Agent", "GetEntities").Inc()
+	c.Assert(requests.With("Client", "FullStatus").Value(), gc.Equals, float64(3))
+
+	s.assertOutput(c, `# HELP requests_total Requests handled.
+# TYPE requests_total counter
+requests_total{facade="Agent",method="GetEntities"} 1
+requests_total{facade="Client",method="FullStatus"} 3
+`)
+}
+
+func (s *instrumentSuite) TestCounterCannotDecrease(c *gc.C) {
+	counter := s.registry.NewCounterVec("errors_total", "Errors.").With()
+	c.Assert(func() { counter.Add(-1) }, gc.PanicMatches, "counter cannot decrease")
+}
+
+func (s *instrumentSuite) TestGauge(c *gc.C) {
+	connections := s.registry.NewGaugeVec("connections", "Active connections.").With()
+	connections.Inc()
+	connections.Inc()
+	connections.Dec()
+	c.Assert(connections.Value(), gc.Equals, float64(1))
+
+	s.assertOutput(c, `# HELP connections Active connections.
+# TYPE connections gauge
+connections 1
+`)
+}
+
+func (s *instrumentSuite) TestHistogram(c *gc.C) {
+	durations := s.registry.NewHistogramVec("duration_seconds", "Request durations.", []float64{0.25, 1}, "method")
+	durations.With("Ping").Observe(0.25)
+	durations.With("Ping").Observe(0.5)
+	durations.With("Ping").Observe(2)
+	c.Assert(durations.With("Ping").Count(), gc.Equals, uint64(3))
+
+	s.assertOutput(c, `# HELP duration_seconds Request durations.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{method="Ping",le="0.25"} 1
+duration_seconds_bucket{method="Ping",le="1"} 2
+duration_seconds_bucket{method="Ping",le="+Inf"} 3
+duration_seconds_sum{method="Ping"} 2.75
+duration_seconds_count{method="Ping"} 3
+`)
+}
+
+func (s *instrumentSuite) TestLabelValuesQuoted(c *gc.C) {
+	s.registry.NewCounterVec("restarts_total", "Restarts.", "name").With(`a "quoted"\name`).Inc()
+	s.assertOutput(c, `# HELP restarts_total Restarts.
+# TYPE restarts_total counter
+restarts_total{name="a \"quoted\"\\name"} 1
+`)
+}
+
+func (s *instrumentSuite) TestFamiliesSortedByName(c *gc.C) {
+	s.registry.NewGaugeVec("b", "B.")
+	s.registry.NewGaugeVec("a", "A.")
+	s.assertOutput(c, `# HELP a A.
+# TYPE a gauge
+# HELP b B.
+# TYPE b gauge
+`)
+}
+
+func (s *instrumentSuite) TestDuplicateName(c *gc.C) {
+	s.registry.NewCounterVec("requests_total", "Requests.")
+	c.Assert(func() {
+		s.registry.NewGaugeVec("requests_total", "Requests.")
+	}, gc.PanicMatches, `metric "requests_total" already registered`)
+}
+
+func (s *instrumentSuite) TestWrongLabelCount(c *gc.C) {
+	requests := s.registry.NewCounterVec("requests_total", "Requests.", "facade", "method")
+	c.Assert(func() {
+		requests.With("Client")
+	}, gc.PanicMatches, `metric "requests_total": expected 2 label values, got 1`)
+}
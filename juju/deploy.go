@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 
@@ -29,8 +30,12 @@ type DeployServiceParams struct {
 	NumUnits       int
 	// Placement is a list of placement directives which may be used
 	// instead of a machine spec.
-	Placement        []*instance.Placement
-	Storage          map[string]storage.Constraints
+	Placement []*instance.Placement
+	Storage   map[string]storage.Constraints
+	// AttachStorage contains IDs of existing storage that should be
+	// attached to the service unit that will be deployed. This must
+	// be empty if NumUnits is not 1.
+	AttachStorage    []names.StorageTag
 	EndpointBindings map[string]string
 	// Resources is a map of resource name to IDs of pending resources.
 	Resources map[string]string
@@ -74,6 +79,7 @@ func DeployService(st ServiceDeployer, args DeployServiceParams) (*state.Service
 		Charm:            args.Charm,
 		Channel:          args.Channel,
 		Storage:          stateStorageConstraints(args.Storage),
+		AttachStorage:    args.AttachStorage,
 		Settings:         settings,
 		NumUnits:         args.NumUnits,
 		Placement:        args.Placement,
@@ -112,14 +118,26 @@ func getEffectiveBindingsForCharmMeta(charmMeta *charm.Meta, givenBindings map[s
 }
 
 // AddUnits starts n units of the given service using the specified placement
-// directives to allocate the machines.
-func AddUnits(st *state.State, svc *state.Service, n int, placement []*instance.Placement) ([]*state.Unit, error) {
+// directives to allocate the machines. If attachStorage is non-empty, n must
+// be 1 and the existing storage is attached to the new unit.
+func AddUnits(
+	st *state.State,
+	svc *state.Service,
+	n int,
+	placement []*instance.Placement,
+	attachStorage []names.StorageTag,
+) ([]*state.Unit, error) {
+	if len(attachStorage) > 0 && n != 1 {
+		return nil, errors.Errorf("cannot attach existing storage to more than one unit")
+	}
 	units := make([]*state.Unit, n)
 	// Hard code for now till we implement a different approach.
 	policy := state.AssignCleanEmpty
 	// TODO what do we do if we fail half-way through this process?
 	for i := 0; i < n; i++ {
-		unit, err := svc.AddUnit()
+		unit, err := svc.AddUnitWithParams(state.AddUnitParams{
+			AttachStorage: attachStorage,
+		})
 		if err != nil {
 			return nil, errors.Annotatef(err, "cannot add unit %d/%d to service %q", i+1, n, svc.Name())
 		}
@@ -31,6 +31,10 @@ func (s *permissionSuite) TestParseModelAccessValid(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 	c.Check(access, gc.Equals, permission.ModelWriteAccess)
 
+	access, err = permission.ParseModelAccess("admin")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ModelAdminAccess)
+
 	access, err = permission.ParseModelAccess("orange")
 	c.Check(err, gc.ErrorMatches, "invalid model access permission.*")
 }
@@ -39,3 +43,16 @@ func (s *permissionSuite) TestParseModelAccessInvalid(c *gc.C) {
 	_, err := permission.ParseModelAccess("preposterous")
 	c.Check(err, gc.ErrorMatches, "invalid model access permission.*")
 }
+
+func (s *permissionSuite) TestParseControllerAccess(c *gc.C) {
+	access, err := permission.ParseControllerAccess("add-model")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ControllerAddModelAccess)
+
+	access, err = permission.ParseControllerAccess("superuser")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ControllerSuperuserAccess)
+
+	_, err = permission.ParseControllerAccess("write")
+	c.Check(err, gc.ErrorMatches, `invalid controller access permission "write"`)
+}
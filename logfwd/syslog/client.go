@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+)
+
+const (
+	// dialTimeout is how long Dial waits for a connection.
+	dialTimeout = 30 * time.Second
+
+	// sendTimeout is how long Send waits for a message to be written.
+	sendTimeout = 30 * time.Second
+)
+
+// ClientConfig holds the details needed to connect to a syslog
+// collector.
+type ClientConfig struct {
+	// Address is the host:port of the collector.
+	Address string
+
+	// CACert is the PEM-encoded certificate of the CA which signed
+	// the collector's certificate. Messages are sent over TLS when it
+	// is set, and over plain TCP otherwise.
+	CACert string
+
+	// ClientCert and ClientKey are the PEM-encoded certificate and
+	// key with which the client authenticates itself to the collector.
+	// They are optional, and may only be set along with CACert.
+	ClientCert string
+	ClientKey  string
+}
+
+// Validate returns an error if the config is not valid.
+func (cfg ClientConfig) Validate() error {
+	host, port, err := net.SplitHostPort(cfg.Address)
+	if err != nil || host == "" || port == "" {
+		return errors.NotValidf("syslog address %q (expected host:port)", cfg.Address)
+	}
+	if cfg.CACert != "" {
+		if _, err := cert.ParseCert(cfg.CACert); err != nil {
+			return errors.Annotate(err, "invalid syslog CA certificate")
+		}
+	}
+	if (cfg.ClientCert == "") != (cfg.ClientKey == "") {
+		return errors.New("syslog client certificate and key must be set together")
+	}
+	if cfg.ClientCert != "" {
+		if cfg.CACert == "" {
+			return errors.NotValidf("syslog client certificate without CA certificate")
+		}
+		if _, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey)); err != nil {
+			return errors.Annotate(err, "invalid syslog client certificate")
+		}
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration for connections to the
+// collector, or nil if connections should not use TLS.
+func (cfg ClientConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	host, _, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    pool,
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.ClientCert != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+// Client sends messages to a syslog collector.
+type Client struct {
+	conn net.Conn
+}
+
+// Dial connects to the syslog collector described by cfg.
+func Dial(cfg ClientConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	if tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.Address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.Address)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot connect to syslog collector at %s", cfg.Address)
+	}
+	return &Client{conn: conn}, nil
+}
+
+// Send sends the message to the collector. Messages are framed by
+// prefixing them with their length, as RFC 5425 requires and RFC 6587
+// recommends for plain TCP.
+func (c *Client) Send(m Message) error {
+	msg := m.String()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(sendTimeout)); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := fmt.Fprintf(c.conn, "%d %s", len(msg), msg); err != nil {
+		return errors.Annotate(err, "cannot send syslog message")
+	}
+	return nil
+}
+
+// Close closes the connection to the collector.
+func (c *Client) Close() error {
+	return errors.Trace(c.conn.Close())
+}
@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestValidate(c *gc.C) {
+	clientCert, clientKey, err := cert.NewClient(coretesting.CACert, coretesting.CAKey, time.Now().AddDate(1, 0, 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		cfg syslog.ClientConfig
+		err string
+	}{{
+		cfg: syslog.ClientConfig{Address: "logs.example.com:6514"},
+	}, {
+		cfg: syslog.ClientConfig{
+			Address:    "10.0.0.1:6514",
+			CACert:     coretesting.CACert,
+			ClientCert: clientCert,
+			ClientKey:  clientKey,
+		},
+	}, {
+		cfg: syslog.ClientConfig{Address: "logs.example.com"},
+		err: `syslog address "logs.example.com" \(expected host:port\) not valid`,
+	}, {
+		cfg: syslog.ClientConfig{Address: ":6514"},
+		err: `syslog address ":6514" \(expected host:port\) not valid`,
+	}, {
+		cfg: syslog.ClientConfig{Address: "logs.example.com:6514", CACert: "foo"},
+		err: `invalid syslog CA certificate: .*`,
+	}, {
+		cfg: syslog.ClientConfig{Address: "logs.example.com:6514", CACert: coretesting.CACert, ClientCert: clientCert},
+		err: `syslog client certificate and key must be set together`,
+	}, {
+		cfg: syslog.ClientConfig{Address: "logs.example.com:6514", ClientCert: clientCert, ClientKey: clientKey},
+		err: `syslog client certificate without CA certificate not valid`,
+	}, {
+		cfg: syslog.ClientConfig{
+			Address:    "logs.example.com:6514",
+			CACert:     coretesting.CACert,
+			ClientCert: clientCert,
+			ClientKey:  coretesting.OtherCAKey,
+		},
+		err: `invalid syslog client certificate: .*`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.cfg.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *clientSuite) TestSend(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	received := s.receive(c, listener, 2)
+	client, err := syslog.Dial(syslog.ClientConfig{Address: listener.Addr().String()})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	for _, msg := range []string{"one", "two"} {
+		err = client.Send(syslog.Message{Msg: msg})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.checkReceived(c, received, "<0>1 - - - - - - one", "<0>1 - - - - - - two")
+}
+
+func (s *clientSuite) TestSendTLS(c *gc.C) {
+	serverCert, serverKey, err := cert.NewServer(coretesting.CACert, coretesting.CAKey, time.Now().AddDate(1, 0, 0), []string{"127.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	keyPair, err := tls.X509KeyPair([]byte(serverCert), []byte(serverKey))
+	c.Assert(err, jc.ErrorIsNil)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	received := s.receive(c, listener, 1)
+	client, err := syslog.Dial(syslog.ClientConfig{
+		Address: listener.Addr().String(),
+		CACert:  coretesting.CACert,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send(syslog.Message{Msg: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkReceived(c, received, "<0>1 - - - - - - secret")
+}
+
+func (s *clientSuite) TestDialTLSUntrusted(c *gc.C) {
+	serverCert, serverKey, err := cert.NewServer(coretesting.OtherCACert, coretesting.OtherCAKey, time.Now().AddDate(1, 0, 0), []string{"127.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	keyPair, err := tls.X509KeyPair([]byte(serverCert), []byte(serverKey))
+	c.Assert(err, jc.ErrorIsNil)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Read(make([]byte, 1))
+			conn.Close()
+		}
+	}()
+
+	_, err = syslog.Dial(syslog.ClientConfig{
+		Address: listener.Addr().String(),
+		CACert:  coretesting.CACert,
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot connect to syslog collector at .*: x509: .*")
+}
+
+// receive accepts a connection on listener and returns a channel on
+// which the first count messages sent over it are delivered.
+func (s *clientSuite) receive(c *gc.C, listener net.Listener, count int) <-chan string {
+	received := make(chan string, count)
+	go func() {
+		defer close(received)
+		conn, err := listener.Accept()
+		if err != nil {
+			c.Errorf("accept failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for i := 0; i < count; i++ {
+			msg, err := readFramedMessage(reader)
+			if err != nil {
+				c.Errorf("read failed: %v", err)
+				return
+			}
+			received <- msg
+		}
+	}()
+	return received
+}
+
+func (s *clientSuite) checkReceived(c *gc.C, received <-chan string, expected ...string) {
+	for _, msg := range expected {
+		select {
+		case actual := <-received:
+			c.Check(actual, gc.Equals, msg)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for message %q", msg)
+		}
+	}
+}
+
+// readFramedMessage reads a message prefixed with its length.
+func readFramedMessage(reader *bufio.Reader) (string, error) {
+	prefix, err := reader.ReadString(' ')
+	if err != nil {
+		return "", err
+	}
+	length, err := strconv.Atoi(prefix[:len(prefix)-1])
+	if err != nil {
+		return "", fmt.Errorf("bad message length %q", prefix)
+	}
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(reader, msg); err != nil {
+		return "", err
+	}
+	return string(msg), nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package syslog formats messages as described by RFC 5424 and sends
+// them to remote syslog collectors over TCP, optionally secured with
+// TLS as described by RFC 5425.
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Facility identifies the part of a system which logged a message.
+type Facility int
+
+// These are the facilities used by juju; RFC 5424 defines the rest.
+const (
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+)
+
+// Severity is the importance of a message.
+type Severity int
+
+// These are the severities defined by RFC 5424.
+const (
+	SeverityEmergency Severity = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInformational
+	SeverityDebug
+)
+
+// nilValue stands in for a header field, or the structured data, of
+// a message when it has no value.
+const nilValue = "-"
+
+// timestampFormat is the RFC 3339 layout of message timestamps, which
+// may have at most microsecond precision.
+const timestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// Param is a name/value pair in a structured data element.
+type Param struct {
+	Name  string
+	Value string
+}
+
+// SDElement is an element of a message's structured data. IDs which
+// are not registered with IANA must have the form name@<private
+// enterprise number>.
+type SDElement struct {
+	ID     string
+	Params []Param
+}
+
+// Message is a syslog message.
+type Message struct {
+	Facility       Facility
+	Severity       Severity
+	Time           time.Time
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData []SDElement
+	Msg            string
+}
+
+// String returns the message in the syslog protocol's format.
+func (m Message) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 ", int(m.Facility)*8+int(m.Severity))
+	if m.Time.IsZero() {
+		buf.WriteString(nilValue)
+	} else {
+		buf.WriteString(m.Time.UTC().Format(timestampFormat))
+	}
+	for _, field := range []struct {
+		value  string
+		maxLen int
+	}{
+		{m.Hostname, 255},
+		{m.AppName, 48},
+		{m.ProcID, 128},
+		{m.MsgID, 32},
+	} {
+		buf.WriteByte(' ')
+		buf.WriteString(headerField(field.value, field.maxLen))
+	}
+	buf.WriteByte(' ')
+	if len(m.StructuredData) == 0 {
+		buf.WriteString(nilValue)
+	}
+	for _, elem := range m.StructuredData {
+		buf.WriteByte('[')
+		buf.WriteString(elem.ID)
+		for _, param := range elem.Params {
+			fmt.Fprintf(&buf, ` %s="%s"`, param.Name, paramValueEscaper.Replace(param.Value))
+		}
+		buf.WriteByte(']')
+	}
+	if m.Msg != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(m.Msg)
+	}
+	return buf.String()
+}
+
+// headerField returns value as it may appear in a message's header:
+// printable ASCII without spaces, at most maxLen characters long.
+func headerField(value string, maxLen int) string {
+	if value == "" {
+		return nilValue
+	}
+	field := []byte(value)
+	if len(field) > maxLen {
+		field = field[:maxLen]
+	}
+	for i, c := range field {
+		if c < '!' || c > '~' {
+			field[i] = '_'
+		}
+	}
+	return string(field)
+}
+
+// paramValueEscaper escapes the characters which may not appear
+// unescaped in structured data parameter values.
+var paramValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
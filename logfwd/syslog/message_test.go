@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package syslog_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+type messageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&messageSuite{})
+
+func (s *messageSuite) TestString(c *gc.C) {
+	m := syslog.Message{
+		Facility: syslog.FacilityUser,
+		Severity: syslog.SeverityError,
+		Time:     time.Date(2016, 6, 19, 15, 34, 37, 123456789, time.FixedZone("", 3600)),
+		Hostname: "machine-0.deadbeef",
+		AppName:  "juju",
+		StructuredData: []syslog.SDElement{{
+			ID: "juju@28978",
+			Params: []syslog.Param{
+				{Name: "module", Value: "juju.worker"},
+				{Name: "location", Value: "worker.go:42"},
+			},
+		}},
+		Msg: "stuff happened",
+	}
+	c.Check(m.String(), gc.Equals, `<11>1 2016-06-19T14:34:37.123456Z machine-0.deadbeef juju - - `+
+		`[juju@28978 module="juju.worker" location="worker.go:42"] stuff happened`)
+}
+
+func (s *messageSuite) TestStringNilValues(c *gc.C) {
+	m := syslog.Message{
+		Facility: syslog.FacilityDaemon,
+		Severity: syslog.SeverityDebug,
+	}
+	c.Check(m.String(), gc.Equals, `<31>1 - - - - - -`)
+}
+
+func (s *messageSuite) TestStringEscapesParamValues(c *gc.C) {
+	m := syslog.Message{
+		StructuredData: []syslog.SDElement{{
+			ID:     "x@1",
+			Params: []syslog.Param{{Name: "v", Value: `a"b\c]d`}},
+		}},
+	}
+	c.Check(m.String(), gc.Equals, `<0>1 - - - - - [x@1 v="a\"b\\c\]d"]`)
+}
+
+func (s *messageSuite) TestStringSanitisesHeaderFields(c *gc.C) {
+	m := syslog.Message{
+		Hostname: "my host",
+		MsgID:    strings.Repeat("m", 40),
+	}
+	c.Check(m.String(), gc.Equals, `<0>1 - my_host - - `+strings.Repeat("m", 32)+` -`)
+}
@@ -0,0 +1,301 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// Issue describes a problem that would cause a model migration to
+// fail, along with the migration phase in which it would be found.
+type Issue struct {
+	Phase   coremigration.Phase
+	Message string
+}
+
+func newIssue(phase coremigration.Phase, format string, args ...interface{}) Issue {
+	return Issue{Phase: phase, Message: fmt.Sprintf(format, args...)}
+}
+
+// IssuesToParams converts issues into their API representation.
+func IssuesToParams(issues []Issue) []params.MigrationIssue {
+	result := make([]params.MigrationIssue, len(issues))
+	for i, issue := range issues {
+		result[i] = params.MigrationIssue{
+			Phase:   issue.Phase.String(),
+			Message: issue.Message,
+		}
+	}
+	return result
+}
+
+// SourcePrecheckBackend is implemented by *state.State but defined as
+// an interface for easier testing.
+type SourcePrecheckBackend interface {
+	PrecheckBackend
+	IsModelMigrationActive() (bool, error)
+	Charm(*charm.URL) (*state.Charm, error)
+}
+
+// SourcePrecheck checks that the model described would be accepted for
+// migration by its source controller. Unlike Precheck, it doesn't stop
+// at the first problem but returns all of the problems found. An error
+// is only returned if the checks could not be run.
+func SourcePrecheck(backend SourcePrecheckBackend, model description.Model) ([]Issue, error) {
+	var issues []Issue
+	addIssue := func(format string, args ...interface{}) {
+		issues = append(issues, newIssue(coremigration.PRECHECK, format, args...))
+	}
+
+	cleanupNeeded, err := backend.NeedsCleanup()
+	if err != nil {
+		return nil, errors.Annotate(err, "precheck cleanups")
+	}
+	if cleanupNeeded {
+		addIssue("cleanup needed")
+	}
+
+	active, err := backend.IsModelMigrationActive()
+	if err != nil {
+		return nil, errors.Annotate(err, "precheck migration status")
+	}
+	if active {
+		addIssue("model is already being migrated")
+	}
+
+	agentVersion, err := modelAgentVersion(model)
+	if err != nil {
+		addIssue("%v", err)
+	} else {
+		for _, machine := range model.Machines() {
+			checkMachineTools(machine, agentVersion, addIssue)
+		}
+		for _, service := range model.Services() {
+			for _, unit := range service.Units() {
+				tools := unit.Tools()
+				if tools == nil {
+					addIssue("unit %s has no tools", unit.Name())
+				} else if v := tools.Version().Number; v != agentVersion {
+					addIssue("unit %s is running tools %s, model agent version is %s", unit.Name(), v, agentVersion)
+				}
+			}
+		}
+	}
+
+	for _, charmURL := range getUsedCharms(model).SortedValues() {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			addIssue("bad charm URL %q: %v", charmURL, err)
+			continue
+		}
+		ch, err := backend.Charm(curl)
+		if errors.IsNotFound(err) {
+			addIssue("charm %s not found", charmURL)
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "precheck charm %s", charmURL)
+		}
+		if ch.IsPlaceholder() || !ch.IsUploaded() {
+			addIssue("charm %s has not been uploaded", charmURL)
+		}
+	}
+	return issues, nil
+}
+
+func checkMachineTools(machine description.Machine, agentVersion version.Number, addIssue func(string, ...interface{})) {
+	tools := machine.Tools()
+	if tools == nil {
+		addIssue("machine %s has no tools", machine.Id())
+	} else if v := tools.Version().Number; v != agentVersion {
+		addIssue("machine %s is running tools %s, model agent version is %s", machine.Id(), v, agentVersion)
+	}
+	for _, container := range machine.Containers() {
+		checkMachineTools(container, agentVersion, addIssue)
+	}
+}
+
+func modelAgentVersion(model description.Model) (version.Number, error) {
+	value, _ := model.Config()[config.AgentVersionKey].(string)
+	if value == "" {
+		return version.Zero, errors.New("model has no agent version")
+	}
+	number, err := version.Parse(value)
+	if err != nil {
+		return version.Zero, errors.Annotate(err, "invalid model agent version")
+	}
+	return number, nil
+}
+
+// DryRunImport checks whether the model described could be imported
+// into the controller, without leaving the model behind. The model is
+// imported under a new name and UUID into a scratch model, compared
+// with its description, and then removed again. All problems found are
+// returned; an error is only returned if the checks could not be run.
+// The model description is altered by the import.
+func DryRunImport(st *state.State, model description.Model) ([]Issue, error) {
+	var issues []Issue
+	addIssue := func(phase coremigration.Phase, format string, args ...interface{}) {
+		issues = append(issues, newIssue(phase, format, args...))
+	}
+
+	if agentVersion, err := modelAgentVersion(model); err != nil {
+		addIssue(coremigration.PRECHECK, "%v", err)
+	} else if agentVersion.Compare(jujuversion.Current) > 0 {
+		addIssue(coremigration.PRECHECK, "model agent version %s is newer than controller version %s",
+			agentVersion, jujuversion.Current)
+	}
+
+	if _, err := st.GetModel(model.Tag()); err == nil {
+		addIssue(coremigration.PRECHECK, "model with UUID %s already exists", model.Tag().Id())
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	owner := model.Owner()
+	if owner.IsLocal() {
+		if _, err := st.User(owner); errors.IsNotFound(err) {
+			addIssue(coremigration.PRECHECK, "owner %s does not exist", owner.Canonical())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	name, _ := model.Config()["name"].(string)
+	models, err := st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range models {
+		if m.Owner() == owner && m.Name() == name {
+			addIssue(coremigration.PRECHECK, "owner %s already has a model called %q", owner.Canonical(), name)
+			break
+		}
+	}
+
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerConfig, err := controllerModel.Config()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if providerType, _ := model.Config()["type"].(string); providerType != controllerConfig.Type() {
+		addIssue(coremigration.PRECHECK, "model provider type %q does not match controller provider type %q",
+			providerType, controllerConfig.Type())
+	}
+
+	validationIssues, err := importScratchModel(st, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(issues, validationIssues...), nil
+}
+
+// importScratchModel imports the model under a new name and UUID,
+// checks that everything described made it into the database, and
+// removes the model again.
+func importScratchModel(st *state.State, model description.Model) ([]Issue, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model.UpdateConfig(map[string]interface{}{
+		"name": "dry-run-" + uuid.String()[:8],
+		"uuid": uuid.String(),
+	})
+
+	_, dbState, err := ImportModelDescription(st, model)
+	if err != nil {
+		// A failed import may leave part of the model behind.
+		if cleanupErr := removeScratchModel(st, model); cleanupErr != nil {
+			return nil, errors.Annotate(cleanupErr, "removing scratch model")
+		}
+		return []Issue{newIssue(coremigration.IMPORT, "%v", err)}, nil
+	}
+	defer dbState.Close()
+
+	issues, err := validateImport(dbState, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := dbState.RemoveImportingModelDocs(); err != nil {
+		return nil, errors.Annotate(err, "removing scratch model")
+	}
+	return issues, nil
+}
+
+func removeScratchModel(st *state.State, model description.Model) error {
+	if _, err := st.GetModel(model.Tag()); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	dbState, err := st.ForModel(model.Tag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer dbState.Close()
+	return errors.Trace(dbState.RemoveImportingModelDocs())
+}
+
+func validateImport(st *state.State, model description.Model) ([]Issue, error) {
+	var issues []Issue
+	addIssue := func(format string, args ...interface{}) {
+		issues = append(issues, newIssue(coremigration.VALIDATION, format, args...))
+	}
+
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if expected := countMachines(model.Machines()); len(machines) != expected {
+		addIssue("expected %d machines, found %d", expected, len(machines))
+	}
+
+	services, err := st.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if expected := len(model.Services()); len(services) != expected {
+		addIssue("expected %d services, found %d", expected, len(services))
+	}
+	for _, service := range model.Services() {
+		dbService, err := st.Service(service.Name())
+		if errors.IsNotFound(err) {
+			addIssue("service %s not imported", service.Name())
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := dbService.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if expected := len(service.Units()); len(units) != expected {
+			addIssue("expected %d units of service %s, found %d", expected, service.Name(), len(units))
+		}
+	}
+	return issues, nil
+}
+
+func countMachines(machines []description.Machine) int {
+	count := len(machines)
+	for _, machine := range machines {
+		count += countMachines(machine.Containers())
+	}
+	return count
+}
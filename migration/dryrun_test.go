@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type SourcePrecheckSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SourcePrecheckSuite{})
+
+// Assert that *state.State implements the SourcePrecheckBackend
+var _ migration.SourcePrecheckBackend = (*state.State)(nil)
+
+func newPrecheckModel(agentVersion string) description.Model {
+	return description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "precheck",
+			"uuid":          utils.MustNewUUID().String(),
+			"agent-version": agentVersion,
+		},
+	})
+}
+
+func addMachineWithTools(model description.Model, id string, number string) description.Machine {
+	machine := model.AddMachine(description.MachineArgs{
+		Id: names.NewMachineTag(id),
+	})
+	if number != "" {
+		machine.SetTools(description.AgentToolsArgs{
+			Version: version.MustParseBinary(number + "-trusty-amd64"),
+		})
+	}
+	return machine
+}
+
+func (*SourcePrecheckSuite) TestNoIssues(c *gc.C) {
+	model := newPrecheckModel("2.0.1")
+	addMachineWithTools(model, "0", "2.0.1")
+
+	issues, err := migration.SourcePrecheck(&fakeSourcePrecheckBackend{}, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestAllIssuesReported(c *gc.C) {
+	model := newPrecheckModel("2.0.1")
+	machine := addMachineWithTools(model, "0", "2.0.1")
+	machine.AddContainer(description.MachineArgs{
+		Id: names.NewMachineTag("0/lxc/0"),
+	}).SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.0-trusty-amd64"),
+	})
+	addMachineWithTools(model, "1", "")
+	model.AddService(description.ServiceArgs{
+		Tag:      names.NewServiceTag("mysql"),
+		CharmURL: "cs:trusty/mysql-1",
+	})
+
+	backend := &fakeSourcePrecheckBackend{
+		fakePrecheckBackend: fakePrecheckBackend{cleanupNeeded: true},
+		migrating:           true,
+	}
+	issues, err := migration.SourcePrecheck(backend, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []migration.Issue{{
+		Phase:   coremigration.PRECHECK,
+		Message: "cleanup needed",
+	}, {
+		Phase:   coremigration.PRECHECK,
+		Message: "model is already being migrated",
+	}, {
+		Phase:   coremigration.PRECHECK,
+		Message: "machine 0/lxc/0 is running tools 2.0.0, model agent version is 2.0.1",
+	}, {
+		Phase:   coremigration.PRECHECK,
+		Message: "machine 1 has no tools",
+	}, {
+		Phase:   coremigration.PRECHECK,
+		Message: "charm cs:trusty/mysql-1 not found",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestMissingAgentVersion(c *gc.C) {
+	model := newPrecheckModel("")
+	addMachineWithTools(model, "0", "2.0.1")
+
+	issues, err := migration.SourcePrecheck(&fakeSourcePrecheckBackend{}, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []migration.Issue{{
+		Phase:   coremigration.PRECHECK,
+		Message: "model has no agent version",
+	}})
+}
+
+func (*SourcePrecheckSuite) TestCleanupError(c *gc.C) {
+	backend := &fakeSourcePrecheckBackend{}
+	backend.cleanupError = errors.New("boom")
+	_, err := migration.SourcePrecheck(backend, newPrecheckModel("2.0.1"))
+	c.Assert(err, gc.ErrorMatches, "precheck cleanups: boom")
+}
+
+type fakeSourcePrecheckBackend struct {
+	fakePrecheckBackend
+	migrating bool
+}
+
+func (f *fakeSourcePrecheckBackend) IsModelMigrationActive() (bool, error) {
+	return f.migrating, nil
+}
+
+func (f *fakeSourcePrecheckBackend) Charm(curl *charm.URL) (*state.Charm, error) {
+	return nil, errors.NotFoundf("charm %q", curl)
+}
+
+type DryRunSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&DryRunSuite{})
+
+func (s *DryRunSuite) SetUpTest(c *gc.C) {
+	s.InitialConfig = dummyModelConfig(c)
+	s.StateSuite.SetUpTest(c)
+}
+
+func (s *DryRunSuite) TestDryRunImport(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+	})
+
+	issues, err := migration.DryRunImport(s.State, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, gc.HasLen, 0)
+
+	// The scratch model has gone.
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+}
+
+func (s *DryRunSuite) TestDryRunImportExistingModel(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	dbModel, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	issues, err := migration.DryRunImport(s.State, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []migration.Issue{{
+		Phase:   coremigration.PRECHECK,
+		Message: "model with UUID " + dbModel.UUID() + " already exists",
+	}, {
+		Phase:   coremigration.PRECHECK,
+		Message: `owner ` + dbModel.Owner().Canonical() + ` already has a model called "` + dbModel.Name() + `"`,
+	}})
+
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+}
+
+func (s *DryRunSuite) TestDryRunImportProviderMismatch(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": utils.MustNewUUID().String(),
+		"type": "unknown",
+	})
+
+	issues, err := migration.DryRunImport(s.State, model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 2)
+	c.Check(issues[0], jc.DeepEquals, migration.Issue{
+		Phase:   coremigration.PRECHECK,
+		Message: `model provider type "unknown" does not match controller provider type "dummy"`,
+	})
+	c.Check(issues[1].Phase, gc.Equals, coremigration.IMPORT)
+
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+}
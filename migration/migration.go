@@ -58,7 +58,14 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	return ImportModelDescription(st, model)
+}
 
+// ImportModelDescription transforms the model config of an already
+// deserialized model based on information from the controller model,
+// and then imports that as a new database model. It allows callers to
+// adjust the model before it is imported.
+func ImportModelDescription(st *state.State, model description.Model) (*state.Model, *state.State, error) {
 	controllerModel, err := st.ControllerModel()
 	if err != nil {
 		return nil, nil, errors.Trace(err)
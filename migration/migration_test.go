@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
@@ -43,10 +44,17 @@ var _ = gc.Suite(&ImportSuite{})
 func (s *ImportSuite) SetUpTest(c *gc.C) {
 	// Specify the config to use for the controller model before calling
 	// SetUpTest of the StateSuite, otherwise we get testing.ModelConfig(c).
-	// The default provider type specified in the testing.ModelConfig function
-	// is one that isn't registered as a valid provider. For our tests here we
-	// need a real registered provider, so we use the dummy provider.
-	// NOTE: make a better test provider.
+	s.InitialConfig = dummyModelConfig(c)
+	s.StateSuite.SetUpTest(c)
+}
+
+// dummyModelConfig returns the config for a controller model using the
+// dummy provider. The default provider type specified in the
+// testing.ModelConfig function is one that isn't registered as a valid
+// provider. For tests that import models we need a real registered
+// provider, so we use the dummy provider.
+// NOTE: make a better test provider.
+func dummyModelConfig(c *gc.C) *config.Config {
 	env, err := environs.Prepare(
 		modelcmd.BootstrapContext(testing.Context(c)),
 		jujuclienttesting.NewMemStore(),
@@ -57,9 +65,7 @@ func (s *ImportSuite) SetUpTest(c *gc.C) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-
-	s.InitialConfig = testing.CustomModelConfig(c, env.Config().AllAttrs())
-	s.StateSuite.SetUpTest(c)
+	return testing.CustomModelConfig(c, env.Config().AllAttrs())
 }
 
 func (s *ImportSuite) TestBadBytes(c *gc.C) {
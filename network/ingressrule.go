@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// AllSourceCIDR is the source CIDR of ingress rules which allow traffic
+// from anywhere.
+const AllSourceCIDR = "0.0.0.0/0"
+
+// IngressRule represents a range of ports opened to traffic from a
+// single source CIDR. A port range opened to several CIDRs is
+// represented by one rule for each, so that rules remain comparable.
+type IngressRule struct {
+	PortRange
+	SourceCIDR string
+}
+
+// NewIngressRule returns an IngressRule for the given port range and
+// source CIDR. An empty source CIDR means traffic from anywhere.
+func NewIngressRule(portRange PortRange, sourceCIDR string) IngressRule {
+	if sourceCIDR == "" {
+		sourceCIDR = AllSourceCIDR
+	}
+	return IngressRule{PortRange: portRange, SourceCIDR: sourceCIDR}
+}
+
+// IngressRulesFromPortRanges returns ingress rules opening each of the
+// port ranges to traffic from each of the source CIDRs.
+func IngressRulesFromPortRanges(portRanges []PortRange, sourceCIDRs ...string) []IngressRule {
+	if len(sourceCIDRs) == 0 {
+		sourceCIDRs = []string{AllSourceCIDR}
+	}
+	rules := make([]IngressRule, 0, len(portRanges)*len(sourceCIDRs))
+	for _, portRange := range portRanges {
+		for _, cidr := range sourceCIDRs {
+			rules = append(rules, NewIngressRule(portRange, cidr))
+		}
+	}
+	return rules
+}
+
+// Validate returns an error if the port range or the source CIDR of the
+// rule is not valid.
+func (r IngressRule) Validate() error {
+	if err := r.PortRange.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, _, err := net.ParseCIDR(r.SourceCIDR); err != nil {
+		return errors.NotValidf("source CIDR %q", r.SourceCIDR)
+	}
+	return nil
+}
+
+// OpenToAll returns whether the rule allows traffic from anywhere.
+func (r IngressRule) OpenToAll() bool {
+	return r.SourceCIDR == AllSourceCIDR
+}
+
+func (r IngressRule) String() string {
+	return fmt.Sprintf("%s from %s", r.PortRange, r.SourceCIDR)
+}
+
+func (r IngressRule) GoString() string {
+	return r.String()
+}
+
+type ingressRuleSlice []IngressRule
+
+func (p ingressRuleSlice) Len() int      { return len(p) }
+func (p ingressRuleSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p ingressRuleSlice) Less(i, j int) bool {
+	if p[i].PortRange != p[j].PortRange {
+		return portRangeSlice{p[i].PortRange, p[j].PortRange}.Less(0, 1)
+	}
+	return p[i].SourceCIDR < p[j].SourceCIDR
+}
+
+// SortIngressRules sorts the given rules by port range, then by source
+// CIDR.
+func SortIngressRules(rules []IngressRule) {
+	sort.Sort(ingressRuleSlice(rules))
+}
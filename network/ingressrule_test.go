@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package network_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type IngressRuleSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&IngressRuleSuite{})
+
+func (*IngressRuleSuite) TestNewIngressRuleDefaultsToAll(c *gc.C) {
+	rule := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "")
+	c.Assert(rule.SourceCIDR, gc.Equals, network.AllSourceCIDR)
+	c.Assert(rule.OpenToAll(), jc.IsTrue)
+	c.Assert(rule.String(), gc.Equals, "80/tcp from 0.0.0.0/0")
+}
+
+func (*IngressRuleSuite) TestIngressRulesFromPortRanges(c *gc.C) {
+	ranges := []network.PortRange{
+		network.MustParsePortRange("80/tcp"),
+		network.MustParsePortRange("53/udp"),
+	}
+	rules := network.IngressRulesFromPortRanges(ranges, "10.0.0.0/8", "192.168.0.0/16")
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(ranges[0], "10.0.0.0/8"),
+		network.NewIngressRule(ranges[0], "192.168.0.0/16"),
+		network.NewIngressRule(ranges[1], "10.0.0.0/8"),
+		network.NewIngressRule(ranges[1], "192.168.0.0/16"),
+	})
+
+	rules = network.IngressRulesFromPortRanges(ranges)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(ranges[0], network.AllSourceCIDR),
+		network.NewIngressRule(ranges[1], network.AllSourceCIDR),
+	})
+}
+
+func (*IngressRuleSuite) TestValidate(c *gc.C) {
+	rule := network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8")
+	c.Assert(rule.Validate(), jc.ErrorIsNil)
+
+	rule.SourceCIDR = "10.0.0.0"
+	c.Assert(rule.Validate(), gc.ErrorMatches, `source CIDR "10.0.0.0" not valid`)
+
+	rule = network.NewIngressRule(network.PortRange{FromPort: 80, ToPort: 79, Protocol: "tcp"}, "")
+	c.Assert(rule.Validate(), gc.ErrorMatches, "invalid port range 80-79/tcp")
+}
+
+func (*IngressRuleSuite) TestSortIngressRules(c *gc.C) {
+	rules := []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp"), ""),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("22/tcp"), ""),
+	}
+	network.SortIngressRules(rules)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("22/tcp"), ""),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "192.168.0.0/16"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp"), ""),
+	})
+}
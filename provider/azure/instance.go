@@ -19,6 +19,8 @@ import (
 	"github.com/juju/names"
 )
 
+var _ instance.InstanceIngressFirewaller = (*azureInstance)(nil)
+
 type azureInstance struct {
 	compute.VirtualMachine
 	env               *azureEnviron
@@ -184,6 +186,12 @@ func (inst *azureInstance) internalNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.OpenIngressRules(machineId, jujunetwork.IngressRulesFromPortRanges(ports))
+}
+
+// OpenIngressRules is specified in the InstanceIngressFirewaller
+// interface.
+func (inst *azureInstance) OpenIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
@@ -211,8 +219,9 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 	// NSG in memory, so we can easily tell which priorities are available.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
+	for _, ingressRule := range rules {
+		ports := ingressRule.PortRange
+		ruleName := ingressSecurityRuleName(prefix, ingressRule)
 
 		// Check if the rule already exists; OpenPorts must be idempotent.
 		var found bool
@@ -230,7 +239,7 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 
 		priority, err := nextSecurityRulePriority(nsg, securityRuleInternalMax+1, securityRuleMax)
 		if err != nil {
-			return errors.Annotatef(err, "getting security rule priority for %s", ports)
+			return errors.Annotatef(err, "getting security rule priority for %s", ingressRule)
 		}
 
 		var protocol network.SecurityRuleProtocol
@@ -250,13 +259,18 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 			portRange = fmt.Sprint(ports.FromPort)
 		}
 
+		description, sourceAddressPrefix := ports.String(), "*"
+		if !ingressRule.OpenToAll() {
+			description, sourceAddressPrefix = ingressRule.String(), ingressRule.SourceCIDR
+		}
+
 		rule := network.SecurityRule{
 			Properties: &network.SecurityRulePropertiesFormat{
-				Description:              to.StringPtr(ports.String()),
+				Description:              to.StringPtr(description),
 				Protocol:                 protocol,
 				SourcePortRange:          to.StringPtr("*"),
 				DestinationPortRange:     to.StringPtr(portRange),
-				SourceAddressPrefix:      to.StringPtr("*"),
+				SourceAddressPrefix:      to.StringPtr(sourceAddressPrefix),
 				DestinationAddressPrefix: to.StringPtr(internalNetworkAddress.Value),
 				Access:    network.Allow,
 				Priority:  to.IntPtr(priority),
@@ -266,7 +280,7 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 		if _, err := securityRuleClient.CreateOrUpdate(
 			inst.env.resourceGroup, securityGroupName, ruleName, rule,
 		); err != nil {
-			return errors.Annotatef(err, "creating security rule for %s", ports)
+			return errors.Annotatef(err, "creating security rule for %s", ingressRule)
 		}
 		securityRules = append(securityRules, rule)
 	}
@@ -275,6 +289,12 @@ func (inst *azureInstance) OpenPorts(machineId string, ports []jujunetwork.PortR
 
 // ClosePorts is specified in the Instance interface.
 func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.PortRange) error {
+	return inst.CloseIngressRules(machineId, jujunetwork.IngressRulesFromPortRanges(ports))
+}
+
+// CloseIngressRules is specified in the InstanceIngressFirewaller
+// interface.
+func (inst *azureInstance) CloseIngressRules(machineId string, rules []jujunetwork.IngressRule) error {
 	inst.env.mu.Lock()
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -284,8 +304,8 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	// on changes made by the provisioner.
 	vmName := resourceName(names.NewMachineTag(machineId))
 	prefix := instanceNetworkSecurityRulePrefix(instance.Id(vmName))
-	for _, ports := range ports {
-		ruleName := securityRuleName(prefix, ports)
+	for _, rule := range rules {
+		ruleName := ingressSecurityRuleName(prefix, rule)
 		logger.Debugf("deleting security rule %q", ruleName)
 		result, err := securityRuleClient.Delete(
 			inst.env.resourceGroup, securityGroupName, ruleName,
@@ -299,8 +319,23 @@ func (inst *azureInstance) ClosePorts(machineId string, ports []jujunetwork.Port
 	return nil
 }
 
-// Ports is specified in the Instance interface.
+// Ports is specified in the Instance interface. Only the ports open
+// to all sources are returned.
 func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRange, err error) {
+	rules, err := inst.IngressRules(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rule := range rules {
+		if rule.OpenToAll() {
+			ports = append(ports, rule.PortRange)
+		}
+	}
+	return ports, nil
+}
+
+// IngressRules is specified in the InstanceIngressFirewaller interface.
+func (inst *azureInstance) IngressRules(machineId string) (rules []jujunetwork.IngressRule, err error) {
 	inst.env.mu.Lock()
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	inst.env.mu.Unlock()
@@ -355,12 +390,17 @@ func (inst *azureInstance) Ports(machineId string) (ports []jujunetwork.PortRang
 		default:
 			protocols = []string{"tcp", "udp"}
 		}
+		sourceCIDR := to.String(rule.Properties.SourceAddressPrefix)
+		switch sourceCIDR {
+		case "", "*", "Internet":
+			sourceCIDR = jujunetwork.AllSourceCIDR
+		}
 		for _, protocol := range protocols {
 			portRange.Protocol = protocol
-			ports = append(ports, portRange)
+			rules = append(rules, jujunetwork.NewIngressRule(portRange, sourceCIDR))
 		}
 	}
-	return ports, nil
+	return rules, nil
 }
 
 // deleteInstanceNetworkSecurityRules deletes network security rules in the
@@ -409,6 +449,22 @@ func instanceNetworkSecurityRulePrefix(id instance.Id) string {
 
 // securityRuleName returns the security rule name for the given port range,
 // and prefix returned by instanceNetworkSecurityRulePrefix.
+// securityRuleNameReplacer replaces the characters of a CIDR which
+// are not valid in security rule names.
+var securityRuleNameReplacer = strings.NewReplacer("/", "_", ":", ".")
+
+// ingressSecurityRuleName returns the name of the security rule for
+// the ingress rule. Rules open to all sources are named as for
+// securityRuleName, so that they match those created before ingress
+// rules could be restricted.
+func ingressSecurityRuleName(prefix string, rule jujunetwork.IngressRule) string {
+	ruleName := securityRuleName(prefix, rule.PortRange)
+	if !rule.OpenToAll() {
+		ruleName += "-" + securityRuleNameReplacer.Replace(rule.SourceCIDR)
+	}
+	return ruleName
+}
+
 func securityRuleName(prefix string, ports jujunetwork.PortRange) string {
 	ruleName := fmt.Sprintf("%s%s-%d", prefix, ports.Protocol, ports.FromPort)
 	if ports.FromPort != ports.ToPort {
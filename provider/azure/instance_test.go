@@ -436,6 +436,100 @@ func (s *instanceSuite) TestInstanceOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestInstanceIngressRules(c *gc.C) {
+	inst := s.getInstance(c)
+	nsgSender := networkSecurityGroupSender([]network.SecurityRule{{
+		Name: to.StringPtr("machine-0-tcp-80"),
+		Properties: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.SecurityRuleProtocolTCP,
+			DestinationPortRange: to.StringPtr("80"),
+			SourceAddressPrefix:  to.StringPtr("*"),
+			Access:               network.Allow,
+			Priority:             to.IntPtr(200),
+			Direction:            network.Inbound,
+		},
+	}, {
+		Name: to.StringPtr("machine-0-tcp-22-10.0.0.0_8"),
+		Properties: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.SecurityRuleProtocolTCP,
+			DestinationPortRange: to.StringPtr("22"),
+			SourceAddressPrefix:  to.StringPtr("10.0.0.0/8"),
+			Access:               network.Allow,
+			Priority:             to.IntPtr(201),
+			Direction:            network.Inbound,
+		},
+	}})
+	s.sender = azuretesting.Senders{nsgSender}
+
+	rules, err := inst.(instance.InstanceIngressFirewaller).IngressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []jujunetwork.IngressRule{
+		jujunetwork.NewIngressRule(jujunetwork.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"}, "0.0.0.0/0"),
+		jujunetwork.NewIngressRule(jujunetwork.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"}, "10.0.0.0/8"),
+	})
+
+	// Only the rules open to all sources are reported as ports.
+	s.sender = azuretesting.Senders{nsgSender}
+	ports, err := inst.Ports("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []jujunetwork.PortRange{{
+		FromPort: 80,
+		ToPort:   80,
+		Protocol: "tcp",
+	}})
+}
+
+func (s *instanceSuite) TestInstanceOpenIngressRules(c *gc.C) {
+	internalSubnetId := path.Join(
+		"/subscriptions", fakeSubscriptionId,
+		"resourceGroups/juju-testenv-model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"providers/Microsoft.Network/virtualnetworks/juju-internal-network/subnets/juju-internal-subnet",
+	)
+	ipConfiguration := network.InterfaceIPConfiguration{
+		Properties: &network.InterfaceIPConfigurationPropertiesFormat{
+			PrivateIPAddress: to.StringPtr("10.0.0.4"),
+			Subnet: &network.SubResource{
+				ID: to.StringPtr(internalSubnetId),
+			},
+		},
+	}
+	s.networkInterfaces = []network.Interface{
+		makeNetworkInterface("nic-0", "machine-0", ipConfiguration),
+	}
+
+	inst := s.getInstance(c)
+	okSender := mocks.NewSender()
+	okSender.EmitContent("{}")
+	nsgSender := networkSecurityGroupSender(nil)
+	s.sender = azuretesting.Senders{nsgSender, okSender}
+
+	err := inst.(instance.InstanceIngressFirewaller).OpenIngressRules("0", []jujunetwork.IngressRule{
+		jujunetwork.NewIngressRule(jujunetwork.PortRange{
+			Protocol: "tcp",
+			FromPort: 22,
+			ToPort:   22,
+		}, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "PUT")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, securityRulePath("machine-0-tcp-22-10.0.0.0_8"))
+	assertRequestBody(c, s.requests[1], &network.SecurityRule{
+		Properties: &network.SecurityRulePropertiesFormat{
+			Description:              to.StringPtr("22/tcp from 10.0.0.0/8"),
+			Protocol:                 network.SecurityRuleProtocolTCP,
+			SourcePortRange:          to.StringPtr("*"),
+			SourceAddressPrefix:      to.StringPtr("10.0.0.0/8"),
+			DestinationPortRange:     to.StringPtr("22"),
+			DestinationAddressPrefix: to.StringPtr("10.0.0.4"),
+			Access:    network.Allow,
+			Priority:  to.IntPtr(200),
+			Direction: network.Inbound,
+		},
+	})
+}
+
 func (s *instanceSuite) TestInstanceOpenPortsAlreadyOpen(c *gc.C) {
 	internalSubnetId := path.Join(
 		"/subscriptions", fakeSubscriptionId,
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
 	"time"
@@ -235,6 +236,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -332,6 +334,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	}
 	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
 	vol.AvailZone = inst.AvailZone
+	vol.SnapshotId = p.SnapshotId
 	resp, err := v.ec2.CreateVolume(vol)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -359,6 +362,50 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 	return &volume, nil, nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter
+// interface.
+//
+// EBS snapshots are taken asynchronously; volumes cannot be created
+// from a snapshot until it has completed, so creating volumes from
+// snapshots will be retried by the storage provisioner until then.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	name := fmt.Sprintf("juju-%s-snapshot-%s", v.envName, p.Snapshot)
+	resp, err := v.ec2.CreateSnapshot(p.VolumeId, name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating snapshot of volume %s", p.VolumeId)
+	}
+	snapshotId := resp.Id
+
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = name
+	if err := tagResources(v.ec2, resourceTags, snapshotId); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	return &storage.VolumeSnapshot{
+		p.Snapshot,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       p.Size,
+		},
+	}, nil
+}
+
 // ListVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) ListVolumes() ([]string, error) {
 	filter := ec2.NewFilter()
@@ -4,9 +4,17 @@
 package ec2_test
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -15,6 +23,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"gopkg.in/amz.v3/aws"
 	awsec2 "gopkg.in/amz.v3/ec2"
 	"gopkg.in/amz.v3/ec2/ec2test"
 	gc "gopkg.in/check.v1"
@@ -566,6 +575,185 @@ func (s *ebsVolumeSuite) TestCreateVolumesErrors(c *gc.C) {
 
 var imageId = "ami-ccf405a5" // Ubuntu Maverick, i386, EBS store
 
+// ec2Interceptor sits in front of the ec2test server, which doesn't
+// implement snapshots. It answers snapshot requests itself, passes
+// everything else through, and records the parameters of each request.
+type ec2Interceptor struct {
+	mu       sync.Mutex
+	requests []url.Values
+}
+
+const createSnapshotResponse = `<CreateSnapshotResponse xmlns="http://ec2.amazonaws.com/doc/2014-10-01/">
+  <requestId>59dbff89-35bd-4eac-99ed-be587EXAMPLE</requestId>
+  <snapshotId>snap-%s</snapshotId>
+  <volumeId>%s</volumeId>
+  <status>pending</status>
+  <progress>0%%</progress>
+</CreateSnapshotResponse>`
+
+const createTagsResponse = `<CreateTagsResponse xmlns="http://ec2.amazonaws.com/doc/2014-10-01/">
+  <requestId>7a62c49f-347e-4fc4-9331-6e8eEXAMPLE</requestId>
+  <return>true</return>
+</CreateTagsResponse>`
+
+const volumeNotFoundResponse = `<Response>
+  <Errors><Error><Code>InvalidVolume.NotFound</Code><Message>The volume '%s' does not exist.</Message></Error></Errors>
+  <RequestID>ea966190-f9aa-478e-9ede-example</RequestID>
+</Response>`
+
+func (s *ebsVolumeSuite) interceptEC2(c *gc.C) *ec2Interceptor {
+	target, err := url.Parse(s.srv.ec2srv.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	interceptor := &ec2Interceptor{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for k, v := range req.URL.Query() {
+			form[k] = append(form[k], v...)
+		}
+		interceptor.mu.Lock()
+		interceptor.requests = append(interceptor.requests, form)
+		interceptor.mu.Unlock()
+
+		switch action := form.Get("Action"); {
+		case action == "CreateSnapshot":
+			volumeId := form.Get("VolumeId")
+			if !strings.HasPrefix(volumeId, "vol-") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, volumeNotFoundResponse, volumeId)
+				return
+			}
+			fmt.Fprintf(w, createSnapshotResponse, strings.TrimPrefix(volumeId, "vol-"), volumeId)
+		case action == "CreateTags" && strings.HasPrefix(form.Get("ResourceId.1"), "snap-"):
+			fmt.Fprint(w, createTagsResponse)
+		default:
+			proxy.ServeHTTP(w, req)
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+
+	region := aws.Regions["test"]
+	region.EC2Endpoint = server.URL
+	aws.Regions["test"] = region
+	return interceptor
+}
+
+// requestsFor returns the parameters of the recorded requests with the
+// given action.
+func (i *ec2Interceptor) requestsFor(action string) []url.Values {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var requests []url.Values
+	for _, form := range i.requests {
+		if form.Get("Action") == action {
+			requests = append(requests, form)
+		}
+	}
+	return requests
+}
+
+func (s *ebsVolumeSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	interceptor := s.interceptEC2(c)
+	vs := s.volumeSource(c, nil)
+	snapshotter, ok := vs.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     10240,
+		Provider: ec2.EBS_ProviderType,
+		ResourceTags: map[string]string{
+			"abc": "123",
+		},
+	}, {
+		Snapshot: "1",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "bad-1",
+		Size:     1024,
+		Provider: ec2.EBS_ProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0",
+		storage.VolumeSnapshotInfo{
+			SnapshotId: "snap-0",
+			Size:       10240,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`creating snapshot of volume bad-1: The volume 'bad-1' does not exist. \(InvalidVolume.NotFound\)`,
+	)
+	c.Assert(results[1].VolumeSnapshot, gc.IsNil)
+
+	name := "juju-" + s.TestConfig["name"].(string) + "-snapshot-0"
+	snapshots := interceptor.requestsFor("CreateSnapshot")
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Check(snapshots[0].Get("VolumeId"), gc.Equals, "vol-0")
+	c.Check(snapshots[0].Get("Description"), gc.Equals, name)
+
+	createTags := interceptor.requestsFor("CreateTags")
+	c.Assert(createTags, gc.HasLen, 1)
+	c.Check(createTags[0].Get("ResourceId.1"), gc.Equals, "snap-0")
+	snapshotTags := make(map[string]string)
+	for i := 1; createTags[0].Get(fmt.Sprintf("Tag.%d.Key", i)) != ""; i++ {
+		key := createTags[0].Get(fmt.Sprintf("Tag.%d.Key", i))
+		snapshotTags[key] = createTags[0].Get(fmt.Sprintf("Tag.%d.Value", i))
+	}
+	c.Check(snapshotTags, jc.DeepEquals, map[string]string{
+		"abc":  "123",
+		"Name": name,
+	})
+}
+
+func (s *ebsVolumeSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	interceptor := s.interceptEC2(c)
+	vs := s.volumeSource(c, nil)
+	instanceId := s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0]
+	results, err := vs.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       10 * 1024,
+		Provider:   ec2.EBS_ProviderType,
+		SnapshotId: "snap-42",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceId),
+			},
+		},
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		Size:     10 * 1024,
+		Provider: ec2.EBS_ProviderType,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceId),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+
+	createVolumes := interceptor.requestsFor("CreateVolume")
+	c.Assert(createVolumes, gc.HasLen, 2)
+	c.Check(createVolumes[0].Get("SnapshotId"), gc.Equals, "snap-42")
+	c.Check(createVolumes[1].Get("SnapshotId"), gc.Equals, "")
+}
+
 func (s *ebsVolumeSuite) setupAttachVolumesTest(
 	c *gc.C, vs storage.VolumeSource, state awsec2.InstanceState,
 ) []storage.VolumeAttachmentParams {
@@ -87,6 +87,7 @@ type environ struct {
 var _ environs.NetworkingEnviron = (*environ)(nil)
 var _ simplestreams.HasRegion = (*environ)(nil)
 var _ state.Prechecker = (*environ)(nil)
+var _ environs.IngressFirewaller = (*environ)(nil)
 var _ state.InstanceDistributor = (*environ)(nil)
 
 type defaultVpc struct {
@@ -1287,37 +1288,42 @@ func (e *environ) allControllerManagedVolumes() ([]string, error) {
 	return listVolumes(e.ec2(), filter)
 }
 
-func portsToIPPerms(ports []network.PortRange) []ec2.IPPerm {
-	ipPerms := make([]ec2.IPPerm, len(ports))
-	for i, p := range ports {
+func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
+	ipPerms := make([]ec2.IPPerm, len(rules))
+	for i, r := range rules {
 		ipPerms[i] = ec2.IPPerm{
-			Protocol:  p.Protocol,
-			FromPort:  p.FromPort,
-			ToPort:    p.ToPort,
-			SourceIPs: []string{"0.0.0.0/0"},
+			Protocol:  r.Protocol,
+			FromPort:  r.FromPort,
+			ToPort:    r.ToPort,
+			SourceIPs: []string{r.SourceCIDR},
 		}
 	}
 	return ipPerms
 }
 
 func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	return e.openIngressRulesInGroup(name, network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) openIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Give permissions for anyone to access the given ports.
+	// Give permissions for the rules' source CIDRs to access the
+	// given ports.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	ipPerms := portsToIPPerms(ports)
+	ipPerms := rulesToIPPerms(rules)
 	_, err = e.ec2().AuthorizeSecurityGroup(g, ipPerms)
 	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
-		if len(ports) == 1 {
+		if len(rules) == 1 {
 			return nil
 		}
-		// If there's more than one port and we get a duplicate error,
-		// then we go through authorizing each port individually,
-		// otherwise the ports that were *not* duplicates will have
+		// If there's more than one rule and we get a duplicate error,
+		// then we go through authorizing each rule individually,
+		// otherwise the rules that were *not* duplicates will have
 		// been ignored
 		for i := range ipPerms {
 			_, err := e.ec2().AuthorizeSecurityGroup(g, ipPerms[i:i+1])
@@ -1334,41 +1340,64 @@ func (e *environ) openPortsInGroup(name string, ports []network.PortRange) error
 }
 
 func (e *environ) closePortsInGroup(name string, ports []network.PortRange) error {
-	if len(ports) == 0 {
+	return e.closeIngressRulesInGroup(name, network.IngressRulesFromPortRanges(ports))
+}
+
+func (e *environ) closeIngressRulesInGroup(name string, rules []network.IngressRule) error {
+	if len(rules) == 0 {
 		return nil
 	}
-	// Revoke permissions for anyone to access the given ports.
-	// Note that ec2 allows the revocation of permissions that aren't
-	// granted, so this is naturally idempotent.
+	// Revoke permissions for the rules' source CIDRs to access the
+	// given ports. Note that ec2 allows the revocation of permissions
+	// that aren't granted, so this is naturally idempotent.
 	g, err := e.groupByName(name)
 	if err != nil {
 		return err
 	}
-	_, err = e.ec2().RevokeSecurityGroup(g, portsToIPPerms(ports))
+	_, err = e.ec2().RevokeSecurityGroup(g, rulesToIPPerms(rules))
 	if err != nil {
 		return fmt.Errorf("cannot close ports: %v", err)
 	}
 	return nil
 }
 
+// portsInGroup returns the port ranges open to traffic from anywhere
+// in the named group.
 func (e *environ) portsInGroup(name string) (ports []network.PortRange, err error) {
+	rules, err := e.ingressRulesInGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.OpenToAll() {
+			ports = append(ports, r.PortRange)
+		}
+	}
+	network.SortPortRanges(ports)
+	return ports, nil
+}
+
+func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := e.groupInfoByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range group.IPPerms {
-		if len(p.SourceIPs) != 1 {
+		if len(p.SourceIPs) == 0 {
 			logger.Warningf("unexpected IP permission found: %v", p)
 			continue
 		}
-		ports = append(ports, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: p.Protocol,
 			FromPort: p.FromPort,
 			ToPort:   p.ToPort,
-		})
+		}
+		for _, sourceIP := range p.SourceIPs {
+			rules = append(rules, network.NewIngressRule(portRange, sourceIP))
+		}
 	}
-	network.SortPortRanges(ports)
-	return ports, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
 
 func (e *environ) OpenPorts(ports []network.PortRange) error {
@@ -1403,6 +1432,41 @@ func (e *environ) Ports() ([]network.PortRange, error) {
 	return e.portsInGroup(e.globalGroupName())
 }
 
+// OpenIngressRules is part of the environs.IngressFirewaller interface.
+func (e *environ) OpenIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
+			e.Config().FirewallMode())
+	}
+	if err := e.openIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules is part of the environs.IngressFirewaller interface.
+func (e *environ) CloseIngressRules(rules []network.IngressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
+			e.Config().FirewallMode())
+	}
+	if err := e.closeIngressRulesInGroup(e.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules is part of the environs.IngressFirewaller interface.
+func (e *environ) IngressRules() ([]network.IngressRule, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
+			e.Config().FirewallMode())
+	}
+	return e.ingressRulesInGroup(e.globalGroupName())
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
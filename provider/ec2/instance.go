@@ -25,6 +25,7 @@ func (inst *ec2Instance) String() string {
 }
 
 var _ instance.Instance = (*ec2Instance)(nil)
+var _ instance.InstanceIngressFirewaller = (*ec2Instance)(nil)
 
 func (inst *ec2Instance) Id() instance.Id {
 	return instance.Id(inst.InstanceId)
@@ -112,3 +113,43 @@ func (inst *ec2Instance) Ports(machineId string) ([]network.PortRange, error) {
 	}
 	return ranges, nil
 }
+
+// OpenIngressRules is part of the instance.InstanceIngressFirewaller
+// interface.
+func (inst *ec2Instance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.openIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseIngressRules is part of the instance.InstanceIngressFirewaller
+// interface.
+func (inst *ec2Instance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.closeIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// IngressRules is part of the instance.InstanceIngressFirewaller
+// interface.
+func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			inst.e.Config().FirewallMode())
+	}
+	return inst.e.ingressRulesInGroup(inst.e.machineGroupName(machineId))
+}
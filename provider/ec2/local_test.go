@@ -832,6 +832,112 @@ func (t *localServerSuite) prepareAndBootstrap(c *gc.C) environs.Environ {
 	return env
 }
 
+func (t *localServerSuite) TestInstanceIngressRules(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, "1")
+	fwInst, ok := inst.(instance.InstanceIngressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	rules, err := fwInst.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	http := network.PortRange{80, 80, "tcp"}
+	https := network.PortRange{443, 443, "tcp"}
+	err = fwInst.OpenIngressRules("1", []network.IngressRule{
+		network.NewIngressRule(https, ""),
+		network.NewIngressRule(http, "192.168.0.0/16"),
+		network.NewIngressRule(http, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwInst.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(http, "10.0.0.0/8"),
+		network.NewIngressRule(http, "192.168.0.0/16"),
+		network.NewIngressRule(https, "0.0.0.0/0"),
+	})
+
+	// Only traffic from anywhere counts as open ports.
+	ports, err := inst.Ports("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, []network.PortRange{https})
+
+	err = fwInst.CloseIngressRules("1", []network.IngressRule{
+		network.NewIngressRule(http, "192.168.0.0/16"),
+		network.NewIngressRule(https, ""),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwInst.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(http, "10.0.0.0/8"),
+	})
+
+	// The global firewall is not available in instance mode.
+	envFw, ok := env.(environs.IngressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	_, err = envFw.IngressRules()
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for retrieving ports from model`)
+	err = envFw.OpenIngressRules([]network.IngressRule{network.NewIngressRule(http, "")})
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening ports on model`)
+	err = envFw.CloseIngressRules([]network.IngressRule{network.NewIngressRule(http, "")})
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for closing ports on model`)
+}
+
+func (t *localServerSuite) TestGlobalIngressRules(c *gc.C) {
+	controllerEnv := t.prepareAndBootstrap(c)
+	cfg, err := controllerEnv.Config().Apply(map[string]interface{}{
+		"uuid":          "7e386e08-cba7-44a4-a76e-7c1633584210",
+		"firewall-mode": "global",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	// Starting an instance creates the global security group.
+	inst, _ := testing.AssertStartInstance(c, env, "0")
+	envFw, ok := env.(environs.IngressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	rules, err := envFw.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	mysql := network.PortRange{3306, 3306, "tcp"}
+	dns := network.PortRange{53, 53, "udp"}
+	err = envFw.OpenIngressRules([]network.IngressRule{
+		network.NewIngressRule(mysql, "10.0.0.0/8"),
+		network.NewIngressRule(dns, ""),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = envFw.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(mysql, "10.0.0.0/8"),
+		network.NewIngressRule(dns, "0.0.0.0/0"),
+	})
+
+	err = envFw.CloseIngressRules([]network.IngressRule{
+		network.NewIngressRule(mysql, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = envFw.IngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(dns, "0.0.0.0/0"),
+	})
+
+	// Per-instance rules are not available in global mode.
+	fwInst, ok := inst.(instance.InstanceIngressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	_, err = fwInst.IngressRules("0")
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for retrieving ports from instance`)
+	err = fwInst.OpenIngressRules("0", rules)
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for opening ports on instance`)
+	err = fwInst.CloseIngressRules("0", rules)
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "global" for closing ports on instance`)
+}
+
 func (t *localServerSuite) TestSpaceConstraintsSpaceNotInPlacementZone(c *gc.C) {
 	c.Skip("temporarily disabled")
 	env := t.prepareAndBootstrap(c)
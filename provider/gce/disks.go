@@ -55,6 +55,8 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(environConfig *config.Config, cfg *storage.Config) (storage.VolumeSource, error) {
 	// Connect and authenticate.
 	env, err := newEnviron(environConfig)
@@ -195,6 +197,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		SnapshotName:       p.SnapshotId,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -235,6 +238,45 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 	return volume, volumeAttachment, nil
 }
 
+// snapshotName returns the name of the GCE snapshot for the Juju
+// snapshot with the given ID. Snapshots are global resources, so
+// their names include the model UUID.
+func (v *volumeSource) snapshotName(snapshot string) string {
+	return fmt.Sprintf("juju-%s-snapshot-%s", v.modelUUID, snapshot)
+}
+
+func (v *volumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshotName, err := v.createOneVolumeSnapshot(p)
+		if err != nil {
+			logger.Errorf("could not create snapshot of %q: %v", p.VolumeId, err)
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			p.Snapshot,
+			storage.VolumeSnapshotInfo{
+				SnapshotId: snapshotName,
+				Size:       p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneVolumeSnapshot(p storage.VolumeSnapshotParams) (string, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return "", errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	snapshotName := v.snapshotName(p.Snapshot)
+	if err := v.gce.CreateSnapshot(zone, p.VolumeId, snapshotName, v.modelUUID); err != nil {
+		return "", errors.Annotatef(err, "cannot create snapshot of volume %q", p.VolumeId)
+	}
+	return snapshotName, nil
+}
+
 func (v *volumeSource) DestroyVolumes(volNames []string) ([]error, error) {
 	var wg sync.WaitGroup
 	wg.Add(len(volNames))
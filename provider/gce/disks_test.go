@@ -166,6 +166,58 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 }
 
+func (s *volumeSourceSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{s.BaseDisk}
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: s.BaseDisk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].SnapshotId = "juju-snapshot"
+	res, err := s.source.CreateVolumes(s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+
+	called, calls := s.FakeConn.WasCalled("CreateDisks")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].Disks, gc.HasLen, 1)
+	c.Check(calls[0].Disks[0].SnapshotName, gc.Equals, "juju-snapshot")
+}
+
+func (s *volumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	snapshotter, ok := s.source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	res, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0",
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     1024,
+		Provider: "gce",
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	snapshotName := "juju-" + s.Config.UUID() + "-snapshot-0"
+	c.Assert(res[0].VolumeSnapshot, jc.DeepEquals, &storage.VolumeSnapshot{
+		"0",
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotName,
+			Size:       1024,
+		},
+	})
+
+	called, calls := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Check(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(calls[0].VolumeName, gc.Equals, s.BaseDisk.Name)
+	c.Check(calls[0].Name, gc.Equals, snapshotName)
+}
+
 func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	errs, err := s.source.DestroyVolumes([]string{"a--volume-name"})
 	c.Check(err, jc.ErrorIsNil)
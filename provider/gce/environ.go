@@ -30,6 +30,10 @@ type gceConnection interface {
 	OpenPorts(fwname string, ports ...network.PortRange) error
 	ClosePorts(fwname string, ports ...network.PortRange) error
 
+	IngressRules(fwname string) ([]network.IngressRule, error)
+	OpenIngressRules(fwname string, rules ...network.IngressRule) error
+	CloseIngressRules(fwname string, rules ...network.IngressRule) error
+
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 
 	// Storage related methods.
@@ -52,6 +56,9 @@ type gceConnection interface {
 	DetachDisk(zone, instanceId, volumeName string) error
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot named <snapshotName> of the
+	// <volumeName> disk in <zone>.
+	CreateSnapshot(zone, volumeName, snapshotName, description string) error
 }
 
 type environ struct {
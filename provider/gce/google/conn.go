@@ -35,6 +35,10 @@ type rawConnectionWrapper interface {
 	// the named firewall and returns it. If the firewall is not found,
 	// errors.NotFound is returned.
 	GetFirewall(projectID, name string) (*compute.Firewall, error)
+	// ListFirewalls sends an API request to GCE for the information
+	// about the firewalls whose names start with the given prefix, and
+	// returns them.
+	ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error)
 	// AddFirewall requests GCE to add a firewall with the provided info.
 	// If the firewall already exists then an error will be returned.
 	// The call blocks until the firewall is added or the request fails.
@@ -70,6 +74,9 @@ type rawConnectionWrapper interface {
 	// InstanceDisks returns the disks attached to the instance identified
 	// by instanceId
 	InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot, matching the specified
+	// spec, of the disk identified by disk.
+	CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) error
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -59,6 +59,18 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	return NewDisk(d), nil
 }
 
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, volumeName, snapshotName, description string) error {
+	spec := &compute.Snapshot{
+		Name:        snapshotName,
+		Description: description,
+	}
+	if err := gce.raw.CreateSnapshot(gce.projectID, zone, volumeName, spec); err != nil {
+		return errors.Annotatef(err, "cannot create snapshot %q of disk %q", snapshotName, volumeName)
+	}
+	return nil
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
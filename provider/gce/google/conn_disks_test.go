@@ -40,6 +40,21 @@ func (s *connSuite) TestConnectionCreateDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ComputeDisk.Name, gc.Equals, fakeVolName)
 }
 
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "juju-snapshot", "a snapshot")
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot, jc.DeepEquals, &compute.Snapshot{
+		Name:        "juju-snapshot",
+		Description: "a snapshot",
+	})
+}
+
 func (s *connSuite) TestConnectionDisks(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
@@ -92,8 +92,9 @@ func (gce *Connection) Instances(prefix string, statuses ...string) ([]Instance,
 }
 
 // removeInstance sends a request to the GCE API to remove the instance
-// with the provided ID (in the specified zone). The call blocks until
-// the instance is removed (or the request fails).
+// with the provided ID (in the specified zone), along with all of its
+// firewalls. The call blocks until the instance is removed (or the
+// request fails).
 func (gce *Connection) removeInstance(id, zone string) error {
 	err := gce.raw.RemoveInstance(gce.projectID, zone, id)
 	if err != nil {
@@ -101,14 +102,22 @@ func (gce *Connection) removeInstance(id, zone string) error {
 		return errors.Trace(err)
 	}
 
+	// Rules restricted to particular source CIDRs are held in firewalls
+	// of their own, which must be removed along with the instance's.
 	fwname := id
-	err = gce.raw.RemoveFirewall(gce.projectID, fwname)
-	if errors.IsNotFound(err) {
-		return nil
-	}
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, fwname)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	for _, firewall := range firewalls {
+		if !isIngressFirewall(fwname, firewall) {
+			continue
+		}
+		err := gce.raw.RemoveFirewall(gce.projectID, firewall.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -206,17 +206,47 @@ func (s *connSuite) TestConnectionRemoveInstance(c *gc.C) {
 }
 
 func (s *connSuite) TestConnectionRemoveInstanceAPI(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}}
+
 	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
 	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ListFirewalls")
 	c.Check(s.FakeConn.Calls[1].ProjectID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[1].Prefix, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionRemoveInstanceSourceRangeFirewalls(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name: "spam",
+	}, {
+		Name:         "spam-10174f2d",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+	}, {
+		Name:         "spam-other",
+		TargetTags:   []string{"spam-other"},
+		SourceRanges: []string{"10.0.0.0/8"},
+	}}
+
+	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveInstance")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam-10174f2d")
 }
 
 func (s *connSuite) TestConnectionRemoveInstanceFailed(c *gc.C) {
@@ -229,6 +259,18 @@ func (s *connSuite) TestConnectionRemoveInstanceFailed(c *gc.C) {
 }
 
 func (s *connSuite) TestConnectionRemoveInstanceFirewallFailed(c *gc.C) {
+	failure := errors.New("<unknown>")
+	s.FakeConn.Err = failure
+	s.FakeConn.FailOnCall = 2
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}}
+
+	err := google.ConnRemoveInstance(s.Conn, "spam", "a-zone")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+}
+
+func (s *connSuite) TestConnectionRemoveInstanceListFirewallsFailed(c *gc.C) {
 	failure := errors.New("<unknown>")
 	s.FakeConn.Err = failure
 	s.FakeConn.FailOnCall = 1
@@ -249,16 +291,19 @@ func (s *connSuite) TestConnectionRemoveInstances(c *gc.C) {
 
 func (s *connSuite) TestConnectionRemoveInstancesAPI(c *gc.C) {
 	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}}
 
 	err := s.Conn.RemoveInstances("sp", "spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[2].Prefix, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesMultiple(c *gc.C) {
@@ -270,19 +315,25 @@ func (s *connSuite) TestConnectionRemoveInstancesMultiple(c *gc.C) {
 		},
 	}
 
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}, {Name: "special"}}
+
 	err := s.Conn.RemoveInstances("", "spam", "special")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 5)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 7)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveInstance")
-	c.Check(s.FakeConn.Calls[3].ID, gc.Equals, "special")
-	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[4].Name, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[2].Prefix, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[4].FuncName, gc.Equals, "RemoveInstance")
+	c.Check(s.FakeConn.Calls[4].ID, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[5].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[5].Prefix, gc.Equals, "special")
+	c.Check(s.FakeConn.Calls[6].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[6].Name, gc.Equals, "special")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesPartialMatch(c *gc.C) {
@@ -294,15 +345,18 @@ func (s *connSuite) TestConnectionRemoveInstancesPartialMatch(c *gc.C) {
 		},
 	}
 
+	s.FakeConn.Firewalls = []*compute.Firewall{{Name: "spam"}, {Name: "special"}}
+
 	err := s.Conn.RemoveInstances("", "spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveInstance")
 	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
-	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
-	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[3].Name, gc.Equals, "spam")
 }
 
 func (s *connSuite) TestConnectionRemoveInstancesListFailed(c *gc.C) {
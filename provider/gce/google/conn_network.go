@@ -4,7 +4,12 @@
 package google
 
 import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
 )
@@ -20,7 +25,10 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "while getting ports from GCE")
 	}
+	return firewallPorts(firewall)
+}
 
+func firewallPorts(firewall *compute.Firewall) ([]network.PortRange, error) {
 	var ports []network.PortRange
 	for _, allowed := range firewall.Allowed {
 		for _, portRangeStr := range allowed.Ports {
@@ -36,6 +44,102 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 	return ports, nil
 }
 
+// ingressFirewallName returns the name of the firewall holding the
+// ingress rules for traffic from the source CIDR to instances targeted
+// by the named firewall. Rules allowing traffic from anywhere are held
+// in the named firewall itself.
+func ingressFirewallName(fwname, sourceCIDR string) string {
+	if sourceCIDR == network.AllSourceCIDR {
+		return fwname
+	}
+	hash := sha1.Sum([]byte(sourceCIDR))
+	return fmt.Sprintf("%s-%x", fwname, hash[:4])
+}
+
+// isIngressFirewall returns whether the firewall holds ingress rules
+// for instances targeted by the named firewall.
+func isIngressFirewall(fwname string, firewall *compute.Firewall) bool {
+	if firewall.Name == fwname {
+		return true
+	}
+	if len(firewall.TargetTags) != 1 || firewall.TargetTags[0] != fwname {
+		return false
+	}
+	for _, sourceCIDR := range firewall.SourceRanges {
+		if firewall.Name == ingressFirewallName(fwname, sourceCIDR) {
+			return true
+		}
+	}
+	return false
+}
+
+// IngressRules builds a list of all ingress rules for a given firewall
+// name (within the Connection's project) and returns it. This includes
+// the rules opened for traffic from particular source CIDRs, which are
+// held in firewalls of their own.
+func (gce Connection) IngressRules(fwname string) ([]network.IngressRule, error) {
+	firewalls, err := gce.raw.ListFirewalls(gce.projectID, fwname)
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting ingress rules from GCE")
+	}
+
+	var rules []network.IngressRule
+	for _, firewall := range firewalls {
+		if !isIngressFirewall(fwname, firewall) {
+			continue
+		}
+		ports, err := firewallPorts(firewall)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, network.IngressRulesFromPortRanges(ports, firewall.SourceRanges...)...)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
+// groupBySourceCIDR returns the port ranges of the rules, keyed by
+// their source CIDR, along with the sorted source CIDRs.
+func groupBySourceCIDR(rules []network.IngressRule) (map[string][]network.PortRange, []string) {
+	ports := make(map[string][]network.PortRange)
+	var sourceCIDRs []string
+	for _, rule := range rules {
+		if _, ok := ports[rule.SourceCIDR]; !ok {
+			sourceCIDRs = append(sourceCIDRs, rule.SourceCIDR)
+		}
+		ports[rule.SourceCIDR] = append(ports[rule.SourceCIDR], rule.PortRange)
+	}
+	sort.Strings(sourceCIDRs)
+	return ports, sourceCIDRs
+}
+
+// OpenIngressRules sends requests to the GCE API to open the provided
+// ingress rules for instances targeted by the named firewall. Rules for
+// each source CIDR are held in a firewall of their own.
+func (gce Connection) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	ports, sourceCIDRs := groupBySourceCIDR(rules)
+	for _, sourceCIDR := range sourceCIDRs {
+		name := ingressFirewallName(fwname, sourceCIDR)
+		if err := gce.openPorts(name, fwname, sourceCIDR, ports[sourceCIDR]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CloseIngressRules sends requests to the GCE API to close the provided
+// ingress rules for instances targeted by the named firewall.
+func (gce Connection) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	ports, sourceCIDRs := groupBySourceCIDR(rules)
+	for _, sourceCIDR := range sourceCIDRs {
+		name := ingressFirewallName(fwname, sourceCIDR)
+		if err := gce.closePorts(name, fwname, sourceCIDR, ports[sourceCIDR]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // OpenPorts sends a request to the GCE API to open the provided port
 // ranges on the named firewall. If the firewall does not exist yet it
 // is created, with the provided port ranges opened. Otherwise the
@@ -43,6 +147,10 @@ func (gce Connection) Ports(fwname string) ([]network.PortRange, error) {
 // ports it already has open. The call blocks until the ports are
 // opened or the request fails.
 func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error {
+	return gce.openPorts(fwname, fwname, network.AllSourceCIDR, ports)
+}
+
+func (gce Connection) openPorts(fwname, target, sourceCIDR string, ports []network.PortRange) error {
 	// TODO(ericsnow) Short-circuit if ports is empty.
 
 	// Compose the full set of open ports.
@@ -59,7 +167,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 	// Send the request, depending on the current ports.
 	if currentPortsSet.IsEmpty() {
 		// Create a new firewall.
-		firewall := firewallSpec(fwname, inputPortsSet)
+		firewall := ingressFirewallSpec(fwname, target, sourceCIDR, inputPortsSet)
 		if err := gce.raw.AddFirewall(gce.projectID, firewall); err != nil {
 			return errors.Annotatef(err, "opening port(s) %+v", ports)
 		}
@@ -68,7 +176,7 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 
 	// Update an existing firewall.
 	newPortsSet := currentPortsSet.Union(inputPortsSet)
-	firewall := firewallSpec(fwname, newPortsSet)
+	firewall := ingressFirewallSpec(fwname, target, sourceCIDR, newPortsSet)
 	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
 		return errors.Annotatef(err, "opening port(s) %+v", ports)
 	}
@@ -82,6 +190,10 @@ func (gce Connection) OpenPorts(fwname string, ports ...network.PortRange) error
 // match the provided port ranges. The call blocks until the ports are
 // closed or the request fails.
 func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) error {
+	return gce.closePorts(fwname, fwname, network.AllSourceCIDR, ports)
+}
+
+func (gce Connection) closePorts(fwname, target, sourceCIDR string, ports []network.PortRange) error {
 	// Compose the full set of open ports.
 	currentPorts, err := gce.Ports(fwname)
 	if err != nil {
@@ -105,7 +217,7 @@ func (gce Connection) ClosePorts(fwname string, ports ...network.PortRange) erro
 	}
 
 	// Update an existing firewall.
-	firewall := firewallSpec(fwname, newPortsSet)
+	firewall := ingressFirewallSpec(fwname, target, sourceCIDR, newPortsSet)
 	if err := gce.raw.UpdateFirewall(gce.projectID, fwname, firewall); err != nil {
 		return errors.Annotatef(err, "closing port(s) %+v", ports)
 	}
@@ -173,3 +173,95 @@ func (s *connSuite) TestConnectionClosePortsUpdate(c *gc.C) {
 		}},
 	})
 }
+
+func (s *connSuite) TestConnectionIngressRules(c *gc.C) {
+	s.FakeConn.Firewalls = []*compute.Firewall{{
+		Name:         "spam",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}},
+	}, {
+		Name:         "spam-10174f2d",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	}, {
+		// A different target with a name sharing the prefix.
+		Name:         "spam2",
+		TargetTags:   []string{"spam2"},
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+
+	rules, err := s.Conn.IngressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(rules, jc.DeepEquals, []network.IngressRule{
+		network.NewIngressRule(network.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"}, "10.0.0.0/8"),
+		network.NewIngressRule(network.PortRange{FromPort: 80, ToPort: 81, Protocol: "tcp"}, "0.0.0.0/0"),
+	})
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListFirewalls")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, "spam")
+}
+
+func (s *connSuite) TestConnectionOpenIngressRulesAdd(c *gc.C) {
+	s.FakeConn.Err = errors.NotFoundf("spam")
+
+	rule := network.NewIngressRule(network.PortRange{
+		FromPort: 22,
+		ToPort:   22,
+		Protocol: "tcp",
+	}, "10.0.0.0/8")
+	err := s.Conn.OpenIngressRules("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "spam-10174f2d")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddFirewall")
+	c.Check(s.FakeConn.Calls[1].Firewall, jc.DeepEquals, &compute.Firewall{
+		Name:         "spam-10174f2d",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionCloseIngressRulesRemove(c *gc.C) {
+	s.FakeConn.Firewall = &compute.Firewall{
+		Name:         "spam-10174f2d",
+		TargetTags:   []string{"spam"},
+		SourceRanges: []string{"10.0.0.0/8"},
+		Allowed: []*compute.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"22"},
+		}},
+	}
+
+	rule := network.NewIngressRule(network.PortRange{
+		FromPort: 22,
+		ToPort:   22,
+		Protocol: "tcp",
+	}, "10.0.0.0/8")
+	err := s.Conn.CloseIngressRules("spam", rule)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetFirewall")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "spam-10174f2d")
+}
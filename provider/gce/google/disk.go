@@ -114,6 +114,9 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// SnapshotName is the name of the snapshot from which the disk
+	// should be created, if any. (detached only)
+	SnapshotName string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -175,13 +178,18 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SnapshotName != "" {
+		// Snapshots are global resources.
+		disk.SourceSnapshot = "global/snapshots/" + ds.SnapshotName
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
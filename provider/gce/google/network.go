@@ -58,14 +58,21 @@ func (ns *NetworkSpec) newInterface(name string) *compute.NetworkInterface {
 // firewallSpec expands a port range set in to compute.FirewallAllowed
 // and returns a compute.Firewall for the provided name.
 func firewallSpec(name string, ps network.PortSet) *compute.Firewall {
+	return ingressFirewallSpec(name, name, network.AllSourceCIDR, ps)
+}
+
+// ingressFirewallSpec returns a compute.Firewall for the provided name,
+// allowing traffic from the source CIDR to the port range set on
+// instances with the target tag.
+func ingressFirewallSpec(name, target, sourceCIDR string, ps network.PortSet) *compute.Firewall {
 	firewall := compute.Firewall{
 		// Allowed is set below.
 		// Description is not set.
 		Name: name,
 		// Network: (defaults to global)
 		// SourceTags is not set.
-		TargetTags:   []string{name},
-		SourceRanges: []string{"0.0.0.0/0"},
+		TargetTags:   []string{target},
+		SourceRanges: []string{sourceCIDR},
 	}
 
 	for _, protocol := range ps.Protocols() {
@@ -129,6 +129,25 @@ func (rc *rawConn) GetFirewall(projectID, name string) (*compute.Firewall, error
 	return firewallList.Items[0], nil
 }
 
+func (rc *rawConn) ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error) {
+	call := rc.Firewalls.List(projectID)
+	call = call.Filter("name eq " + prefix + ".*")
+
+	var results []*compute.Firewall
+	for {
+		firewallList, err := call.Do()
+		if err != nil {
+			return nil, errors.Annotate(err, "while listing firewalls from GCE")
+		}
+		results = append(results, firewallList.Items...)
+		if firewallList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(firewallList.NextPageToken)
+	}
+	return results, nil
+}
+
 func (rc *rawConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := rc.Firewalls.Insert(projectID, firewall)
 	operation, err := call.Do()
@@ -267,6 +286,15 @@ func (rc *rawConn) DetachDisk(project, zone, instanceId, diskDeviceName string)
 	return nil
 }
 
+func (rc *rawConn) CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) error {
+	call := rc.Disks.CreateSnapshot(project, zone, disk, spec)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not create a snapshot of disk %q", disk)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
 func (rc *rawConn) InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error) {
 	instance, err := rc.GetInstance(project, zone, instanceId)
 	if err != nil {
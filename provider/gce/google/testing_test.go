@@ -158,6 +158,7 @@ type fakeCall struct {
 	AttachedDisk *compute.AttachedDisk
 	DeviceName   string
 	ComputeDisk  *compute.Disk
+	Snapshot     *compute.Snapshot
 }
 
 type fakeConn struct {
@@ -167,6 +168,7 @@ type fakeConn struct {
 	Instance      *compute.Instance
 	Instances     []*compute.Instance
 	Firewall      *compute.Firewall
+	Firewalls     []*compute.Firewall
 	Zones         []*compute.Zone
 	Err           error
 	FailOnCall    int
@@ -269,6 +271,21 @@ func (rc *fakeConn) GetFirewall(projectID, name string) (*compute.Firewall, erro
 	return rc.Firewall, err
 }
 
+func (rc *fakeConn) ListFirewalls(projectID, prefix string) ([]*compute.Firewall, error) {
+	call := fakeCall{
+		FuncName:  "ListFirewalls",
+		ProjectID: projectID,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Firewalls, err
+}
+
 func (rc *fakeConn) AddFirewall(projectID string, firewall *compute.Firewall) error {
 	call := fakeCall{
 		FuncName:  "AddFirewall",
@@ -346,6 +363,23 @@ func (rc *fakeConn) CreateDisk(project, zone string, spec *compute.Disk) error {
 	return err
 }
 
+func (rc *fakeConn) CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) error {
+	call := fakeCall{
+		FuncName:  "CreateSnapshot",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        disk,
+		Snapshot:  spec,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) ListDisks(project, zone string) ([]*compute.Disk, error) {
 	call := fakeCall{
 		FuncName:  "ListDisks",
@@ -19,6 +19,7 @@ type environInstance struct {
 }
 
 var _ instance.Instance = (*environInstance)(nil)
+var _ instance.InstanceIngressFirewaller = (*environInstance)(nil)
 
 func newInstance(base *google.Instance, env *environ) *environInstance {
 	return &environInstance{
@@ -93,3 +94,27 @@ func (inst *environInstance) Ports(machineID string) ([]network.PortRange, error
 	ports, err := inst.env.gce.Ports(name)
 	return ports, errors.Trace(err)
 }
+
+// OpenIngressRules opens the given ingress rules on the instance,
+// which should have been started with the given machine id.
+func (inst *environInstance) OpenIngressRules(machineID string, rules []network.IngressRule) error {
+	name := common.MachineFullName(inst.env.Config().UUID(), machineID)
+	err := inst.env.gce.OpenIngressRules(name, rules...)
+	return errors.Trace(err)
+}
+
+// CloseIngressRules closes the given ingress rules on the instance,
+// which should have been started with the given machine id.
+func (inst *environInstance) CloseIngressRules(machineID string, rules []network.IngressRule) error {
+	name := common.MachineFullName(inst.env.Config().UUID(), machineID)
+	err := inst.env.gce.CloseIngressRules(name, rules...)
+	return errors.Trace(err)
+}
+
+// IngressRules returns the ingress rules open on the instance, which
+// should have been started with the given machine id.
+func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRule, error) {
+	name := common.MachineFullName(inst.env.Config().UUID(), machineID)
+	rules, err := inst.env.gce.IngressRules(name)
+	return rules, errors.Trace(err)
+}
@@ -434,11 +434,14 @@ type fakeConnCall struct {
 	InstanceSpec google.InstanceSpec
 	FirewallName string
 	PortRanges   []network.PortRange
+	IngressRules []network.IngressRule
 	Region       string
 	Disks        []google.DiskSpec
 	VolumeName   string
 	InstanceId   string
 	Mode         string
+	Name         string
+	Description  string
 }
 
 type fakeConn struct {
@@ -447,6 +450,7 @@ type fakeConn struct {
 	Inst       *google.Instance
 	Insts      []google.Instance
 	PortRanges []network.PortRange
+	Rules      []network.IngressRule
 	Zones      []google.AvailabilityZone
 
 	GoogleDisks   []*google.Disk
@@ -534,6 +538,32 @@ func (fc *fakeConn) ClosePorts(fwname string, ports ...network.PortRange) error
 	return fc.err()
 }
 
+func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "IngressRules",
+		FirewallName: fwname,
+	})
+	return fc.Rules, fc.err()
+}
+
+func (fc *fakeConn) OpenIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenIngressRules",
+		FirewallName: fwname,
+		IngressRules: rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseIngressRules(fwname string, rules ...network.IngressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseIngressRules",
+		FirewallName: fwname,
+		IngressRules: rules,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -607,6 +637,17 @@ func (fc *fakeConn) InstanceDisks(zone, instanceId string) ([]*google.AttachedDi
 	return fc.AttachedDisks, fc.err()
 }
 
+func (fc *fakeConn) CreateSnapshot(zone, volumeName, snapshotName, description string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:    "CreateSnapshot",
+		ZoneName:    zone,
+		VolumeName:  volumeName,
+		Name:        snapshotName,
+		Description: description,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) WasCalled(funcName string) (bool, []fakeConnCall) {
 	var calls []fakeConnCall
 	called := false
@@ -7,6 +7,7 @@ package lxd
 
 import (
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/tools/lxdclient"
 )
 
@@ -14,6 +15,7 @@ var (
 	Provider           environs.EnvironProvider = providerInstance
 	GlobalFirewallName                          = (*environ).globalFirewallName
 	NewInstance                                 = newInstance
+	StorageProvider    storage.Provider         = &lxdStorageProvider{}
 )
 
 func ExposeInstRaw(inst *environInstance) *lxdclient.Instance {
@@ -39,3 +41,7 @@ func ExposeEnvClient(env *environ) lxdInstances {
 func GetImageSources(env *environ) ([]lxdclient.Remote, error) {
 	return env.getImageSources()
 }
+
+func NewFilesystemSource(env *environ, pool string) storage.FilesystemSource {
+	return newFilesystemSource(env, pool)
+}
@@ -17,5 +17,9 @@ const (
 func init() {
 	environs.RegisterProvider(providerType, providerInstance)
 
-	registry.RegisterEnvironStorageProviders(providerType)
+	// Register the LXD specific providers.
+	registry.RegisterProvider(lxdStorageProviderType, &lxdStorageProvider{})
+
+	// Inform the storage provider registry about the LXD providers.
+	registry.RegisterEnvironStorageProviders(providerType, lxdStorageProviderType)
 }
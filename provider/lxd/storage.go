@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+const (
+	// lxdStorageProviderType is the name of the storage provider
+	// which creates custom storage volumes in LXD storage pools.
+	lxdStorageProviderType = storage.ProviderType("lxd")
+
+	// lxdPoolAttribute is the name of the pool attribute used to
+	// specify the LXD storage pool in which volumes are created.
+	lxdPoolAttribute = "lxd-pool"
+
+	// defaultLXDPool is the LXD storage pool used when none is
+	// specified.
+	defaultLXDPool = "default"
+)
+
+// lxdStorageProvider creates LXD custom storage volumes, and attaches
+// them to containers as filesystems.
+type lxdStorageProvider struct{}
+
+var _ storage.Provider = (*lxdStorageProvider)(nil)
+
+var storageConfigFields = schema.Fields{
+	lxdPoolAttribute: schema.String(),
+}
+
+var storageConfigChecker = schema.FieldMap(
+	storageConfigFields,
+	schema.Defaults{
+		lxdPoolAttribute: defaultLXDPool,
+	},
+)
+
+type storageConfig struct {
+	pool string
+}
+
+func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
+	out, err := storageConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating LXD storage config")
+	}
+	coerced := out.(map[string]interface{})
+	pool := coerced[lxdPoolAttribute].(string)
+	if pool == "" {
+		return nil, errors.Errorf("%s must not be empty", lxdPoolAttribute)
+	}
+	return &storageConfig{pool: pool}, nil
+}
+
+// ValidateConfig is defined on the Provider interface.
+func (*lxdStorageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newStorageConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// Supports is defined on the Provider interface.
+func (*lxdStorageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*lxdStorageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lxdStorageProvider) Dynamic() bool {
+	return true
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*lxdStorageProvider) VolumeSource(environConfig *config.Config, providerConfig *storage.Config) (storage.VolumeSource, error) {
+	// Containers cannot be given block devices.
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*lxdStorageProvider) FilesystemSource(environConfig *config.Config, providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	cfg, err := newStorageConfig(providerConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := newEnviron(environConfig, newRawProvider)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create an environ with this config")
+	}
+	return newFilesystemSource(env, cfg.pool), nil
+}
+
+type filesystemSource struct {
+	raw       lxdStorage
+	pool      string
+	modelUUID string
+}
+
+var _ storage.FilesystemSource = (*filesystemSource)(nil)
+
+func newFilesystemSource(env *environ, pool string) *filesystemSource {
+	return &filesystemSource{
+		raw:       env.raw,
+		pool:      pool,
+		modelUUID: env.uuid,
+	}
+}
+
+// volumeName returns the name of the LXD storage volume backing the
+// filesystem with the given tag. Volumes are named after the model so
+// that models sharing an LXD pool do not collide.
+func (s *filesystemSource) volumeName(p storage.FilesystemParams) string {
+	return "juju-" + s.modelUUID + "-" + p.Tag.String()
+}
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *filesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	if params.Size == 0 {
+		return errors.NotValidf("zero filesystem size")
+	}
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *filesystemSource) CreateFilesystems(params []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	volumes, err := s.raw.StorageVolumes(s.pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	existing := make(map[string]bool)
+	for _, volume := range volumes {
+		existing[volume.Name] = true
+	}
+	results := make([]storage.CreateFilesystemsResult, len(params))
+	for i, p := range params {
+		if err := s.ValidateFilesystemParams(p); err != nil {
+			results[i].Error = err
+			continue
+		}
+		filesystem, err := s.createFilesystem(p, existing)
+		if err != nil {
+			logger.Errorf("could not create filesystem %q: %v", p.Tag.Id(), err)
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+// createFilesystem creates the LXD storage volume backing a filesystem.
+// A volume which already exists, left behind by an earlier attempt that
+// was interrupted before it was recorded, is taken over as is.
+func (s *filesystemSource) createFilesystem(p storage.FilesystemParams, existing map[string]bool) (*storage.Filesystem, error) {
+	name := s.volumeName(p)
+	if !existing[name] {
+		config := map[string]string{
+			"size": fmt.Sprintf("%dMiB", p.Size),
+		}
+		if err := s.raw.CreateStorageVolume(s.pool, name, config); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.Filesystem{
+		Tag: p.Tag,
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: name,
+			Size:         p.Size,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *filesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, id := range filesystemIds {
+		err := s.raw.RemoveStorageVolume(s.pool, id)
+		if err != nil && !errors.IsNotFound(err) {
+			results[i] = errors.Trace(err)
+		}
+	}
+	return results, nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *filesystemSource) AttachFilesystems(params []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(params))
+	for i, p := range params {
+		disk := lxdclient.DiskDevice{
+			Pool:     s.pool,
+			Source:   p.FilesystemId,
+			Path:     p.Path,
+			ReadOnly: p.ReadOnly,
+		}
+		if err := s.raw.AttachDisk(string(p.InstanceId), p.FilesystemId, disk); err != nil {
+			logger.Errorf("could not attach %q to %q: %v", p.FilesystemId, p.InstanceId, err)
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].FilesystemAttachment = &storage.FilesystemAttachment{
+			Filesystem: p.Filesystem,
+			Machine:    p.Machine,
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     p.Path,
+				ReadOnly: p.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *filesystemSource) DetachFilesystems(params []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(params))
+	for i, p := range params {
+		err := s.raw.DetachDisk(string(p.InstanceId), p.FilesystemId)
+		if err != nil && !errors.IsNotFound(err) {
+			results[i] = errors.Trace(err)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type storageProviderSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&storageProviderSuite{})
+
+func (s *storageProviderSuite) TestSupports(c *gc.C) {
+	c.Assert(lxd.StorageProvider.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(lxd.StorageProvider.Supports(storage.StorageKindBlock), jc.IsFalse)
+}
+
+func (s *storageProviderSuite) TestScope(c *gc.C) {
+	c.Assert(lxd.StorageProvider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(lxd.StorageProvider.Dynamic(), jc.IsTrue)
+}
+
+func (s *storageProviderSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("lxd-pool", "lxd", map[string]interface{}{
+		"lxd-pool": "fast",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = lxd.StorageProvider.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageProviderSuite) TestValidateConfigInvalid(c *gc.C) {
+	cfg, err := storage.NewConfig("lxd-pool", "lxd", map[string]interface{}{
+		"lxd-pool": 123,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = lxd.StorageProvider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating LXD storage config: lxd-pool: expected string, got int\(123\)`)
+}
+
+func (s *storageProviderSuite) TestVolumeSourceNotSupported(c *gc.C) {
+	cfg, err := storage.NewConfig("lxd", "lxd", map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = lxd.StorageProvider.VolumeSource(s.Config, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type filesystemSourceSuite struct {
+	lxd.BaseSuite
+
+	source storage.FilesystemSource
+}
+
+var _ = gc.Suite(&filesystemSourceSuite{})
+
+func (s *filesystemSourceSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.source = lxd.NewFilesystemSource(s.Env, "default")
+}
+
+func (s *filesystemSourceSuite) volumeName(tag names.FilesystemTag) string {
+	return "juju-" + s.Config.UUID() + "-" + tag.String()
+}
+
+func (s *filesystemSourceSuite) TestCreateFilesystems(c *gc.C) {
+	tag := names.NewFilesystemTag("0")
+	results, err := s.source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      tag,
+		Size:     1024,
+		Provider: "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: tag,
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: s.volumeName(tag),
+				Size:         1024,
+			},
+		},
+	}})
+	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
+		"StorageVolumes", []interface{}{"default"},
+	}, {
+		"CreateStorageVolume", []interface{}{
+			"default", s.volumeName(tag), map[string]string{"size": "1024MiB"},
+		},
+	}})
+}
+
+func (s *filesystemSourceSuite) TestCreateFilesystemsExisting(c *gc.C) {
+	tag := names.NewFilesystemTag("0")
+	s.Client.Volumes = []lxdclient.StorageVolume{{
+		Name: s.volumeName(tag),
+		Type: "custom",
+	}}
+
+	results, err := s.source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:      tag,
+		Size:     1024,
+		Provider: "lxd",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: tag,
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: s.volumeName(tag),
+				Size:         1024,
+			},
+		},
+	}})
+	s.Stub.CheckCallNames(c, "StorageVolumes")
+}
+
+func (s *filesystemSourceSuite) TestCreateFilesystemsListError(c *gc.C) {
+	s.Stub.SetErrors(errors.New("boom"))
+
+	_, err := s.source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.Stub.CheckCallNames(c, "StorageVolumes")
+}
+
+func (s *filesystemSourceSuite) TestCreateFilesystemsError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("boom"))
+
+	results, err := s.source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}, {
+		Tag: names.NewFilesystemTag("1"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(results[1].Error, gc.ErrorMatches, "zero filesystem size not valid")
+	s.Stub.CheckCallNames(c, "StorageVolumes", "CreateStorageVolume")
+}
+
+func (s *filesystemSourceSuite) TestDestroyFilesystems(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.NotFoundf("volume"), errors.New("boom"))
+
+	results, err := s.source.DestroyFilesystems([]string{"a", "b", "c"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, "boom")
+	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RemoveStorageVolume", []interface{}{"default", "a"}},
+		{"RemoveStorageVolume", []interface{}{"default", "b"}},
+		{"RemoveStorageVolume", []interface{}{"default", "c"}},
+	})
+}
+
+func (s *filesystemSourceSuite) TestAttachFilesystems(c *gc.C) {
+	results, err := s.source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id("juju-machine-0"),
+			ReadOnly:   true,
+		},
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "juju-fs-0",
+		Path:         "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		},
+	}})
+	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
+		"AttachDisk", []interface{}{
+			"juju-machine-0", "juju-fs-0", lxdclient.DiskDevice{
+				Pool:     "default",
+				Source:   "juju-fs-0",
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *filesystemSourceSuite) TestDetachFilesystems(c *gc.C) {
+	s.Stub.SetErrors(errors.NotFoundf("device"))
+
+	results, err := s.source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id("juju-machine-0"),
+		},
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "juju-fs-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+	s.Stub.CheckCalls(c, []gitjujutesting.StubCall{{
+		"DetachDisk", []interface{}{"juju-machine-0", "juju-fs-0"},
+	}})
+}
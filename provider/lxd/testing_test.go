@@ -308,6 +308,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.Env.raw = &rawProvider{
 		lxdInstances:   s.Client,
 		lxdImages:      s.Client,
+		lxdStorage:     s.Client,
 		Firewaller:     s.Firewaller,
 		policyProvider: s.Policy,
 	}
@@ -493,8 +494,9 @@ func (s *stubPolicy) SupportedArchitectures() ([]string, error) {
 type StubClient struct {
 	*gitjujutesting.Stub
 
-	Insts []lxdclient.Instance
-	Inst  *lxdclient.Instance
+	Insts   []lxdclient.Instance
+	Inst    *lxdclient.Instance
+	Volumes []lxdclient.StorageVolume
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -546,6 +548,51 @@ func (conn *StubClient) Addresses(name string) ([]network.Address, error) {
 	}}, nil
 }
 
+func (conn *StubClient) StorageVolumes(pool string) ([]lxdclient.StorageVolume, error) {
+	conn.AddCall("StorageVolumes", pool)
+	if err := conn.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return conn.Volumes, nil
+}
+
+func (conn *StubClient) CreateStorageVolume(pool, name string, config map[string]string) error {
+	conn.AddCall("CreateStorageVolume", pool, name, config)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) RemoveStorageVolume(pool, name string) error {
+	conn.AddCall("RemoveStorageVolume", pool, name)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) AttachDisk(container, device string, disk lxdclient.DiskDevice) error {
+	conn.AddCall("AttachDisk", container, device, disk)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (conn *StubClient) DetachDisk(container, device string) error {
+	conn.AddCall("DetachDisk", container, device)
+	if err := conn.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 // TODO(ericsnow) Move stubFirewaller to environs/testing or provider/common/testing.
 
 type stubFirewaller struct {
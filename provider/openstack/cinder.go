@@ -4,6 +4,7 @@
 package openstack
 
 import (
+	"fmt"
 	"math"
 	"net/url"
 	"sync"
@@ -99,6 +100,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -127,6 +129,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		SnapshotId:       arg.SnapshotId,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -149,6 +152,33 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 	return &storage.Volume{arg.Tag, cinderToJujuVolumeInfo(cinderVolume)}, nil
 }
 
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			VolumeId: arg.VolumeId,
+			Name:     fmt.Sprintf("juju-%s-snapshot-%s", s.envName, arg.Snapshot),
+			// Volumes are usually attached when snapshotted,
+			// which Cinder refuses unless forced.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %s", arg.VolumeId)
+			continue
+		}
+		logger.Debugf("created snapshot: %+v", snapshot)
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			arg.Snapshot,
+			storage.VolumeSnapshotInfo{
+				SnapshotId: snapshot.ID,
+				Size:       arg.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
 // ListVolumes is specified on the storage.VolumeSource interface.
 func (s *cinderVolumeSource) ListVolumes() ([]string, error) {
 	cinderVolumes, err := s.storageAdapter.GetVolumesDetail()
@@ -388,6 +418,7 @@ type openstackStorage interface {
 	GetVolumesDetail() ([]cinder.Volume, error)
 	DeleteVolume(volumeId string) error
 	CreateVolume(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
 	AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error)
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
@@ -456,6 +487,15 @@ func (ga *openstackStorageAdapter) CreateVolume(args cinder.CreateVolumeVolumePa
 	return &resp.Volume, nil
 }
 
+// CreateSnapshot is part of the openstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
 // GetVolumesDetail is part of the openstackStorage interface.
 func (ga *openstackStorageAdapter) GetVolumesDetail() ([]cinder.Volume, error) {
 	resp, err := ga.cinderClient.GetVolumesDetail()
@@ -208,6 +208,80 @@ func (s *cinderVolumeSourceSuite) TestResourceTags(c *gc.C) {
 	c.Assert(created, jc.IsTrue)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeFromSnapshot(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:       2,
+				Name:       "juju-testenv-volume-123",
+				SnapshotId: "snapshot-id",
+			})
+			return &cinder.Volume{ID: mockVolId}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.CreateVolumes([]storage.VolumeParams{{
+		Provider:   openstack.CinderProviderType,
+		Tag:        mockVolumeTag,
+		Size:       2 * 1024,
+		SnapshotId: "snapshot-id",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	mockAdapter.CheckCallNames(c, "CreateVolume", "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			if args.VolumeId == "bad-volume" {
+				return nil, errors.New("no snapshot for you")
+			}
+			return &cinder.Snapshot{ID: "snapshot-id"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshotter, ok := volSource.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "0",
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     mockVolSize,
+	}, {
+		Snapshot: "1",
+		Volume:   names.NewVolumeTag("124"),
+		VolumeId: "bad-volume",
+		Size:     mockVolSize,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.CreateVolumeSnapshotsResult{
+		VolumeSnapshot: &storage.VolumeSnapshot{
+			"0",
+			storage.VolumeSnapshotInfo{
+				SnapshotId: "snapshot-id",
+				Size:       mockVolSize,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, "creating snapshot of volume bad-volume: no snapshot for you")
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{{
+		"CreateSnapshot", []interface{}{cinder.CreateSnapshotSnapshotParams{
+			VolumeId: mockVolId,
+			Name:     "juju-testenv-snapshot-0",
+			Force:    true,
+		}},
+	}, {
+		"CreateSnapshot", []interface{}{cinder.CreateSnapshotSnapshotParams{
+			VolumeId: "bad-volume",
+			Name:     "juju-testenv-snapshot-1",
+			Force:    true,
+		}},
+	}})
+}
+
 func (s *cinderVolumeSourceSuite) TestListVolumes(c *gc.C) {
 	mockAdapter := &mockAdapter{
 		getVolumesDetail: func() ([]cinder.Volume, error) {
@@ -435,6 +509,7 @@ type mockAdapter struct {
 	getVolumesDetail      func() ([]cinder.Volume, error)
 	deleteVolume          func(string) error
 	createVolume          func(cinder.CreateVolumeVolumeParams) (*cinder.Volume, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
 	attachVolume          func(string, string, string) (*nova.VolumeAttachment, error)
 	volumeStatusNotifier  func(string, string, int, time.Duration) <-chan error
 	detachVolume          func(string, string) error
@@ -476,6 +551,14 @@ func (ma *mockAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cind
 	return nil, errors.NotImplementedf("CreateVolume")
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
 func (ma *mockAdapter) AttachVolume(serverId, volumeId, mountPoint string) (*nova.VolumeAttachment, error) {
 	ma.MethodCall(ma, "AttachVolume", serverId, volumeId, mountPoint)
 	if ma.attachVolume != nil {
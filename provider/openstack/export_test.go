@@ -417,6 +417,8 @@ func ResolveNetwork(e environs.Environ, networkName string) (string, error) {
 
 var PortsToRuleInfo = portsToRuleInfo
 var RuleMatchesPortRange = ruleMatchesPortRange
+var IngressRulesToRuleInfo = ingressRulesToRuleInfo
+var RuleMatchesIngressRule = ruleMatchesIngressRule
 
 var MakeServiceURL = &makeServiceURL
 var ProviderInstance = providerInstance
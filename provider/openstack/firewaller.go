@@ -58,6 +58,24 @@ type Firewaller interface {
 
 	// InstancePorts returns the port ranges opened for the specified  instance.
 	InstancePorts(inst instance.Instance, machineId string) ([]network.PortRange, error)
+
+	// OpenIngressRules opens the given ingress rules for the whole environment.
+	OpenIngressRules(rules []network.IngressRule) error
+
+	// CloseIngressRules closes the given ingress rules for the whole environment.
+	CloseIngressRules(rules []network.IngressRule) error
+
+	// IngressRules returns the ingress rules opened for the whole environment.
+	IngressRules() ([]network.IngressRule, error)
+
+	// OpenInstanceIngressRules opens the given ingress rules for the specified instance.
+	OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// CloseInstanceIngressRules closes the given ingress rules for the specified instance.
+	CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error
+
+	// InstanceIngressRules returns the ingress rules opened for the specified instance.
+	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -354,13 +372,89 @@ func (c *defaultFirewaller) InstancePorts(inst instance.Instance, machineId stri
 	return portRanges, nil
 }
 
+// OpenIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.openIngressRulesInGroup(c.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in global group: %v", rules)
+	return nil
+}
+
+// CloseIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.closeIngressRulesInGroup(c.globalGroupName(), rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in global group: %v", rules)
+	return nil
+}
+
+// IngressRules implements Firewaller interface.
+func (c *defaultFirewaller) IngressRules() ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from model",
+			c.environ.Config().FirewallMode())
+	}
+	return c.ingressRulesInGroup(c.globalGroupName())
+}
+
+// OpenInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) OpenInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	name := c.machineGroupName(machineId)
+	if err := c.openIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseInstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) CloseInstanceIngressRules(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	name := c.machineGroupName(machineId)
+	if err := c.closeIngressRulesInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed ingress rules in security group %s: %v", name, rules)
+	return nil
+}
+
+// InstanceIngressRules implements Firewaller interface.
+func (c *defaultFirewaller) InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving ports from instance",
+			c.environ.Config().FirewallMode())
+	}
+	return c.ingressRulesInGroup(c.machineGroupName(machineId))
+}
+
 func (c *defaultFirewaller) openPortsInGroup(name string, portRanges []network.PortRange) error {
+	return c.openIngressRulesInGroup(name, network.IngressRulesFromPortRanges(portRanges))
+}
+
+func (c *defaultFirewaller) openIngressRulesInGroup(name string, ingressRules []network.IngressRule) error {
 	novaclient := c.environ.nova()
 	group, err := novaclient.SecurityGroupByName(name)
 	if err != nil {
 		return err
 	}
-	rules := portsToRuleInfo(group.Id, portRanges)
+	rules := ingressRulesToRuleInfo(group.Id, ingressRules)
 	for _, rule := range rules {
 		_, err := novaclient.CreateSecurityGroupRule(rule)
 		if err != nil {
@@ -381,8 +475,28 @@ func ruleMatchesPortRange(rule nova.SecurityGroupRule, portRange network.PortRan
 		*rule.ToPort == portRange.ToPort
 }
 
+// ruleMatchesIngressRule checks if supplied nova security group rule
+// matches both the port range and the source CIDR of the ingress rule.
+func ruleMatchesIngressRule(rule nova.SecurityGroupRule, ingressRule network.IngressRule) bool {
+	return ruleMatchesPortRange(rule, ingressRule.PortRange) &&
+		ruleSourceCIDR(rule) == ingressRule.SourceCIDR
+}
+
+// ruleSourceCIDR returns the source CIDR of the nova security group
+// rule; rules without one allow traffic from anywhere.
+func ruleSourceCIDR(rule nova.SecurityGroupRule) string {
+	if cidr := rule.IPRange["cidr"]; cidr != "" {
+		return cidr
+	}
+	return network.AllSourceCIDR
+}
+
 func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.PortRange) error {
-	if len(portRanges) == 0 {
+	return c.closeIngressRulesInGroup(name, network.IngressRulesFromPortRanges(portRanges))
+}
+
+func (c *defaultFirewaller) closeIngressRulesInGroup(name string, ingressRules []network.IngressRule) error {
+	if len(ingressRules) == 0 {
 		return nil
 	}
 	novaclient := c.environ.nova()
@@ -391,9 +505,9 @@ func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.
 		return err
 	}
 	// TODO: Hey look ma, it's quadratic
-	for _, portRange := range portRanges {
+	for _, ingressRule := range ingressRules {
 		for _, p := range (*group).Rules {
-			if !ruleMatchesPortRange(p, portRange) {
+			if !ruleMatchesIngressRule(p, ingressRule) {
 				continue
 			}
 			err := novaclient.DeleteSecurityGroupRule(p.Id)
@@ -406,21 +520,39 @@ func (c *defaultFirewaller) closePortsInGroup(name string, portRanges []network.
 	return nil
 }
 
+// portsInGroup returns the port ranges open to traffic from anywhere in
+// the named group.
 func (c *defaultFirewaller) portsInGroup(name string) (portRanges []network.PortRange, err error) {
+	rules, err := c.ingressRulesInGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.OpenToAll() {
+			portRanges = append(portRanges, r.PortRange)
+		}
+	}
+	network.SortPortRanges(portRanges)
+	return portRanges, nil
+}
+
+func (c *defaultFirewaller) ingressRulesInGroup(name string) (rules []network.IngressRule, err error) {
 	group, err := c.environ.nova().SecurityGroupByName(name)
 	if err != nil {
 		return nil, err
 	}
 	for _, p := range (*group).Rules {
-		portRanges = append(portRanges, network.PortRange{
+		portRange := network.PortRange{
 			Protocol: *p.IPProtocol,
 			FromPort: *p.FromPort,
 			ToPort:   *p.ToPort,
-		})
+		}
+		rules = append(rules, network.NewIngressRule(portRange, ruleSourceCIDR(p)))
 	}
-	network.SortPortRanges(portRanges)
-	return portRanges, nil
+	network.SortIngressRules(rules)
+	return rules, nil
 }
+
 func (c *defaultFirewaller) globalGroupName() string {
 	return fmt.Sprintf("%s-global", c.jujuGroupName())
 }
@@ -394,6 +394,24 @@ func (inst *openstackInstance) Ports(machineId string) ([]network.PortRange, err
 	return inst.e.firewaller.InstancePorts(inst, machineId)
 }
 
+// OpenIngressRules is part of the instance.InstanceIngressFirewaller
+// interface.
+func (inst *openstackInstance) OpenIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.OpenInstanceIngressRules(inst, machineId, rules)
+}
+
+// CloseIngressRules is part of the instance.InstanceIngressFirewaller
+// interface.
+func (inst *openstackInstance) CloseIngressRules(machineId string, rules []network.IngressRule) error {
+	return inst.e.firewaller.CloseInstanceIngressRules(inst, machineId, rules)
+}
+
+// IngressRules is part of the instance.InstanceIngressFirewaller
+// interface.
+func (inst *openstackInstance) IngressRules(machineId string) ([]network.IngressRule, error) {
+	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -1258,14 +1276,19 @@ func (e *Environ) machinesFilter() *nova.Filter {
 
 // portsToRuleInfo maps port ranges to nova rules
 func portsToRuleInfo(groupId string, ports []network.PortRange) []nova.RuleInfo {
-	rules := make([]nova.RuleInfo, len(ports))
-	for i, portRange := range ports {
+	return ingressRulesToRuleInfo(groupId, network.IngressRulesFromPortRanges(ports))
+}
+
+// ingressRulesToRuleInfo maps ingress rules to nova rules
+func ingressRulesToRuleInfo(groupId string, ingressRules []network.IngressRule) []nova.RuleInfo {
+	rules := make([]nova.RuleInfo, len(ingressRules))
+	for i, r := range ingressRules {
 		rules[i] = nova.RuleInfo{
 			ParentGroupId: groupId,
-			FromPort:      portRange.FromPort,
-			ToPort:        portRange.ToPort,
-			IPProtocol:    portRange.Protocol,
-			Cidr:          "0.0.0.0/0",
+			FromPort:      r.FromPort,
+			ToPort:        r.ToPort,
+			IPProtocol:    r.Protocol,
+			Cidr:          r.SourceCIDR,
 		}
 	}
 	return rules
@@ -1283,6 +1306,21 @@ func (e *Environ) Ports() ([]network.PortRange, error) {
 	return e.firewaller.Ports()
 }
 
+// OpenIngressRules is part of the environs.IngressFirewaller interface.
+func (e *Environ) OpenIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.OpenIngressRules(rules)
+}
+
+// CloseIngressRules is part of the environs.IngressFirewaller interface.
+func (e *Environ) CloseIngressRules(rules []network.IngressRule) error {
+	return e.firewaller.CloseIngressRules(rules)
+}
+
+// IngressRules is part of the environs.IngressFirewaller interface.
+func (e *Environ) IngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.IngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
@@ -281,6 +281,44 @@ func (*localTests) TestPortsToRuleInfo(c *gc.C) {
 	}
 }
 
+func (*localTests) TestIngressRulesToRuleInfo(c *gc.C) {
+	rules := IngressRulesToRuleInfo("groupid", []network.IngressRule{
+		network.NewIngressRule(network.MustParsePortRange("80/tcp"), "10.0.0.0/8"),
+		network.NewIngressRule(network.MustParsePortRange("53/udp"), ""),
+	})
+	c.Check(rules, gc.DeepEquals, []nova.RuleInfo{{
+		IPProtocol:    "tcp",
+		FromPort:      80,
+		ToPort:        80,
+		Cidr:          "10.0.0.0/8",
+		ParentGroupId: "groupid",
+	}, {
+		IPProtocol:    "udp",
+		FromPort:      53,
+		ToPort:        53,
+		Cidr:          "0.0.0.0/0",
+		ParentGroupId: "groupid",
+	}})
+}
+
+func (*localTests) TestRuleMatchesIngressRule(c *gc.C) {
+	proto := "tcp"
+	port := 80
+	rule := nova.SecurityGroupRule{
+		IPProtocol: &proto,
+		FromPort:   &port,
+		ToPort:     &port,
+		IPRange:    map[string]string{"cidr": "10.0.0.0/8"},
+	}
+	portRange := network.MustParsePortRange("80/tcp")
+	c.Check(RuleMatchesIngressRule(rule, network.NewIngressRule(portRange, "10.0.0.0/8")), jc.IsTrue)
+	c.Check(RuleMatchesIngressRule(rule, network.NewIngressRule(portRange, "")), jc.IsFalse)
+
+	// Rules without a source CIDR allow traffic from anywhere.
+	rule.IPRange = nil
+	c.Check(RuleMatchesIngressRule(rule, network.NewIngressRule(portRange, "")), jc.IsTrue)
+}
+
 func (*localTests) TestRuleMatchesPortRange(c *gc.C) {
 	proto_tcp := "tcp"
 	proto_udp := "udp"
@@ -274,6 +274,26 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	return nil
 }
 
+// ImportResourceBlob stores the content of a resource whose metadata has
+// already been imported into the model as part of a model migration. The
+// imported metadata (including the username and timestamp) is preserved.
+func (st resourceState) ImportResourceBlob(serviceID, name string, r io.Reader) error {
+	id := newResourceID(serviceID, name)
+	res, _, err := st.persist.GetResource(id)
+	if err != nil {
+		return errors.Annotate(err, "while getting resource info")
+	}
+	if res.IsPlaceholder() {
+		return errors.NotValidf("placeholder resource %q", id)
+	}
+	logger.Debugf("importing content for resource %q for service %q", name, serviceID)
+
+	if err := st.storeResource(res, r); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // OpenResource returns metadata about the resource, and a reader for
 // the resource.
 func (st resourceState) OpenResource(serviceID, name string) (resource.Resource, io.ReadCloser, error) {
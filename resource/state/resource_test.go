@@ -682,6 +682,43 @@ func (s *ResourceSuite) TestUnitSetterErr(c *gc.C) {
 	s.stub.CheckCall(c, 0, "Read", p)
 }
 
+func (s *ResourceSuite) TestImportResourceBlobOkay(c *gc.C) {
+	res := newUploadResource(c, "spam", "spamspamspam")
+	res.Timestamp = s.timestamp
+	hash := res.Fingerprint.String()
+	path := "service-a-service/resources/spam"
+	file := &stubReader{stub: s.stub}
+	s.persist.ReturnGetResource = res
+	s.persist.ReturnGetResourcePath = ""
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	err := st.ImportResourceBlob("a-service", "spam", file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"GetResource",
+		"StageResource",
+		"PutAndCheckHash",
+		"Activate",
+	)
+	s.stub.CheckCall(c, 0, "GetResource", "a-service/spam")
+	s.stub.CheckCall(c, 1, "StageResource", res, path)
+	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, res.Size, hash)
+}
+
+func (s *ResourceSuite) TestImportResourceBlobPlaceholder(c *gc.C) {
+	res := resourcetesting.NewPlaceholderResource(c, "spam", "a-service")
+	s.persist.ReturnGetResource = res
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	err := st.ImportResourceBlob("a-service", "spam", &stubReader{stub: s.stub})
+
+	s.stub.CheckCallNames(c, "GetResource")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func newUploadResources(c *gc.C, names ...string) []resource.Resource {
 	var resources []resource.Resource
 	for _, name := range names {
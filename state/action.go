@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -19,6 +20,10 @@ const (
 	actionMarker = "_a_"
 )
 
+// maxActionMessages is the number of progress messages kept for an
+// action; older messages are discarded as new ones are logged.
+var maxActionMessages = 1000
+
 var (
 	actionLogger = loggo.GetLogger("juju.state.action")
 
@@ -64,6 +69,12 @@ type actionNotificationDoc struct {
 	// ActionID is the unique identifier for the Action this notification
 	// represents.
 	ActionID string `bson:"actionid"`
+
+	// CancelRequested mirrors the field of the same name on the Action.
+	// Setting it causes the receiver's notification watcher to report
+	// the Action again, which is how a running Action learns that it
+	// has been cancelled.
+	CancelRequested bool `bson:"cancel-requested,omitempty"`
 }
 
 type actionDoc struct {
@@ -104,6 +115,26 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Timeout is how long the action may run before the receiver
+	// fails it; zero means the action may run indefinitely.
+	Timeout time.Duration `bson:"timeout,omitempty"`
+
+	// CancelRequested is set when a running action is cancelled; the
+	// receiver is then expected to stop it and finish it with
+	// ActionCancelled.
+	CancelRequested bool `bson:"cancel-requested,omitempty"`
+
+	// Logs holds the progress messages logged by the action while it
+	// was running, oldest first.
+	Logs []ActionMessage `bson:"messages,omitempty"`
+}
+
+// ActionMessage is a timestamped progress message logged by a
+// running action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +193,24 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Timeout returns how long the action may run before it is failed, or
+// zero if it may run indefinitely.
+func (a *action) Timeout() time.Duration {
+	return a.doc.Timeout
+}
+
+// CancelRequested reports whether the action was cancelled while it
+// was running.
+func (a *action) CancelRequested() bool {
+	return a.doc.CancelRequested
+}
+
+// Messages returns the progress messages logged by the action,
+// oldest first.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -207,37 +256,119 @@ func (a *action) Finish(results ActionResults) (Action, error) {
 	return a.removeAndLog(results.Status, results.Results, results.Message)
 }
 
+// Log appends a timestamped progress message to the action. It asserts
+// that the action is currently running. Only the most recent
+// maxActionMessages messages are kept.
+func (a *action) Log(message string) error {
+	err := a.st.runTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{{"$push", bson.D{
+			{"messages", bson.D{
+				{"$each", []ActionMessage{{
+					Timestamp: nowToTheSecond(),
+					Message:   message,
+				}}},
+				{"$slice", -maxActionMessages},
+			}},
+		}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action is not running", a.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+	}
+	return nil
+}
+
+// Cancel cancels the action. A pending action is finished immediately
+// with ActionCancelled; a running action is flagged so that its
+// receiver stops it and finishes it. Cancelling an action which has
+// already finished is an error.
+func (a *action) Cancel() (Action, error) {
+	doc := a.doc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			current, err := a.st.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			doc = current.(*action).doc
+		}
+		switch doc.Status {
+		case ActionPending:
+			ops := a.finishOps(ActionCancelled, nil, "action cancelled")
+			ops[0].Assert = bson.D{{"status", ActionPending}}
+			return ops, nil
+		case ActionRunning:
+			if doc.CancelRequested {
+				return nil, jujutxn.ErrNoOperations
+			}
+			update := bson.D{{"$set", bson.D{{"cancel-requested", true}}}}
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     a.doc.DocId,
+				Assert: bson.D{{"status", ActionRunning}},
+				Update: update,
+			}, {
+				C:      actionNotificationsC,
+				Id:     a.notificationDocID(),
+				Assert: txn.DocExists,
+				Update: update,
+			}}, nil
+		default:
+			return nil, errors.Errorf("action is already %s", doc.Status)
+		}
+	}
+	if err := a.st.run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot cancel action %q", a.Id())
+	}
+	return a.st.Action(a.Id())
+}
+
 // removeAndLog takes the action off of the pending queue, and creates
 // an actionresult to capture the outcome of the action. It asserts that
 // the action is not already completed.
 func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]interface{}, message string) (Action, error) {
-	err := a.st.runTransaction([]txn.Op{
-		{
-			C:  actionsC,
-			Id: a.doc.DocId,
-			Assert: bson.D{{"status", bson.D{
-				{"$nin", []interface{}{
-					ActionCompleted,
-					ActionCancelled,
-					ActionFailed,
-				}}}}},
-			Update: bson.D{{"$set", bson.D{
-				{"status", finalStatus},
-				{"message", message},
-				{"results", results},
-				{"completed", nowToTheSecond()},
-			}}},
-		}, {
-			C:      actionNotificationsC,
-			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
-			Remove: true,
-		}})
+	err := a.st.runTransaction(a.finishOps(finalStatus, results, message))
 	if err != nil {
 		return nil, err
 	}
 	return a.st.Action(a.Id())
 }
 
+// finishOps returns the operations that record the outcome of the
+// action and remove its notification. They assert that the action is
+// not already completed.
+func (a *action) finishOps(finalStatus ActionStatus, results map[string]interface{}, message string) []txn.Op {
+	return []txn.Op{{
+		C:  actionsC,
+		Id: a.doc.DocId,
+		Assert: bson.D{{"status", bson.D{
+			{"$nin", []interface{}{
+				ActionCompleted,
+				ActionCancelled,
+				ActionFailed,
+			}}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", finalStatus},
+			{"message", message},
+			{"results", results},
+			{"completed", nowToTheSecond()},
+		}}},
+	}, {
+		C:      actionNotificationsC,
+		Id:     a.notificationDocID(),
+		Remove: true,
+	}}
+}
+
+// notificationDocID returns the id of the action's notification doc.
+func (a *action) notificationDocID() string {
+	return a.st.docID(ensureActionMarker(a.Receiver()) + a.Id())
+}
+
 // newAction builds an Action for the given State and actionDoc.
 func newAction(st *State, adoc actionDoc) Action {
 	return &action{
@@ -247,7 +378,7 @@ func newAction(st *State, adoc actionDoc) Action {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeout time.Duration) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	actionId, err := NewUUID()
 	if err != nil {
@@ -263,6 +394,7 @@ func newActionDoc(st *State, receiverTag names.Tag, actionName string, parameter
 			Parameters: parameters,
 			Enqueued:   nowToTheSecond(),
 			Status:     ActionPending,
+			Timeout:    timeout,
 		}, actionNotificationDoc{
 			DocId:     st.docID(prefix + actionId.String()),
 			ModelUUID: modelUUID,
@@ -338,16 +470,26 @@ func (st *State) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction
 func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return st.EnqueueActionWithTimeout(receiver, actionName, payload, 0)
+}
+
+// EnqueueActionWithTimeout enqueues an action which the receiver will
+// fail if it runs for longer than timeout. A zero timeout means the
+// action may run indefinitely.
+func (st *State) EnqueueActionWithTimeout(receiver names.Tag, actionName string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if timeout < 0 {
+		return nil, errors.NotValidf("negative timeout %v", timeout)
+	}
 
 	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload)
+	doc, ndoc, err := newActionDoc(st, receiver, actionName, payload, timeout)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
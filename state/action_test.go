@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -463,6 +464,156 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestAddActionWithTimeout(c *gc.C) {
+	a, err := s.unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, 5*time.Minute)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Timeout(), gc.Equals, 5*time.Minute)
+
+	a, err = s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Timeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ActionSuite) TestAddActionRejectsNegativeTimeout(c *gc.C) {
+	_, err := s.unit.AddActionWithTimeout("snapshot", nil, -time.Second)
+	c.Assert(err, gc.ErrorMatches, "negative timeout -1s not valid")
+}
+
+func (s *ActionSuite) TestCancelPending(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+	c.Assert(result.CancelRequested(), jc.IsFalse)
+	_, message := result.Results()
+	c.Assert(message, gc.Equals, "action cancelled")
+
+	actions, err := unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestCancelRunning(c *gc.C) {
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	preventUnitDestroyRemove(c, unit)
+
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := unit.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	result, err := a.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(result.CancelRequested(), jc.IsTrue)
+
+	// The receiver is told about the cancellation through its
+	// notification watcher.
+	wc.AssertChange(a.Id())
+	wc.AssertNoChange()
+
+	// Cancelling again changes nothing.
+	result, err = result.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.CancelRequested(), jc.IsTrue)
+	wc.AssertNoChange()
+
+	// The receiver finishes the action once it has stopped it.
+	result, err = result.Finish(state.ActionResults{Status: state.ActionCancelled, Message: "action cancelled"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status(), gc.Equals, state.ActionCancelled)
+}
+
+func (s *ActionSuite) TestCancelFinished(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = a.Cancel()
+	c.Assert(err, gc.ErrorMatches, `cannot cancel action ".*": action is already completed`)
+}
+
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("dumping table users")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("dumping table groups")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "dumping table users")
+	c.Check(messages[1].Message, gc.Equals, "dumping table groups")
+	for _, m := range messages {
+		c.Check(m.Timestamp.IsZero(), jc.IsFalse)
+	}
+
+	// The messages are kept once the action has finished.
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Messages(), gc.HasLen, 2)
+}
+
+func (s *ActionSuite) TestLogKeepsMostRecentMessages(c *gc.C) {
+	s.PatchValue(state.MaxActionMessages, 2)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, message := range []string{"one", "two", "three"} {
+		err = a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "two")
+	c.Check(messages[1].Message, gc.Equals, "three")
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -878,6 +1029,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeout(string, map[string]interface{}, time.Duration) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
@@ -588,8 +588,37 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing filesystems and volumes, e.g. those
+	// of storage attached to a unit after being detached from another.
+	for tag, params := range args.filesystemAttachments {
+		f, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		storageTag, err := f.Storage()
+		if err != nil && !errors.IsNotAssigned(err) {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		filesystemOps = append(filesystemOps, attachExistingMachineStorageOp(filesystemsC, tag.Id()))
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag, storageTag, params,
+		})
+		if volumeTag, err := f.Volume(); err == nil {
+			// The filesystem is backed by a volume, so attach that too.
+			volumeOps = append(volumeOps, attachExistingMachineStorageOp(volumesC, volumeTag.Id()))
+			volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+				volumeTag, VolumeAttachmentParams{},
+			})
+		} else if err != ErrNoBackingVolume {
+			return nil, nil, nil, errors.Trace(err)
+		}
+	}
+	for tag, params := range args.volumeAttachments {
+		volumeOps = append(volumeOps, attachExistingMachineStorageOp(volumesC, tag.Id()))
+		volumeAttachments = append(volumeAttachments, volumeAttachmentTemplate{
+			tag, params,
+		})
+	}
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
@@ -605,6 +634,18 @@ func (st *State) machineStorageOps(
 	return ops, volumeAttachments, fsAttachments, nil
 }
 
+// attachExistingMachineStorageOp returns a txn.Op that increments the
+// attachment count of an existing volume or filesystem, which must be
+// Alive and unattached, for a new attachment to a machine.
+func attachExistingMachineStorageOp(collection, id string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     id,
+		Assert: append(bson.D{{"attachmentcount", 0}}, isAliveDoc...),
+		Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+	}
+}
+
 // addMachineStorageAttachmentsOps returns txn.Ops for adding the IDs of
 // attached volumes and filesystems to an existing machine. Filesystem
 // mount points are checked against existing filesystem attachments for
@@ -9,12 +9,16 @@ import (
 	"github.com/juju/juju/state/bakerystorage"
 )
 
-// The capped collection used for transaction logs defaults to 10MB.
-// It's tweaked in export_test.go to 1MB to avoid the overhead of
-// creating and deleting the large file repeatedly in tests.
+// The capped collections used for transaction logs and the audit log
+// default to 10MB and 20MB respectively. They're tweaked in
+// export_test.go to 1MB to avoid the overhead of creating and deleting
+// the large files repeatedly in tests.
 var (
 	txnLogSize      = 10000000
 	txnLogSizeTests = 1000000
+
+	auditLogSize      = 20000000
+	auditLogSizeTests = 1000000
 )
 
 // allCollections should be the single source of truth for information about
@@ -124,6 +128,16 @@ func allCollections() collectionSchema {
 		// one model migration document exists per environment.
 		migrationsActiveC: {global: true},
 
+		// This collection records the reports made by the migration
+		// minion workers of each model's agents as they complete the
+		// phases of a model migration.
+		migrationsMinionSyncC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"migration-id", "phase"},
+			}},
+		},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -134,6 +148,10 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// This collection holds the controller-level permissions granted
+		// to users, such as the ability to add models.
+		controllerUsersC: {global: true},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -150,6 +168,21 @@ func allCollections() collectionSchema {
 		// for passing onward to other tools.
 		metricsC: {global: true},
 
+		// This collection holds the latest value of each metric reported
+		// by each unit, as kept by the "prometheus" metrics sender.
+		latestMetricsC: {
+			global:    true,
+			rawAccess: true,
+		},
+
+		// This collection holds the failures to collect, spool or send
+		// metrics reported by units, kept for the model's metrics history.
+		metricFailuresC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit"},
+			}},
+		},
+
 		// This collection holds persistent state for the metrics manager.
 		metricsManagerC: {global: true},
 
@@ -163,6 +196,26 @@ func allCollections() collectionSchema {
 		// was implemented.
 		actionresultsC: {global: true},
 
+		// This collection records the API calls made by users that
+		// change, or attempt to change, the state of any model. It is
+		// capped so that the oldest records are discarded as new ones
+		// are added.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"time"},
+			}, {
+				Key: []string{"user", "time"},
+			}, {
+				Key: []string{"model-uuid", "time"},
+			}},
+		},
+
 		// This collection holds storage items for a macaroon bakery.
 		bakeryStorageItemsC: {
 			global:  true,
@@ -283,6 +336,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "volumeid"},
+			}},
+		},
 
 		// -----
 
@@ -399,6 +457,7 @@ const (
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	auditLogC                = "auditlog"
 	assignUnitC              = "assignUnits"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
@@ -409,22 +468,26 @@ const (
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
+	controllerUsersC         = "controllerusers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
 	legacyipaddressesC       = "ipaddresses"
+	latestMetricsC           = "latestmetrics"
 	leaseC                   = "lease"
 	leasesC                  = "leases"
 	machinesC                = "machines"
 	meterStatusC             = "meterStatus"
+	metricFailuresC          = "metricfailures"
 	metricsC                 = "metrics"
 	metricsManagerC          = "metricsmanager"
 	minUnitsC                = "minunits"
 	migrationsStatusC        = "migrations.status"
 	migrationsActiveC        = "migrations.active"
 	migrationsC              = "migrations"
+	migrationsMinionSyncC    = "migrations.minionsync"
 	modelUserLastConnectionC = "modelUserLastConnection"
 	modelUsersC              = "modelusers"
 	modelsC                  = "models"
@@ -459,6 +522,7 @@ const (
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
 	volumeAttachmentsC       = "volumeattachments"
+	volumeSnapshotsC         = "volumesnapshots"
 	volumesC                 = "volumes"
 	// "payloads" (see payload/persistence/mongo.go)
 	// "resources" (see resource/persistence/mongo.go)
@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/audit"
+)
+
+// auditLogDoc records a single audited API call. Documents are only
+// ever inserted; the capped collection takes care of discarding the
+// oldest records.
+type auditLogDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	Time      int64         `bson:"time"`
+	User      string        `bson:"user"`
+	ModelUUID string        `bson:"model-uuid"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args,omitempty"`
+	Error     string        `bson:"error,omitempty"`
+}
+
+// AuditLogFilter defines the criteria used to select entries from the
+// audit log. Zero-valued fields do not restrict the results.
+type AuditLogFilter struct {
+	// User restricts the results to calls made by the given user.
+	User names.UserTag
+
+	// ModelUUID restricts the results to calls made against the
+	// given model.
+	ModelUUID string
+
+	// Facade restricts the results to calls made on the named facade.
+	Facade string
+
+	// From restricts the results to calls made at or after this time.
+	From time.Time
+
+	// To restricts the results to calls made before this time.
+	To time.Time
+
+	// Limit restricts the number of results returned. The most
+	// recent entries are kept.
+	Limit int
+}
+
+// PutAuditEntry records the given entry in the controller's audit log.
+func (st *State) PutAuditEntry(entry audit.AuditEntry) error {
+	if err := entry.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	doc := auditLogDoc{
+		Id:        bson.NewObjectId(),
+		Time:      entry.Timestamp.UnixNano(),
+		User:      entry.UserTag.Canonical(),
+		ModelUUID: entry.ModelUUID,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Error:     entry.Error,
+	}
+	return errors.Annotate(auditLog.Insert(doc), "cannot record audit entry")
+}
+
+// AuditEntries returns the entries in the controller's audit log that
+// match the supplied filter, ordered from oldest to newest.
+func (st *State) AuditEntries(filter AuditLogFilter) ([]audit.AuditEntry, error) {
+	auditLog, closer := st.getRawCollection(auditLogC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.User.Id() != "" {
+		// Users are recorded in canonical form, so that local
+		// users match whether or not the domain is specified.
+		query = append(query, bson.DocElem{"user", filter.User.Canonical()})
+	}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.Facade != "" {
+		query = append(query, bson.DocElem{"facade", filter.Facade})
+	}
+	timeRange := bson.D{}
+	if !filter.From.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.From.UnixNano()})
+	}
+	if !filter.To.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lt", filter.To.UnixNano()})
+	}
+	if len(timeRange) > 0 {
+		query = append(query, bson.DocElem{"time", timeRange})
+	}
+
+	// Query newest first so that any limit keeps the most recent
+	// entries; the results are reversed below.
+	q := auditLog.Find(query).Sort("-time", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []auditLogDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read audit log")
+	}
+
+	entries := make([]audit.AuditEntry, len(docs))
+	for i, doc := range docs {
+		if !names.IsValidUser(doc.User) {
+			return nil, errors.Errorf("audit entry %s has invalid user %q", doc.Id.Hex(), doc.User)
+		}
+		entries[len(docs)-1-i] = audit.AuditEntry{
+			Timestamp: time.Unix(0, doc.Time).UTC(),
+			UserTag:   names.NewUserTag(doc.User),
+			ModelUUID: doc.ModelUUID,
+			Facade:    doc.Facade,
+			Version:   doc.Version,
+			Method:    doc.Method,
+			Args:      doc.Args,
+			Error:     doc.Error,
+		}
+	}
+	return entries, nil
+}
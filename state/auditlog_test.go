@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+	start time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.start = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// entry returns an audit entry for the given user. Users are recorded
+// in canonical form, so the entry's tag is canonicalised to match
+// what is read back.
+func (s *AuditLogSuite) entry(user, facade, method string, offset time.Duration) audit.AuditEntry {
+	return audit.AuditEntry{
+		Timestamp: s.start.Add(offset),
+		UserTag:   names.NewUserTag(names.NewUserTag(user).Canonical()),
+		ModelUUID: s.State.ModelUUID(),
+		Facade:    facade,
+		Version:   1,
+		Method:    method,
+	}
+}
+
+func (s *AuditLogSuite) putEntries(c *gc.C, entries ...audit.AuditEntry) {
+	for _, entry := range entries {
+		err := s.State.PutAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditLogSuite) TestPutAndGet(c *gc.C) {
+	entry := s.entry("bob", "Service", "Set", 0)
+	entry.Args = `{"servicename":"mysql"}`
+	entry.Error = "permission denied"
+	s.putEntries(c, entry)
+
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{entry})
+}
+
+func (s *AuditLogSuite) TestPutInvalidEntry(c *gc.C) {
+	entry := s.entry("bob", "Service", "Set", 0)
+	entry.Method = ""
+	err := s.State.PutAuditEntry(entry)
+	c.Assert(err, gc.ErrorMatches, "empty Method not valid")
+}
+
+func (s *AuditLogSuite) TestLocalUser(c *gc.C) {
+	entry := audit.AuditEntry{
+		Timestamp: s.start,
+		UserTag:   names.NewLocalUserTag("bob"),
+		ModelUUID: s.State.ModelUUID(),
+		Facade:    "Service",
+		Version:   1,
+		Method:    "Set",
+	}
+	s.putEntries(c, entry)
+
+	for _, user := range []string{"bob", "bob@local"} {
+		c.Logf("filtering by %q", user)
+		entries, err := s.State.AuditEntries(state.AuditLogFilter{
+			User: names.NewUserTag(user),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{entry})
+	}
+	entries, err := s.State.AuditEntries(state.AuditLogFilter{
+		User: names.NewUserTag("bob@external"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *AuditLogSuite) TestFilters(c *gc.C) {
+	e0 := s.entry("bob", "Service", "Set", 0)
+	e1 := s.entry("mary", "Service", "Expose", time.Minute)
+	e2 := s.entry("bob", "Client", "AddMachines", 2*time.Minute)
+	e3 := s.entry("bob@external", "Client", "ModelSet", 3*time.Minute)
+	e4 := s.entry("bob", "Service", "Unexpose", 4*time.Minute)
+	e4.ModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	s.putEntries(c, e0, e1, e2, e3, e4)
+
+	for i, test := range []struct {
+		about    string
+		filter   state.AuditLogFilter
+		expected []audit.AuditEntry
+	}{{
+		about:    "no filter",
+		expected: []audit.AuditEntry{e0, e1, e2, e3, e4},
+	}, {
+		about:    "by user",
+		filter:   state.AuditLogFilter{User: names.NewUserTag("bob")},
+		expected: []audit.AuditEntry{e0, e2, e4},
+	}, {
+		about:    "by external user",
+		filter:   state.AuditLogFilter{User: names.NewUserTag("bob@external")},
+		expected: []audit.AuditEntry{e3},
+	}, {
+		about:    "by model",
+		filter:   state.AuditLogFilter{ModelUUID: s.State.ModelUUID()},
+		expected: []audit.AuditEntry{e0, e1, e2, e3},
+	}, {
+		about:    "by facade",
+		filter:   state.AuditLogFilter{Facade: "Client"},
+		expected: []audit.AuditEntry{e2, e3},
+	}, {
+		about: "by time range",
+		filter: state.AuditLogFilter{
+			From: s.start.Add(time.Minute),
+			To:   s.start.Add(3 * time.Minute),
+		},
+		expected: []audit.AuditEntry{e1, e2},
+	}, {
+		about:    "limit keeps the most recent",
+		filter:   state.AuditLogFilter{Limit: 2},
+		expected: []audit.AuditEntry{e3, e4},
+	}, {
+		about: "combined",
+		filter: state.AuditLogFilter{
+			User:   names.NewUserTag("bob"),
+			Facade: "Service",
+			From:   s.start.Add(time.Second),
+		},
+		expected: []audit.AuditEntry{e4},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		entries, err := s.State.AuditEntries(test.filter)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(entries, jc.DeepEquals, test.expected)
+	}
+}
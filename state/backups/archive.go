@@ -22,6 +22,9 @@ const (
 	filesBundle  = "root.tar"
 	dbDumpDir    = "dump"
 	metadataFile = "metadata.json"
+
+	manifestFile          = "manifest.json"
+	manifestSignatureFile = "manifest.sig"
 )
 
 var legacyVersion = version.Number{Major: 1, Minor: 20}
@@ -47,6 +50,14 @@ type ArchivePaths struct {
 
 	// MetadataFile is the path to the metadata file.
 	MetadataFile string
+
+	// ManifestFile is the path to the manifest listing the archive's
+	// contents.
+	ManifestFile string
+
+	// ManifestSignatureFile is the path to the controller's
+	// signature of the manifest.
+	ManifestSignatureFile string
 }
 
 // NewCanonicalArchivePaths composes a new ArchivePaths with default
@@ -59,6 +70,9 @@ func NewCanonicalArchivePaths() ArchivePaths {
 		FilesBundle:  path.Join(contentDir, filesBundle),
 		DBDumpDir:    path.Join(contentDir, dbDumpDir),
 		MetadataFile: path.Join(contentDir, metadataFile),
+
+		ManifestFile:          path.Join(contentDir, manifestFile),
+		ManifestSignatureFile: path.Join(contentDir, manifestSignatureFile),
 	}
 }
 
@@ -73,6 +87,9 @@ func NewNonCanonicalArchivePaths(rootDir string) ArchivePaths {
 		FilesBundle:  filepath.Join(rootDir, contentDir, filesBundle),
 		DBDumpDir:    filepath.Join(rootDir, contentDir, dbDumpDir),
 		MetadataFile: filepath.Join(rootDir, contentDir, metadataFile),
+
+		ManifestFile:          filepath.Join(rootDir, contentDir, manifestFile),
+		ManifestSignatureFile: filepath.Join(rootDir, contentDir, manifestSignatureFile),
 	}
 }
 
@@ -0,0 +1,223 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+
+	corebackups "github.com/juju/juju/core/backups"
+)
+
+// archiveExtension is added to a backup's ID to name its archive in
+// storage outside the controller.
+const archiveExtension = ".tar.gz"
+
+// ArchiveStorage stores backup archives, keyed by backup ID. It
+// holds only the archives themselves: backup metadata is always kept
+// in the controller's database.
+type ArchiveStorage interface {
+	// File returns the archive with the given ID. If there is no
+	// such archive the error satisfies errors.IsNotFound.
+	File(id string) (io.ReadCloser, error)
+
+	// AddFile stores the archive with the given ID.
+	AddFile(id string, file io.Reader, size int64) error
+
+	// RemoveFile removes the archive with the given ID.
+	RemoveFile(id string) error
+
+	// Close releases any resources held by the storage.
+	Close() error
+}
+
+// NewArchiveStorage returns the ArchiveStorage which keeps archives
+// outside the controller, as described by cfg. Archives kept in the
+// controller's own database are handled by NewStorage.
+func NewArchiveStorage(cfg corebackups.StorageConfig) (ArchiveStorage, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch cfg.Type {
+	case corebackups.StorageDirectory:
+		return NewDirectoryStorage(cfg.Directory), nil
+	case corebackups.StorageS3:
+		return NewS3Storage(cfg.S3)
+	}
+	return nil, errors.NotSupportedf("archive storage %q", cfg.Type)
+}
+
+//---------------------------
+// directory storage
+
+// NewDirectoryStorage returns an ArchiveStorage which keeps archives
+// in the given directory, creating it if necessary.
+func NewDirectoryStorage(dir string) ArchiveStorage {
+	return &directoryStorage{dir: dir}
+}
+
+type directoryStorage struct {
+	dir string
+}
+
+func (s *directoryStorage) path(id string) string {
+	return filepath.Join(s.dir, id+archiveExtension)
+}
+
+// File implements ArchiveStorage.
+func (s *directoryStorage) File(id string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile implements ArchiveStorage. The archive is written to a
+// temporary file first, so that a partly written archive never
+// appears under the backup's name.
+func (s *directoryStorage) AddFile(id string, file io.Reader, size int64) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Annotate(err, "cannot create backup directory")
+	}
+	tmp, err := ioutil.TempFile(s.dir, id+".tmp")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, file)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot write backup archive %q", id)
+	}
+	if written != size {
+		return errors.Errorf("backup archive %q: expected %d bytes, wrote %d", id, size, written)
+	}
+	return errors.Trace(os.Rename(tmp.Name(), s.path(id)))
+}
+
+// RemoveFile implements ArchiveStorage.
+func (s *directoryStorage) RemoveFile(id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements ArchiveStorage.
+func (s *directoryStorage) Close() error {
+	return nil
+}
+
+//---------------------------
+// S3 storage
+
+// NewS3Storage returns an ArchiveStorage which keeps archives in a
+// bucket of an S3-compatible object store.
+func NewS3Storage(cfg corebackups.S3Config) (ArchiveStorage, error) {
+	region, ok := aws.Regions[cfg.Region]
+	if cfg.Endpoint != "" {
+		region = aws.Region{
+			Name:       cfg.Region,
+			S3Endpoint: cfg.Endpoint,
+		}
+	} else if !ok {
+		return nil, errors.NotValidf("S3 region %q", cfg.Region)
+	}
+	auth := aws.Auth{
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s3Storage{bucket: bucket}, nil
+}
+
+type s3Storage struct {
+	mu         sync.Mutex
+	madeBucket bool
+	bucket     *s3.Bucket
+}
+
+func s3Key(id string) string {
+	return id + archiveExtension
+}
+
+// makeBucket creates the bucket the first time an archive is added.
+func (s *s3Storage) makeBucket() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.madeBucket {
+		return nil
+	}
+	err := s.bucket.PutBucket(s3.Private)
+	if err != nil && s3ErrorCode(err) != "BucketAlreadyOwnedByYou" {
+		return errors.Trace(err)
+	}
+	s.madeBucket = true
+	return nil
+}
+
+// File implements ArchiveStorage.
+func (s *s3Storage) File(id string) (io.ReadCloser, error) {
+	file, err := s.bucket.GetReader(s3Key(id))
+	if s3ErrorStatusCode(err) == 404 {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile implements ArchiveStorage.
+func (s *s3Storage) AddFile(id string, file io.Reader, size int64) error {
+	if err := s.makeBucket(); err != nil {
+		return errors.Annotate(err, "cannot create backup bucket")
+	}
+	err := s.bucket.PutReader(s3Key(id), file, size, "application/x-gzip", s3.Private)
+	return errors.Annotatef(err, "cannot upload backup archive %q", id)
+}
+
+// RemoveFile implements ArchiveStorage.
+func (s *s3Storage) RemoveFile(id string) error {
+	err := s.bucket.Del(s3Key(id))
+	if s3ErrorStatusCode(err) == 404 {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements ArchiveStorage.
+func (s *s3Storage) Close() error {
+	return nil
+}
+
+// s3ErrorStatusCode returns the HTTP status of the S3 request error,
+// or 0 if err is not an S3 error.
+func s3ErrorStatusCode(err error) int {
+	if err, ok := err.(*s3.Error); ok {
+		return err.StatusCode
+	}
+	return 0
+}
+
+// s3ErrorCode returns the S3 error code of err, or "" if err is not
+// an S3 error.
+func s3ErrorCode(err error) string {
+	if err, ok := err.(*s3.Error); ok {
+		return err.Code
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+// archiveStorageSuite checks the behaviour common to all archive
+// storage implementations.
+type archiveStorageSuite struct {
+	testing.BaseSuite
+	stor backups.ArchiveStorage
+}
+
+func (s *archiveStorageSuite) TestAddFile(c *gc.C) {
+	err := s.stor.AddFile("spam", strings.NewReader("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	file, err := s.stor.File("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	data, err := ioutil.ReadAll(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *archiveStorageSuite) TestFileNotFound(c *gc.C) {
+	_, err := s.stor.File("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *archiveStorageSuite) TestRemoveFile(c *gc.C) {
+	err := s.stor.AddFile("spam", strings.NewReader("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.stor.RemoveFile("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.stor.File("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type directoryStorageSuite struct {
+	archiveStorageSuite
+	dir string
+}
+
+var _ = gc.Suite(&directoryStorageSuite{})
+
+func (s *directoryStorageSuite) SetUpTest(c *gc.C) {
+	s.archiveStorageSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "backups")
+	stor, err := backups.NewArchiveStorage(corebackups.StorageConfig{
+		Type:      corebackups.StorageDirectory,
+		Directory: s.dir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stor = stor
+}
+
+func (s *directoryStorageSuite) TestArchiveFile(c *gc.C) {
+	err := s.stor.AddFile("spam", strings.NewReader("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, "spam.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *directoryStorageSuite) TestAddFileShort(c *gc.C) {
+	err := s.stor.AddFile("spam", strings.NewReader("<archive>"), 20)
+	c.Assert(err, gc.ErrorMatches, `backup archive "spam": expected 20 bytes, wrote 9`)
+
+	// Nothing is left behind.
+	infos, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(infos, gc.HasLen, 0)
+}
+
+func (s *directoryStorageSuite) TestRemoveFileNotFound(c *gc.C) {
+	err := s.stor.RemoveFile("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = os.Stat(s.dir)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+type s3StorageSuite struct {
+	archiveStorageSuite
+	srv *s3test.Server
+}
+
+var _ = gc.Suite(&s3StorageSuite{})
+
+func (s *s3StorageSuite) SetUpTest(c *gc.C) {
+	s.archiveStorageSuite.SetUpTest(c)
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.srv = srv
+	stor, err := backups.NewArchiveStorage(corebackups.StorageConfig{
+		Type: corebackups.StorageS3,
+		S3: corebackups.S3Config{
+			Endpoint: srv.URL(),
+			Region:   "test",
+			Bucket:   "juju-backups",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stor = stor
+}
+
+func (s *s3StorageSuite) TearDownTest(c *gc.C) {
+	s.srv.Quit()
+	s.archiveStorageSuite.TearDownTest(c)
+}
+
+type archiveStorageConfigSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&archiveStorageConfigSuite{})
+
+func (s *archiveStorageConfigSuite) TestInvalidConfig(c *gc.C) {
+	_, err := backups.NewArchiveStorage(corebackups.StorageConfig{
+		Type: corebackups.StorageDirectory,
+	})
+	c.Check(err, gc.ErrorMatches, "directory storage with no directory not valid")
+}
+
+func (s *archiveStorageConfigSuite) TestControllerStorage(c *gc.C) {
+	_, err := backups.NewArchiveStorage(corebackups.StorageConfig{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *archiveStorageConfigSuite) TestUnknownRegion(c *gc.C) {
+	_, err := backups.NewArchiveStorage(corebackups.StorageConfig{
+		Type: corebackups.StorageS3,
+		S3: corebackups.S3Config{
+			Region: "nowhere",
+			Bucket: "juju-backups",
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `S3 region "nowhere" not valid`)
+}
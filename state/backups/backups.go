@@ -85,7 +85,7 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 type Backups interface {
 	// Create creates and stores a new juju backup archive. It updates
 	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, enc Encryption) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -118,8 +118,12 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 }
 
 // Create creates and stores a new juju backup archive and updates the
-// provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+// provided metadata. If enc is not zero, the archive is encrypted.
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, enc Encryption) error {
+	if err := enc.Validate(); err != nil {
+		return errors.Annotate(err, "invalid backup encryption")
+	}
+	meta.Encrypted = !enc.IsZero()
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -142,7 +146,13 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "while preparing for DB dump")
 	}
-	args := createArgs{filesToBackUp, dumper, metadataFile}
+	args := createArgs{
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		meta:           meta,
+		encryption:     enc,
+	}
 	result, err := runCreate(&args)
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
@@ -56,7 +56,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	dbInfo := backups.DBInfo{"a", "b", "c", targets}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.Encryption{})
 
 	c.Check(err, gc.ErrorMatches, expected)
 }
@@ -96,7 +96,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.Encryption{})
 
 	// Test the call values.
 	s.Storage.CheckCalled(c, "spam", meta, archiveFile, "Add", "Metadata")
@@ -149,6 +149,38 @@ func (s *backupsSuite) TestCreateFailToCreate(c *gc.C) {
 	s.checkFailure(c, "while creating backup archive: failed!")
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	received, testCreate := backups.NewTestCreate(nil)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.setStored("spam")
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju")}
+	meta := backupstesting.NewMetadataStarted()
+	enc := backups.Encryption{Key: "secret"}
+	err := s.api.Create(meta, &paths, &dbInfo, enc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(backups.ExposeCreateEncryption(received), gc.Equals, enc)
+	c.Check(meta.Encrypted, jc.IsTrue)
+}
+
+func (s *backupsSuite) TestCreateInvalidEncryption(c *gc.C) {
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju")}
+	meta := backupstesting.NewMetadataStarted()
+	enc := backups.Encryption{Key: "secret", PublicKey: "<public key>"}
+	err := s.api.Create(meta, &paths, &dbInfo, enc)
+
+	c.Check(err, gc.ErrorMatches, "invalid backup encryption: encryption with both a key and a public key not valid")
+}
+
 func (s *backupsSuite) TestCreateFailToFinishMeta(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return []string{}, nil
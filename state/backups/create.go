@@ -31,6 +31,11 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	// meta is used to write and sign the archive's manifest. If it
+	// is nil, no manifest is written.
+	meta *Metadata
+	// encryption describes how the archive is encrypted, if at all.
+	encryption Encryption
 }
 
 type createResult struct {
@@ -47,6 +52,8 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.meta = args.meta
+	builder.encryption = args.encryption
 	defer func() {
 		if cerr := builder.cleanUp(); cerr != nil {
 			cerr.Log(logger)
@@ -96,6 +103,10 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// meta is used to write and sign the archive's manifest.
+	meta *Metadata
+	// encryption describes how the archive is encrypted, if at all.
+	encryption Encryption
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -285,6 +296,15 @@ func (b *builder) buildDBDump() error {
 	return nil
 }
 
+func (b *builder) buildManifest() error {
+	logger.Infof("writing manifest")
+	if b.meta == nil {
+		logger.Infof("nothing to do")
+		return nil
+	}
+	return errors.Trace(writeManifest(b.archivePaths, b.meta))
+}
+
 func (b *builder) buildArchive(outFile io.Writer) error {
 	tarball := gzip.NewWriter(outFile)
 	defer tarball.Close()
@@ -313,8 +333,23 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encryption.IsZero() {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		// The checksum is of the encrypted archive, since that is
+		// the file which is stored and downloaded.
+		encrypter, err := NewEncryptingWriter(hasher, b.encryption)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypter); err != nil {
+			return errors.Trace(err)
+		}
+		if err := encrypter.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -337,6 +372,11 @@ func (b *builder) buildAll() error {
 		return errors.Trace(err)
 	}
 
+	// List the contents.
+	if err := b.buildManifest(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Bundle it all into a tarball.
 	if err := b.buildArchiveAndChecksum(); err != nil {
 		return errors.Trace(err)
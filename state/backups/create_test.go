@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"bytes"
 	"os"
 	"runtime"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/juju/juju/state/backups"
 	backupstesting "github.com/juju/juju/state/backups/testing"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type createSuite struct {
@@ -39,7 +41,7 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	_, testFiles, expected := s.createTestFiles(c)
 
 	dumper := &TestDBDumper{}
-	args := backups.NewTestCreateArgs(testFiles, dumper, metadataFile)
+	args := backups.NewTestCreateArgs(testFiles, dumper, metadataFile, nil, backups.Encryption{})
 	result, err := backups.Create(args)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.NotNil)
@@ -56,11 +58,88 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestSignedManifest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.CACert = coretesting.CACert
+	meta.CAPrivateKey = coretesting.CAKey
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, _ := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(testFiles, &TestDBDumper{}, metadataFile, meta, backups.Encryption{})
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, _, _ := backups.ExposeCreateResult(result)
+	defer archiveFile.Close()
+
+	ws, err := backups.NewArchiveWorkspaceReader(archiveFile)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ws.Close()
+	manifest, err := ws.Verify(coretesting.CACert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manifest.Version, gc.Equals, meta.Origin.Version)
+	var paths []string
+	for _, file := range manifest.Files {
+		paths = append(paths, file.Path)
+	}
+	c.Check(paths, jc.SameContents, []string{"metadata.json", "root.tar"})
+}
+
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, expected := s.createTestFiles(c)
+
+	enc := backups.Encryption{Key: "secret"}
+	args := backups.NewTestCreateArgs(testFiles, &TestDBDumper{}, metadataFile, meta, enc)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, size, checksum := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+	defer file.Close()
+
+	// The size and checksum are those of the encrypted file.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+
+	encrypted, err := backups.IsEncryptedArchive(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+
+	decrypted, err := os.Create(c.MkDir() + "/decrypted.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	defer decrypted.Close()
+	err = backups.DecryptArchive(decrypted, file, backups.Decryption{Key: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = decrypted.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkArchive(c, decrypted, expected)
+}
+
+func (s *createSuite) TestInvalidEncryption(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	_, testFiles, _ := s.createTestFiles(c)
+
+	enc := backups.Encryption{PublicKey: "<not a key>"}
+	args := backups.NewTestCreateArgs(testFiles, &TestDBDumper{}, bytes.NewBufferString("{}"), meta, enc)
+	_, err := backups.Create(args)
+
+	c.Check(err, gc.ErrorMatches, "while encrypting archive: public key \\(no PEM data\\) not valid")
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var testFiles []string
 	dumper := &TestDBDumper{}
 
-	args := backups.NewTestCreateArgs(testFiles, dumper, nil)
+	args := backups.NewTestCreateArgs(testFiles, dumper, nil, nil, backups.Encryption{})
 	_, err := backups.Create(args)
 
 	c.Check(err, gc.ErrorMatches, "missing metadataReader")
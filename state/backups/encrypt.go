@@ -0,0 +1,317 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// An encrypted backup archive is laid out as follows:
+//
+//	magic     "JUJUBKE1"
+//	mode      1 byte: encryptWithKey or encryptWithPublicKey
+//	key info  for encryptWithKey, a 16 byte salt; for
+//	          encryptWithPublicKey, a 2 byte big-endian length
+//	          followed by the RSA-OAEP encrypted key material
+//	iv        16 bytes
+//	data      the gzipped archive, encrypted with AES-256-CTR
+//	mac       32 byte HMAC-SHA256 of everything before it
+//
+// The first half of the 64 bytes of key material is the AES key and
+// the second half is the HMAC key.
+const (
+	encryptedMagic = "JUJUBKE1"
+
+	encryptWithKey       byte = 1
+	encryptWithPublicKey byte = 2
+
+	keyMaterialSize  = 64
+	saltSize         = 16
+	pbkdf2Iterations = 10000
+)
+
+// Encryption describes how a new backup archive is encrypted. At most
+// one of Key and PublicKey may be set; if neither is, the archive is
+// not encrypted.
+type Encryption struct {
+	// Key is a secret from which the archive's keys are derived.
+	Key string
+
+	// PublicKey is a PEM-encoded RSA public key. The archive's keys
+	// are generated randomly and stored in the archive encrypted
+	// with this key, so only the holder of the matching private key
+	// can decrypt the archive.
+	PublicKey string
+}
+
+// IsZero returns true if archives should not be encrypted.
+func (e Encryption) IsZero() bool {
+	return e.Key == "" && e.PublicKey == ""
+}
+
+// Validate returns an error if the encryption is not usable.
+func (e Encryption) Validate() error {
+	if e.Key != "" && e.PublicKey != "" {
+		return errors.NotValidf("encryption with both a key and a public key")
+	}
+	if e.PublicKey != "" {
+		if _, err := parsePublicKey(e.PublicKey); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Decryption holds the secret needed to decrypt an encrypted backup
+// archive: either the key it was encrypted with, or the PEM-encoded
+// RSA private key matching the public key it was encrypted with.
+type Decryption struct {
+	Key        string
+	PrivateKey string
+}
+
+// NewEncryptingWriter returns a writer which encrypts everything
+// written to it as described by enc, and writes the result to w. The
+// archive is incomplete until the returned writer is closed, which
+// does not close w.
+func NewEncryptingWriter(w io.Writer, enc Encryption) (io.WriteCloser, error) {
+	if err := enc.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if enc.IsZero() {
+		return nil, errors.New("no encryption key given")
+	}
+
+	var header bytes.Buffer
+	header.WriteString(encryptedMagic)
+	var keyMaterial []byte
+	if enc.Key != "" {
+		salt, err := randomBytes(saltSize)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keyMaterial = deriveKeyMaterial(enc.Key, salt)
+		header.WriteByte(encryptWithKey)
+		header.Write(salt)
+	} else {
+		publicKey, err := parsePublicKey(enc.PublicKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if keyMaterial, err = randomBytes(keyMaterialSize); err != nil {
+			return nil, errors.Trace(err)
+		}
+		wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, keyMaterial, nil)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot encrypt archive key")
+		}
+		header.WriteByte(encryptWithPublicKey)
+		binary.Write(&header, binary.BigEndian, uint16(len(wrapped)))
+		header.Write(wrapped)
+	}
+	iv, err := randomBytes(aes.BlockSize)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header.Write(iv)
+
+	block, err := aes.NewCipher(keyMaterial[:32])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ew := &encryptingWriter{
+		w:      w,
+		stream: cipher.NewCTR(block, iv),
+		mac:    hmac.New(sha256.New, keyMaterial[32:]),
+	}
+	if err := ew.write(header.Bytes()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ew, nil
+}
+
+type encryptingWriter struct {
+	w      io.Writer
+	stream cipher.Stream
+	mac    hash.Hash
+}
+
+func (ew *encryptingWriter) write(data []byte) error {
+	ew.mac.Write(data)
+	_, err := ew.w.Write(data)
+	return err
+}
+
+// Write implements io.Writer.
+func (ew *encryptingWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, len(p))
+	ew.stream.XORKeyStream(buf, p)
+	if err := ew.write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close implements io.Closer by writing the archive's MAC.
+func (ew *encryptingWriter) Close() error {
+	_, err := ew.w.Write(ew.mac.Sum(nil))
+	return err
+}
+
+// IsEncryptedArchive returns true if the archive read from r was
+// encrypted by a juju controller. The reader is left at the start of
+// the archive.
+func IsEncryptedArchive(r io.ReadSeeker) (bool, error) {
+	magic := make([]byte, len(encryptedMagic))
+	n, err := io.ReadFull(r, magic)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, errors.Trace(err)
+	}
+	if _, err := r.Seek(0, os.SEEK_SET); err != nil {
+		return false, errors.Trace(err)
+	}
+	return n == len(magic) && string(magic) == encryptedMagic, nil
+}
+
+// DecryptArchive decrypts the encrypted archive read from r, and
+// writes the gzipped archive to w. The archive's MAC can only be
+// checked once all of it has been read, so if DecryptArchive returns
+// an error then anything written to w must be discarded.
+func DecryptArchive(w io.Writer, r io.ReadSeeker, dec Decryption) error {
+	size, err := r.Seek(0, os.SEEK_END)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := r.Seek(0, os.SEEK_SET); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The header is read before the key is known, so it is kept to
+	// add to the MAC afterwards.
+	var header bytes.Buffer
+	hr := io.TeeReader(r, &header)
+	prefix := make([]byte, len(encryptedMagic)+1)
+	if _, err := io.ReadFull(hr, prefix); err != nil || string(prefix[:len(encryptedMagic)]) != encryptedMagic {
+		return errors.New("backup archive is not encrypted")
+	}
+	var keyMaterial []byte
+	switch prefix[len(encryptedMagic)] {
+	case encryptWithKey:
+		if dec.Key == "" {
+			return errors.New("backup archive is encrypted with a key, but no key was given")
+		}
+		salt := make([]byte, saltSize)
+		if _, err := io.ReadFull(hr, salt); err != nil {
+			return errors.Annotate(err, "cannot read archive header")
+		}
+		keyMaterial = deriveKeyMaterial(dec.Key, salt)
+	case encryptWithPublicKey:
+		if dec.PrivateKey == "" {
+			return errors.New("backup archive is encrypted with a public key, but no private key was given")
+		}
+		privateKey, err := parsePrivateKey(dec.PrivateKey)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var length uint16
+		if err := binary.Read(hr, binary.BigEndian, &length); err != nil {
+			return errors.Annotate(err, "cannot read archive header")
+		}
+		wrapped := make([]byte, length)
+		if _, err := io.ReadFull(hr, wrapped); err != nil {
+			return errors.Annotate(err, "cannot read archive header")
+		}
+		keyMaterial, err = rsa.DecryptOAEP(sha256.New(), rand.Reader, privateKey, wrapped, nil)
+		if err != nil {
+			return errors.New("cannot decrypt archive key: wrong private key")
+		}
+	default:
+		return errors.Errorf("unknown backup archive encryption mode %d", prefix[len(encryptedMagic)])
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(hr, iv); err != nil {
+		return errors.Annotate(err, "cannot read archive header")
+	}
+
+	dataSize := size - int64(header.Len()) - sha256.Size
+	if dataSize < 0 {
+		return errors.New("backup archive is truncated")
+	}
+	block, err := aes.NewCipher(keyMaterial[:32])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mac := hmac.New(sha256.New, keyMaterial[32:])
+	mac.Write(header.Bytes())
+	decrypted := cipher.StreamReader{
+		S: cipher.NewCTR(block, iv),
+		R: io.TeeReader(io.LimitReader(r, dataSize), mac),
+	}
+	if _, err := io.Copy(w, decrypted); err != nil {
+		return errors.Trace(err)
+	}
+	expected := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r, expected); err != nil {
+		return errors.Annotate(err, "cannot read archive MAC")
+	}
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.New("backup archive failed integrity check (wrong key, or the archive is corrupt)")
+	}
+	return nil
+}
+
+func deriveKeyMaterial(key string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(key), salt, pbkdf2Iterations, keyMaterialSize, sha256.New)
+}
+
+func randomBytes(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, buf); err != nil {
+		return nil, errors.Annotate(err, "cannot generate random data")
+	}
+	return buf, nil
+}
+
+func parsePublicKey(data string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.NotValidf("public key (no PEM data)")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot parse public key")
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.NotValidf("public key of type %T", key)
+	}
+	return publicKey, nil
+}
+
+func parsePrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.NotValidf("private key (no PEM data)")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot parse private key")
+	}
+	return key, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type encryptSuite struct {
+	testing.IsolationSuite
+	publicKey  string
+	privateKey string
+}
+
+var _ = gc.Suite(&encryptSuite{})
+
+func (s *encryptSuite) SetUpSuite(c *gc.C) {
+	s.IsolationSuite.SetUpSuite(c)
+	s.publicKey, s.privateKey = newRSAKey(c)
+}
+
+func newRSAKey(c *gc.C) (publicKey, privateKey string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	c.Assert(err, jc.ErrorIsNil)
+	publicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	privateKey = string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+	return publicKey, privateKey
+}
+
+const archiveData = "<compressed tarball>"
+
+func encrypt(c *gc.C, enc backups.Encryption) *bytes.Reader {
+	var buf bytes.Buffer
+	w, err := backups.NewEncryptingWriter(&buf, enc)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = io.WriteString(w, archiveData)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Not(jc.Contains), archiveData)
+	return bytes.NewReader(buf.Bytes())
+}
+
+func (s *encryptSuite) TestRoundTripWithKey(c *gc.C) {
+	archive := encrypt(c, backups.Encryption{Key: "secret"})
+
+	var out bytes.Buffer
+	err := backups.DecryptArchive(&out, archive, backups.Decryption{Key: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.String(), gc.Equals, archiveData)
+}
+
+func (s *encryptSuite) TestRoundTripWithPublicKey(c *gc.C) {
+	archive := encrypt(c, backups.Encryption{PublicKey: s.publicKey})
+
+	var out bytes.Buffer
+	err := backups.DecryptArchive(&out, archive, backups.Decryption{PrivateKey: s.privateKey})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.String(), gc.Equals, archiveData)
+}
+
+func (s *encryptSuite) TestIsEncryptedArchive(c *gc.C) {
+	archive := encrypt(c, backups.Encryption{Key: "secret"})
+	encrypted, err := backups.IsEncryptedArchive(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+	pos, err := archive.Seek(0, os.SEEK_CUR)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pos, gc.Equals, int64(0))
+
+	encrypted, err = backups.IsEncryptedArchive(bytes.NewReader([]byte(archiveData)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsFalse)
+
+	encrypted, err = backups.IsEncryptedArchive(bytes.NewReader(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsFalse)
+}
+
+func (s *encryptSuite) TestDecryptNotEncrypted(c *gc.C) {
+	var out bytes.Buffer
+	err := backups.DecryptArchive(&out, bytes.NewReader([]byte(archiveData)), backups.Decryption{Key: "secret"})
+	c.Check(err, gc.ErrorMatches, "backup archive is not encrypted")
+}
+
+func (s *encryptSuite) TestDecryptWrongKey(c *gc.C) {
+	archive := encrypt(c, backups.Encryption{Key: "secret"})
+
+	var out bytes.Buffer
+	err := backups.DecryptArchive(&out, archive, backups.Decryption{Key: "guess"})
+	c.Check(err, gc.ErrorMatches, `backup archive failed integrity check \(wrong key, or the archive is corrupt\)`)
+}
+
+func (s *encryptSuite) TestDecryptWrongPrivateKey(c *gc.C) {
+	archive := encrypt(c, backups.Encryption{PublicKey: s.publicKey})
+	_, otherPrivateKey := newRSAKey(c)
+
+	var out bytes.Buffer
+	err := backups.DecryptArchive(&out, archive, backups.Decryption{PrivateKey: otherPrivateKey})
+	c.Check(err, gc.ErrorMatches, "cannot decrypt archive key: wrong private key")
+}
+
+func (s *encryptSuite) TestDecryptMissingKey(c *gc.C) {
+	var out bytes.Buffer
+	archive := encrypt(c, backups.Encryption{Key: "secret"})
+	err := backups.DecryptArchive(&out, archive, backups.Decryption{PrivateKey: s.privateKey})
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted with a key, but no key was given")
+
+	archive = encrypt(c, backups.Encryption{PublicKey: s.publicKey})
+	err = backups.DecryptArchive(&out, archive, backups.Decryption{Key: "secret"})
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted with a public key, but no private key was given")
+}
+
+func (s *encryptSuite) TestDecryptTampered(c *gc.C) {
+	archive := encrypt(c, backups.Encryption{Key: "secret"})
+	data := make([]byte, archive.Len())
+	_, err := archive.Read(data)
+	c.Assert(err, jc.ErrorIsNil)
+	data[len(data)-40] ^= 1
+
+	var out bytes.Buffer
+	err = backups.DecryptArchive(&out, bytes.NewReader(data), backups.Decryption{Key: "secret"})
+	c.Check(err, gc.ErrorMatches, `backup archive failed integrity check \(wrong key, or the archive is corrupt\)`)
+}
+
+func (s *encryptSuite) TestEncryptionValidate(c *gc.C) {
+	c.Check(backups.Encryption{}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.Encryption{Key: "secret"}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.Encryption{PublicKey: s.publicKey}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.Encryption{Key: "secret", PublicKey: s.publicKey}.Validate(), gc.ErrorMatches,
+		"encryption with both a key and a public key not valid")
+	c.Check(backups.Encryption{PublicKey: s.privateKey}.Validate(), gc.ErrorMatches,
+		"cannot parse public key: .*")
+}
@@ -93,11 +93,13 @@ func ExposeCreateResult(result *createResult) (io.ReadCloser, int64, string) {
 }
 
 // NewTestCreateArgs builds a new args value for create() calls.
-func NewTestCreateArgs(filesToBackUp []string, db DBDumper, metar io.Reader) *createArgs {
+func NewTestCreateArgs(filesToBackUp []string, db DBDumper, metar io.Reader, meta *Metadata, enc Encryption) *createArgs {
 	args := createArgs{
 		filesToBackUp:  filesToBackUp,
 		db:             db,
 		metadataReader: metar,
+		meta:           meta,
+		encryption:     enc,
 	}
 	return &args
 }
@@ -107,6 +109,12 @@ func ExposeCreateArgs(args *createArgs) ([]string, DBDumper) {
 	return args.filesToBackUp, args.db
 }
 
+// ExposeCreateEncryption extracts the encryption in a create() args
+// value.
+func ExposeCreateEncryption(args *createArgs) Encryption {
+	return args.encryption
+}
+
 // NewTestCreateResult builds a new create() result.
 func NewTestCreateResult(file io.ReadCloser, size int64, checksum string) *createResult {
 	result := createResult{
@@ -0,0 +1,234 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/cert"
+)
+
+// Manifest lists the contents of a backup archive, so that they can be
+// checked before the archive is restored.
+type Manifest struct {
+	// Version is the juju version which created the archive.
+	Version version.Number `json:"version"`
+
+	// Model is the UUID of the controller model which was backed up.
+	Model string `json:"model"`
+
+	// Files lists every file in the archive's content directory,
+	// other than the manifest and its signature.
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes one file in a backup archive.
+type ManifestFile struct {
+	// Path is the slash-separated path of the file, relative to the
+	// archive's content directory.
+	Path string `json:"path"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex-encoded SHA-256 hash of the file.
+	SHA256 string `json:"sha256"`
+}
+
+// buildManifest lists the files in the archive content described by
+// paths.
+func buildManifest(paths ArchivePaths, meta *Metadata) (*Manifest, error) {
+	manifest := &Manifest{
+		Version: meta.Origin.Version,
+		Model:   meta.Origin.Model,
+	}
+	err := filepath.Walk(paths.ContentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || path == paths.ManifestFile || path == paths.ManifestSignatureFile {
+			return nil
+		}
+		rel, err := filepath.Rel(paths.ContentDir, path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			// Verify refuses anything but regular files, so
+			// don't make a backup which would fail it.
+			return errors.Errorf("%s is not a regular file", filepath.ToSlash(rel))
+		}
+		sum, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Path:   filepath.ToSlash(rel),
+			Size:   info.Size(),
+			SHA256: sum,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "while listing archive contents")
+	}
+	return manifest, nil
+}
+
+// writeManifest writes the manifest for the archive content described
+// by paths, and signs it with the controller's CA key if meta has one.
+func writeManifest(paths ArchivePaths, meta *Metadata) error {
+	manifest, err := buildManifest(paths, meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(paths.ManifestFile, data, 0600); err != nil {
+		return errors.Annotate(err, "while writing manifest")
+	}
+	if meta.CACert == "" || meta.CAPrivateKey == "" {
+		logger.Warningf("no controller CA key; backup manifest will not be signed")
+		return nil
+	}
+	_, key, err := cert.ParseCertAndKey(meta.CACert, meta.CAPrivateKey)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse controller CA key")
+	}
+	digest := sha256.Sum256(data)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return errors.Annotate(err, "cannot sign manifest")
+	}
+	err = ioutil.WriteFile(paths.ManifestSignatureFile, signature, 0600)
+	return errors.Annotate(err, "while writing manifest signature")
+}
+
+// Verify checks the unpacked archive against its manifest: every file
+// listed must be present and unchanged, and no other files, symlinks or
+// other special files may be present. The manifest must also have been
+// signed by the controller with the given CA certificate, which must
+// not be empty.
+func (ws *ArchiveWorkspace) Verify(caCert string) (*Manifest, error) {
+	if caCert == "" {
+		return nil, errors.New("cannot verify backup manifest without a controller CA certificate")
+	}
+	data, err := ioutil.ReadFile(ws.ManifestFile)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup manifest")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := verifyManifestSignature(data, ws.ManifestSignatureFile, caCert); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Annotate(err, "cannot parse backup manifest")
+	}
+
+	want := make(map[string]ManifestFile)
+	for _, file := range manifest.Files {
+		want[file.Path] = file
+	}
+	err = filepath.Walk(ws.ContentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || path == ws.ManifestFile || path == ws.ManifestSignatureFile {
+			return nil
+		}
+		rel, err := filepath.Rel(ws.ContentDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !info.Mode().IsRegular() {
+			return errors.Errorf("%s is not a regular file", rel)
+		}
+		file, ok := want[rel]
+		if !ok {
+			return errors.Errorf("%s is not in the manifest", rel)
+		}
+		delete(want, rel)
+		sum, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+		if info.Size() != file.Size || sum != file.SHA256 {
+			return errors.Errorf("%s does not match the manifest", rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "backup archive failed verification")
+	}
+	for path := range want {
+		return nil, errors.Errorf("backup archive failed verification: %s is missing", path)
+	}
+	return &manifest, nil
+}
+
+func verifyManifestSignature(data []byte, signatureFile, caCert string) error {
+	signature, err := ioutil.ReadFile(signatureFile)
+	if os.IsNotExist(err) {
+		return errors.New("backup manifest is not signed")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	caCertificate, err := cert.ParseCert(caCert)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse controller CA certificate")
+	}
+	publicKey, ok := caCertificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.Errorf("unexpected controller CA key type %T", caCertificate.PublicKey)
+	}
+	digest := sha256.Sum256(data)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return errors.New("backup manifest was not signed by this controller")
+	}
+	return nil
+}
+
+// CheckRestoreCompatible returns an error if a backup made by juju
+// version backupVersion cannot be restored into a controller running
+// version current. Backups may only be restored by the same major and
+// minor version of juju which created them.
+func CheckRestoreCompatible(backupVersion, current version.Number) error {
+	if backupVersion.Major != current.Major || backupVersion.Minor != current.Minor {
+		return errors.Errorf(
+			"backup made by juju %s cannot be restored by juju %s (major and minor versions must match)",
+			backupVersion, current,
+		)
+	}
+	return nil
+}
+
+func sha256File(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
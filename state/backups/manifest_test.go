@@ -0,0 +1,166 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type manifestSuite struct {
+	LegacySuite
+}
+
+var _ = gc.Suite(&manifestSuite{})
+
+// newWorkspace creates a backup archive whose manifest is signed with
+// the given CA key, and unpacks it.
+func (s *manifestSuite) newWorkspace(c *gc.C, caCert, caKey string) *backups.ArchiveWorkspace {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.CACert = caCert
+	meta.CAPrivateKey = caKey
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, _ := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(testFiles, &TestDBDumper{}, metadataFile, meta, backups.Encryption{})
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, _, _ := backups.ExposeCreateResult(result)
+	defer archiveFile.Close()
+
+	ws, err := backups.NewArchiveWorkspaceReader(archiveFile)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { ws.Close() })
+	return ws
+}
+
+func (s *manifestSuite) TestVerify(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	_, err := ws.Verify(coretesting.CACert)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *manifestSuite) TestVerifyChangedFile(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	err := ioutil.WriteFile(ws.MetadataFile, []byte("{}"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup archive failed verification: metadata.json does not match the manifest")
+}
+
+func (s *manifestSuite) TestVerifyMissingFile(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	err := os.Remove(ws.FilesBundle)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup archive failed verification: root.tar is missing")
+}
+
+func (s *manifestSuite) TestVerifyExtraFile(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	err := ioutil.WriteFile(filepath.Join(ws.DBDumpDir, "extra.bson"), []byte("<BSON>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup archive failed verification: dump/extra.bson is not in the manifest")
+}
+
+func (s *manifestSuite) TestVerifyNoManifest(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	err := os.Remove(ws.ManifestFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup manifest not found")
+}
+
+func (s *manifestSuite) TestVerifyUnsigned(c *gc.C) {
+	ws := s.newWorkspace(c, "", "")
+
+	_, err := ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup manifest is not signed")
+}
+
+func (s *manifestSuite) TestVerifyNoCACert(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+
+	_, err := ws.Verify("")
+	c.Check(err, gc.ErrorMatches, "cannot verify backup manifest without a controller CA certificate")
+}
+
+func (s *manifestSuite) TestVerifySymlink(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	err := os.Symlink(ws.FilesBundle, filepath.Join(ws.DBDumpDir, "extra.bson"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup archive failed verification: dump/extra.bson is not a regular file")
+}
+
+func (s *manifestSuite) TestVerifyReplacedBySymlink(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.CACert, coretesting.CAKey)
+	target := filepath.Join(c.MkDir(), "root.tar")
+	err := os.Rename(ws.FilesBundle, target)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink(target, ws.FilesBundle)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup archive failed verification: root.tar is not a regular file")
+}
+
+func (s *manifestSuite) TestVerifyOtherController(c *gc.C) {
+	ws := s.newWorkspace(c, coretesting.OtherCACert, coretesting.OtherCAKey)
+
+	_, err := ws.Verify(coretesting.CACert)
+	c.Check(err, gc.ErrorMatches, "backup manifest was not signed by this controller")
+}
+
+func (s *manifestSuite) TestCheckRestoreCompatible(c *gc.C) {
+	for i, test := range []struct {
+		backup, current string
+		err             string
+	}{{
+		backup:  "2.0.1",
+		current: "2.0.1",
+	}, {
+		backup:  "2.0.1",
+		current: "2.0.3",
+	}, {
+		backup:  "2.0-beta15",
+		current: "2.0.0",
+	}, {
+		backup:  "2.0.1",
+		current: "2.1.0",
+		err:     `backup made by juju 2.0.1 cannot be restored by juju 2.1.0 \(major and minor versions must match\)`,
+	}, {
+		backup:  "1.25.6",
+		current: "2.0.0",
+		err:     `backup made by juju 1.25.6 cannot be restored by juju 2.0.0 \(major and minor versions must match\)`,
+	}} {
+		c.Logf("test %d: %s -> %s", i, test.backup, test.current)
+		err := backups.CheckRestoreCompatible(version.MustParse(test.backup), version.MustParse(test.current))
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
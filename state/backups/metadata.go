@@ -66,6 +66,16 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was made by the
+	// controller's backup schedule, rather than at a user's request.
+	// Only scheduled backups are removed by the retention policy.
+	Scheduled bool
+
+	// Encrypted records whether the archive is encrypted. Encrypted
+	// archives must be decrypted with verify-backup before they can
+	// be restored.
+	Encrypted bool
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
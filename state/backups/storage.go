@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	corebackups "github.com/juju/juju/core/backups"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/names"
@@ -42,9 +43,11 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started   int64  `bson:"started,minsize"`
+	Finished  int64  `bson:"finished,minsize"`
+	Notes     string `bson:"notes,omitempty"`
+	Scheduled bool   `bson:"scheduled,omitempty"`
+	Encrypted bool   `bson:"encrypted,omitempty"`
 
 	// origin
 
@@ -121,6 +124,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
+	meta.Encrypted = doc.Encrypted
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -171,6 +176,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
+	doc.Encrypted = meta.Encrypted
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -541,14 +548,29 @@ type DB interface {
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
-func NewStorage(st DB) filestorage.FileStorage {
+// archives (and metadata). The metadata is always kept in the
+// controller's database; the archives are kept wherever the model's
+// backup storage config says.
+func NewStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
+	var files filestorage.RawFileStorage
+	switch storageConfig := cfg.BackupStorage(); storageConfig.Type {
+	case "", corebackups.StorageController:
+		files = newFileStorage(dbWrap, backupStorageRoot)
+	default:
+		files, err = NewArchiveStorage(storageConfig)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot open backup archive storage")
+		}
+	}
 	docs := newMetadataStorage(dbWrap)
-	return filestorage.NewFileStorage(docs, files)
+	return filestorage.NewFileStorage(docs, files), nil
 }
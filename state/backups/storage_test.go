@@ -4,6 +4,9 @@
 package backups_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -66,6 +69,8 @@ func (s *storageSuite) checkMeta(c *gc.C, meta, expected *backups.Metadata, id s
 		c.Check(meta.ID(), gc.Equals, id)
 	}
 	c.Check(meta.Notes, gc.Equals, expected.Notes)
+	c.Check(meta.Scheduled, gc.Equals, expected.Scheduled)
+	c.Check(meta.Encrypted, gc.Equals, expected.Encrypted)
 	c.Check(meta.Started.Unix(), gc.Equals, expected.Started.Unix())
 	c.Check(meta.Checksum(), gc.Equals, expected.Checksum())
 	c.Check(meta.ChecksumFormat(), gc.Equals, expected.ChecksumFormat())
@@ -101,6 +106,30 @@ func (s *storageSuite) TestGetBackupMetadataFound(c *gc.C) {
 	s.checkMeta(c, meta, original, id)
 }
 
+func (s *storageSuite) TestGetBackupMetadataScheduled(c *gc.C) {
+	original := s.metadata(c)
+	original.Scheduled = true
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
+func (s *storageSuite) TestGetBackupMetadataEncrypted(c *gc.C) {
+	original := s.metadata(c)
+	original.Encrypted = true
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
 func (s *storageSuite) TestGetBackupMetadataNotFound(c *gc.C) {
 	_, err := backups.GetBackupMetadata(s.State, "spam")
 
@@ -166,3 +195,28 @@ func (s *storageSuite) TestSetBackupStoredTimeNotFound(c *gc.C) {
 
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *storageSuite) TestNewStorageDirectory(c *gc.C) {
+	dir := c.MkDir()
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"backup-storage":   "directory",
+		"backup-directory": dir,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stor, err := backups.NewStorage(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	defer stor.Close()
+	meta := s.metadata(c)
+	meta.Raw.Size = 9
+	id, err := backups.NewBackups(stor).Add(strings.NewReader("<archive>"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The archive is kept in the directory, and its metadata in the
+	// controller's database.
+	data, err := ioutil.ReadFile(filepath.Join(dir, id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+	_, err = backups.GetBackupMetadata(s.State, id)
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -36,6 +36,8 @@ type FakeBackups struct {
 	PathsArg *backups.Paths
 	// DBInfoArg holds the ConnInfo that was passed in.
 	DBInfoArg *backups.DBInfo
+	// EncryptionArg holds the encryption that was passed in.
+	EncryptionArg backups.Encryption
 	// MetaArg holds the backup metadata that was passed in.
 	MetaArg *backups.Metadata
 	// PrivateAddr Holds the address for the internal network of the machine.
@@ -50,9 +52,10 @@ var _ backups.Backups = (*FakeBackups)(nil)
 
 // Create creates and stores a new juju backup archive and returns
 // its associated metadata.
-func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo) error {
+func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo, enc backups.Encryption) error {
 	b.Calls = append(b.Calls, "Create")
 
+	b.EncryptionArg = enc
 	b.PathsArg = paths
 	b.DBInfoArg = dbInfo
 	b.MetaArg = meta
@@ -7,6 +7,12 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"io"
 	"path"
 	"strings"
@@ -14,6 +20,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
 
+	"github.com/juju/juju/cert"
 	"github.com/juju/juju/state/backups"
 )
 
@@ -55,6 +62,64 @@ func (f *File) AddToArchive(archive *tar.Writer) error {
 
 // NewArchive returns a new archive file containing the files.
 func NewArchive(meta *backups.Metadata, files, dump []File) (*bytes.Buffer, error) {
+	topfiles, err := archiveContents(meta, files, dump)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return packArchive(topfiles)
+}
+
+// NewSignedArchive returns a new archive file with a few files
+// provided, and a manifest of them. The manifest is signed with
+// meta's CA private key if it has one.
+func NewSignedArchive(meta *backups.Metadata) (*bytes.Buffer, error) {
+	files, dump := basicFiles()
+	topfiles, err := archiveContents(meta, files, dump)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	manifest := backups.Manifest{
+		Version: meta.Origin.Version,
+		Model:   meta.Origin.Model,
+	}
+	for _, file := range topfiles {
+		if file.IsDir {
+			continue
+		}
+		sum := sha256.Sum256([]byte(file.Content))
+		manifest.Files = append(manifest.Files, backups.ManifestFile{
+			Path:   strings.TrimPrefix(file.Name, "juju-backup/"),
+			Size:   int64(len(file.Content)),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	topfiles = append(topfiles, File{
+		Name:    "juju-backup/manifest.json",
+		Content: string(data),
+	})
+	if meta.CAPrivateKey != "" {
+		_, key, err := cert.ParseCertAndKey(meta.CACert, meta.CAPrivateKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		digest := sha256.Sum256(data)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		topfiles = append(topfiles, File{
+			Name:    "juju-backup/manifest.sig",
+			Content: string(signature),
+		})
+	}
+	return packArchive(topfiles)
+}
+
+func archiveContents(meta *backups.Metadata, files, dump []File) ([]File, error) {
 	dirs := set.NewStrings()
 	var sysFiles []File
 	for _, file := range files {
@@ -124,7 +189,10 @@ func NewArchive(meta *backups.Metadata, files, dump []File) (*bytes.Buffer, erro
 			},
 		)
 	}
+	return topfiles, nil
+}
 
+func packArchive(topfiles []File) (*bytes.Buffer, error) {
 	var arFile bytes.Buffer
 	compressed := gzip.NewWriter(&arFile)
 	defer compressed.Close()
@@ -137,7 +205,16 @@ func NewArchive(meta *backups.Metadata, files, dump []File) (*bytes.Buffer, erro
 
 // NewArchiveBasic returns a new archive file with a few files provided.
 func NewArchiveBasic(meta *backups.Metadata) (*bytes.Buffer, error) {
-	files := []File{
+	files, dump := basicFiles()
+	arFile, err := NewArchive(meta, files, dump)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return arFile, nil
+}
+
+func basicFiles() (files, dump []File) {
+	files = []File{
 		{
 			Name:    "var/lib/juju/tools/1.21-alpha2.1-trusty-amd64/jujud",
 			Content: "<some binary data goes here>",
@@ -147,7 +224,7 @@ func NewArchiveBasic(meta *backups.Metadata) (*bytes.Buffer, error) {
 			Content: "<an ssh key goes here>",
 		},
 	}
-	dump := []File{
+	dump = []File{
 		{
 			Name:    "juju/machines.bson",
 			Content: "<BSON data goes here>",
@@ -157,12 +234,7 @@ func NewArchiveBasic(meta *backups.Metadata) (*bytes.Buffer, error) {
 			Content: "<BSON data goes here>",
 		},
 	}
-
-	arFile, err := NewArchive(meta, files, dump)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return arFile, nil
+	return files, dump
 }
 
 func writeToTar(archive io.Writer, files []File) error {
@@ -0,0 +1,142 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ControllerAccess represents the level of access granted to a user on
+// the controller, as opposed to any one model.
+type ControllerAccess string
+
+const (
+	// ControllerUndefinedAccess is not a valid access type. It is the
+	// value unmarshaled when access is not defined by the document at all.
+	ControllerUndefinedAccess ControllerAccess = ""
+
+	// ControllerAddModelAccess allows a user to add models to the
+	// controller.
+	ControllerAddModelAccess ControllerAccess = "add-model"
+
+	// ControllerSuperuserAccess allows a user full control over the
+	// controller and all of its models.
+	ControllerSuperuserAccess ControllerAccess = "superuser"
+)
+
+// controllerAccessLevels orders the valid controller access permissions,
+// each level granting everything granted by those before it.
+var controllerAccessLevels = map[ControllerAccess]int{
+	ControllerAddModelAccess:  1,
+	ControllerSuperuserAccess: 2,
+}
+
+// Validate returns an error if the access is not a valid controller
+// access permission.
+func (a ControllerAccess) Validate() error {
+	if _, ok := controllerAccessLevels[a]; !ok {
+		return errors.NotValidf("controller access %q", a)
+	}
+	return nil
+}
+
+// EqualOrGreaterThan returns whether the access grants at least the
+// permissions granted by other.
+func (a ControllerAccess) EqualOrGreaterThan(other ControllerAccess) bool {
+	return controllerAccessLevels[a] >= controllerAccessLevels[other]
+}
+
+type controllerUserDoc struct {
+	ID          string           `bson:"_id"`
+	UserName    string           `bson:"user"`
+	Access      ControllerAccess `bson:"access"`
+	CreatedBy   string           `bson:"createdby"`
+	DateCreated time.Time        `bson:"datecreated"`
+}
+
+func controllerUserID(user names.UserTag) string {
+	return strings.ToLower(user.Canonical())
+}
+
+// ControllerAccess returns the controller access granted to the given
+// user, or a NotFound error if none has been granted.
+func (st *State) ControllerAccess(user names.UserTag) (ControllerAccess, error) {
+	controllerUsers, closer := st.getCollection(controllerUsersC)
+	defer closer()
+
+	var doc controllerUserDoc
+	err := controllerUsers.FindId(controllerUserID(user)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ControllerUndefinedAccess, errors.NotFoundf("controller access for user %q", user.Canonical())
+	}
+	if err != nil {
+		return ControllerUndefinedAccess, errors.Trace(err)
+	}
+	return doc.Access, nil
+}
+
+// SetControllerAccess grants the given controller access to the user,
+// replacing any access they had before.
+func (st *State) SetControllerAccess(user, createdBy names.UserTag, access ControllerAccess) error {
+	if err := access.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	// Ensure local users exist in state before granting them access.
+	if user.IsLocal() {
+		if _, err := st.User(user); err != nil {
+			return errors.Annotatef(err, "user %q does not exist locally", user.Name())
+		}
+	}
+
+	id := controllerUserID(user)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := st.ControllerAccess(user)
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllerUsersC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &controllerUserDoc{
+					ID:          id,
+					UserName:    user.Canonical(),
+					Access:      access,
+					CreatedBy:   createdBy.Canonical(),
+					DateCreated: nowToTheSecond(),
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllerUsersC,
+			Id:     id,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"access", access}}}},
+		}}, nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "cannot set controller access for user %q", user.Canonical())
+}
+
+// RemoveControllerAccess removes all controller access granted to the
+// given user.
+func (st *State) RemoveControllerAccess(user names.UserTag) error {
+	ops := []txn.Op{{
+		C:      controllerUsersC,
+		Id:     controllerUserID(user),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("controller access for user %q", user.Canonical())
+	}
+	return errors.Trace(err)
+}
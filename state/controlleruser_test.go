@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ControllerUserSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerUserSuite{})
+
+func (s *ControllerUserSuite) TestControllerAccessNotFound(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	_, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), s.Owner, state.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+
+	err = s.State.SetControllerAccess(user.UserTag(), s.Owner, state.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerSuperuserAccess)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessInvalid(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), s.Owner, "root")
+	c.Assert(err, gc.ErrorMatches, `controller access "root" not valid`)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessNoLocalUser(c *gc.C) {
+	err := s.State.SetControllerAccess(names.NewUserTag("nobody"), s.Owner, state.ControllerAddModelAccess)
+	c.Assert(err, gc.ErrorMatches, `user "nobody" does not exist locally: user "nobody" not found`)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessExternalUser(c *gc.C) {
+	user := names.NewUserTag("bob@external")
+	err := s.State.SetControllerAccess(user, s.Owner, state.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ControllerAddModelAccess)
+}
+
+func (s *ControllerUserSuite) TestRemoveControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), s.Owner, state.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerUserSuite) TestSuperuserIsControllerAdministrator(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), s.Owner, state.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsFalse)
+
+	err = s.State.SetControllerAccess(user.UserTag(), s.Owner, state.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	isAdmin, err = s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+}
+
+func (s *ControllerUserSuite) TestControllerAccessEqualOrGreaterThan(c *gc.C) {
+	c.Assert(state.ControllerSuperuserAccess.EqualOrGreaterThan(state.ControllerAddModelAccess), jc.IsTrue)
+	c.Assert(state.ControllerAddModelAccess.EqualOrGreaterThan(state.ControllerAddModelAccess), jc.IsTrue)
+	c.Assert(state.ControllerAddModelAccess.EqualOrGreaterThan(state.ControllerSuperuserAccess), jc.IsFalse)
+}
@@ -46,6 +46,7 @@ var (
 	ImageStorageNewStorage = &imageStorageNewStorage
 	MachineIdLessThan      = machineIdLessThan
 	ControllerAvailable    = &controllerAvailable
+	MaxActionMessages      = &maxActionMessages
 	GetOrCreatePorts       = getOrCreatePorts
 	GetPorts               = getPorts
 	PortsGlobalKey         = portsGlobalKey
@@ -247,6 +248,7 @@ func GetPasswordHash(e Authenticator) string {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -740,10 +740,10 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if !provider.Supports(storage.StorageKindFilesystem) {
 		var volumeOps []txn.Op
 		volumeParams := VolumeParams{
-			params.storage,
-			filesystemTag, // volume is bound to filesystem
-			params.Pool,
-			params.Size,
+			storage: params.storage,
+			binding: filesystemTag, // volume is bound to filesystem
+			Pool:    params.Pool,
+			Size:    params.Size,
 		}
 		volumeOps, volumeTag, err = st.addVolumeOps(volumeParams, machineId)
 		if err != nil {
@@ -141,6 +141,10 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithTimeout queues an action like AddAction, but the
+	// ActionReceiver will fail it if it runs for longer than timeout.
+	AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -208,6 +212,18 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Timeout returns how long the action may run before it is failed,
+	// or zero if it may run indefinitely.
+	Timeout() time.Duration
+
+	// CancelRequested reports whether the action was cancelled while it
+	// was running.
+	CancelRequested() bool
+
+	// Messages returns the progress messages logged by the action,
+	// oldest first.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -219,4 +235,12 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Cancel cancels the action. A pending action is finished
+	// immediately; a running action is flagged so that its receiver
+	// stops it.
+	Cancel() (Action, error)
+
+	// Log appends a timestamped progress message to the running action.
+	Log(message string) error
 }
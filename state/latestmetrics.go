@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// LatestMetric holds the most recent numeric value of a metric reported
+// by a unit.
+type LatestMetric struct {
+	ModelUUID string
+	Unit      string
+	CharmURL  string
+	Key       string
+	Value     float64
+	Time      time.Time
+}
+
+type latestMetricDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Unit      string    `bson:"unit"`
+	CharmURL  string    `bson:"charm-url"`
+	Key       string    `bson:"key"`
+	Value     float64   `bson:"value"`
+	Time      time.Time `bson:"time"`
+}
+
+func latestMetricDocID(modelUUID, unit, key string) string {
+	return modelUUID + "#" + unit + "#" + key
+}
+
+// UpdateLatestMetrics records the given metrics as the latest values
+// of their unit's metrics. A value older than the one already recorded
+// for the same unit and key is ignored.
+func (st *State) UpdateLatestMetrics(metrics []LatestMetric) error {
+	latestMetrics, closer := st.getRawCollection(latestMetricsC)
+	defer closer()
+	for _, m := range metrics {
+		id := latestMetricDocID(m.ModelUUID, m.Unit, m.Key)
+		doc := latestMetricDoc{
+			DocID:     id,
+			ModelUUID: m.ModelUUID,
+			Unit:      m.Unit,
+			CharmURL:  m.CharmURL,
+			Key:       m.Key,
+			Value:     m.Value,
+			Time:      m.Time.UTC(),
+		}
+		// If a newer value is already recorded the selector matches
+		// nothing, and the insert fails because the id is taken.
+		selector := bson.D{{"_id", id}, {"time", bson.D{{"$lte", doc.Time}}}}
+		_, err := latestMetrics.Upsert(selector, doc)
+		if mgo.IsDup(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot update latest value of metric %q of unit %q", m.Key, m.Unit)
+		}
+	}
+	return nil
+}
+
+// LatestMetrics returns the latest value of every metric reported by
+// every unit, in all models.
+func (st *State) LatestMetrics() ([]LatestMetric, error) {
+	latestMetrics, closer := st.getRawCollection(latestMetricsC)
+	defer closer()
+	var docs []latestMetricDoc
+	if err := latestMetrics.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get latest metrics")
+	}
+	result := make([]LatestMetric, len(docs))
+	for i, doc := range docs {
+		result[i] = LatestMetric{
+			ModelUUID: doc.ModelUUID,
+			Unit:      doc.Unit,
+			CharmURL:  doc.CharmURL,
+			Key:       doc.Key,
+			Value:     doc.Value,
+			Time:      doc.Time.UTC(),
+		}
+	}
+	return result, nil
+}
+
+// removeLatestMetrics removes the latest metric values of the model.
+func (st *State) removeLatestMetrics() error {
+	latestMetrics, closer := st.getRawCollection(latestMetricsC)
+	defer closer()
+	_, err := latestMetrics.RemoveAll(bson.D{{"model-uuid", st.ModelUUID()}})
+	return errors.Annotate(err, "cannot remove latest metrics")
+}
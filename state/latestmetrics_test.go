@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type LatestMetricsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&LatestMetricsSuite{})
+
+func (s *LatestMetricsSuite) latestMetric(unit, key string, value float64, t time.Time) state.LatestMetric {
+	return state.LatestMetric{
+		ModelUUID: s.State.ModelUUID(),
+		Unit:      unit,
+		CharmURL:  "cs:quantal/metered",
+		Key:       key,
+		Value:     value,
+		Time:      t,
+	}
+}
+
+func (s *LatestMetricsSuite) TestUpdateLatestMetrics(c *gc.C) {
+	now := state.NowToTheSecond()
+	err := s.State.UpdateLatestMetrics([]state.LatestMetric{
+		s.latestMetric("metered/0", "pings", 5, now),
+		s.latestMetric("metered/1", "pings", 7, now),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Newer values replace older ones; older values are ignored.
+	err = s.State.UpdateLatestMetrics([]state.LatestMetric{
+		s.latestMetric("metered/0", "pings", 6.5, now.Add(time.Minute)),
+		s.latestMetric("metered/1", "pings", 1, now.Add(-time.Minute)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	latest, err := s.State.LatestMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, jc.SameContents, []state.LatestMetric{
+		s.latestMetric("metered/0", "pings", 6.5, now.Add(time.Minute)),
+		s.latestMetric("metered/1", "pings", 7, now),
+	})
+}
+
+func (s *LatestMetricsSuite) TestLatestMetricsAllModels(c *gc.C) {
+	now := state.NowToTheSecond()
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	other := s.latestMetric("metered/0", "pings", 3, now)
+	other.ModelUUID = otherSt.ModelUUID()
+	err := otherSt.UpdateLatestMetrics([]state.LatestMetric{other})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateLatestMetrics([]state.LatestMetric{
+		s.latestMetric("metered/0", "pings", 5, now),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	latest, err := s.State.LatestMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, jc.SameContents, []state.LatestMetric{
+		s.latestMetric("metered/0", "pings", 5, now),
+		other,
+	})
+}
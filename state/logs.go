@@ -59,10 +59,11 @@ func InitDbLogs(session *mgo.Session) error {
 // lastSentDoc captures timestamp of the last log record forwarded
 // to a log sink.
 type lastSentDoc struct {
-	ID        string `bson:"_id"`
-	ModelUUID string `bson:"model-uuid"`
-	Sink      string `bson:"sink"`
-	Time      int64  `bson:"timestamp"`
+	ID        string   `bson:"_id"`
+	ModelUUID string   `bson:"model-uuid"`
+	Sink      string   `bson:"sink"`
+	Time      int64    `bson:"timestamp"`
+	RecordIDs []string `bson:"record-ids,omitempty"`
 }
 
 // NewLastSentLogger returns a NewLastSentLogger struct that records and retrieves
@@ -87,6 +88,14 @@ type DbLoggerLastSent struct {
 
 // Set records the timestamp.
 func (logger *DbLoggerLastSent) Set(t time.Time) error {
+	return logger.SetPosition(t, nil)
+}
+
+// SetPosition records the timestamp, along with the IDs (see
+// LogRecord.ID) of the records with exactly that timestamp which have
+// been forwarded. Many records may share a timestamp, so the IDs are
+// needed to resume forwarding without repeating or missing any.
+func (logger *DbLoggerLastSent) SetPosition(t time.Time, recordIDs []string) error {
 	collection := logger.session.DB(logsDB).C(forwardedC)
 	_, err := collection.UpsertId(
 		logger.id,
@@ -95,6 +104,7 @@ func (logger *DbLoggerLastSent) Set(t time.Time) error {
 			ModelUUID: logger.model,
 			Sink:      logger.sink,
 			Time:      t.UnixNano(),
+			RecordIDs: recordIDs,
 		},
 	)
 	return errors.Trace(err)
@@ -102,17 +112,24 @@ func (logger *DbLoggerLastSent) Set(t time.Time) error {
 
 // Get retrieves the timestamp.
 func (logger *DbLoggerLastSent) Get() (time.Time, error) {
+	t, _, err := logger.GetPosition()
+	return t, errors.Trace(err)
+}
+
+// GetPosition retrieves the timestamp and the IDs of the forwarded
+// records with exactly that timestamp, as recorded by SetPosition.
+func (logger *DbLoggerLastSent) GetPosition() (time.Time, []string, error) {
 	zeroTime := time.Time{}
 	collection := logger.session.DB(logsDB).C(forwardedC)
 	var lastSent lastSentDoc
 	err := collection.FindId(logger.id).One(&lastSent)
 	if err != nil {
 		if err == mgo.ErrNotFound {
-			return zeroTime, errors.Trace(ErrNeverForwarded)
+			return zeroTime, nil, errors.Trace(ErrNeverForwarded)
 		}
-		return zeroTime, errors.Trace(err)
+		return zeroTime, nil, errors.Trace(err)
 	}
-	return time.Unix(0, lastSent.Time).UTC(), nil
+	return time.Unix(0, lastSent.Time).UTC(), lastSent.RecordIDs, nil
 }
 
 // logDoc describes log messages stored in MongoDB.
@@ -196,6 +213,9 @@ type LogTailer interface {
 // LogRecord defines a single Juju log message as returned by
 // LogTailer.
 type LogRecord struct {
+	// ID uniquely identifies the record among those in the logs
+	// collection.
+	ID        string
 	Time      time.Time
 	Entity    string
 	Module    string
@@ -218,6 +238,22 @@ type LogTailerParams struct {
 	ExcludeModule []string
 	Oplog         *mgo.Collection // For testing only
 	AllModels     bool
+
+	// EndTime, if set, excludes logs written at or after it. A
+	// tailer with an EndTime stops once it has returned the logs
+	// already recorded, as if NoTail were set.
+	EndTime time.Time
+
+	// Message, if set, is a regular expression which log messages
+	// must match.
+	Message string
+
+	// IncludeLocation and ExcludeLocation filter logs by the source
+	// location which wrote them. Each value is a file name,
+	// optionally followed by ":" and a line number, and may contain
+	// '*' wildcards, e.g. "uniter.go", "uniter.go:42" or "*_test.go".
+	IncludeLocation []string
+	ExcludeLocation []string
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -301,7 +337,7 @@ func (t *logTailer) loop() error {
 		return errors.Trace(err)
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
@@ -394,8 +430,12 @@ func (t *logTailer) tailOplog() error {
 }
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
+	timeSel := bson.M{"$gte": params.StartTime}
+	if !params.EndTime.IsZero() {
+		timeSel["$lt"] = params.EndTime
+	}
 	sel := bson.D{
-		{"t", bson.M{"$gte": params.StartTime}},
+		{"t", timeSel},
 	}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
@@ -419,6 +459,17 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeLocation) > 0 {
+		sel = append(sel,
+			bson.DocElem{"l", bson.RegEx{Pattern: makeLocationPattern(params.IncludeLocation)}})
+	}
+	if len(params.ExcludeLocation) > 0 {
+		sel = append(sel,
+			bson.DocElem{"l", bson.M{"$not": bson.RegEx{Pattern: makeLocationPattern(params.ExcludeLocation)}}})
+	}
+	if params.Message != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.Message}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -445,6 +496,15 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+func makeLocationPattern(locations []string) string {
+	var patterns []string
+	for _, location := range locations {
+		pattern := regexp.QuoteMeta(location)
+		patterns = append(patterns, strings.Replace(pattern, `\*`, ".*", -1))
+	}
+	return `^(` + strings.Join(patterns, "|") + `)(:[0-9]+)?$`
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -495,6 +555,7 @@ func (s *objectIdSet) Length() int {
 
 func logDocToRecord(doc *logDoc) *LogRecord {
 	return &LogRecord{
+		ID:        doc.Id.Hex(),
 		Time:      doc.Time,
 		Entity:    doc.Entity,
 		Module:    doc.Module,
@@ -64,6 +64,28 @@ func (s *LogsSuite) TestLastSentLoggerNoSet(c *gc.C) {
 	logger := state.NewLastSentLogger(s.State, "test")
 	_, err := logger.Get()
 	c.Assert(err, gc.ErrorMatches, state.ErrNeverForwarded.Error())
+	_, _, err = logger.GetPosition()
+	c.Assert(err, gc.ErrorMatches, state.ErrNeverForwarded.Error())
+}
+
+func (s *LogsSuite) TestLastSentLoggerPosition(c *gc.C) {
+	logger := state.NewLastSentLogger(s.State, "test-sink")
+	t := time.Date(2016, 04, 15, 16, 0, 0, 42, time.UTC)
+	err := logger.SetPosition(t, []string{"id0", "id1"})
+	c.Assert(err, jc.ErrorIsNil)
+	t1, ids, err := logger.GetPosition()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t1, gc.DeepEquals, t)
+	c.Assert(ids, jc.DeepEquals, []string{"id0", "id1"})
+
+	// Set clears the record IDs.
+	t2 := t.Add(time.Second)
+	err = logger.Set(t2)
+	c.Assert(err, jc.ErrorIsNil)
+	t3, ids, err := logger.GetPosition()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t3, gc.DeepEquals, t2)
+	c.Assert(ids, gc.HasLen, 0)
 }
 
 func (s *LogsSuite) TestIndexesCreated(c *gc.C) {
@@ -647,6 +669,89 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestEndTime(c *gc.C) {
+	threshT := time.Now()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c, threshT, threshT.Add(5*time.Second), 5, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Only the earlier logs are reported, and the tailer stops
+	// itself rather than tailing the oplog.
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestMessage(c *gc.C) {
+	match := logTemplate{Message: "hook failed: install"}
+	other := logTemplate{Message: "all is well"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 1, match)
+		s.writeLogs(c, 1, other)
+	}
+	params := &state.LogTailerParams{
+		Message: "failed: [a-z]+$",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, match)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeLocation(c *gc.C) {
+	uniter := logTemplate{Location: "uniter.go:42"}
+	uniterOther := logTemplate{Location: "uniter.go:99"}
+	uniterTest := logTemplate{Location: "uniter_test.go:42"}
+	deployer := logTemplate{Location: "deployer.go:10"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, uniter)
+		s.writeLogs(c, 1, deployer)
+		s.writeLogs(c, 1, uniterOther)
+		s.writeLogs(c, 1, uniterTest)
+	}
+	params := &state.LogTailerParams{
+		IncludeLocation: []string{"uniter.go", "*_test.go:42"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, uniter)
+		s.assertTailer(c, tailer, 1, uniterOther)
+		s.assertTailer(c, tailer, 1, uniterTest)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeLocation(c *gc.C) {
+	uniter := logTemplate{Location: "uniter.go:42"}
+	uniterOther := logTemplate{Location: "uniter.go:99"}
+	deployer := logTemplate{Location: "deployer.go:10"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, uniter)
+		s.writeLogs(c, 1, deployer)
+		s.writeLogs(c, 1, uniterOther)
+	}
+	params := &state.LogTailerParams{
+		ExcludeLocation: []string{"uniter.go:42", "deployer.*"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, uniterOther)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -759,6 +864,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			if !ok {
 				c.Fatalf("tailer died unexpectedly: %v", tailer.Err())
 			}
+			c.Assert(log.ID, gc.Not(gc.Equals), "")
 			c.Assert(log.Entity, gc.Equals, lt.Entity.String())
 			c.Assert(log.Module, gc.Equals, lt.Module)
 			c.Assert(log.Location, gc.Equals, lt.Location)
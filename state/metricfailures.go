@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MetricFailureKind describes the stage at which a unit failed to
+// produce or deliver metrics.
+type MetricFailureKind string
+
+const (
+	// MetricCollectFailure is recorded when the collect-metrics hook
+	// fails.
+	MetricCollectFailure MetricFailureKind = "collect"
+
+	// MetricSpoolFailure is recorded when collected metrics could not
+	// be written to or read from the unit's metric spool.
+	MetricSpoolFailure MetricFailureKind = "spool"
+
+	// MetricSendFailure is recorded when spooled metrics could not be
+	// sent to the controller, or were rejected by it and dropped.
+	MetricSendFailure MetricFailureKind = "send"
+)
+
+// Validate returns an error if the kind is not known.
+func (k MetricFailureKind) Validate() error {
+	switch k {
+	case MetricCollectFailure, MetricSpoolFailure, MetricSendFailure:
+		return nil
+	}
+	return errors.NotValidf("metric failure kind %q", k)
+}
+
+// MetricFailure records a unit's failure to collect, spool or send
+// metrics.
+type MetricFailure struct {
+	st  *State
+	doc metricFailureDoc
+}
+
+type metricFailureDoc struct {
+	DocID     string            `bson:"_id"`
+	UUID      string            `bson:"uuid"`
+	ModelUUID string            `bson:"model-uuid"`
+	Unit      string            `bson:"unit"`
+	Kind      MetricFailureKind `bson:"kind"`
+	Message   string            `bson:"message"`
+	Time      time.Time         `bson:"time"`
+}
+
+// MetricFailureParam contains the properties of a metric failure used
+// when recording it.
+type MetricFailureParam struct {
+	UUID    string
+	Unit    names.UnitTag
+	Kind    MetricFailureKind
+	Message string
+	Time    time.Time
+}
+
+// AddMetricFailure records a unit's failure to collect, spool or send
+// metrics. Failures are identified by their UUID, so that units can
+// safely report the same failure more than once.
+func (st *State) AddMetricFailure(param MetricFailureParam) (*MetricFailure, error) {
+	if param.UUID == "" {
+		return nil, errors.NotValidf("empty metric failure UUID")
+	}
+	if err := param.Kind.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := st.Unit(param.Unit.Id()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	failure := &MetricFailure{
+		st: st,
+		doc: metricFailureDoc{
+			DocID:     st.docID(param.UUID),
+			UUID:      param.UUID,
+			ModelUUID: st.ModelUUID(),
+			Unit:      param.Unit.Id(),
+			Kind:      param.Kind,
+			Message:   param.Message,
+			Time:      param.Time.UTC(),
+		},
+	}
+	failures, closer := st.getCollection(metricFailuresC)
+	defer closer()
+	// Failures are a write-once debugging record which nothing watches,
+	// so they are written directly rather than through a transaction;
+	// see probablyUpdateStatusHistory for a similar situation.
+	err := failures.Writeable().Insert(&failure.doc)
+	if mgo.IsDup(err) {
+		return nil, errors.AlreadyExistsf("metric failure UUID %q", param.UUID)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add metric failure")
+	}
+	return failure, nil
+}
+
+func (st *State) queryMetricFailures(query bson.M) ([]MetricFailure, error) {
+	c, closer := st.getCollection(metricFailuresC)
+	defer closer()
+	var docs []metricFailureDoc
+	if err := c.Find(query).Sort("time").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]MetricFailure, len(docs))
+	for i, doc := range docs {
+		results[i] = MetricFailure{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// MetricFailuresForUnit returns the metric failures recorded for the
+// given unit, oldest first.
+func (st *State) MetricFailuresForUnit(unit string) ([]MetricFailure, error) {
+	return st.queryMetricFailures(bson.M{"unit": unit})
+}
+
+// MetricFailuresForService returns the metric failures recorded for the
+// units of the given service, oldest first.
+func (st *State) MetricFailuresForService(service string) ([]MetricFailure, error) {
+	if _, err := st.Service(service); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.queryMetricFailures(bson.M{
+		"unit": bson.M{"$regex": "^" + service + "/"},
+	})
+}
+
+// cleanupOldMetricFailures removes the metric failures recorded before
+// the model's metrics history.
+func (st *State) cleanupOldMetricFailures(now time.Time) error {
+	history, err := st.metricsHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	failures, closer := st.getCollection(metricFailuresC)
+	defer closer()
+	info, err := failures.Writeable().RemoveAll(bson.M{
+		"time": bson.M{"$lte": now.Add(-history)},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	metricsLogger.Tracef("cleanup removed %d metric failures", info.Removed)
+	return nil
+}
+
+// UUID returns the UUID of the failure.
+func (f *MetricFailure) UUID() string {
+	return f.doc.UUID
+}
+
+// Unit returns the name of the unit that reported the failure.
+func (f *MetricFailure) Unit() string {
+	return f.doc.Unit
+}
+
+// Kind returns the stage at which metrics failed.
+func (f *MetricFailure) Kind() MetricFailureKind {
+	return f.doc.Kind
+}
+
+// Message returns the description of the failure.
+func (f *MetricFailure) Message() string {
+	return f.doc.Message
+}
+
+// Time returns the time the failure happened on the unit.
+func (f *MetricFailure) Time() time.Time {
+	return f.doc.Time
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type MetricFailureSuite struct {
+	ConnSuite
+	service *state.Service
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&MetricFailureSuite{})
+
+func (s *MetricFailureSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	s.service = s.Factory.MakeService(c, &factory.ServiceParams{Charm: meteredCharm})
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Service: s.service, SetCharmURL: true})
+}
+
+func (s *MetricFailureSuite) addFailure(c *gc.C, unit *state.Unit, kind state.MetricFailureKind, t time.Time) *state.MetricFailure {
+	failure, err := s.State.AddMetricFailure(state.MetricFailureParam{
+		UUID:    utils.MustNewUUID().String(),
+		Unit:    unit.UnitTag(),
+		Kind:    kind,
+		Message: "boom",
+		Time:    t,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return failure
+}
+
+func (s *MetricFailureSuite) TestAddMetricFailure(c *gc.C) {
+	now := state.NowToTheSecond()
+	uuid := utils.MustNewUUID().String()
+	failure, err := s.State.AddMetricFailure(state.MetricFailureParam{
+		UUID:    uuid,
+		Unit:    s.unit.UnitTag(),
+		Kind:    state.MetricCollectFailure,
+		Message: "collect-metrics hook failed",
+		Time:    now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failure.UUID(), gc.Equals, uuid)
+	c.Assert(failure.Unit(), gc.Equals, s.unit.Name())
+	c.Assert(failure.Kind(), gc.Equals, state.MetricCollectFailure)
+	c.Assert(failure.Message(), gc.Equals, "collect-metrics hook failed")
+	c.Assert(failure.Time().Equal(now), jc.IsTrue)
+
+	failures, err := s.State.MetricFailuresForUnit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].UUID(), gc.Equals, uuid)
+	c.Assert(failures[0].Time().Equal(now), jc.IsTrue)
+}
+
+func (s *MetricFailureSuite) TestAddMetricFailureDuplicate(c *gc.C) {
+	param := state.MetricFailureParam{
+		UUID: utils.MustNewUUID().String(),
+		Unit: s.unit.UnitTag(),
+		Kind: state.MetricSendFailure,
+		Time: time.Now(),
+	}
+	_, err := s.State.AddMetricFailure(param)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMetricFailure(param)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MetricFailureSuite) TestAddMetricFailureInvalidKind(c *gc.C) {
+	_, err := s.State.AddMetricFailure(state.MetricFailureParam{
+		UUID: utils.MustNewUUID().String(),
+		Unit: s.unit.UnitTag(),
+		Kind: "explode",
+		Time: time.Now(),
+	})
+	c.Assert(err, gc.ErrorMatches, `metric failure kind "explode" not valid`)
+}
+
+func (s *MetricFailureSuite) TestAddMetricFailureUnknownUnit(c *gc.C) {
+	_, err := s.State.AddMetricFailure(state.MetricFailureParam{
+		UUID: utils.MustNewUUID().String(),
+		Unit: names.NewUnitTag("nope/0"),
+		Kind: state.MetricSpoolFailure,
+		Time: time.Now(),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MetricFailureSuite) TestMetricFailuresForService(c *gc.C) {
+	now := state.NowToTheSecond()
+	other := s.Factory.MakeUnit(c, &factory.UnitParams{Service: s.service, SetCharmURL: true})
+	second := s.addFailure(c, other, state.MetricSendFailure, now)
+	first := s.addFailure(c, s.unit, state.MetricCollectFailure, now.Add(-time.Minute))
+
+	// Failures of units of other services are not included.
+	ch, _, err := s.service.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	otherService := s.Factory.MakeService(c, &factory.ServiceParams{Name: "metered2", Charm: ch})
+	otherUnit := s.Factory.MakeUnit(c, &factory.UnitParams{Service: otherService, SetCharmURL: true})
+	s.addFailure(c, otherUnit, state.MetricSpoolFailure, now)
+
+	failures, err := s.State.MetricFailuresForService(s.service.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 2)
+	c.Assert(failures[0].UUID(), gc.Equals, first.UUID())
+	c.Assert(failures[1].UUID(), gc.Equals, second.UUID())
+
+	_, err = s.State.MetricFailuresForService("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MetricFailureSuite) TestCleanupOldMetricFailures(c *gc.C) {
+	now := time.Now()
+	old := s.addFailure(c, s.unit, state.MetricCollectFailure, now.Add(-25*time.Hour))
+	recent := s.addFailure(c, s.unit, state.MetricCollectFailure, now.Add(-time.Hour))
+
+	err := s.State.CleanupOldMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	failures, err := s.State.MetricFailuresForUnit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].UUID(), gc.Equals, recent.UUID())
+	c.Assert(failures[0].UUID(), gc.Not(gc.Equals), old.UUID())
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{"metrics-history": "30m"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CleanupOldMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+	failures, err = s.State.MetricFailuresForUnit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
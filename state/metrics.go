@@ -19,6 +19,8 @@ import (
 var metricsLogger = loggo.GetLogger("juju.state.metrics")
 
 const (
+	// CleanupAge is the default time sent metrics are kept for; the
+	// "metrics-history" model config setting overrides it.
 	CleanupAge = time.Hour * 24
 )
 
@@ -227,8 +229,19 @@ func (st *State) MetricBatch(id string) (*MetricBatch, error) {
 	return &MetricBatch{st: st, doc: doc}, nil
 }
 
-// CleanupOldMetrics looks for metrics that are 24 hours old (or older)
-// and have been sent. Any metrics it finds are deleted.
+// metricsHistory returns how long sent metric batches and metric
+// failures are kept in the model.
+func (st *State) metricsHistory() (time.Duration, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.MetricsHistory(), nil
+}
+
+// CleanupOldMetrics looks for metrics that have been sent and are older
+// than the model's metrics history (24 hours by default), and for metric
+// failures older than that. Any it finds are deleted.
 func (st *State) CleanupOldMetrics() error {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	now := time.Now()
@@ -243,10 +256,11 @@ func (st *State) CleanupOldMetrics() error {
 		"sent":        true,
 		"delete-time": bson.M{"$lte": now},
 	})
-	if err == nil {
-		metricsLogger.Tracef("cleanup removed %d metrics", info.Removed)
+	if err != nil {
+		return errors.Trace(err)
 	}
-	return errors.Trace(err)
+	metricsLogger.Tracef("cleanup removed %d metrics", info.Removed)
+	return errors.Trace(st.cleanupOldMetricFailures(now))
 }
 
 // MetricsToSend returns batchSize metrics that need to be sent
@@ -339,7 +353,11 @@ func (m *MetricBatch) Metrics() []Metric {
 // SetSent marks the metric has having been sent at
 // the specified time.
 func (m *MetricBatch) SetSent(t time.Time) error {
-	deleteTime := t.UTC().Add(CleanupAge)
+	history, err := m.st.metricsHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	deleteTime := t.UTC().Add(history)
 	ops := setSentOps([]string{m.UUID()}, deleteTime)
 	if err := m.st.runTransaction(ops); err != nil {
 		return errors.Annotatef(err, "cannot set metric sent for metric %q", m.UUID())
@@ -370,8 +388,12 @@ func setSentOps(batchUUIDs []string, deleteTime time.Time) []txn.Op {
 
 // SetMetricBatchesSent sets sent on each MetricBatch corresponding to the uuids provided.
 func (st *State) SetMetricBatchesSent(batchUUIDs []string) error {
+	history, err := st.metricsHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(fwereade): 2016-03-17 lp:1558657
-	deleteTime := time.Now().UTC().Add(CleanupAge)
+	deleteTime := time.Now().UTC().Add(history)
 	ops := setSentOps(batchUUIDs, deleteTime)
 	if err := st.runTransaction(ops); err != nil {
 		return errors.Annotatef(err, "cannot set metric sent in bulk call")
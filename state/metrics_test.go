@@ -206,6 +206,29 @@ func (s *MetricSuite) TestCleanupMetrics(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *MetricSuite) TestCleanupMetricsHistory(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"metrics-history": "1h"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	metric, err := s.State.AddMetrics(
+		state.BatchParam{
+			UUID:     utils.MustNewUUID().String(),
+			Created:  now,
+			CharmURL: s.meteredCharm.URL().String(),
+			Metrics:  []state.Metric{{"pings", "5", now}},
+			Unit:     s.unit.UnitTag(),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = metric.SetSent(now.Add(-2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CleanupOldMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.MetricBatch(metric.UUID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *MetricSuite) TestCleanupNoMetrics(c *gc.C) {
 	err := s.State.CleanupOldMetrics()
 	c.Assert(err, jc.ErrorIsNil)
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/hex"
 	"strings"
 	"time"
 
@@ -11,9 +12,11 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	"github.com/juju/utils/set"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // Export the current model for the State.
@@ -84,6 +87,33 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.relations(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.spaces(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.subnets(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.linklayerdevices(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.ipaddresses(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.storage(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.resources(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.payloads(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.actions(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.metricBatches(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := export.model.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -163,6 +193,7 @@ func (e *exporter) modelUsers() error {
 			DateCreated:    user.DateCreated(),
 			LastConnection: lastConn,
 			ReadOnly:       user.ReadOnly(),
+			Access:         string(user.Access()),
 		}
 		e.model.AddUser(arg)
 	}
@@ -305,6 +336,25 @@ func (e *exporter) newMachine(exParent description.Machine, machine *Machine, in
 	}
 	exMachine.SetConstraints(constraintsArgs)
 
+	blockDevices, err := e.st.blockDevices(machine.Id())
+	if err != nil {
+		return nil, errors.Annotatef(err, "block devices for machine %s", machine.Id())
+	}
+	for _, device := range blockDevices {
+		exMachine.AddBlockDevice(description.BlockDeviceArgs{
+			Name:           device.DeviceName,
+			Links:          device.DeviceLinks,
+			Label:          device.Label,
+			UUID:           device.UUID,
+			HardwareID:     device.HardwareId,
+			BusAddress:     device.BusAddress,
+			Size:           device.Size,
+			FilesystemType: device.FilesystemType,
+			InUse:          device.InUse,
+			MountPoint:     device.MountPoint,
+		})
+	}
+
 	return exMachine, nil
 }
 
@@ -442,6 +492,8 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 		CharmModifiedVersion: service.doc.CharmModifiedVersion,
 		ForceCharm:           service.doc.ForceCharm,
 		Exposed:              service.doc.Exposed,
+		ExposedToCIDRs:       service.doc.ExposedToCIDRs,
+		ExposedToSpaces:      service.doc.ExposedToSpaces,
 		MinUnits:             service.doc.MinUnits,
 		Settings:             serviceSettingsDoc.Settings,
 		SettingsRefCount:     refCount,
@@ -449,6 +501,20 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   service.doc.MetricCredentials,
 	}
+	storageConstraints, err := service.StorageConstraints()
+	if err != nil {
+		return errors.Annotatef(err, "storage constraints for service %s", service.Name())
+	}
+	if len(storageConstraints) > 0 {
+		args.StorageConstraints = make(map[string]description.StorageConstraintArgs)
+		for name, cons := range storageConstraints {
+			args.StorageConstraints[name] = description.StorageConstraintArgs{
+				Pool:  cons.Pool,
+				Size:  cons.Size,
+				Count: cons.Count,
+			}
+		}
+	}
 	exService := e.model.AddService(args)
 	// Find the current service status.
 	globalKey := service.globalKey()
@@ -577,6 +643,552 @@ func (e *exporter) relations() error {
 	return nil
 }
 
+func (e *exporter) spaces() error {
+	spaces, err := e.st.AllSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d spaces", len(spaces))
+
+	for _, space := range spaces {
+		e.model.AddSpace(description.SpaceArgs{
+			Name:       space.Name(),
+			Public:     space.doc.IsPublic,
+			ProviderID: string(space.ProviderId()),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) subnets() error {
+	subnets, err := e.st.AllSubnets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("read %d subnets", len(subnets))
+
+	for _, subnet := range subnets {
+		e.model.AddSubnet(description.SubnetArgs{
+			CIDR:              subnet.CIDR(),
+			ProviderId:        string(subnet.ProviderId()),
+			VLANTag:           subnet.VLANTag(),
+			AvailabilityZone:  subnet.AvailabilityZone(),
+			SpaceName:         subnet.SpaceName(),
+			AllocatableIPHigh: subnet.AllocatableIPHigh(),
+			AllocatableIPLow:  subnet.AllocatableIPLow(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) linklayerdevices() error {
+	linkLayerDevices, closer := e.st.getCollection(linkLayerDevicesC)
+	defer closer()
+
+	// Sorting by machine id means that host machines are exported before
+	// their containers, whose devices may have parents on the host.
+	var docs []linkLayerDeviceDoc
+	if err := linkLayerDevices.Find(nil).Sort("machine-id", "name").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all link-layer devices")
+	}
+	e.logger.Debugf("read %d link-layer devices", len(docs))
+
+	for _, doc := range docs {
+		device := newLinkLayerDevice(e.st, doc)
+		e.model.AddLinkLayerDevice(description.LinkLayerDeviceArgs{
+			ProviderID:  string(device.ProviderID()),
+			MachineID:   device.MachineID(),
+			Name:        device.Name(),
+			MTU:         device.MTU(),
+			Type:        string(device.Type()),
+			MACAddress:  device.MACAddress(),
+			IsAutoStart: device.IsAutoStart(),
+			IsUp:        device.IsUp(),
+			ParentName:  device.ParentName(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) ipaddresses() error {
+	ipAddresses, closer := e.st.getCollection(ipAddressesC)
+	defer closer()
+
+	var docs []ipAddressDoc
+	if err := ipAddresses.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all ip addresses")
+	}
+	e.logger.Debugf("read %d ip addresses", len(docs))
+
+	for _, doc := range docs {
+		addr := newIPAddress(e.st, doc)
+		e.model.AddIPAddress(description.IPAddressArgs{
+			ProviderID:       string(addr.ProviderID()),
+			DeviceName:       addr.DeviceName(),
+			MachineID:        addr.MachineID(),
+			SubnetCIDR:       addr.SubnetCIDR(),
+			ConfigMethod:     string(addr.ConfigMethod()),
+			Value:            addr.Value(),
+			DNSServers:       addr.DNSServers(),
+			DNSSearchDomains: addr.DNSSearchDomains(),
+			GatewayAddress:   addr.GatewayAddress(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) storage() error {
+	if err := e.storageInstances(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.storagePools(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.volumes(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := e.filesystems(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (e *exporter) storageInstances() error {
+	storageInstances, closer := e.st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	if err := storageInstances.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all storage instances")
+	}
+	e.logger.Debugf("read %d storage instances", len(docs))
+
+	attachments, err := e.readAllStorageAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		instance := &storageInstance{e.st, doc}
+		if doc.Snapshot != "" {
+			// Volume snapshots are not migrated, so the storage
+			// instance's volume must be created from the snapshot
+			// before the model can be exported.
+			_, err := e.st.storageInstanceVolume(instance.StorageTag())
+			if errors.IsNotFound(err) {
+				return errors.NotSupportedf(
+					"exporting storage %q before its volume is created from snapshot %q",
+					doc.Id, doc.Snapshot,
+				)
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		// Detached storage has no owner.
+		owner, _ := instance.Owner()
+		e.model.AddStorage(description.StorageArgs{
+			Tag:         instance.StorageTag(),
+			Kind:        instance.Kind().String(),
+			Owner:       owner,
+			Name:        instance.StorageName(),
+			Attachments: attachments[doc.Id],
+		})
+	}
+	return nil
+}
+
+func (e *exporter) readAllStorageAttachments() (map[string][]names.UnitTag, error) {
+	storageAttachments, closer := e.st.getCollection(storageAttachmentsC)
+	defer closer()
+
+	var docs []storageAttachmentDoc
+	if err := storageAttachments.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all storage attachments")
+	}
+	e.logger.Debugf("read %d storage attachment documents", len(docs))
+
+	result := make(map[string][]names.UnitTag)
+	for _, doc := range docs {
+		units := result[doc.StorageInstance]
+		result[doc.StorageInstance] = append(units, names.NewUnitTag(doc.Unit))
+	}
+	return result, nil
+}
+
+func (e *exporter) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(e.st))
+	pools, err := pm.List()
+	if err != nil {
+		return errors.Annotate(err, "listing pools")
+	}
+	e.logger.Debugf("read %d storage pools", len(pools))
+
+	for _, cfg := range pools {
+		e.model.AddStoragePool(description.StoragePoolArgs{
+			Name:       cfg.Name(),
+			Provider:   string(cfg.Provider()),
+			Attributes: cfg.Attrs(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) volumes() error {
+	volumes, closer := e.st.getCollection(volumesC)
+	defer closer()
+
+	var docs []volumeDoc
+	if err := volumes.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all volumes")
+	}
+	e.logger.Debugf("read %d volumes", len(docs))
+
+	attachments, err := e.readAllVolumeAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		if err := e.addVolume(&volume{e.st, doc}, attachments[doc.Name]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addVolume(vol *volume, volAttachments []volumeAttachmentDoc) error {
+	args := description.VolumeArgs{
+		Tag: vol.VolumeTag(),
+	}
+	if tag, err := vol.StorageInstance(); err == nil {
+		// only returns an error when no storage tag.
+		args.Storage = tag
+	} else if !errors.IsNotAssigned(err) {
+		return errors.Trace(err)
+	}
+	if binding := vol.LifeBinding(); binding != nil {
+		args.Binding = binding
+	}
+	if info, err := vol.Info(); err == nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.HardwareID = info.HardwareId
+		args.VolumeID = info.VolumeId
+		args.Persistent = info.Persistent
+	} else {
+		params, _ := vol.Params()
+		if params.SnapshotId != "" {
+			// Volume snapshots are not migrated.
+			return errors.NotSupportedf(
+				"exporting volume %q before it is created from snapshot %q",
+				vol.doc.Name, params.SnapshotId,
+			)
+		}
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+
+	globalKey := vol.globalKey()
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for volume %s", vol.doc.Name)
+	}
+
+	exVolume := e.model.AddVolume(args)
+	exVolume.SetStatus(statusArgs)
+	exVolume.SetStatusHistory(e.statusHistoryArgs(globalKey))
+	for _, doc := range volAttachments {
+		va := volumeAttachment{doc}
+		args := description.VolumeAttachmentArgs{
+			Machine: va.Machine(),
+		}
+		if info, err := va.Info(); err == nil {
+			args.Provisioned = true
+			args.ReadOnly = info.ReadOnly
+			args.DeviceName = info.DeviceName
+			args.DeviceLink = info.DeviceLink
+			args.BusAddress = info.BusAddress
+		} else {
+			params, _ := va.Params()
+			args.ReadOnly = params.ReadOnly
+		}
+		exVolume.AddAttachment(args)
+	}
+	return nil
+}
+
+func (e *exporter) readAllVolumeAttachments() (map[string][]volumeAttachmentDoc, error) {
+	volumeAttachments, closer := e.st.getCollection(volumeAttachmentsC)
+	defer closer()
+
+	var docs []volumeAttachmentDoc
+	if err := volumeAttachments.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all volume attachments")
+	}
+	e.logger.Debugf("read %d volume attachment documents", len(docs))
+
+	result := make(map[string][]volumeAttachmentDoc)
+	for _, doc := range docs {
+		volumeId := doc.Volume
+		result[volumeId] = append(result[volumeId], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) filesystems() error {
+	filesystems, closer := e.st.getCollection(filesystemsC)
+	defer closer()
+
+	var docs []filesystemDoc
+	if err := filesystems.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all filesystems")
+	}
+	e.logger.Debugf("read %d filesystems", len(docs))
+
+	attachments, err := e.readAllFilesystemAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		if err := e.addFilesystem(&filesystem{e.st, doc}, attachments[doc.FilesystemId]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addFilesystem(fs *filesystem, fsAttachments []filesystemAttachmentDoc) error {
+	args := description.FilesystemArgs{
+		Tag: fs.FilesystemTag(),
+	}
+	if tag, err := fs.Storage(); err == nil {
+		// only returns an error when no storage tag.
+		args.Storage = tag
+	} else if !errors.IsNotAssigned(err) {
+		return errors.Trace(err)
+	}
+	if tag, err := fs.Volume(); err == nil {
+		args.Volume = tag
+	} else if err != ErrNoBackingVolume {
+		return errors.Trace(err)
+	}
+	if binding := fs.LifeBinding(); binding != nil {
+		args.Binding = binding
+	}
+	if info, err := fs.Info(); err == nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.FilesystemID = info.FilesystemId
+	} else {
+		params, _ := fs.Params()
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+
+	globalKey := fs.globalKey()
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotatef(err, "status for filesystem %s", fs.doc.FilesystemId)
+	}
+
+	exFilesystem := e.model.AddFilesystem(args)
+	exFilesystem.SetStatus(statusArgs)
+	exFilesystem.SetStatusHistory(e.statusHistoryArgs(globalKey))
+	for _, doc := range fsAttachments {
+		fa := filesystemAttachment{doc}
+		args := description.FilesystemAttachmentArgs{
+			Machine: fa.Machine(),
+		}
+		if info, err := fa.Info(); err == nil {
+			args.Provisioned = true
+			args.MountPoint = info.MountPoint
+			args.ReadOnly = info.ReadOnly
+		} else {
+			params, _ := fa.Params()
+			args.MountPoint = params.Location
+			args.ReadOnly = params.ReadOnly
+		}
+		exFilesystem.AddAttachment(args)
+	}
+	return nil
+}
+
+func (e *exporter) readAllFilesystemAttachments() (map[string][]filesystemAttachmentDoc, error) {
+	filesystemAttachments, closer := e.st.getCollection(filesystemAttachmentsC)
+	defer closer()
+
+	var docs []filesystemAttachmentDoc
+	if err := filesystemAttachments.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all filesystem attachments")
+	}
+	e.logger.Debugf("read %d filesystem attachment documents", len(docs))
+
+	result := make(map[string][]filesystemAttachmentDoc)
+	for _, doc := range docs {
+		filesystemId := doc.Filesystem
+		result[filesystemId] = append(result[filesystemId], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) resources() error {
+	persist := NewResourcePersistence(e.st.newPersistence())
+	services, err := e.st.AllServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, service := range services {
+		serviceResources, err := persist.ListResources(service.Name())
+		if err != nil {
+			return errors.Annotatef(err, "resources for %s", service.Name())
+		}
+		e.logger.Debugf("read %d resources for %s", len(serviceResources.Resources), service.Name())
+
+		for i, res := range serviceResources.Resources {
+			exResource := e.model.AddResource(description.ResourceArgs{
+				Service:  service.Name(),
+				Name:     res.Name,
+				Revision: e.resourceRevisionArgs(res.Resource, res.Timestamp, res.Username),
+			})
+			// The charm store resources are aligned with the service
+			// resources, with empty values for those not yet polled.
+			if storeRes := serviceResources.CharmStoreResources[i]; storeRes.Name != "" {
+				exResource.SetCharmStoreRevision(e.resourceRevisionArgs(storeRes, time.Time{}, ""))
+			}
+			for _, unitResources := range serviceResources.UnitResources {
+				for _, unitRes := range unitResources.Resources {
+					if unitRes.Name != res.Name {
+						continue
+					}
+					exResource.SetUnitRevision(
+						unitResources.Tag.Id(),
+						e.resourceRevisionArgs(unitRes.Resource, unitRes.Timestamp, unitRes.Username),
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exporter) resourceRevisionArgs(res charmresource.Resource, timestamp time.Time, username string) description.ResourceRevisionArgs {
+	return description.ResourceRevisionArgs{
+		Revision:       res.Revision,
+		Type:           res.Type.String(),
+		Path:           res.Path,
+		Description:    res.Description,
+		Origin:         res.Origin.String(),
+		FingerprintHex: hex.EncodeToString(res.Fingerprint.Bytes()),
+		Size:           res.Size,
+		Timestamp:      timestamp,
+		Username:       username,
+	}
+}
+
+func (e *exporter) payloads() error {
+	envPayloads, err := e.st.EnvPayloads()
+	if errors.IsNotSupported(err) {
+		e.logger.Debugf("payloads not supported, skipping")
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	payloads, err := envPayloads.ListAll()
+	if err != nil {
+		return errors.Annotate(err, "listing payloads")
+	}
+	e.logger.Debugf("read %d payloads", len(payloads))
+
+	for _, payload := range payloads {
+		e.model.AddPayload(description.PayloadArgs{
+			Unit:   names.NewUnitTag(payload.Unit),
+			Name:   payload.Name,
+			Type:   payload.Type,
+			RawID:  payload.ID,
+			State:  payload.Status,
+			Labels: payload.Labels,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) actions() error {
+	actions, closer := e.st.getCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	if err := actions.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all actions")
+	}
+	e.logger.Debugf("read %d actions", len(docs))
+
+	for _, doc := range docs {
+		var messages []description.ActionMessageArgs
+		for _, m := range doc.Logs {
+			messages = append(messages, description.ActionMessageArgs{
+				Timestamp: m.Timestamp,
+				Message:   m.Message,
+			})
+		}
+		e.model.AddAction(description.ActionArgs{
+			Id:         e.st.localID(doc.DocId),
+			Receiver:   doc.Receiver,
+			Name:       doc.Name,
+			Parameters: doc.Parameters,
+			Enqueued:   doc.Enqueued,
+			Started:    doc.Started,
+			Completed:  doc.Completed,
+			Status:     string(doc.Status),
+			Message:    doc.Message,
+			Results:    doc.Results,
+
+			Timeout:         doc.Timeout,
+			CancelRequested: doc.CancelRequested,
+			Messages:        messages,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) metricBatches() error {
+	metrics, closer := e.st.getCollection(metricsC)
+	defer closer()
+
+	// Metrics that have already been sent to the collector don't need
+	// to be migrated; they are only kept around until they are cleaned up.
+	// The metrics collection is global, so filter on the model as well.
+	query := bson.D{{"model-uuid", e.st.ModelUUID()}, {"sent", false}}
+	var docs []metricBatchDoc
+	if err := metrics.Find(query).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get unsent metric batches")
+	}
+	e.logger.Debugf("read %d unsent metric batches", len(docs))
+
+	for _, doc := range docs {
+		var metricsArgs []description.MetricArgs
+		for _, metric := range doc.Metrics {
+			metricsArgs = append(metricsArgs, description.MetricArgs{
+				Key:   metric.Key,
+				Value: metric.Value,
+				Time:  metric.Time,
+			})
+		}
+		e.model.AddMetricBatch(description.MetricBatchArgs{
+			UUID:        doc.UUID,
+			Unit:        names.NewUnitTag(doc.Unit),
+			CharmURL:    doc.CharmUrl,
+			Created:     doc.Created,
+			Credentials: doc.Credentials,
+			Metrics:     metricsArgs,
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -14,8 +15,11 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -178,6 +182,7 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	c.Assert(exportedAdmin.DateCreated(), gc.Equals, owner.DateCreated())
 	c.Assert(exportedAdmin.LastConnection(), gc.Equals, lastConnection)
 	c.Assert(exportedAdmin.ReadOnly(), jc.IsFalse)
+	c.Assert(exportedAdmin.Access(), gc.Equals, "admin")
 
 	c.Assert(exportedBob.Name(), gc.Equals, bobTag)
 	c.Assert(exportedBob.DisplayName(), gc.Equals, "")
@@ -185,6 +190,7 @@ func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	c.Assert(exportedBob.DateCreated(), gc.Equals, bob.DateCreated())
 	c.Assert(exportedBob.LastConnection(), gc.Equals, lastConnection)
 	c.Assert(exportedBob.ReadOnly(), jc.IsTrue)
+	c.Assert(exportedBob.Access(), gc.Equals, "read")
 }
 
 func (s *MigrationExportSuite) TestMachines(c *gc.C) {
@@ -439,3 +445,259 @@ type goodToken struct{}
 func (*goodToken) Check(interface{}) error {
 	return nil
 }
+
+func (s *MigrationExportSuite) TestSpaces(c *gc.C) {
+	_, err := s.State.AddSpace("one", network.Id("provider"), nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces := model.Spaces()
+	c.Assert(spaces, gc.HasLen, 1)
+	space := spaces[0]
+	c.Assert(space.Name(), gc.Equals, "one")
+	c.Assert(space.ProviderID(), gc.Equals, "provider")
+	c.Assert(space.Public(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("bam", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        network.Id("foo"),
+		VLANTag:           64,
+		AvailabilityZone:  "bar",
+		SpaceName:         "bam",
+		AllocatableIPHigh: "10.0.0.100",
+		AllocatableIPLow:  "10.0.0.10",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets := model.Subnets()
+	c.Assert(subnets, gc.HasLen, 1)
+	subnet := subnets[0]
+	c.Assert(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Assert(subnet.ProviderId(), gc.Equals, "foo")
+	c.Assert(subnet.VLANTag(), gc.Equals, 64)
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Assert(subnet.SpaceName(), gc.Equals, "bam")
+	c.Assert(subnet.AllocatableIPHigh(), gc.Equals, "10.0.0.100")
+	c.Assert(subnet.AllocatableIPLow(), gc.Equals, "10.0.0.10")
+}
+
+func (s *MigrationExportSuite) TestLinkLayerDevicesAndAddresses(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
+	})
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "0.1.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:       "foo",
+		Type:       state.EthernetDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		IsUp:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:     "foo",
+		ConfigMethod:   state.StaticAddress,
+		CIDRAddress:    "0.1.2.3/24",
+		GatewayAddress: "0.1.2.1",
+		DNSServers:     []string{"ns1.example.com"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	devices := model.LinkLayerDevices()
+	c.Assert(devices, gc.HasLen, 1)
+	device := devices[0]
+	c.Assert(device.Name(), gc.Equals, "foo")
+	c.Assert(device.MachineID(), gc.Equals, machine.Id())
+	c.Assert(device.Type(), gc.Equals, string(state.EthernetDevice))
+	c.Assert(device.MACAddress(), gc.Equals, "aa:bb:cc:dd:ee:f0")
+	c.Assert(device.IsUp(), jc.IsTrue)
+
+	addresses := model.IPAddresses()
+	c.Assert(addresses, gc.HasLen, 1)
+	addr := addresses[0]
+	c.Assert(addr.Value(), gc.Equals, "0.1.2.3")
+	c.Assert(addr.MachineID(), gc.Equals, machine.Id())
+	c.Assert(addr.DeviceName(), gc.Equals, "foo")
+	c.Assert(addr.SubnetCIDR(), gc.Equals, "0.1.2.0/24")
+	c.Assert(addr.ConfigMethod(), gc.Equals, string(state.StaticAddress))
+	c.Assert(addr.GatewayAddress(), gc.Equals, "0.1.2.1")
+	c.Assert(addr.DNSServers(), jc.DeepEquals, []string{"ns1.example.com"})
+}
+
+func (s *MigrationExportSuite) TestStoragePools(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
+		"value": 42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools := model.StoragePools()
+	c.Assert(pools, gc.HasLen, 1)
+	pool := pools[0]
+	c.Assert(pool.Name(), gc.Equals, "test-pool")
+	c.Assert(pool.Provider(), gc.Equals, string(provider.LoopProviderType))
+	c.Assert(pool.Attributes(), jc.DeepEquals, map[string]interface{}{
+		"value": 42,
+	})
+}
+
+func (s *MigrationExportSuite) TestVolumes(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume:     state.VolumeParams{Pool: "loop", Size: 1234},
+			Attachment: state.VolumeAttachmentParams{ReadOnly: true},
+		}},
+	})
+	machineTag := machine.MachineTag()
+	volTag := names.NewVolumeTag("0/0")
+	err := s.State.SetVolumeInfo(volTag, state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		VolumeId:   "volume id",
+		Persistent: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volTag, state.VolumeAttachmentInfo{
+		DeviceName: "device name",
+		DeviceLink: "device link",
+		BusAddress: "bus address",
+		ReadOnly:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	volume := volumes[0]
+	c.Check(volume.Tag(), gc.Equals, volTag)
+	binding, err := volume.Binding()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(binding, gc.Equals, machineTag)
+	c.Check(volume.Provisioned(), jc.IsTrue)
+	c.Check(volume.Size(), gc.Equals, uint64(1500))
+	c.Check(volume.Pool(), gc.Equals, "loop")
+	c.Check(volume.HardwareID(), gc.Equals, "magic")
+	c.Check(volume.VolumeID(), gc.Equals, "volume id")
+	c.Check(volume.Persistent(), jc.IsTrue)
+	c.Check(volume.Status(), gc.NotNil)
+
+	attachments := volume.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment := attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsTrue)
+	c.Check(attachment.ReadOnly(), jc.IsTrue)
+	c.Check(attachment.DeviceName(), gc.Equals, "device name")
+	c.Check(attachment.DeviceLink(), gc.Equals, "device link")
+	c.Check(attachment.BusAddress(), gc.Equals, "bus address")
+}
+
+func (s *MigrationExportSuite) TestVolumesUnprovisionedFromSnapshot(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop", Size: 1234, SnapshotId: "snap-123"},
+		}},
+	})
+
+	_, err := s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `exporting volume "0/0" before it is created from snapshot "snap-123" not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MigrationExportSuite) TestFilesystems(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 1234},
+			Attachment: state.FilesystemAttachmentParams{
+				Location: "location",
+				ReadOnly: true},
+		}},
+	})
+	machineTag := machine.MachineTag()
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystems := model.Filesystems()
+	c.Assert(filesystems, gc.HasLen, 1)
+	filesystem := filesystems[0]
+	c.Check(filesystem.Tag(), gc.Equals, names.NewFilesystemTag("0/0"))
+	binding, err := filesystem.Binding()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(binding, gc.Equals, machineTag)
+	c.Check(filesystem.Provisioned(), jc.IsFalse)
+	c.Check(filesystem.Size(), gc.Equals, uint64(1234))
+	c.Check(filesystem.Pool(), gc.Equals, "rootfs")
+
+	attachments := filesystem.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment := attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsFalse)
+	c.Check(attachment.MountPoint(), gc.Equals, "location")
+	c.Check(attachment.ReadOnly(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestActions(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Service:     s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy")),
+		SetCharmURL: true,
+	})
+	action, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions := model.Actions()
+	c.Assert(actions, gc.HasLen, 1)
+	exported := actions[0]
+	c.Check(exported.Id(), gc.Equals, action.Id())
+	c.Check(exported.Receiver(), gc.Equals, unit.Name())
+	c.Check(exported.Name(), gc.Equals, "snapshot")
+	c.Check(exported.Parameters(), jc.DeepEquals, action.Parameters())
+	c.Check(exported.Status(), gc.Equals, string(state.ActionPending))
+	c.Check(exported.Started().IsZero(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestMetricBatches(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	unsent := s.Factory.MakeMetric(c, &factory.MetricParams{Time: &now})
+	unit, err := s.State.Unit(unsent.Unit())
+	c.Assert(err, jc.ErrorIsNil)
+	// Sent metrics are not exported.
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit, Sent: true})
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	batches := model.MetricBatches()
+	c.Assert(batches, gc.HasLen, 1)
+	batch := batches[0]
+	c.Check(batch.UUID(), gc.Equals, unsent.UUID())
+	c.Check(batch.Unit().Id(), gc.Equals, unsent.Unit())
+	c.Check(batch.CharmURL(), gc.Equals, unsent.CharmURL())
+	c.Check(batch.Created().Equal(now), jc.IsTrue)
+
+	metrics := batch.Metrics()
+	c.Assert(metrics, gc.HasLen, 1)
+	c.Check(metrics[0].Key(), gc.Equals, unsent.Metrics()[0].Key)
+	c.Check(metrics[0].Value(), gc.Equals, unsent.Metrics()[0].Value)
+}
@@ -4,12 +4,19 @@
 package state
 
 import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -17,7 +24,12 @@ import (
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/tools"
 )
 
@@ -94,6 +106,33 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	if err := restore.spaces(); err != nil {
+		return nil, nil, errors.Annotate(err, "spaces")
+	}
+	if err := restore.subnets(); err != nil {
+		return nil, nil, errors.Annotate(err, "subnets")
+	}
+	if err := restore.linklayerdevices(); err != nil {
+		return nil, nil, errors.Annotate(err, "link-layer devices")
+	}
+	if err := restore.ipaddresses(); err != nil {
+		return nil, nil, errors.Annotate(err, "ip addresses")
+	}
+	if err := restore.storage(); err != nil {
+		return nil, nil, errors.Annotate(err, "storage")
+	}
+	if err := restore.resources(); err != nil {
+		return nil, nil, errors.Annotate(err, "resources")
+	}
+	if err := restore.payloads(); err != nil {
+		return nil, nil, errors.Annotate(err, "payloads")
+	}
+	if err := restore.actions(); err != nil {
+		return nil, nil, errors.Annotate(err, "actions")
+	}
+	if err := restore.metricBatches(); err != nil {
+		return nil, nil, errors.Annotate(err, "metric batches")
+	}
 
 	// NOTE: at the end of the import make sure that the mode of the model
 	// is set to "imported" not "active" (or whatever we call it). This way
@@ -190,6 +229,12 @@ func (i *importer) modelUsers() error {
 		if user.ReadOnly() {
 			access = ModelReadAccess
 		}
+		if user.Access() != "" {
+			access = ModelAccess(user.Access())
+			if err := access.Validate(); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		ops = append(ops, createModelUserOp(
 			modelUUID,
 			user.Name(),
@@ -247,7 +292,6 @@ func (i *importer) machine(m description.Machine) error {
 	//    - adds status doc
 	//    - adds machine block devices doc
 
-	// TODO: consider filesystems and volumes
 	mStatus := m.Status()
 	if mStatus == nil {
 		return errors.NotValidf("missing status")
@@ -298,6 +342,10 @@ func (i *importer) machine(m description.Machine) error {
 		return errors.Trace(err)
 	}
 
+	if err := i.machineBlockDevices(mdoc.Id, m.BlockDevices()); err != nil {
+		return errors.Annotate(err, "block devices")
+	}
+
 	machine := newMachine(i.st, mdoc)
 	if annotations := m.Annotations(); len(annotations) > 0 {
 		if err := i.st.SetAnnotations(machine, annotations); err != nil {
@@ -318,6 +366,28 @@ func (i *importer) machine(m description.Machine) error {
 	return nil
 }
 
+func (i *importer) machineBlockDevices(machineId string, devices []description.BlockDevice) error {
+	if len(devices) == 0 {
+		return nil
+	}
+	info := make([]BlockDeviceInfo, len(devices))
+	for j, device := range devices {
+		info[j] = BlockDeviceInfo{
+			DeviceName:     device.Name(),
+			DeviceLinks:    device.Links(),
+			Label:          device.Label(),
+			UUID:           device.UUID(),
+			HardwareId:     device.HardwareID(),
+			BusAddress:     device.BusAddress(),
+			Size:           device.Size(),
+			FilesystemType: device.FilesystemType(),
+			InUse:          device.InUse(),
+			MountPoint:     device.MountPoint(),
+		}
+	}
+	return setMachineBlockDevices(i.st, machineId, info)
+}
+
 func (i *importer) machinePortsOps(m description.Machine) []txn.Op {
 	var result []txn.Op
 	machineID := m.Id()
@@ -418,9 +488,39 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		SupportedContainersKnown: supportedSet,
 		SupportedContainers:      supportedContainers,
 		Placement:                m.Placement(),
+		Volumes:                  i.machineVolumes(id),
+		Filesystems:              i.machineFilesystems(id),
 	}, nil
 }
 
+// machineVolumes returns the names of the volumes in the model that are
+// attached to the specified machine.
+func (i *importer) machineVolumes(machineId string) []string {
+	var result []string
+	for _, volume := range i.model.Volumes() {
+		for _, attachment := range volume.Attachments() {
+			if attachment.Machine().Id() == machineId {
+				result = append(result, volume.Tag().Id())
+			}
+		}
+	}
+	return result
+}
+
+// machineFilesystems returns the names of the filesystems in the model that
+// are attached to the specified machine.
+func (i *importer) machineFilesystems(machineId string) []string {
+	var result []string
+	for _, filesystem := range i.model.Filesystems() {
+		for _, attachment := range filesystem.Attachments() {
+			if attachment.Machine().Id() == machineId {
+				result = append(result, filesystem.Tag().Id())
+			}
+		}
+	}
+	return result
+}
+
 func (i *importer) makeMachineJobs(jobs []string) ([]MachineJob, error) {
 	// At time of writing, there are three valid jobs. If any jobs gets
 	// deprecated or changed in the future, older models that specify those
@@ -538,10 +638,10 @@ func (i *importer) service(s description.Service) error {
 	// TODO: update never set malarky... maybe...
 
 	ops := addServiceOps(i.st, addServiceOpsArgs{
-		serviceDoc:  sdoc,
-		statusDoc:   statusDoc,
-		constraints: i.constraints(s.Constraints()),
-		// storage          TODO,
+		serviceDoc:         sdoc,
+		statusDoc:          statusDoc,
+		constraints:        i.constraints(s.Constraints()),
+		storage:            i.storageConstraints(s.StorageConstraints()),
 		settings:           s.Settings(),
 		settingsRefCount:   s.SettingsRefCount(),
 		leadershipSettings: s.LeadershipSettings(),
@@ -579,6 +679,21 @@ func (i *importer) service(s description.Service) error {
 	return nil
 }
 
+func (i *importer) storageConstraints(cons map[string]description.StorageConstraint) map[string]StorageConstraints {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]StorageConstraints)
+	for key, value := range cons {
+		result[key] = StorageConstraints{
+			Pool:  value.Pool(),
+			Size:  value.Size(),
+			Count: value.Count(),
+		}
+	}
+	return result
+}
+
 func (i *importer) unit(s description.Service, u description.Unit) error {
 	i.logger.Debugf("importing unit %s", u.Name())
 
@@ -667,6 +782,8 @@ func (i *importer) makeServiceDoc(s description.Service) (*serviceDoc, error) {
 		UnitCount:            len(s.Units()),
 		RelationCount:        i.relationCount(s.Name()),
 		Exposed:              s.Exposed(),
+		ExposedToCIDRs:       s.ExposedToCIDRs(),
+		ExposedToSpaces:      s.ExposedToSpaces(),
 		MinUnits:             s.MinUnits(),
 		MetricCredentials:    s.MetricsCredentials(),
 	}, nil
@@ -705,20 +822,32 @@ func (i *importer) makeUnitDoc(s description.Service, u description.Unit) (*unit
 	}
 
 	return &unitDoc{
-		Name:         u.Name(),
-		Service:      s.Name(),
-		Series:       s.Series(),
-		CharmURL:     charmUrl,
-		Principal:    u.Principal().Id(),
-		Subordinates: subordinates,
-		// StorageAttachmentCount int `bson:"storageattachmentcount"`
-		MachineId:    u.Machine().Id(),
-		Tools:        i.makeTools(u.Tools()),
-		Life:         Alive,
-		PasswordHash: u.PasswordHash(),
+		Name:                   u.Name(),
+		Service:                s.Name(),
+		Series:                 s.Series(),
+		CharmURL:               charmUrl,
+		Principal:              u.Principal().Id(),
+		Subordinates:           subordinates,
+		StorageAttachmentCount: i.unitStorageAttachmentCount(u.Tag()),
+		MachineId:              u.Machine().Id(),
+		Tools:                  i.makeTools(u.Tools()),
+		Life:                   Alive,
+		PasswordHash:           u.PasswordHash(),
 	}, nil
 }
 
+func (i *importer) unitStorageAttachmentCount(unit names.UnitTag) int {
+	count := 0
+	for _, s := range i.model.Storages() {
+		for _, tag := range s.Attachments() {
+			if tag == unit {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (i *importer) relations() error {
 	i.logger.Debugf("importing relations")
 	for _, r := range i.model.Relations() {
@@ -800,6 +929,672 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	return doc
 }
 
+func (i *importer) spaces() error {
+	i.logger.Debugf("importing spaces")
+	for _, s := range i.model.Spaces() {
+		// The subnets are added after the spaces, and refer to their
+		// space by name.
+		_, err := i.st.AddSpace(s.Name(), network.Id(s.ProviderID()), nil, s.Public())
+		if err != nil {
+			i.logger.Errorf("error importing space %s: %s", s.Name(), err)
+			return errors.Annotate(err, s.Name())
+		}
+	}
+	i.logger.Debugf("importing spaces succeeded")
+	return nil
+}
+
+func (i *importer) subnets() error {
+	i.logger.Debugf("importing subnets")
+	for _, subnet := range i.model.Subnets() {
+		_, err := i.st.AddSubnet(SubnetInfo{
+			CIDR:              subnet.CIDR(),
+			ProviderId:        network.Id(subnet.ProviderId()),
+			VLANTag:           subnet.VLANTag(),
+			AvailabilityZone:  subnet.AvailabilityZone(),
+			SpaceName:         subnet.SpaceName(),
+			AllocatableIPHigh: subnet.AllocatableIPHigh(),
+			AllocatableIPLow:  subnet.AllocatableIPLow(),
+		})
+		if err != nil {
+			return errors.Annotate(err, subnet.CIDR())
+		}
+	}
+	i.logger.Debugf("importing subnets succeeded")
+	return nil
+}
+
+func (i *importer) linklayerdevices() error {
+	i.logger.Debugf("importing link-layer devices")
+	// Devices are grouped by machine, preserving the order of the
+	// machines in the model so hosts are processed before containers.
+	var machineIds []string
+	devices := make(map[string][]LinkLayerDeviceArgs)
+	for _, device := range i.model.LinkLayerDevices() {
+		machineId := device.MachineID()
+		if _, found := devices[machineId]; !found {
+			machineIds = append(machineIds, machineId)
+		}
+		devices[machineId] = append(devices[machineId], LinkLayerDeviceArgs{
+			Name:        device.Name(),
+			MTU:         device.MTU(),
+			ProviderID:  network.Id(device.ProviderID()),
+			Type:        LinkLayerDeviceType(device.Type()),
+			MACAddress:  device.MACAddress(),
+			IsAutoStart: device.IsAutoStart(),
+			IsUp:        device.IsUp(),
+			ParentName:  device.ParentName(),
+		})
+	}
+	for _, machineId := range machineIds {
+		machine, err := i.st.Machine(machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := i.machineLinkLayerDevices(machine, devices[machineId]); err != nil {
+			return errors.Annotatef(err, "machine %s", machineId)
+		}
+	}
+	i.logger.Debugf("importing link-layer devices succeeded")
+	return nil
+}
+
+// machineLinkLayerDevices sets the devices on the machine, making sure that
+// parent devices are set before their children. Container devices may refer
+// to a parent on the host machine using its global key, and these parents
+// were set when the host's devices were imported.
+func (i *importer) machineLinkLayerDevices(machine *Machine, devicesArgs []LinkLayerDeviceArgs) error {
+	seenNames := set.NewStrings("") // sentinel for empty ParentName.
+	for {
+		var argsToSet []LinkLayerDeviceArgs
+		for _, args := range devicesArgs {
+			if seenNames.Contains(args.Name) {
+				continue
+			}
+			if seenNames.Contains(args.ParentName) || strings.Contains(args.ParentName, "#") {
+				argsToSet = append(argsToSet, args)
+			}
+		}
+		if len(argsToSet) == 0 {
+			break
+		}
+		if err := machine.SetLinkLayerDevices(argsToSet...); err != nil {
+			return errors.Trace(err)
+		}
+		for _, args := range argsToSet {
+			seenNames.Add(args.Name)
+		}
+	}
+	if missing := len(devicesArgs) - (seenNames.Size() - 1); missing != 0 {
+		return errors.NotValidf("%d link-layer devices with unknown parents", missing)
+	}
+	return nil
+}
+
+func (i *importer) ipaddresses() error {
+	i.logger.Debugf("importing ip addresses")
+	for _, addr := range i.model.IPAddresses() {
+		machine, err := i.st.Machine(addr.MachineID())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cidrAddress, err := ipAddressCIDR(addr.Value(), addr.SubnetCIDR())
+		if err != nil {
+			return errors.Annotatef(err, "ip address %s", addr.Value())
+		}
+		err = machine.SetDevicesAddresses(LinkLayerDeviceAddress{
+			DeviceName:       addr.DeviceName(),
+			ConfigMethod:     AddressConfigMethod(addr.ConfigMethod()),
+			ProviderID:       network.Id(addr.ProviderID()),
+			CIDRAddress:      cidrAddress,
+			DNSServers:       addr.DNSServers(),
+			DNSSearchDomains: addr.DNSSearchDomains(),
+			GatewayAddress:   addr.GatewayAddress(),
+		})
+		if err != nil {
+			return errors.Annotatef(err, "ip address %s", addr.Value())
+		}
+	}
+	i.logger.Debugf("importing ip addresses succeeded")
+	return nil
+}
+
+// ipAddressCIDR combines the address value with the prefix size of
+// its subnet. Addresses exported without a subnet CIDR are given a
+// host-length prefix, so they are imported as machine-local addresses.
+func ipAddressCIDR(value, subnetCIDR string) (string, error) {
+	if subnetCIDR == "" {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return "", errors.NotValidf("address %q", value)
+		}
+		prefixSize := 8 * net.IPv6len
+		if ip.To4() != nil {
+			prefixSize = 8 * net.IPv4len
+		}
+		return fmt.Sprintf("%s/%d", value, prefixSize), nil
+	}
+	_, ipNet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	prefixSize, _ := ipNet.Mask.Size()
+	return fmt.Sprintf("%s/%d", value, prefixSize), nil
+}
+
+func (i *importer) storage() error {
+	if err := i.storagePools(); err != nil {
+		return errors.Annotate(err, "storage pools")
+	}
+	if err := i.storageInstances(); err != nil {
+		return errors.Annotate(err, "storage instances")
+	}
+	if err := i.volumes(); err != nil {
+		return errors.Annotate(err, "volumes")
+	}
+	if err := i.filesystems(); err != nil {
+		return errors.Annotate(err, "filesystems")
+	}
+	return nil
+}
+
+func (i *importer) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(i.st))
+	for _, pool := range i.model.StoragePools() {
+		_, err := pm.Create(pool.Name(), storage.ProviderType(pool.Provider()), pool.Attributes())
+		if err != nil {
+			return errors.Annotatef(err, "creating pool %q", pool.Name())
+		}
+	}
+	return nil
+}
+
+func (i *importer) storageInstances() error {
+	i.logger.Debugf("importing storage instances")
+	for _, s := range i.model.Storages() {
+		if err := i.storageInstance(s); err != nil {
+			return errors.Annotate(err, s.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing storage instances succeeded")
+	return nil
+}
+
+func (i *importer) storageInstance(s description.Storage) error {
+	owner, err := s.Owner()
+	if err != nil {
+		return errors.Annotate(err, "storage owner")
+	}
+	var ownerTag string
+	var charmURL *charm.URL
+	if owner != nil {
+		ownerTag = owner.String()
+		charmURL, err = i.storageCharmURL(owner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	attachments := s.Attachments()
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     s.Tag().Id(),
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:              s.Tag().Id(),
+			Kind:            parseStorageKind(s.Kind()),
+			Life:            Alive,
+			Owner:           ownerTag,
+			StorageName:     s.Name(),
+			AttachmentCount: len(attachments),
+			CharmURL:        charmURL,
+		},
+	}}
+	for _, unit := range attachments {
+		ops = append(ops, createStorageAttachmentOp(s.Tag(), unit))
+	}
+	return i.st.runTransaction(ops)
+}
+
+// storageCharmURL returns the charm URL of the service that owns, or whose
+// unit owns, the storage.
+func (i *importer) storageCharmURL(owner names.Tag) (*charm.URL, error) {
+	var serviceName string
+	switch tag := owner.(type) {
+	case names.ServiceTag:
+		serviceName = tag.Id()
+	case names.UnitTag:
+		name, err := names.UnitService(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		serviceName = name
+	default:
+		return nil, nil
+	}
+	for _, service := range i.model.Services() {
+		if service.Name() == serviceName {
+			return charm.ParseURL(service.CharmURL())
+		}
+	}
+	return nil, errors.NotFoundf("service %q", serviceName)
+}
+
+func (i *importer) volumes() error {
+	i.logger.Debugf("importing volumes")
+	for _, volume := range i.model.Volumes() {
+		if err := i.volume(volume); err != nil {
+			return errors.Annotate(err, volume.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing volumes succeeded")
+	return nil
+}
+
+func (i *importer) volume(volume description.Volume) error {
+	attachments := volume.Attachments()
+	tag := volume.Tag()
+	doc := volumeDoc{
+		Name:            tag.Id(),
+		StorageId:       volume.Storage().Id(),
+		Life:            Alive,
+		AttachmentCount: len(attachments),
+	}
+	binding, err := volume.Binding()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if binding != nil {
+		doc.Binding = binding.String()
+	}
+	if volume.Provisioned() {
+		doc.Info = &VolumeInfo{
+			HardwareId: volume.HardwareID(),
+			Size:       volume.Size(),
+			Pool:       volume.Pool(),
+			VolumeId:   volume.VolumeID(),
+			Persistent: volume.Persistent(),
+		}
+	} else {
+		doc.Params = &VolumeParams{
+			Size: volume.Size(),
+			Pool: volume.Pool(),
+		}
+	}
+	globalKey := volumeGlobalKey(tag.Id())
+	ops := []txn.Op{
+		createStatusOp(i.st, globalKey, i.makeStatusDoc(volume.Status())),
+		{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.addVolumeAttachmentOp(tag.Id(), attachment))
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if err := i.importStatusHistory(globalKey, volume.StatusHistory()); err != nil {
+		return errors.Annotate(err, "status history")
+	}
+	return nil
+}
+
+func (i *importer) addVolumeAttachmentOp(volumeId string, attachment description.VolumeAttachment) txn.Op {
+	machineId := attachment.Machine().Id()
+	doc := volumeAttachmentDoc{
+		Volume:  volumeId,
+		Machine: machineId,
+		Life:    Alive,
+	}
+	if attachment.Provisioned() {
+		doc.Info = &VolumeAttachmentInfo{
+			DeviceName: attachment.DeviceName(),
+			DeviceLink: attachment.DeviceLink(),
+			BusAddress: attachment.BusAddress(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		doc.Params = &VolumeAttachmentParams{
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+	return txn.Op{
+		C:      volumeAttachmentsC,
+		Id:     volumeAttachmentId(machineId, volumeId),
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}
+}
+
+func (i *importer) filesystems() error {
+	i.logger.Debugf("importing filesystems")
+	for _, fs := range i.model.Filesystems() {
+		if err := i.filesystem(fs); err != nil {
+			return errors.Annotate(err, fs.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing filesystems succeeded")
+	return nil
+}
+
+func (i *importer) filesystem(fs description.Filesystem) error {
+	attachments := fs.Attachments()
+	tag := fs.Tag()
+	doc := filesystemDoc{
+		FilesystemId:    tag.Id(),
+		StorageId:       fs.Storage().Id(),
+		VolumeId:        fs.Volume().Id(),
+		Life:            Alive,
+		AttachmentCount: len(attachments),
+	}
+	binding, err := fs.Binding()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if binding != nil {
+		doc.Binding = binding.String()
+	}
+	if fs.Provisioned() {
+		doc.Info = &FilesystemInfo{
+			Size:         fs.Size(),
+			Pool:         fs.Pool(),
+			FilesystemId: fs.FilesystemID(),
+		}
+	} else {
+		doc.Params = &FilesystemParams{
+			Size: fs.Size(),
+			Pool: fs.Pool(),
+		}
+	}
+	globalKey := filesystemGlobalKey(tag.Id())
+	ops := []txn.Op{
+		createStatusOp(i.st, globalKey, i.makeStatusDoc(fs.Status())),
+		{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.addFilesystemAttachmentOp(tag.Id(), attachment))
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if err := i.importStatusHistory(globalKey, fs.StatusHistory()); err != nil {
+		return errors.Annotate(err, "status history")
+	}
+	return nil
+}
+
+func (i *importer) addFilesystemAttachmentOp(fsId string, attachment description.FilesystemAttachment) txn.Op {
+	machineId := attachment.Machine().Id()
+	doc := filesystemAttachmentDoc{
+		Filesystem: fsId,
+		Machine:    machineId,
+		Life:       Alive,
+	}
+	if attachment.Provisioned() {
+		doc.Info = &FilesystemAttachmentInfo{
+			MountPoint: attachment.MountPoint(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		doc.Params = &FilesystemAttachmentParams{
+			Location: attachment.MountPoint(),
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+	return txn.Op{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(machineId, fsId),
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}
+}
+
+func (i *importer) resources() error {
+	i.logger.Debugf("importing resources")
+	persist := NewResourcePersistence(i.st.newPersistence())
+	for _, r := range i.model.Resources() {
+		if err := i.resource(persist, r); err != nil {
+			return errors.Annotatef(err, "resource %s/%s", r.Service(), r.Name())
+		}
+	}
+	i.logger.Debugf("importing resources succeeded")
+	return nil
+}
+
+func (i *importer) resource(persist *ResourcePersistence, r description.Resource) error {
+	// This matches the ID format used by the resources component.
+	id := fmt.Sprintf("%s/%s", r.Service(), r.Name())
+
+	// Only the metadata is written here. The resource content lives in
+	// the source controller's blob store, and is transferred separately
+	// once the model has been imported (see ImportResourceBlob).
+	res, err := i.makeResource(id, r.Service(), r.Name(), r.Revision())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := persist.SetResource(res); err != nil {
+		return errors.Trace(err)
+	}
+
+	if storeRevision := r.CharmStoreRevision(); storeRevision != nil {
+		storeRes, err := i.makeCharmResource(r.Name(), storeRevision)
+		if err != nil {
+			return errors.Annotate(err, "charm store revision")
+		}
+		if err := persist.SetCharmStoreResource(id, r.Service(), storeRes, GetClock().Now()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for unit, revision := range r.UnitRevisions() {
+		unitRes, err := i.makeResource(id, r.Service(), r.Name(), revision)
+		if err != nil {
+			return errors.Annotatef(err, "unit %s revision", unit)
+		}
+		if err := persist.SetUnitResource(unit, unitRes); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (i *importer) makeResource(id, serviceID, name string, revision description.ResourceRevision) (resource.Resource, error) {
+	chRes, err := i.makeCharmResource(name, revision)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return resource.Resource{
+		Resource:  chRes,
+		ID:        id,
+		ServiceID: serviceID,
+		Username:  revision.Username(),
+		Timestamp: revision.Timestamp(),
+	}, nil
+}
+
+func (i *importer) makeCharmResource(name string, revision description.ResourceRevision) (charmresource.Resource, error) {
+	var res charmresource.Resource
+	resType, err := charmresource.ParseType(revision.Type())
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(revision.Origin())
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	fpSum, err := hex.DecodeString(revision.FingerprintHex())
+	if err != nil {
+		return res, errors.Annotate(err, "fingerprint")
+	}
+	fp, err := resource.DeserializeFingerprint(fpSum)
+	if err != nil {
+		return res, errors.Annotate(err, "fingerprint")
+	}
+	return charmresource.Resource{
+		Meta: charmresource.Meta{
+			Name:        name,
+			Type:        resType,
+			Path:        revision.Path(),
+			Description: revision.Description(),
+		},
+		Origin:      origin,
+		Revision:    revision.Revision(),
+		Fingerprint: fp,
+		Size:        revision.Size(),
+	}, nil
+}
+
+func (i *importer) payloads() error {
+	i.logger.Debugf("importing payloads")
+	for _, p := range i.model.Payloads() {
+		if err := i.payload(p); err != nil {
+			return errors.Annotatef(err, "payload %s", p.Name())
+		}
+	}
+	i.logger.Debugf("importing payloads succeeded")
+	return nil
+}
+
+func (i *importer) payload(p description.Payload) error {
+	unit, err := i.st.Unit(p.Unit().Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitPayloads, err := i.st.UnitPayloads(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return unitPayloads.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: p.Name(),
+			Type: p.Type(),
+		},
+		ID:     p.RawID(),
+		Status: p.State(),
+		Labels: p.Labels(),
+		Unit:   unit.Name(),
+	})
+}
+
+func (i *importer) actions() error {
+	i.logger.Debugf("importing actions")
+	for _, a := range i.model.Actions() {
+		if err := i.action(a); err != nil {
+			return errors.Annotatef(err, "action %s", a.Id())
+		}
+	}
+	i.logger.Debugf("importing actions succeeded")
+	return nil
+}
+
+func (i *importer) action(a description.Action) error {
+	modelUUID := i.st.ModelUUID()
+	var logs []ActionMessage
+	for _, m := range a.Messages() {
+		logs = append(logs, ActionMessage{
+			Timestamp: m.Timestamp(),
+			Message:   m.Message(),
+		})
+	}
+	doc := &actionDoc{
+		DocId:      i.st.docID(a.Id()),
+		ModelUUID:  modelUUID,
+		Receiver:   a.Receiver(),
+		Name:       a.Name(),
+		Parameters: a.Parameters(),
+		Enqueued:   a.Enqueued(),
+		Started:    a.Started(),
+		Completed:  a.Completed(),
+		Status:     ActionStatus(a.Status()),
+		Message:    a.Message(),
+		Results:    a.Results(),
+
+		Timeout:         a.Timeout(),
+		CancelRequested: a.CancelRequested(),
+		Logs:            logs,
+	}
+	ops := []txn.Op{{
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	// Actions that haven't finished yet need their notification so
+	// that the receiver still sees them.
+	if doc.Status == ActionPending || doc.Status == ActionRunning {
+		notificationDoc := &actionNotificationDoc{
+			DocId:     i.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+			ModelUUID: modelUUID,
+			Receiver:  a.Receiver(),
+			ActionID:  a.Id(),
+
+			CancelRequested: a.CancelRequested(),
+		}
+		ops = append(ops, txn.Op{
+			C:      actionNotificationsC,
+			Id:     notificationDoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: notificationDoc,
+		})
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (i *importer) metricBatches() error {
+	i.logger.Debugf("importing metric batches")
+	for _, b := range i.model.MetricBatches() {
+		if err := i.metricBatch(b); err != nil {
+			return errors.Annotatef(err, "metric batch %s", b.UUID())
+		}
+	}
+	i.logger.Debugf("importing metric batches succeeded")
+	return nil
+}
+
+func (i *importer) metricBatch(b description.MetricBatch) error {
+	var metrics []Metric
+	for _, m := range b.Metrics() {
+		metrics = append(metrics, Metric{
+			Key:   m.Key(),
+			Value: m.Value(),
+			Time:  m.Time(),
+		})
+	}
+	// The metrics were validated against the charm when they were first
+	// added to the source model, so the doc is inserted directly.
+	doc := &metricBatchDoc{
+		UUID:        b.UUID(),
+		ModelUUID:   i.st.ModelUUID(),
+		Unit:        b.Unit().Id(),
+		CharmUrl:    b.CharmURL(),
+		Sent:        false,
+		Created:     b.Created(),
+		Metrics:     metrics,
+		Credentials: b.Credentials(),
+	}
+	ops := []txn.Op{{
+		C:      metricsC,
+		Id:     doc.UUID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (i *importer) importStatusHistory(globalKey string, history []description.Status) error {
 	docs := make([]interface{}, len(history))
 	for i, statusVal := range history {
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -18,6 +19,8 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -157,6 +160,7 @@ func (s *MigrationImportSuite) AssertUserEqual(c *gc.C, newUser, oldUser *state.
 	c.Assert(newUser.CreatedBy(), gc.Equals, oldUser.CreatedBy())
 	c.Assert(newUser.DateCreated(), gc.Equals, oldUser.DateCreated())
 	c.Assert(newUser.ReadOnly(), gc.Equals, oldUser.ReadOnly())
+	c.Assert(newUser.Access(), gc.Equals, oldUser.Access())
 
 	connTime, err := oldUser.LastConnection()
 	if state.IsNeverConnectedError(err) {
@@ -286,7 +290,7 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	err = service.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
 	// Expose the service.
-	c.Assert(service.SetExposed(), jc.ErrorIsNil)
+	c.Assert(service.SetExposedTo([]string{"10.0.0.0/8"}, nil), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(service, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, service, status.StatusActive, 5)
@@ -308,6 +312,7 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(imported.ServiceTag(), gc.Equals, exported.ServiceTag())
 	c.Assert(imported.Series(), gc.Equals, exported.Series())
 	c.Assert(imported.IsExposed(), gc.Equals, exported.IsExposed())
+	c.Assert(imported.ExposedToCIDRs(), jc.DeepEquals, exported.ExposedToCIDRs())
 	c.Assert(imported.MetricCredentials(), jc.DeepEquals, exported.MetricCredentials())
 
 	exportedConfig, err := exported.ConfigSettings()
@@ -515,3 +520,406 @@ func (m *mockModel) Config() map[string]interface{} {
 	c["name"] = m.name
 	return c
 }
+
+func (s *MigrationImportSuite) TestSpaces(c *gc.C) {
+	space, err := s.State.AddSpace("one", network.Id("provider"), nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Space(space.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(imported.Name(), gc.Equals, space.Name())
+	c.Assert(imported.ProviderId(), gc.Equals, space.ProviderId())
+}
+
+func (s *MigrationImportSuite) TestSubnets(c *gc.C) {
+	_, err := s.State.AddSpace("bam", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	original, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        network.Id("foo"),
+		VLANTag:           64,
+		AvailabilityZone:  "bar",
+		SpaceName:         "bam",
+		AllocatableIPHigh: "10.0.0.100",
+		AllocatableIPLow:  "10.0.0.10",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	subnet, err := newSt.Subnet(original.CIDR())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Assert(subnet.ProviderId(), gc.Equals, network.Id("foo"))
+	c.Assert(subnet.VLANTag(), gc.Equals, 64)
+	c.Assert(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Assert(subnet.SpaceName(), gc.Equals, "bam")
+	c.Assert(subnet.AllocatableIPHigh(), gc.Equals, "10.0.0.100")
+	c.Assert(subnet.AllocatableIPLow(), gc.Equals, "10.0.0.10")
+}
+
+func (s *MigrationImportSuite) TestLinkLayerDevicesAndAddresses(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
+	})
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "0.1.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	// The child device is listed first to check that parents are always
+	// set before their children.
+	err = machine.SetParentLinkLayerDevicesBeforeTheirChildren([]state.LinkLayerDeviceArgs{{
+		Name:       "foo.42",
+		Type:       state.VLAN_8021QDevice,
+		ParentName: "foo",
+	}, {
+		Name:       "foo",
+		Type:       state.EthernetDevice,
+		MACAddress: "aa:bb:cc:dd:ee:f0",
+		IsUp:       true,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:     "foo",
+		ConfigMethod:   state.StaticAddress,
+		CIDRAddress:    "0.1.2.3/24",
+		GatewayAddress: "0.1.2.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newMachine, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	devices, err := newMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, gc.HasLen, 2)
+
+	device, err := newMachine.LinkLayerDevice("foo.42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(device.ParentName(), gc.Equals, "foo")
+
+	addresses, err := newMachine.AllAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 1)
+	addr := addresses[0]
+	c.Assert(addr.Value(), gc.Equals, "0.1.2.3")
+	c.Assert(addr.DeviceName(), gc.Equals, "foo")
+	c.Assert(addr.SubnetCIDR(), gc.Equals, "0.1.2.0/24")
+	c.Assert(addr.ConfigMethod(), gc.Equals, state.StaticAddress)
+	c.Assert(addr.GatewayAddress(), gc.Equals, "0.1.2.1")
+}
+
+func (s *MigrationImportSuite) TestStoragePools(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
+		"value": 42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	pm = poolmanager.New(state.NewStateSettings(newSt))
+	pool, err := pm.Get("test-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Provider(), gc.Equals, provider.LoopProviderType)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{
+		"value": 42,
+	})
+}
+
+func (s *MigrationImportSuite) TestVolumes(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Volumes: []state.MachineVolumeParams{{
+			Volume:     state.VolumeParams{Pool: "loop", Size: 1234},
+			Attachment: state.VolumeAttachmentParams{ReadOnly: true},
+		}, {
+			Volume: state.VolumeParams{Pool: "loop", Size: 4000},
+		}},
+	})
+	machineTag := machine.MachineTag()
+	volTag := names.NewVolumeTag("0/0")
+	volInfo := state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		Pool:       "loop",
+		VolumeId:   "volume id",
+		Persistent: true,
+	}
+	err := s.State.SetVolumeInfo(volTag, volInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	volAttachmentInfo := state.VolumeAttachmentInfo{
+		DeviceName: "device name",
+		DeviceLink: "device link",
+		BusAddress: "bus address",
+		ReadOnly:   true,
+	}
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volTag, volAttachmentInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	volume, err := newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volume.LifeBinding(), gc.Equals, machineTag)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, volInfo)
+
+	attachment, err := newSt.VolumeAttachment(machineTag, volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attInfo, err := attachment.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attInfo, jc.DeepEquals, volAttachmentInfo)
+
+	volTag = names.NewVolumeTag("0/1")
+	volume, err = newSt.Volume(volTag)
+	c.Assert(err, jc.ErrorIsNil)
+	params, needsProvisioning := volume.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(params.Pool, gc.Equals, "loop")
+	c.Check(params.Size, gc.Equals, uint64(4000))
+
+	newMachine, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := newSt.MachineVolumeAttachments(newMachine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attachments, gc.HasLen, 2)
+}
+
+func (s *MigrationImportSuite) TestFilesystems(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Filesystems: []state.MachineFilesystemParams{{
+			Filesystem: state.FilesystemParams{Pool: "rootfs", Size: 1234},
+			Attachment: state.FilesystemAttachmentParams{
+				Location: "location",
+				ReadOnly: true},
+		}},
+	})
+	machineTag := machine.MachineTag()
+	fsTag := names.NewFilesystemTag("0/0")
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	filesystem, err := newSt.Filesystem(fsTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(filesystem.LifeBinding(), gc.Equals, machineTag)
+	params, needsProvisioning := filesystem.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(params.Pool, gc.Equals, "rootfs")
+	c.Check(params.Size, gc.Equals, uint64(1234))
+
+	attachment, err := newSt.FilesystemAttachment(machineTag, fsTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attParams, needsProvisioning := attachment.Params()
+	c.Check(needsProvisioning, jc.IsTrue)
+	c.Check(attParams.Location, gc.Equals, "location")
+	c.Check(attParams.ReadOnly, jc.IsTrue)
+}
+
+func (s *MigrationImportSuite) TestStorageInstances(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": state.StorageConstraints{Pool: "loop", Size: 1024, Count: 1},
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag := names.NewStorageTag("data/0")
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	instance, err := newSt.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instance.Kind(), gc.Equals, state.StorageKindBlock)
+	owner, ok := instance.Owner()
+	c.Check(ok, jc.IsTrue)
+	c.Check(owner, gc.Equals, unit.Tag())
+	c.Check(instance.StorageName(), gc.Equals, "data")
+	c.Check(instance.CharmURL(), jc.DeepEquals, ch.URL())
+
+	attachments, err := newSt.UnitStorageAttachments(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Check(attachments[0].StorageInstance(), gc.Equals, storageTag)
+}
+
+func (s *MigrationImportSuite) TestDetachedStorageInstances(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data":    state.StorageConstraints{Pool: "loop", Size: 1024, Count: 1},
+		"allecto": state.StorageConstraints{Pool: "loop", Size: 1024, Count: 1},
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	var storageTag, dataTag names.StorageTag
+	attachments, err := s.State.UnitStorageAttachments(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	for _, a := range attachments {
+		if strings.HasPrefix(a.StorageInstance().Id(), "allecto/") {
+			storageTag = a.StorageInstance()
+		} else {
+			dataTag = a.StorageInstance()
+		}
+	}
+	err = s.State.DetachStorage(storageTag, unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	instance, err := newSt.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(instance.Kind(), gc.Equals, state.StorageKindBlock)
+	_, ok := instance.Owner()
+	c.Check(ok, jc.IsFalse)
+	c.Check(instance.StorageName(), gc.Equals, "allecto")
+
+	attachments, err = newSt.UnitStorageAttachments(unit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Check(attachments[0].StorageInstance(), gc.Equals, dataTag)
+
+	// The detached storage may be attached to a unit in the new model.
+	newUnit, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = newSt.AttachStorage(storageTag, newUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MigrationImportSuite) TestStorageConstraints(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": state.StorageConstraints{Pool: "loop", Size: 1024, Count: 1},
+	}
+	s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newService, err := newSt.Service("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := newService.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, jc.DeepEquals, storage)
+}
+
+func (s *MigrationImportSuite) TestBlockDevices(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	devices := []state.BlockDeviceInfo{{
+		DeviceName:     "sda",
+		DeviceLinks:    []string{"/dev/disk/by-id/abc"},
+		Label:          "root",
+		UUID:           "some-uuid",
+		HardwareId:     "magic",
+		BusAddress:     "scsi@1:2.3.4",
+		Size:           1024,
+		FilesystemType: "ext4",
+		InUse:          true,
+		MountPoint:     "/",
+	}}
+	err := machine.SetMachineBlockDevices(devices...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newDevices, err := newSt.BlockDevices(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(newDevices, jc.DeepEquals, devices)
+}
+
+func (s *MigrationImportSuite) TestActions(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Service:     s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy")),
+		SetCharmURL: true,
+	})
+	action, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Receiver(), gc.Equals, unit.Name())
+	c.Check(imported.Name(), gc.Equals, "snapshot")
+	c.Check(imported.Parameters(), jc.DeepEquals, action.Parameters())
+	c.Check(imported.Status(), gc.Equals, state.ActionPending)
+
+	newUnit, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := newUnit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Check(pending[0].Id(), gc.Equals, action.Id())
+}
+
+func (s *MigrationImportSuite) TestCancelledRunningAction(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Service:     s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy")),
+		SetCharmURL: true,
+	})
+	action, err := unit.AddActionWithTimeout("snapshot", nil, 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Cancel()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("stopping")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	imported, err := newSt.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Status(), gc.Equals, state.ActionRunning)
+	c.Check(imported.Timeout(), gc.Equals, 5*time.Minute)
+	c.Check(imported.CancelRequested(), jc.IsTrue)
+	messages := imported.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Check(messages[0].Message, gc.Equals, "stopping")
+}
+
+func (s *MigrationImportSuite) TestMetricBatches(c *gc.C) {
+	now := time.Now().Round(time.Second).UTC()
+	batch := s.Factory.MakeMetric(c, &factory.MetricParams{Time: &now})
+
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The metrics collection is shared between models, so the source
+	// batch has to go before the import can recreate it.
+	err = batch.SetSent(now.Add(-2 * state.CleanupAge))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CleanupOldMetrics()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	in := newModel(out, uuid, "new")
+	_, newSt, err := s.State.Import(in)
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	imported, err := newSt.MetricBatchesForUnit(batch.Unit())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, gc.HasLen, 1)
+	c.Check(imported[0].UUID(), gc.Equals, batch.UUID())
+	c.Check(imported[0].CharmURL(), gc.Equals, batch.CharmURL())
+	c.Check(imported[0].Created().Equal(now), jc.IsTrue)
+	c.Check(imported[0].Sent(), jc.IsFalse)
+	c.Check(imported[0].Metrics(), gc.HasLen, 1)
+}
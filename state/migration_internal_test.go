@@ -6,6 +6,7 @@ package state
 import (
 	"reflect"
 
+	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -29,6 +30,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		statusesHistoryC,
 
 		// machine
+		blockDevicesC,
 		instanceDataC,
 		machinesC,
 		openedPortsC,
@@ -45,6 +47,32 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// relation
 		relationsC,
 		relationScopesC,
+
+		// storage
+		filesystemsC,
+		storageConstraintsC,
+		filesystemAttachmentsC,
+		storageInstancesC,
+		storageAttachmentsC,
+		volumesC,
+		volumeAttachmentsC,
+
+		// network
+		ipAddressesC,
+		linkLayerDevicesC,
+		subnetsC,
+		spacesC,
+
+		// resources and payloads
+		"payloads",
+		"resources",
+
+		// actions
+		actionsC,
+		actionNotificationsC,
+
+		// unsent metrics
+		metricsC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -59,11 +87,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guisettingsC,
 		// Users aren't migrated.
 		usersC,
+		// Controller permissions belong to the controller, not the model.
+		controllerUsersC,
 		userLastLoginC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
-		// Metrics aren't migrated.
-		metricsC,
+		// Action results were folded into the action documents before
+		// models were introduced, so there is nothing to migrate.
+		actionresultsC,
 		// leaseC is deprecated in favour of leasesC.
 		leaseC,
 		// Backup and restore information is not migrated.
@@ -78,6 +109,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// temporary credentials in there; after migration you'll just have
 		// to log back in.
 		bakeryStorageItemsC,
+		// The audit log is a controller-wide record of past API calls,
+		// and stays with the controller that served them.
+		auditLogC,
+		// The latest metric values are served by the controller the
+		// metrics were sent from, and are recorded afresh by the target.
+		latestMetricsC,
 		// Transaction stuff.
 		"txns",
 		"txns.log",
@@ -86,6 +123,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsC,
 		migrationsStatusC,
 		migrationsActiveC,
+		migrationsMinionSyncC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -112,6 +150,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The SSH host keys for each machine will be reported as each
 		// machine agent starts up.
 		sshHostKeysC,
+
+		// The link-layer device reference counts are repopulated as the
+		// devices are added during import.
+		linkLayerDevicesRefsC,
+
+		// Metric failures are a short-lived debugging record and
+		// are not migrated.
+		metricFailuresC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -124,31 +170,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// service / unit
 		charmsC,
-		"payloads",
-		"resources",
 		endpointBindingsC,
 
 		// storage
-		blockDevicesC,
-		filesystemsC,
-		filesystemAttachmentsC,
-		storageInstancesC,
-		storageAttachmentsC,
-		storageConstraintsC,
-		volumesC,
-		volumeAttachmentsC,
-
-		// network
-		ipAddressesC,
-		linkLayerDevicesC,
-		linkLayerDevicesRefsC,
-		subnetsC,
-		spacesC,
-
-		// actions
-		actionsC,
-		actionNotificationsC,
-		actionresultsC,
+		volumeSnapshotsC,
 
 		// uncategorised
 		metricsManagerC, // should really be copied across
@@ -244,16 +269,18 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"SupportedContainers",
 		"SupportedContainersKnown",
 		"Tools",
+		// Volumes and Filesystems are derived from the attachments of
+		// the exported volumes and filesystems.
+		"Volumes",
+		"Filesystems",
 
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
 	)
 	todo := set.NewStrings(
-		"Volumes",
 		"NoVote",
 		"Clean",
-		"Filesystems",
 		"HasVote",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
@@ -309,6 +336,8 @@ func (s *MigrationSuite) TestServiceDocFields(c *gc.C) {
 		"CharmModifiedVersion",
 		"ForceCharm",
 		"Exposed",
+		"ExposedToCIDRs",
+		"ExposedToSpaces",
 		"MinUnits",
 		"MetricCredentials",
 	)
@@ -354,12 +383,12 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Ports",
 		"PublicAddress",
 		"PrivateAddress",
-	)
-	todo := set.NewStrings(
+		// StorageAttachmentCount is derived from the attachments of
+		// the exported storage instances.
 		"StorageAttachmentCount",
 	)
 
-	s.AssertExportedFields(c, unitDoc{}, fields.Union(todo))
+	s.AssertExportedFields(c, unitDoc{}, fields)
 }
 
 func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
@@ -513,6 +542,215 @@ func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"DocID",
+		// Always alive, not explicitly exported.
+		"Life",
+		// AttachmentCount is handled by the number of attachments.
+		"AttachmentCount",
+		// CharmURL comes from the service owning the storage.
+		"CharmURL",
+		// Snapshot is only used when creating the storage
+		// instance's volume; export is refused until then,
+		// as volume snapshots are not migrated.
+		"Snapshot",
+	)
+	migrated := set.NewStrings(
+		"Id",
+		"Kind",
+		"Owner",
+		"StorageName",
+	)
+	s.AssertExportedFields(c, storageInstanceDoc{}, migrated.Union(ignored))
+}
+
+func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		"DocID",
+		"Life",
+		// AttachmentCount is handled by the number of attachments.
+		"AttachmentCount",
+	)
+	migrated := set.NewStrings(
+		"Name",
+		"StorageId",
+		"Binding",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, volumeDoc{}, migrated.Union(ignored))
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
+		"HardwareId", "Size", "Pool", "VolumeId", "Persistent"))
+	// Volume snapshots are not migrated, so export is refused while
+	// a volume with a SnapshotId is unprovisioned.
+	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
+		"Pool", "Size", "SnapshotId"))
+}
+
+func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		"DocID",
+		"Life",
+	)
+	migrated := set.NewStrings(
+		"Volume",
+		"Machine",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, volumeAttachmentDoc{}, migrated.Union(ignored))
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, VolumeAttachmentInfo{}, set.NewStrings(
+		"DeviceName", "DeviceLink", "BusAddress", "ReadOnly"))
+	s.AssertExportedFields(c, VolumeAttachmentParams{}, set.NewStrings(
+		"ReadOnly"))
+}
+
+func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		"DocID",
+		"Life",
+		// AttachmentCount is handled by the number of attachments.
+		"AttachmentCount",
+	)
+	migrated := set.NewStrings(
+		"FilesystemId",
+		"StorageId",
+		"VolumeId",
+		"Binding",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, filesystemDoc{}, migrated.Union(ignored))
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, FilesystemInfo{}, set.NewStrings(
+		"Size", "Pool", "FilesystemId"))
+	s.AssertExportedFields(c, FilesystemParams{}, set.NewStrings(
+		"Pool", "Size"))
+}
+
+func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		"DocID",
+		"Life",
+	)
+	migrated := set.NewStrings(
+		"Filesystem",
+		"Machine",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, filesystemAttachmentDoc{}, migrated.Union(ignored))
+	// The info and params fields are structs.
+	s.AssertExportedFields(c, FilesystemAttachmentInfo{}, set.NewStrings(
+		"MountPoint", "ReadOnly"))
+	s.AssertExportedFields(c, FilesystemAttachmentParams{}, set.NewStrings(
+		"Location", "ReadOnly"))
+}
+
+func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+	)
+	migrated := set.NewStrings(
+		// DocId is exported as the action id.
+		"DocId",
+		"Receiver",
+		"Name",
+		"Parameters",
+		"Enqueued",
+		"Started",
+		"Completed",
+		"Status",
+		"Message",
+		"Results",
+		"Timeout",
+		"CancelRequested",
+		"Logs",
+	)
+	s.AssertExportedFields(c, actionDoc{}, migrated.Union(ignored))
+}
+
+func (s *MigrationSuite) TestMetricBatchDocFields(c *gc.C) {
+	ignored := set.NewStrings(
+		"ModelUUID",
+		// Only unsent batches are migrated.
+		"Sent",
+		// DeleteTime is only set once a batch has been sent.
+		"DeleteTime",
+	)
+	migrated := set.NewStrings(
+		"UUID",
+		"Unit",
+		"CharmUrl",
+		"Created",
+		"Metrics",
+		"Credentials",
+	)
+	s.AssertExportedFields(c, metricBatchDoc{}, migrated.Union(ignored))
+	s.AssertExportedFields(c, Metric{}, set.NewStrings(
+		"Key", "Value", "Time"))
+}
+
+func (s *MigrationSuite) TestBlockDeviceFields(c *gc.C) {
+	s.AssertExportedFields(c, BlockDeviceInfo{}, set.NewStrings(
+		"DeviceName",
+		"DeviceLinks",
+		"Label",
+		"UUID",
+		"HardwareId",
+		"BusAddress",
+		"Size",
+		"FilesystemType",
+		"InUse",
+		"MountPoint",
+	))
+}
+
+func (s *MigrationSuite) TestStorageConstraintsFields(c *gc.C) {
+	s.AssertExportedFields(c, StorageConstraints{}, set.NewStrings(
+		"Pool", "Size", "Count"))
+}
+
+func (s *MigrationSuite) TestIPAddressCIDR(c *gc.C) {
+	for i, test := range []struct {
+		value    string
+		subnet   string
+		expected string
+	}{{
+		value:    "10.0.0.5",
+		subnet:   "10.0.0.0/24",
+		expected: "10.0.0.5/24",
+	}, {
+		value:    "10.0.0.5",
+		expected: "10.0.0.5/32",
+	}, {
+		value:    "fc00::5",
+		expected: "fc00::5/128",
+	}} {
+		c.Logf("test %d: %q in %q", i, test.value, test.subnet)
+		cidr, err := ipAddressCIDR(test.value, test.subnet)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(cidr, gc.Equals, test.expected)
+	}
+}
+
+func (s *MigrationSuite) TestIPAddressCIDRInvalid(c *gc.C) {
+	_, err := ipAddressCIDR("not-an-ip", "")
+	c.Check(err, gc.ErrorMatches, `address "not-an-ip" not valid`)
+	_, err = ipAddressCIDR("10.0.0.5", "bad")
+	c.Check(err, gc.ErrorMatches, `invalid CIDR address: bad`)
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := getExportedFields(doc)
 	unknown := expected.Difference(fields)
@@ -71,6 +71,24 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// Progress returns the counts of the work done so far by the
+	// migration.
+	Progress() MigrationProgress
+
+	// SetProgress records the counts of the work done so far by the
+	// migration.
+	SetProgress(progress MigrationProgress) error
+
+	// SubmitMinionReport records a report from a migration minion
+	// worker about the success or failure to complete its actions
+	// for a given migration phase.
+	SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error
+
+	// MinionReports returns details of the agents that have reported
+	// success or failure for the current migration phase, as well as
+	// those which are yet to report.
+	MinionReports() (*MinionReports, error)
+
 	// Refresh updates the contents of the ModelMigration from the
 	// underlying state.
 	Refresh() error
@@ -153,6 +171,53 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// Progress holds the counts of the work done so far by the
+	// migration.
+	Progress MigrationProgress `bson:"progress"`
+}
+
+// MigrationProgress holds counts of the work done by a model
+// migration, allowing a slow migration to be told apart from a stuck
+// one.
+type MigrationProgress struct {
+	// EntitiesExported holds the number of entities in the exported
+	// model description.
+	EntitiesExported int `bson:"entities-exported"`
+
+	// EntitiesImported holds the number of entities imported into
+	// the target controller.
+	EntitiesImported int `bson:"entities-imported"`
+
+	// BinaryBytes holds the number of bytes of resource content
+	// transferred to the target controller. Charms and tools are
+	// not transferred by the migration, so aren't counted.
+	BinaryBytes int64 `bson:"binary-bytes"`
+}
+
+// modelMigMinionSyncDoc records that an agent of the model being
+// migrated has completed (or failed to complete) its actions for a
+// migration phase. These are written into migrationsMinionSyncC.
+type modelMigMinionSyncDoc struct {
+	// Id has the format "<migration id>:<phase>:<agent tag>".
+	Id string `bson:"_id"`
+
+	MigrationId string `bson:"migration-id"`
+	Phase       string `bson:"phase"`
+	EntityTag   string `bson:"entity-tag"`
+
+	// Time holds the time the report was received (stored as per
+	// UnixNano).
+	Time    int64 `bson:"time"`
+	Success bool  `bson:"success"`
+}
+
+// MinionReports indicates which of the agents of a model being
+// migrated have reported on the current migration phase.
+type MinionReports struct {
+	Succeeded []names.Tag
+	Failed    []names.Tag
+	Unknown   []names.Tag
 }
 
 // Id implements ModelMigration.
@@ -301,6 +366,137 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// Progress implements ModelMigration.
+func (mig *modelMigration) Progress() MigrationProgress {
+	return mig.statusDoc.Progress
+}
+
+// SetProgress implements ModelMigration.
+func (mig *modelMigration) SetProgress(progress MigrationProgress) error {
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"progress": progress}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set migration progress")
+	}
+	mig.statusDoc.Progress = progress
+	return nil
+}
+
+// SubmitMinionReport implements ModelMigration.
+func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return errors.Errorf("unsupported agent tag %q", tag)
+	}
+	doc := modelMigMinionSyncDoc{
+		Id:          fmt.Sprintf("%s:%s:%s", mig.Id(), phase, tag),
+		MigrationId: mig.Id(),
+		Phase:       phase.String(),
+		EntityTag:   tag.String(),
+		Time:        GetClock().Now().UnixNano(),
+		Success:     success,
+	}
+	ops := []txn.Op{{
+		C:      migrationsMinionSyncC,
+		Id:     doc.Id,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	err := mig.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		// Agents may report more than once, but must not change
+		// their minds.
+		coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+		defer closer()
+		var existing modelMigMinionSyncDoc
+		if err := coll.FindId(doc.Id).One(&existing); err != nil {
+			return errors.Annotate(err, "checking existing report")
+		}
+		if existing.Success != success {
+			return errors.Errorf("conflicting reports received for %s/%s/%s",
+				mig.Id(), phase, tag)
+		}
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "failed to submit minion report")
+	}
+	return nil
+}
+
+// MinionReports implements ModelMigration.
+func (mig *modelMigration) MinionReports() (*MinionReports, error) {
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	agents, err := mig.allAgents()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+	defer closer()
+	query := coll.Find(bson.M{
+		"migration-id": mig.Id(),
+		"phase":        phase.String(),
+	})
+	var docs []modelMigMinionSyncDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "retrieving minion reports")
+	}
+	reported := make(map[string]bool)
+	for _, doc := range docs {
+		reported[doc.EntityTag] = doc.Success
+	}
+
+	// Reports from agents which have since gone away are ignored.
+	reports := new(MinionReports)
+	for _, tag := range agents {
+		success, ok := reported[tag.String()]
+		switch {
+		case !ok:
+			reports.Unknown = append(reports.Unknown, tag)
+		case success:
+			reports.Succeeded = append(reports.Succeeded, tag)
+		default:
+			reports.Failed = append(reports.Failed, tag)
+		}
+	}
+	return reports, nil
+}
+
+// allAgents returns the tags of the machine and unit agents of the
+// model being migrated.
+func (mig *modelMigration) allAgents() ([]names.Tag, error) {
+	var tags []names.Tag
+	machines, err := mig.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		tags = append(tags, machine.Tag())
+	}
+	services, err := mig.st.AllServices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, service := range services {
+		units, err := service.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			tags = append(tags, unit.Tag())
+		}
+	}
+	return tags, nil
+}
+
 // Refresh implements ModelMigration.
 func (mig *modelMigration) Refresh() error {
 	// Only the status document is updated. The modelMigDoc is static
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelMigrationSuite struct {
@@ -409,6 +410,79 @@ func (s *ModelMigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *ModelMigrationSuite) TestProgress(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Progress(), gc.Equals, state.MigrationProgress{})
+
+	progress := state.MigrationProgress{
+		EntitiesExported: 10,
+		EntitiesImported: 8,
+		BinaryBytes:      1024,
+	}
+	err = mig.SetProgress(progress)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Progress(), gc.Equals, progress)
+
+	mig2, err := s.State2.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Progress(), gc.Equals, progress)
+}
+
+func (s *ModelMigrationSuite) TestMinionReports(c *gc.C) {
+	f := factory.NewFactory(s.State2)
+	m0 := f.MakeMachine(c, nil)
+	m1 := f.MakeMachine(c, nil)
+	u0 := f.MakeUnit(c, &factory.UnitParams{Machine: m0})
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m0.Tag(), m1.Tag(), u0.Tag()})
+
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(m0.Tag(), phase, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(u0.Tag(), phase, false)
+	c.Assert(err, jc.ErrorIsNil)
+	// Reports for other phases are not counted.
+	err = mig.SubmitMinionReport(m1.Tag(), migration.ABORT, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err = mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.DeepEquals, []names.Tag{m0.Tag()})
+	c.Check(reports.Failed, jc.DeepEquals, []names.Tag{u0.Tag()})
+	c.Check(reports.Unknown, jc.DeepEquals, []names.Tag{m1.Tag()})
+}
+
+func (s *ModelMigrationSuite) TestDuplicateMinionReports(c *gc.C) {
+	m0 := factory.NewFactory(s.State2).MakeMachine(c, nil)
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.SubmitMinionReport(m0.Tag(), phase, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(m0.Tag(), phase, true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(m0.Tag(), phase, false)
+	c.Assert(err, gc.ErrorMatches, "conflicting reports received for .+/"+phase.String()+"/machine-0")
+}
+
+func (s *ModelMigrationSuite) TestMinionReportBadTag(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(names.NewUserTag("bob"), migration.QUIESCE, true)
+	c.Assert(err, gc.ErrorMatches, `unsupported agent tag "user-bob"`)
+}
+
 func (s *ModelMigrationSuite) TestWatchForModelMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createWatcher(c, s.State2)
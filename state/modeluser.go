@@ -46,10 +46,38 @@ const (
 	// being able to make any changes.
 	ModelReadAccess ModelAccess = "read"
 
+	// ModelWriteAccess allows a user to make changes to a model, such as
+	// deploying and scaling services, changing configuration and running
+	// actions, but not to destroy the model or change who can access it.
+	ModelWriteAccess ModelAccess = "write"
+
 	// ModelAdminAccess allows a user full control over the model.
 	ModelAdminAccess ModelAccess = "admin"
 )
 
+// modelAccessLevels orders the valid model access permissions, each
+// level granting everything granted by those before it.
+var modelAccessLevels = map[ModelAccess]int{
+	ModelReadAccess:  1,
+	ModelWriteAccess: 2,
+	ModelAdminAccess: 3,
+}
+
+// Validate returns an error if the access is not a valid model access
+// permission.
+func (a ModelAccess) Validate() error {
+	if _, ok := modelAccessLevels[a]; !ok {
+		return errors.NotValidf("model access %q", a)
+	}
+	return nil
+}
+
+// EqualOrGreaterThan returns whether the access grants at least the
+// permissions granted by other.
+func (a ModelAccess) EqualOrGreaterThan(other ModelAccess) bool {
+	return modelAccessLevels[a] >= modelAccessLevels[other]
+}
+
 // modelUserLastConnectionDoc is updated by the apiserver whenever the user
 // connects over the API. This update is not done using mgo.txn so the values
 // could well change underneath a normal transaction and as such, it should
@@ -111,9 +139,7 @@ func (e *ModelUser) Access() ModelAccess {
 
 // SetAccess changes the user's access permissions on the model.
 func (e *ModelUser) SetAccess(access ModelAccess) error {
-	switch access {
-	case ModelReadAccess, ModelAdminAccess:
-	default:
+	if err := access.Validate(); err != nil {
 		return errors.Errorf("invalid model access %q", access)
 	}
 	op := txn.Op{
@@ -237,6 +263,9 @@ func (st *State) AddModelUser(spec ModelUserSpec) (*ModelUser, error) {
 	if spec.Access == ModelUndefinedAccess {
 		spec.Access = ModelReadAccess
 	}
+	if err := spec.Access.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	modelUUID := st.ModelUUID()
 	op := createModelUserOp(modelUUID, spec.User, spec.CreatedBy, spec.DisplayName, nowToTheSecond(), spec.Access)
@@ -348,9 +377,17 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	return result, nil
 }
 
-// IsControllerAdministrator returns true if the user specified has access to the
-// controller model (the system model).
+// IsControllerAdministrator returns true if the user specified has
+// superuser access to the controller, or admin access to the controller
+// model (the system model).
 func (st *State) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	access, err := st.ControllerAccess(user)
+	if err == nil && access == ControllerSuperuserAccess {
+		return true, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+
 	ssinfo, err := st.ControllerInfo()
 	if err != nil {
 		return false, errors.Annotate(err, "could not get controller info")
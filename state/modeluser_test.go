@@ -102,6 +102,41 @@ func (s *ModelUserSuite) TestSetAccessModelUser(c *gc.C) {
 	c.Assert(modelUser.Access(), gc.Equals, state.ModelReadAccess)
 }
 
+func (s *ModelUserSuite) TestAddWriteModelUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	createdBy := s.Factory.MakeUser(c, &factory.UserParams{Name: "createdby"})
+	modelUser, err := s.State.AddModelUser(state.ModelUserSpec{
+		User: user.UserTag(), CreatedBy: createdBy.UserTag(), Access: state.ModelWriteAccess})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.ReadOnly(), jc.IsFalse)
+	c.Assert(modelUser.Access(), gc.Equals, state.ModelWriteAccess)
+
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsFalse)
+}
+
+func (s *ModelUserSuite) TestAddModelUserInvalidAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "validusername", NoModelUser: true})
+	_, err := s.State.AddModelUser(state.ModelUserSpec{
+		User: user.UserTag(), CreatedBy: s.Owner, Access: "root"})
+	c.Assert(err, gc.ErrorMatches, `model access "root" not valid`)
+}
+
+func (s *ModelUserSuite) TestSetAccessModelUserInvalid(c *gc.C) {
+	modelUser := s.Factory.MakeModelUser(c, nil)
+	err := modelUser.SetAccess("root")
+	c.Assert(err, gc.ErrorMatches, `invalid model access "root"`)
+}
+
+func (s *ModelUserSuite) TestModelAccessEqualOrGreaterThan(c *gc.C) {
+	c.Assert(state.ModelAdminAccess.EqualOrGreaterThan(state.ModelWriteAccess), jc.IsTrue)
+	c.Assert(state.ModelWriteAccess.EqualOrGreaterThan(state.ModelWriteAccess), jc.IsTrue)
+	c.Assert(state.ModelWriteAccess.EqualOrGreaterThan(state.ModelReadAccess), jc.IsTrue)
+	c.Assert(state.ModelReadAccess.EqualOrGreaterThan(state.ModelWriteAccess), jc.IsFalse)
+	c.Assert(state.ModelWriteAccess.EqualOrGreaterThan(state.ModelAdminAccess), jc.IsFalse)
+}
+
 func (s *ModelUserSuite) TestCaseUserNameVsId(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -80,7 +80,7 @@ func SetPayloadsComponent(epFunc newEnvPayloadsFunc, upFunc newUnitPayloadsFunc)
 // EnvPayloads exposes interaction with payloads in state.
 func (st *State) EnvPayloads() (EnvPayloads, error) {
 	if newEnvPayloads == nil {
-		return nil, errors.Errorf("payloads not supported")
+		return nil, errors.NotSupportedf("payloads")
 	}
 
 	persist := &payloadsEnvPersistence{
@@ -99,7 +99,7 @@ func (st *State) EnvPayloads() (EnvPayloads, error) {
 // for a the given unit.
 func (st *State) UnitPayloads(unit *Unit) (UnitPayloads, error) {
 	if newUnitPayloads == nil {
-		return nil, errors.Errorf("payloads not supported")
+		return nil, errors.NotSupportedf("payloads")
 	}
 
 	machineID, err := unit.AssignedMachineId()
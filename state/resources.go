@@ -37,6 +37,10 @@ type Resources interface {
 	// UpdatePendingResource adds the resource to blob storage and updates the metadata.
 	UpdatePendingResource(serviceID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error)
 
+	// ImportResourceBlob stores the content for a resource whose
+	// metadata was imported as part of a model migration.
+	ImportResourceBlob(serviceID, name string, r io.Reader) error
+
 	// OpenResource returns the metadata for a resource and a reader for the resource.
 	OpenResource(serviceID, name string) (resource.Resource, io.ReadCloser, error)
 
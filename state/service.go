@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/names"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/mgo.v2"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -48,6 +51,8 @@ type serviceDoc struct {
 	UnitCount            int        `bson:"unitcount"`
 	RelationCount        int        `bson:"relationcount"`
 	Exposed              bool       `bson:"exposed"`
+	ExposedToCIDRs       []string   `bson:"exposed-to-cidrs,omitempty"`
+	ExposedToSpaces      []string   `bson:"exposed-to-spaces,omitempty"`
 	MinUnits             int        `bson:"minunits"`
 	OwnerTag             string     `bson:"ownertag"`
 	TxnRevno             int64      `bson:"txn-revno"`
@@ -281,32 +286,100 @@ func (s *Service) IsExposed() bool {
 	return s.doc.Exposed
 }
 
-// SetExposed marks the service as exposed.
-// See ClearExposed and IsExposed.
+// ExposedToCIDRs returns the CIDRs to which the service's exposure was
+// restricted by SetExposedTo.
+func (s *Service) ExposedToCIDRs() []string {
+	return s.doc.ExposedToCIDRs
+}
+
+// ExposedToSpaces returns the names of the spaces to which the
+// service's exposure was restricted by SetExposedTo.
+func (s *Service) ExposedToSpaces() []string {
+	return s.doc.ExposedToSpaces
+}
+
+// SetExposed marks the service as exposed, with its open ports
+// accessible from anywhere. See ClearExposed and IsExposed.
 func (s *Service) SetExposed() error {
-	return s.setExposed(true)
+	return s.setExposed(true, nil, nil)
 }
 
-// ClearExposed removes the exposed flag from the service.
-// See SetExposed and IsExposed.
+// SetExposedTo marks the service as exposed, with its open ports
+// accessible only from the given CIDRs and from the subnets of the
+// given spaces. If neither are given, this is the same as SetExposed.
+func (s *Service) SetExposedTo(cidrs, spaces []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.Annotatef(errors.NotValidf("CIDR %q", cidr), "cannot expose service %q", s)
+		}
+	}
+	for _, name := range spaces {
+		if _, err := s.st.Space(name); err != nil {
+			return errors.Annotatef(err, "cannot expose service %q", s)
+		}
+	}
+	return s.setExposed(true, cidrs, spaces)
+}
+
+// ClearExposed removes the exposed flag, and any restrictions on it,
+// from the service. See SetExposed and IsExposed.
 func (s *Service) ClearExposed() error {
-	return s.setExposed(false)
+	return s.setExposed(false, nil, nil)
 }
 
-func (s *Service) setExposed(exposed bool) (err error) {
+func (s *Service) setExposed(exposed bool, cidrs, spaces []string) (err error) {
 	ops := []txn.Op{{
 		C:      servicesC,
 		Id:     s.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-to-cidrs", cidrs},
+			{"exposed-to-spaces", spaces},
+		}}},
 	}}
 	if err := s.st.runTransaction(ops); err != nil {
 		return fmt.Errorf("cannot set exposed flag for service %q to %v: %v", s, exposed, onAbort(err, errNotAlive))
 	}
 	s.doc.Exposed = exposed
+	s.doc.ExposedToCIDRs = cidrs
+	s.doc.ExposedToSpaces = spaces
 	return nil
 }
 
+// ExposedSourceCIDRs returns the CIDRs from which the open ports of an
+// exposed service may be accessed: those it was exposed to, and those
+// of the subnets in the spaces it was exposed to. An exposed service
+// without such restrictions may be accessed from anywhere. No CIDRs
+// are returned for a service that is not exposed.
+func (s *Service) ExposedSourceCIDRs() ([]string, error) {
+	if !s.doc.Exposed {
+		return nil, nil
+	}
+	if len(s.doc.ExposedToCIDRs) == 0 && len(s.doc.ExposedToSpaces) == 0 {
+		return []string{network.AllSourceCIDR}, nil
+	}
+	cidrs := set.NewStrings(s.doc.ExposedToCIDRs...)
+	for _, name := range s.doc.ExposedToSpaces {
+		space, err := s.st.Space(name)
+		if errors.IsNotFound(err) {
+			// A space that no longer exists has no subnets to
+			// allow access from.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := space.Subnets()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			cidrs.Add(subnet.CIDR())
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
 // Charm returns the service's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (s *Service) Charm() (ch *Charm, force bool, err error) {
@@ -867,7 +940,7 @@ const MessageWaitForAgentInit = "Waiting for agent initialization to finish"
 // service will be assigned to a given principal. The asserts param can be used
 // to include additional assertions for the service document.  This method
 // assumes that the service already exists in the db.
-func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []txn.Op, error) {
+func (s *Service) addUnitOps(principalName string, args AddUnitParams, asserts bson.D) (string, []txn.Op, error) {
 	var cons constraints.Value
 	if !s.doc.Subordinate {
 		scons, err := s.Constraints()
@@ -886,12 +959,12 @@ func (s *Service) addUnitOps(principalName string, asserts bson.D) (string, []tx
 	if err != nil {
 		return "", nil, err
 	}
-	args := serviceAddUnitOpsArgs{
+	names, ops, err := s.addUnitOpsWithCons(serviceAddUnitOpsArgs{
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
-	}
-	names, ops, err := s.addUnitOpsWithCons(args)
+		attachStorage: args.AttachStorage,
+	})
 	if err != nil {
 		return names, ops, err
 	}
@@ -905,6 +978,7 @@ type serviceAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+	attachStorage []names.StorageTag
 }
 
 // addServiceUnitOps is just like addUnitOps but explicitly takes a
@@ -930,7 +1004,7 @@ func (s *Service) addUnitOpsWithCons(args serviceAddUnitOpsArgs) (string, []txn.
 	}
 
 	// Create instances of the charm's declared stores.
-	storageOps, numStorageAttachments, err := s.unitStorageOps(name, args.storageCons)
+	storageOps, numStorageAttachments, err := s.unitStorageOps(name, args.storageCons, args.attachStorage)
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
@@ -1005,10 +1079,15 @@ func (s *Service) incUnitCountOp(asserts bson.D) txn.Op {
 }
 
 // unitStorageOps returns operations for creating storage
-// instances and attachments for a new unit. unitStorageOps
+// instances and attachments for a new unit, and for attaching
+// existing, detached storage instances to it. unitStorageOps
 // returns the number of initial storage attachments, to
 // initialise the unit's storage attachment refcount.
-func (s *Service) unitStorageOps(unitName string, cons map[string]StorageConstraints) (ops []txn.Op, numStorageAttachments int, err error) {
+func (s *Service) unitStorageOps(
+	unitName string,
+	cons map[string]StorageConstraints,
+	attachStorage []names.StorageTag,
+) (ops []txn.Op, numStorageAttachments int, err error) {
 	charm, _, err := s.Charm()
 	if err != nil {
 		return nil, -1, err
@@ -1016,16 +1095,52 @@ func (s *Service) unitStorageOps(unitName string, cons map[string]StorageConstra
 	meta := charm.Meta()
 	url := charm.URL()
 	tag := names.NewUnitTag(unitName)
+
+	// Attached storage counts towards the number of storage
+	// instances required by the constraints, so fewer new
+	// instances are created.
+	attachCounts := make(map[string]uint64)
+	for _, storageTag := range attachStorage {
+		si, err := s.st.storageInstance(storageTag)
+		if err != nil {
+			return nil, -1, errors.Annotatef(err, "attaching %s", names.ReadableString(storageTag))
+		}
+		name := si.doc.StorageName
+		if err := validateStorageAttach(si, meta, attachCounts[name]); err != nil {
+			return nil, -1, errors.Annotatef(err, "attaching %s", names.ReadableString(storageTag))
+		}
+		if err := s.st.validateMachineStorageDetached(si); err != nil {
+			return nil, -1, errors.Annotatef(err, "attaching %s", names.ReadableString(storageTag))
+		}
+		attachCounts[name]++
+		ops = append(ops, attachStorageOps(si, tag)...)
+		numStorageAttachments++
+	}
+	if len(attachCounts) > 0 {
+		remaining := make(map[string]StorageConstraints, len(cons))
+		for name, c := range cons {
+			if n := attachCounts[name]; n >= c.Count {
+				c.Count = 0
+			} else {
+				c.Count -= n
+			}
+			remaining[name] = c
+		}
+		cons = remaining
+	}
+
 	// TODO(wallyworld) - record constraints info in data model - size and pool name
-	ops, numStorageAttachments, err = createStorageOps(
+	createOps, numCreated, err := createStorageOps(
 		s.st, tag, meta, url, cons,
 		s.doc.Series,
+		"",    // no snapshot
 		false, // unit is not assigned yet; don't create machine storage
 	)
 	if err != nil {
 		return nil, -1, errors.Trace(err)
 	}
-	return ops, numStorageAttachments, nil
+	ops = append(ops, createOps...)
+	return ops, numStorageAttachments + numCreated, nil
 }
 
 // SCHEMACHANGE
@@ -1040,10 +1155,24 @@ func (s *Service) GetOwnerTag() string {
 	return owner
 }
 
+// AddUnitParams contains parameters for the Service.AddUnitWithParams method.
+type AddUnitParams struct {
+	// AttachStorage identifies storage instances to attach to the unit.
+	// The storage instances must have been detached from their previous
+	// units.
+	AttachStorage []names.StorageTag
+}
+
 // AddUnit adds a new principal unit to the service.
 func (s *Service) AddUnit() (unit *Unit, err error) {
+	return s.AddUnitWithParams(AddUnitParams{})
+}
+
+// AddUnitWithParams adds a new principal unit to the service, using the
+// supplied parameters.
+func (s *Service) AddUnitWithParams(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to service %q", s)
-	name, ops, err := s.addUnitOps("", nil)
+	name, ops, err := s.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
 	}
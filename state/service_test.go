@@ -1345,6 +1345,56 @@ func (s *ServiceSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ServiceSuite) TestServiceExposedTo(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("internal", "", []string{"192.168.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, []string{"internal"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(s.mysql.ExposedToSpaces(), jc.DeepEquals, []string{"internal"})
+	cidrs, err := s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedToCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Assert(s.mysql.ExposedToSpaces(), jc.DeepEquals, []string{"internal"})
+
+	// Exposing without restrictions allows access from anywhere.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedToCIDRs(), gc.HasLen, 0)
+	c.Assert(s.mysql.ExposedToSpaces(), gc.HasLen, 0)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"0.0.0.0/0"})
+
+	// Unexposing clears the restrictions.
+	err = s.mysql.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedToCIDRs(), gc.HasLen, 0)
+	cidrs, err = s.mysql.ExposedSourceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *ServiceSuite) TestServiceExposedToInvalid(c *gc.C) {
+	err := s.mysql.SetExposedTo([]string{"10.0.0.0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "mysql": CIDR "10.0.0.0" not valid`)
+	err = s.mysql.SetExposedTo(nil, []string{"missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot expose service "mysql": space "missing" not found`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
 func (s *ServiceSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
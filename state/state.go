@@ -188,7 +188,27 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		}
 	}
 
-	return st.runTransaction(ops)
+	// Metric batches live in a global collection, so the model's
+	// batches have to be found explicitly.
+	metrics, closer := st.getCollection(metricsC)
+	defer closer()
+	var metricIds []bson.M
+	err = metrics.Find(bson.D{{"model-uuid", st.ModelUUID()}}).Select(bson.D{{"_id", 1}}).All(&metricIds)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, id := range metricIds {
+		ops = append(ops, txn.Op{
+			C:      metricsC,
+			Id:     id["_id"],
+			Remove: true,
+		})
+	}
+
+	if err := st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return st.removeLatestMetrics()
 }
 
 // ForModel returns a connection to mongo for the specified model. The
@@ -1188,6 +1208,11 @@ type AddServiceArgs struct {
 	Placement        []*instance.Placement
 	Constraints      constraints.Value
 	Resources        map[string]string
+
+	// AttachStorage identifies detached storage instances to attach
+	// to the service's unit. It may only be specified when adding
+	// exactly one unit.
+	AttachStorage []names.StorageTag
 }
 
 // AddService creates a new service, running the supplied charm, with the
@@ -1206,6 +1231,9 @@ func (st *State) AddService(args AddServiceArgs) (service *Service, err error) {
 	if args.Charm == nil {
 		return nil, errors.Errorf("charm is nil")
 	}
+	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
+		return nil, errors.Errorf("AttachStorage is non-empty, but NumUnits is %d", args.NumUnits)
+	}
 
 	if err := validateCharmVersion(args.Charm); err != nil {
 		return nil, errors.Trace(err)
@@ -1393,7 +1421,11 @@ func (st *State) AddService(args AddServiceArgs) (service *Service, err error) {
 
 	// Collect unit-adding operations.
 	for x := 0; x < args.NumUnits; x++ {
-		unitName, unitOps, err := svc.addServiceUnitOps(serviceAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
+		unitName, unitOps, err := svc.addServiceUnitOps(serviceAddUnitOpsArgs{
+			cons:          args.Constraints,
+			storageCons:   args.Storage,
+			attachStorage: args.AttachStorage,
+		})
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
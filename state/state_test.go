@@ -2348,6 +2348,25 @@ func (s *StateSuite) TestWatchIPAddresses(c *gc.C) {
 	wc.AssertChangeInSingleEvent(addr.Value())
 }
 
+func (s *StateSuite) TestWatchSubnets(c *gc.C) {
+	w := s.State.WatchSubnets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent()
+
+	_, err := s.State.AddSpace("dmz", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", SpaceName: "dmz"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("10.0.0.0/24")
+
+	err = subnet.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("10.0.0.0/24")
+}
+
 func (s *StateSuite) TestWatchModelsBulkEvents(c *gc.C) {
 	// Alive model...
 	alive, err := s.State.Model()
@@ -2997,6 +3016,28 @@ func (s *StateSuite) TestRemoveImportingModelDocsImporting(c *gc.C) {
 	c.Assert(state.HostedModelCount(c, s.State), gc.Equals, 0)
 }
 
+func (s *StateSuite) TestRemoveImportingModelDocsRemovesMetrics(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st)
+	batch := f.MakeMetric(c, nil)
+	// A batch belonging to another model must survive.
+	other := s.Factory.MakeMetric(c, nil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = st.RemoveImportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.MetricBatch(batch.UUID())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.MetricBatch(other.UUID())
+	c.Check(err, jc.ErrorIsNil)
+}
+
 type attrs map[string]interface{}
 
 func (s *StateSuite) TestWatchModelConfig(c *gc.C) {
@@ -35,8 +35,9 @@ type StorageInstance interface {
 	Kind() StorageKind
 
 	// Owner returns the tag of the service or unit that owns this storage
-	// instance.
-	Owner() names.Tag
+	// instance, and a boolean indicating whether or not there is an owner.
+	// Storage that has been detached from its unit has no owner.
+	Owner() (names.Tag, bool)
 
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
@@ -48,6 +49,11 @@ type StorageInstance interface {
 
 	// CharmURL returns the charm URL that this storage instance was created with.
 	CharmURL() *charm.URL
+
+	// Snapshot returns the ID of the volume snapshot that the storage
+	// instance's volume is to be created from, and a boolean indicating
+	// whether or not the storage instance was created from a snapshot.
+	Snapshot() (string, bool)
 }
 
 // StorageAttachment represents the state of a unit's attachment to a storage
@@ -76,6 +82,31 @@ const (
 	StorageKindFilesystem
 )
 
+// String returns representation of StorageKind for readability.
+func (k StorageKind) String() string {
+	switch k {
+	case StorageKindBlock:
+		return "block"
+	case StorageKindFilesystem:
+		return "filesystem"
+	default:
+		return "unknown"
+	}
+}
+
+// parseStorageKind returns the StorageKind matching the string
+// representation returned by StorageKind.String.
+func parseStorageKind(value string) StorageKind {
+	switch value {
+	case "block":
+		return StorageKindBlock
+	case "filesystem":
+		return StorageKindFilesystem
+	default:
+		return StorageKindUnknown
+	}
+}
+
 type storageInstance struct {
 	st  *State
 	doc storageInstanceDoc
@@ -93,14 +124,17 @@ func (s *storageInstance) Kind() StorageKind {
 	return s.doc.Kind
 }
 
-func (s *storageInstance) Owner() names.Tag {
+func (s *storageInstance) Owner() (names.Tag, bool) {
+	if s.doc.Owner == "" {
+		return nil, false
+	}
 	tag, err := names.ParseTag(s.doc.Owner)
 	if err != nil {
-		// This should be impossible; we do not expose
-		// a means of modifying the owner tag.
+		// This should be impossible; the owner tag is
+		// only ever set to a valid tag or cleared.
 		panic(err)
 	}
-	return tag
+	return tag, true
 }
 
 func (s *storageInstance) StorageName() string {
@@ -116,6 +150,11 @@ func (s *storageInstance) CharmURL() *charm.URL {
 	return s.doc.CharmURL
 }
 
+// Snapshot is required to implement StorageInstance.
+func (s *storageInstance) Snapshot() (string, bool) {
+	return s.doc.Snapshot, s.doc.Snapshot != ""
+}
+
 // storageInstanceDoc describes a charm storage instance.
 type storageInstanceDoc struct {
 	DocID     string `bson:"_id"`
@@ -128,6 +167,7 @@ type storageInstanceDoc struct {
 	StorageName     string      `bson:"storagename"`
 	AttachmentCount int         `bson:"attachmentcount"`
 	CharmURL        *charm.URL  `bson:"charmurl"`
+	Snapshot        string      `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -330,6 +370,9 @@ func removeStorageInstanceOps(
 // instances to be created, keyed on the storage name. These constraints
 // will be correlated with the charm storage metadata for validation
 // and supplementing.
+//
+// If snapshot is non-empty, it is the ID of the volume snapshot from
+// which the volumes of the new storage instances will be created.
 func createStorageOps(
 	st *State,
 	entity names.Tag,
@@ -337,6 +380,7 @@ func createStorageOps(
 	curl *charm.URL,
 	cons map[string]StorageConstraints,
 	series string,
+	snapshot string,
 	machineOpsNeeded bool,
 ) (ops []txn.Op, numStorageAttachments int, err error) {
 
@@ -404,6 +448,7 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				CharmURL:    curl,
+				Snapshot:    snapshot,
 			}
 			if unit, ok := entity.(names.UnitTag); ok {
 				doc.AttachmentCount = 1
@@ -693,6 +738,16 @@ func removeStorageAttachmentOps(
 		Id:     si.doc.Id,
 		Update: bson.D{{"$inc", bson.D{{"attachmentcount", -1}}}},
 	}
+	if si.doc.Life == Alive && si.doc.Owner == "" {
+		// The storage has been detached from the unit, so its
+		// volume or filesystem must be detached from the unit's
+		// machine, so that it may be attached elsewhere.
+		detachOps, err := detachMachineStorageOps(st, si, names.NewUnitTag(s.doc.Unit))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	if si.doc.Life == Alive {
 		// This may be the last reference, but the storage instance is
 		// still alive. The storage instance will be removed when its
@@ -715,6 +770,346 @@ func removeStorageAttachmentOps(
 	return ops, nil
 }
 
+// detachMachineStorageOps returns txn.Ops to detach the volume or filesystem
+// of the storage instance from the machine that the unit is assigned to.
+func detachMachineStorageOps(st *State, si *storageInstance, unit names.UnitTag) ([]txn.Op, error) {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.doc.MachineId == "" {
+		// The unit was never assigned to a machine, so there
+		// is nothing to detach.
+		return nil, nil
+	}
+	machine := names.NewMachineTag(u.doc.MachineId)
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		volume, err := st.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachment, err := st.VolumeAttachment(machine, volume.VolumeTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attachment.Life() != Alive {
+			return nil, nil
+		}
+		return detachVolumeOps(machine, volume.VolumeTag()), nil
+	case StorageKindFilesystem:
+		filesystem, err := st.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attachment, err := st.FilesystemAttachment(machine, filesystem.FilesystemTag())
+		if errors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attachment.Life() != Alive {
+			return nil, nil
+		}
+		// If the filesystem is backed by a volume, the volume will
+		// be detached when the filesystem attachment is removed.
+		return detachFilesystemOps(machine, filesystem.FilesystemTag()), nil
+	}
+	return nil, errors.Errorf("invalid storage kind %v", si.doc.Kind)
+}
+
+// DetachStorage ensures that the storage instance will be detached from
+// the unit at some point, without destroying it. Once the storage has
+// been detached, it has no owner, and may be attached to another unit
+// with AttachStorage. Only storage whose volume or filesystem can outlive
+// the machine it is attached to may be detached.
+func (st *State) DetachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot detach storage %s from unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.storageAttachment(storage, unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Life != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if si.doc.Owner != unit.String() {
+			return nil, errors.NotSupportedf("detaching shared storage")
+		}
+		if err := st.validateStorageDetachable(si); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.validateStorageDetachCount(si, unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := destroyStorageAttachmentOps(storage, unit)
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: append(bson.D{{"owner", unit.String()}}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"owner", ""}}}},
+		})
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// machineStorageDoc holds the details of the volume or filesystem of a
+// storage instance which are needed to detach and attach it.
+type machineStorageDoc struct {
+	tag             names.Tag
+	binding         string
+	attachmentCount int
+}
+
+// storageInstanceMachineStorage returns the details of the volume or
+// filesystem of the storage instance, or a NotFound error if none has
+// been created yet.
+func (st *State) storageInstanceMachineStorage(si *storageInstance) (*machineStorageDoc, error) {
+	switch si.doc.Kind {
+	case StorageKindBlock:
+		volume, err := st.storageInstanceVolume(si.StorageTag())
+		if err != nil {
+			return nil, err
+		}
+		return &machineStorageDoc{
+			volume.VolumeTag(),
+			volume.doc.Binding,
+			volume.doc.AttachmentCount,
+		}, nil
+	case StorageKindFilesystem:
+		filesystem, err := st.storageInstanceFilesystem(si.StorageTag())
+		if err != nil {
+			return nil, err
+		}
+		return &machineStorageDoc{
+			filesystem.FilesystemTag(),
+			filesystem.doc.Binding,
+			filesystem.doc.AttachmentCount,
+		}, nil
+	}
+	return nil, errors.Errorf("invalid storage kind %v", si.doc.Kind)
+}
+
+// validateStorageDetachable returns an error if the volume or filesystem
+// of the storage instance is scoped to, or bound to the lifetime of, a
+// machine. Such storage cannot be attached to another unit's machine.
+func (st *State) validateStorageDetachable(si *storageInstance) error {
+	doc, err := st.storageInstanceMachineStorage(si)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var machineScoped bool
+	switch tag := doc.tag.(type) {
+	case names.VolumeTag:
+		_, machineScoped = names.VolumeMachine(tag)
+	case names.FilesystemTag:
+		_, machineScoped = names.FilesystemMachine(tag)
+	}
+	if machineScoped || doc.binding != si.StorageTag().String() {
+		return errors.NotSupportedf("detaching machine-bound storage")
+	}
+	return nil
+}
+
+// validateStorageDetachCount returns an error if detaching the storage
+// instance would leave the unit with fewer instances of the storage
+// than its charm requires.
+func (st *State) validateStorageDetachCount(si *storageInstance, unit names.UnitTag) error {
+	u, err := st.Unit(unit.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := u.Service()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := svc.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmStorage, ok := ch.Meta().Storage[si.doc.StorageName]
+	if !ok {
+		// The charm no longer declares the storage, so there
+		// is no minimum to maintain.
+		return nil
+	}
+	count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if int(count)-1 < charmStorage.CountMin {
+		return errors.Errorf(
+			"unit requires at least %d %q storage instance(s)",
+			charmStorage.CountMin, si.doc.StorageName,
+		)
+	}
+	return nil
+}
+
+// AttachStorage attaches the storage instance, which must have been
+// detached from its previous unit with DetachStorage, to the specified
+// unit. If the unit is assigned to a machine, the storage's volume or
+// filesystem will be attached to that machine.
+func (st *State) AttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot attach storage %s to unit %s", storage.Id(), unit.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		u, err := st.Unit(unit.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.Life() != Alive {
+			return nil, unitNotAliveErr
+		}
+		si, err := st.storageInstance(storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.doc.Owner == unit.String() {
+			if _, err := st.storageAttachment(storage, unit); err == nil {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		svc, err := u.Service()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := svc.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		count, err := st.countEntityStorageInstancesForName(unit, si.doc.StorageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := validateStorageAttach(si, ch.Meta(), count); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := st.validateMachineStorageDetached(si); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := attachStorageOps(si, unit)
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+		})
+
+		// If the unit is assigned to a machine, attach the storage's
+		// volume or filesystem to the machine.
+		cons, err := u.StorageConstraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attached := *si
+		attached.doc.Owner = unit.String()
+		machineOps, err := unitAssignedMachineStorageOps(
+			st, unit, ch.Meta(), cons, u.Series(), &attached,
+		)
+		if err == nil {
+			ops = append(ops, machineOps...)
+		} else if !errors.IsNotAssigned(err) {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	return st.run(buildTxn)
+}
+
+// validateStorageAttach returns an error if the storage instance cannot
+// be attached to a unit running the charm with the specified metadata,
+// which already has count instances of the same storage.
+func validateStorageAttach(si *storageInstance, charmMeta *charm.Meta, count uint64) error {
+	if si.doc.Life != Alive {
+		return errors.New("storage is not alive")
+	}
+	if owner, ok := si.Owner(); ok {
+		return errors.Errorf("storage is attached to %s", names.ReadableString(owner))
+	}
+	if si.doc.AttachmentCount > 0 {
+		return errors.New("storage is still being detached")
+	}
+	charmStorage, ok := charmMeta.Storage[si.doc.StorageName]
+	if !ok {
+		return errors.NotFoundf("charm storage %q", si.doc.StorageName)
+	}
+	if charmStorage.Shared {
+		return errors.NotSupportedf("attaching shared storage")
+	}
+	var kind StorageKind
+	switch charmStorage.Type {
+	case charm.StorageBlock:
+		kind = StorageKindBlock
+	case charm.StorageFilesystem:
+		kind = StorageKindFilesystem
+	}
+	if kind != si.doc.Kind {
+		return errors.Errorf(
+			"charm storage %q is of kind %s, not %s",
+			si.doc.StorageName, kind, si.doc.Kind,
+		)
+	}
+	if charmStorage.CountMax >= 0 && int(count)+1 > charmStorage.CountMax {
+		return errors.Errorf(
+			"unit already has the maximum of %d %q storage instance(s)",
+			charmStorage.CountMax, si.doc.StorageName,
+		)
+	}
+	return nil
+}
+
+// validateMachineStorageDetached returns an error if the volume or
+// filesystem of the storage instance is still attached to a machine.
+func (st *State) validateMachineStorageDetached(si *storageInstance) error {
+	doc, err := st.storageInstanceMachineStorage(si)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.attachmentCount > 0 {
+		return errors.Errorf("%s is still attached to a machine", names.ReadableString(doc.tag))
+	}
+	return nil
+}
+
+// attachStorageOps returns txn.Ops for making the unit the owner of the
+// detached storage instance, and creating the storage attachment. The
+// caller is responsible for updating the unit's storageattachmentcount
+// field, and for attaching machine storage.
+func attachStorageOps(si *storageInstance, unit names.UnitTag) []txn.Op {
+	isDetached := bson.D{
+		{"life", Alive},
+		{"owner", ""},
+		{"attachmentcount", 0},
+	}
+	return []txn.Op{{
+		C:      storageInstancesC,
+		Id:     si.doc.Id,
+		Assert: isDetached,
+		Update: bson.D{
+			{"$set", bson.D{{"owner", unit.String()}}},
+			{"$inc", bson.D{{"attachmentcount", 1}}},
+		},
+	}, createStorageAttachmentOp(si.StorageTag(), unit)}
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
@@ -1069,27 +1464,98 @@ func defaultStoragePool(cfg *config.Config, kind storage.StorageKind, cons Stora
 func (st *State) AddStorageForUnit(
 	tag names.UnitTag, name string, cons StorageConstraints,
 ) error {
-	u, err := st.Unit(tag.Id())
+	u, ch, err := st.unitAndCharm(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return st.addStorageForUnit(ch, u, name, cons, "")
+}
+
+// AddStorageForUnitFromSnapshot adds block storage instances to the
+// given unit as specified, with their volumes created from the volume
+// snapshot with the given ID. The storage is created in the storage
+// pool of the snapshotted volume, and must be at least as large as the
+// snapshot; missing pool and size constraints are taken from the
+// snapshot. Snapshots of machine-scoped volumes may only be used by
+// units assigned to the same machine.
+func (st *State) AddStorageForUnitFromSnapshot(
+	tag names.UnitTag, name string, cons StorageConstraints, snapshot string,
+) (err error) {
+	defer errors.DeferredAnnotatef(&err, "adding storage from volume snapshot %q", snapshot)
+	u, ch, err := st.unitAndCharm(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if charmStorage, ok := ch.Meta().Storage[name]; ok && charmStorage.Type != charm.StorageBlock {
+		return errors.NotSupportedf("creating %s storage from a volume snapshot", charmStorage.Type)
+	}
+	s, err := st.VolumeSnapshot(snapshot)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	info, err := s.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = s.Pool()
+	} else if cons.Pool != s.Pool() {
+		return errors.Errorf(
+			"storage pool %q does not match snapshot pool %q",
+			cons.Pool, s.Pool(),
+		)
+	}
+	if cons.Size == 0 {
+		cons.Size = info.Size
+	} else if cons.Size < info.Size {
+		return errors.Errorf(
+			"storage size %dM is smaller than snapshot size %dM",
+			cons.Size, info.Size,
+		)
+	}
+	if machineId := volumeSnapshotMachineId(snapshot); machineId != "" {
+		assignedMachineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			assignedMachineId = ""
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if assignedMachineId != machineId {
+			return errors.Errorf(
+				"snapshot is scoped to machine %s, and unit %s is not assigned to it",
+				machineId, u.Name(),
+			)
+		}
+	}
+	return st.addStorageForUnit(ch, u, name, cons, snapshot)
+}
+
+// unitAndCharm returns the unit with the given tag, and the charm
+// of its service.
+func (st *State) unitAndCharm(tag names.UnitTag) (*Unit, *Charm, error) {
+	u, err := st.Unit(tag.Id())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	s, err := u.Service()
 	if err != nil {
-		return errors.Annotatef(err, "getting service for unit %v", u.Tag().Id())
+		return nil, nil, errors.Annotatef(err, "getting service for unit %v", u.Tag().Id())
 	}
 	ch, _, err := s.Charm()
 	if err != nil {
-		return errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
+		return nil, nil, errors.Annotatef(err, "getting charm for unit %q", u.Tag().Id())
 	}
-
-	return st.addStorageForUnit(ch, u, name, cons)
+	return u, ch, nil
 }
 
 // addStorage adds storage instances to given unit as specified.
+// If snapshot is non-empty, the storage instances' volumes will
+// be created from the volume snapshot with that ID.
 func (st *State) addStorageForUnit(
 	ch *Charm, u *Unit,
 	name string, cons StorageConstraints,
+	snapshot string,
 ) error {
 	all, err := u.StorageConstraints()
 	if err != nil {
@@ -1136,7 +1602,7 @@ func (st *State) addStorageForUnit(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ops, err := st.constructAddUnitStorageOps(ch, u, name, completeCons)
+		ops, err := st.constructAddUnitStorageOps(ch, u, name, completeCons, snapshot)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1172,7 +1638,7 @@ func (st *State) validateUnitStorage(
 }
 
 func (st *State) constructAddUnitStorageOps(
-	ch *Charm, u *Unit, name string, cons StorageConstraints,
+	ch *Charm, u *Unit, name string, cons StorageConstraints, snapshot string,
 ) ([]txn.Op, error) {
 	// Create storage db operations
 	storageOps, _, err := createStorageOps(
@@ -1182,6 +1648,7 @@ func (st *State) constructAddUnitStorageOps(
 		ch.URL(),
 		map[string]StorageConstraints{name: cons},
 		u.Series(),
+		snapshot,
 		true, // create machine storage
 	)
 	if err != nil {
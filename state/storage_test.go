@@ -579,7 +579,9 @@ func (s *StorageStateSuite) TestAllStorageInstances(c *gc.C) {
 	for _, one := range all {
 		c.Assert(one.Kind(), gc.DeepEquals, state.StorageKindBlock)
 		c.Assert(nameSet.Contains(one.StorageName()), jc.IsTrue)
-		c.Assert(ownerSet.Contains(one.Owner().String()), jc.IsTrue)
+		owner, ok := one.Owner()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(ownerSet.Contains(owner.String()), jc.IsTrue)
 	}
 }
 
@@ -918,6 +920,203 @@ func (s *StorageStateSuite) testStorageLocationConflict(c *gc.C, first, second,
 	}
 }
 
+// setupDetachableStorage adds a unit of the storage-block charm, with
+// persistent "data" and "allecto" storage, and assigns it to a machine.
+// The tag of the "allecto" storage instance, which may be detached, is
+// returned along with the service and unit.
+func (s *StorageStateSuiteBase) setupDetachableStorage(c *gc.C, pool string) (*state.Service, *state.Unit, names.StorageTag) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storageCons := map[string]state.StorageConstraints{
+		"data":    makeStorageCons("persistent-block", 1024, 1),
+		"allecto": makeStorageCons(pool, 1024, 1),
+	}
+	service := s.AddTestingServiceWithStorage(c, "storage-block", ch, storageCons)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	return service, unit, s.unitStorageTag(c, unit, "allecto")
+}
+
+func (s *StorageStateSuiteBase) unitStorageTag(c *gc.C, u *state.Unit, storageName string) names.StorageTag {
+	attachments, err := s.State.UnitStorageAttachments(u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	for _, a := range attachments {
+		si, err := s.State.StorageInstance(a.StorageInstance())
+		c.Assert(err, jc.ErrorIsNil)
+		if si.StorageName() == storageName {
+			return si.StorageTag()
+		}
+	}
+	c.Fatalf("unit %s has no %q storage", u.Name(), storageName)
+	panic("unreachable")
+}
+
+func (s *StorageStateSuiteBase) assignedMachineTag(c *gc.C, u *state.Unit) names.MachineTag {
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return names.NewMachineTag(machineId)
+}
+
+// detachStorage detaches the storage from the unit, and completes the
+// detachment as the uniter and storage provisioner would.
+func (s *StorageStateSuiteBase) detachStorage(c *gc.C, storageTag names.StorageTag, u *state.Unit) {
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.State.RemoveVolumeAttachment(s.assignedMachineTag(c, u), volume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestDetachStorage(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	machineTag := s.assignedMachineTag(c, u)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage instance no longer has an owner, and its attachment
+	// is dying so that the charm will be told about the detachment.
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+	_, ok := si.Owner()
+	c.Assert(ok, jc.IsFalse)
+	attachment, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Dying)
+	c.Assert(s.volumeAttachment(c, machineTag, volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+
+	// Once the storage attachment is removed, the volume attachment
+	// is destroyed so the storage provisioner will detach it; the
+	// storage instance and volume remain.
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.storageInstanceExists(c, storageTag), jc.IsTrue)
+	c.Assert(s.volumeAttachment(c, machineTag, volume.VolumeTag()).Life(), gc.Equals, state.Dying)
+	c.Assert(s.volume(c, volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+}
+
+func (s *StorageStateSuite) TestDetachStorageIdempotent(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestDetachStorageRequiredStorage(c *gc.C) {
+	_, u, _ := s.setupDetachableStorage(c, "persistent-block")
+	storageTag := s.unitStorageTag(c, u, "data")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot detach storage %s from unit storage-block/0: unit requires at least 1 "data" storage instance\(s\)`,
+		storageTag.Id(),
+	))
+}
+
+func (s *StorageStateSuite) TestDetachStorageMachineBound(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c, "loop-pool")
+	err := s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot detach storage .*: detaching machine-bound storage not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StorageStateSuite) TestAttachStorage(c *gc.C) {
+	svc, u, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	volume := s.storageInstanceVolume(c, storageTag)
+	u2, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.detachStorage(c, storageTag, u)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, ok := si.Owner()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(owner, gc.Equals, u2.Tag())
+	attachment, err := s.State.StorageAttachment(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment.Life(), gc.Equals, state.Alive)
+
+	// The existing volume is attached to the new unit's machine.
+	machineTag := s.assignedMachineTag(c, u2)
+	c.Assert(s.volumeAttachment(c, machineTag, volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+	assertMachineStorageRefs(c, s.State, machineTag)
+}
+
+func (s *StorageStateSuite) TestAttachStorageStillAttachedToMachine(c *gc.C) {
+	svc, u, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	u2, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-block/1: storage is still being detached`)
+
+	err = s.State.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-block/1: volume .* is still attached to a machine`)
+}
+
+func (s *StorageStateSuite) TestAttachStorageOwned(c *gc.C) {
+	svc, _, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	u2, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-block/1: storage is attached to unit storage-block/0`)
+}
+
+func (s *StorageStateSuite) TestAttachStorageWrongCharm(c *gc.C) {
+	_, u, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	s.detachStorage(c, storageTag, u)
+
+	_, u2, _ := s.setupSingleStorage(c, "filesystem", "environscoped")
+	err := s.State.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, `cannot attach storage .* to unit storage-filesystem/0: charm storage "allecto" not found`)
+}
+
+func (s *StorageStateSuite) TestAddUnitAttachStorage(c *gc.C) {
+	svc, u, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	volume := s.storageInstanceVolume(c, storageTag)
+	s.detachStorage(c, storageTag, u)
+
+	u2, err := svc.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit has the attached "allecto" storage in place of a new
+	// instance, and a new instance of the "data" storage.
+	attachments, err := s.State.UnitStorageAttachments(u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 2)
+	c.Assert(s.unitStorageTag(c, u2, "allecto"), gc.Equals, storageTag)
+
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := s.assignedMachineTag(c, u2)
+	c.Assert(s.volumeAttachment(c, machineTag, volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+	assertMachineStorageRefs(c, s.State, machineTag)
+}
+
+func (s *StorageStateSuite) TestAddUnitAttachStorageOwned(c *gc.C) {
+	svc, _, storageTag := s.setupDetachableStorage(c, "persistent-block")
+	_, err := svc.AddUnitWithParams(state.AddUnitParams{
+		AttachStorage: []names.StorageTag{storageTag},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to service "storage-block": .*storage is attached to unit storage-block/0`)
+}
+
 // TODO(axw) the following require shared storage support to test:
 // - StorageAttachments can't be added to Dying StorageInstance
 // - StorageInstance without attachments is removed by Destroy
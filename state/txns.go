@@ -8,6 +8,23 @@ import (
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/instrument"
+)
+
+var (
+	txnsRun = instrument.Default.NewCounterVec(
+		"juju_state_txns_total",
+		"Transactions run against the database, including retries.",
+	).With()
+	txnRetries = instrument.Default.NewCounterVec(
+		"juju_state_txn_retries_total",
+		"Transactions retried because their assertions failed.",
+	).With()
+	txnContentionFailures = instrument.Default.NewCounterVec(
+		"juju_state_txn_contention_failures_total",
+		"Transactions abandoned after too many retries.",
+	).With()
 )
 
 // readTxnRevno is a convenience method delegating to the state's Database.
@@ -77,6 +94,7 @@ func (r *multiModelRunner) RunTransaction(ops []txn.Op) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	txnsRun.Inc()
 	return r.rawRunner.RunTransaction(newOps)
 }
 
@@ -85,7 +103,10 @@ func (r *multiModelRunner) RunTransaction(ops []txn.Op) error {
 // collections will be modified to ensure correct interaction with
 // these collections.
 func (r *multiModelRunner) Run(transactions jujutxn.TransactionSource) error {
-	return r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+	err := r.rawRunner.Run(func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			txnRetries.Inc()
+		}
 		ops, err := transactions(attempt)
 		if err != nil {
 			// Don't use Trace here as jujutxn doens't use juju/errors
@@ -96,8 +117,13 @@ func (r *multiModelRunner) Run(transactions jujutxn.TransactionSource) error {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		txnsRun.Inc()
 		return newOps, nil
 	})
+	if err == jujutxn.ErrExcessiveContention {
+		txnContentionFailures.Inc()
+	}
+	return err
 }
 
 // ResumeTransactions is part of the jujutxn.Runner interface.
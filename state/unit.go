@@ -1703,9 +1703,13 @@ func machineStorageParamsForStorageInstance(
 		volumeAttachmentParams := VolumeAttachmentParams{
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a volume.
+		volume, err := st.storageInstanceVolume(storage.StorageTag())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
+		}
+		if owner, _ := storage.Owner(); unit == owner && volume == nil {
+			// The storage instance is owned by the unit, and has
+			// no volume yet, so we'll need to create a volume.
 			cons := allCons[storage.StorageName()]
 			volumeParams := VolumeParams{
 				storage: storage.StorageTag(),
@@ -1713,16 +1717,21 @@ func machineStorageParamsForStorageInstance(
 				Pool:    cons.Pool,
 				Size:    cons.Size,
 			}
+			if snapshot, ok := storage.Snapshot(); ok {
+				if err := setVolumeParamsSnapshot(st, &volumeParams, snapshot); err != nil {
+					return nil, errors.Annotatef(err, "getting snapshot for storage %q", storage.Tag().Id())
+				}
+			}
 			volumes = append(volumes, MachineVolumeParams{
 				volumeParams, volumeAttachmentParams,
 			})
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) volume already, for which we will
+			// The storage instance is owned by the service, or was
+			// attached to the unit after being detached from another,
+			// so there should be a volume already, for which we will
 			// just add an attachment.
-			volume, err := st.StorageInstanceVolume(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting volume for storage %q", storage.Tag().Id())
+			if volume == nil {
+				return nil, errors.NotFoundf("volume for storage %q", storage.Tag().Id())
 			}
 			volumeAttachments[volume.VolumeTag()] = volumeAttachmentParams
 		}
@@ -1739,9 +1748,13 @@ func machineStorageParamsForStorageInstance(
 			location,
 			charmStorage.ReadOnly,
 		}
-		if unit == storage.Owner() {
-			// The storage instance is owned by the unit, so we'll need
-			// to create a filesystem.
+		filesystem, err := st.storageInstanceFilesystem(storage.StorageTag())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
+		}
+		if owner, _ := storage.Owner(); unit == owner && filesystem == nil {
+			// The storage instance is owned by the unit, and has
+			// no filesystem yet, so we'll need to create a filesystem.
 			cons := allCons[storage.StorageName()]
 			filesystemParams := FilesystemParams{
 				storage: storage.StorageTag(),
@@ -1753,12 +1766,12 @@ func machineStorageParamsForStorageInstance(
 				filesystemParams, filesystemAttachmentParams,
 			})
 		} else {
-			// The storage instance is owned by the service, so there
-			// should be a (shared) filesystem already, for which we will
-			// just add an attachment.
-			filesystem, err := st.StorageInstanceFilesystem(storage.StorageTag())
-			if err != nil {
-				return nil, errors.Annotatef(err, "getting filesystem for storage %q", storage.Tag().Id())
+			// The storage instance is owned by the service, or was
+			// attached to the unit after being detached from another,
+			// so there should be a filesystem already, for which we
+			// will just add an attachment.
+			if filesystem == nil {
+				return nil, errors.NotFoundf("filesystem for storage %q", storage.Tag().Id())
 			}
 			filesystemAttachments[filesystem.FilesystemTag()] = filesystemAttachmentParams
 		}
@@ -2054,6 +2067,12 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeout(name, payload, 0)
+}
+
+// AddActionWithTimeout adds a new Action like AddAction, which the
+// unit will fail if it runs for longer than timeout.
+func (u *Unit) AddActionWithTimeout(name string, payload map[string]interface{}, timeout time.Duration) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2079,7 +2098,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return u.st.EnqueueActionWithTimeout(u.Tag(), name, payloadWithDefaults, timeout)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
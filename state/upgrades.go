@@ -248,3 +248,35 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// AddControllerAccessForExistingUsers grants add-model controller
+// access to every user without any controller access. Before
+// controller permissions existed, any user could add models; this
+// keeps existing users able to do so.
+func AddControllerAccessForExistingUsers(st *State) error {
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner := controllerModel.Owner()
+	users, err := st.AllUsers(true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, user := range users {
+		tag := user.UserTag()
+		if tag.Canonical() == owner.Canonical() {
+			continue
+		}
+		if _, err := st.ControllerAccess(tag); err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err := st.SetControllerAccess(tag, owner, ControllerAddModelAccess); err != nil {
+			return errors.Trace(err)
+		}
+		upgradesLogger.Infof("granted add-model access to existing user %q", tag.Canonical())
+	}
+	return nil
+}
@@ -578,3 +578,27 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestAddControllerAccessForExistingUsers(c *gc.C) {
+	bob, err := s.state.AddUser("bob", "Bob", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	mary, err := s.state.AddUser("mary", "Mary", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.state.SetControllerAccess(mary.UserTag(), s.owner, ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Running the step twice must not change the result.
+	for i := 0; i < 2; i++ {
+		err = AddControllerAccessForExistingUsers(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+
+		access, err := s.state.ControllerAccess(bob.UserTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(access, gc.Equals, ControllerAddModelAccess)
+		access, err = s.state.ControllerAccess(mary.UserTag())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(access, gc.Equals, ControllerSuperuserAccess)
+		_, err = s.state.ControllerAccess(s.owner)
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}
+}
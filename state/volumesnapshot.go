@@ -0,0 +1,250 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume in
+// the model.
+type VolumeSnapshot interface {
+	Lifer
+
+	// Id returns the unique ID of the snapshot. Snapshots of
+	// machine-scoped volumes are scoped to the same machine, and
+	// have IDs of the form "<machine-id>/<n>".
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot was
+	// taken of.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the volume that
+	// the snapshot was taken of. Volumes created from the snapshot
+	// will be created in the same pool.
+	Pool() string
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Name      string              `bson:"name"`
+	ModelUUID string              `bson:"model-uuid"`
+	Life      Life                `bson:"life"`
+	Volume    string              `bson:"volumeid"`
+	Pool      string              `bson:"pool"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Name
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (st *State) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := st.volumeSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (st *State) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// VolumeSnapshots returns all of the snapshots taken of the specified
+// volume.
+func (st *State) VolumeSnapshots(volume names.VolumeTag) ([]VolumeSnapshot, error) {
+	coll, cleanup := st.getCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(bson.D{{"volumeid", volume.Id()}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "getting snapshots of volume %q", volume.Id())
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// newVolumeSnapshotName returns a unique volume snapshot name. If the
+// machine ID supplied is non-empty, the snapshot ID will incorporate
+// it as the snapshot's machine scope.
+func newVolumeSnapshotName(st *State, machineId string) (string, error) {
+	seq, err := st.sequence("volumesnapshot")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := fmt.Sprint(seq)
+	if machineId != "" {
+		id = machineId + "/" + id
+	}
+	return id, nil
+}
+
+// CreateVolumeSnapshot records a request to take a snapshot of the
+// specified volume, which must already be provisioned, and returns
+// the ID of the new snapshot. The snapshot is taken asynchronously
+// by the storage provisioner; the snapshot's Info will be set once
+// it has been taken.
+func (st *State) CreateVolumeSnapshot(tag names.VolumeTag) (_ string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot create snapshot of volume %q", tag.Id())
+	var machineId string
+	if machineTag, ok := names.VolumeMachine(tag); ok {
+		machineId = machineTag.Id()
+	}
+	name, err := newVolumeSnapshotName(st, machineId)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot generate volume snapshot name")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &volumeSnapshotDoc{
+				Name:   name,
+				Volume: tag.Id(),
+				Pool:   info.Pool,
+			},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// SetVolumeSnapshotInfo sets the VolumeSnapshotInfo for the specified
+// volume snapshot. The info may only be set once.
+func (st *State) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := st.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Info != nil {
+			if *s.doc.Info == info {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.New("snapshot info already set")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     id,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
+// setVolumeParamsSnapshot updates the volume parameters so that the
+// volume is created from the volume snapshot with the specified ID, in
+// the snapshotted volume's pool and at least as large as the snapshot.
+func setVolumeParamsSnapshot(st *State, params *VolumeParams, id string) error {
+	s, err := st.volumeSnapshot(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := s.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	params.Pool = s.doc.Pool
+	if params.Size < info.Size {
+		params.Size = info.Size
+	}
+	params.SnapshotId = info.SnapshotId
+	return nil
+}
+
+// volumeSnapshotMachineId returns the ID of the machine that the
+// volume snapshot with the specified ID is scoped to, or the empty
+// string if the snapshot is model-scoped.
+func volumeSnapshotMachineId(id string) string {
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		return id[:i]
+	}
+	return ""
+}
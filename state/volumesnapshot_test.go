@@ -0,0 +1,259 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+// setupProvisionedVolume creates a unit with a single block storage
+// instance in the specified pool, assigns it to a machine, and marks
+// the storage instance's volume as provisioned.
+func (s *VolumeSnapshotSuite) setupProvisionedVolume(c *gc.C, pool string) (*state.Unit, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", pool)
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-123",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, volume.VolumeTag()
+}
+
+func (s *VolumeSnapshotSuite) setupVolumeSnapshot(c *gc.C, pool string) (*state.Unit, string) {
+	u, volumeTag := s.setupProvisionedVolume(c, pool)
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{
+		SnapshotId: "snap-123",
+		Size:       2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, id
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "loop-pool")
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0/0")
+
+	snapshot, err := s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshots, err := s.State.VolumeSnapshots(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0/0")
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshotModelScoped(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "persistent-block")
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0")
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshotUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	_, err = s.State.CreateVolumeSnapshot(volume.VolumeTag())
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot of volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.State.CreateVolumeSnapshot(names.NewVolumeTag("42"))
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot of volume "42": volume "42" not found`)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "loop-pool")
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-123", Size: 1024}
+	err = s.State.SetVolumeSnapshotInfo(id, info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.VolumeSnapshot(id)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotInfo, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotInfo, jc.DeepEquals, info)
+
+	// Setting the same info again is a no-op.
+	err = s.State.SetVolumeSnapshotInfo(id, info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.SnapshotId = "snap-456"
+	err = s.State.SetVolumeSnapshotInfo(id, info)
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0": snapshot info already set`)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfoNoSnapshotId(c *gc.C) {
+	err := s.State.SetVolumeSnapshotInfo("0", state.VolumeSnapshotInfo{Size: 1024})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0": snapshot ID not set`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshot(c *gc.C) {
+	u, id := s.setupVolumeSnapshot(c, "loop-pool")
+	err := s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Count: 1}, id,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageTag := names.NewStorageTag("allecto/1")
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, ok := storageInstance.Snapshot()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(snapshot, gc.Equals, id)
+
+	volume := s.storageInstanceVolume(c, storageTag)
+	c.Assert(volume.VolumeTag(), gc.Equals, names.NewVolumeTag("0/1"))
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams, jc.DeepEquals, state.VolumeParams{
+		Pool:       "loop-pool",
+		Size:       2048,
+		SnapshotId: "snap-123",
+	})
+	assertMachineStorageRefs(c, s.State, names.NewMachineTag("0"))
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotLarger(c *gc.C) {
+	u, id := s.setupVolumeSnapshot(c, "loop-pool")
+	err := s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", makeStorageCons("loop-pool", 4096, 1), id,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, names.NewStorageTag("allecto/1"))
+	volumeParams, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(volumeParams.Size, gc.Equals, uint64(4096))
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotTooSmall(c *gc.C) {
+	u, id := s.setupVolumeSnapshot(c, "loop-pool")
+	err := s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", makeStorageCons("", 1024, 1), id,
+	)
+	c.Assert(err, gc.ErrorMatches, `adding storage from volume snapshot "0/0": storage size 1024M is smaller than snapshot size 2048M`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotPoolMismatch(c *gc.C) {
+	u, id := s.setupVolumeSnapshot(c, "loop-pool")
+	err := s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", makeStorageCons("persistent-block", 0, 1), id,
+	)
+	c.Assert(err, gc.ErrorMatches, `adding storage from volume snapshot "0/0": storage pool "persistent-block" does not match snapshot pool "loop-pool"`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotNotTaken(c *gc.C) {
+	u, volumeTag := s.setupProvisionedVolume(c, "loop-pool")
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Count: 1}, id,
+	)
+	c.Assert(err, gc.ErrorMatches, `adding storage from volume snapshot "0/0": volume snapshot "0/0" not provisioned`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotOtherMachine(c *gc.C) {
+	_, id := s.setupVolumeSnapshot(c, "loop-pool")
+	service, err := s.State.Service("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Count: 1}, id,
+	)
+	c.Assert(err, gc.ErrorMatches, `adding storage from volume snapshot "0/0": snapshot is scoped to machine 0, and unit storage-block/1 is not assigned to it`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageForUnitFromSnapshotFilesystem(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "loop-pool")
+	err := s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "data", state.StorageConstraints{Count: 1}, "0",
+	)
+	c.Assert(err, gc.ErrorMatches, `adding storage from volume snapshot "0": creating filesystem storage from a volume snapshot not supported`)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "loop-pool")
+
+	w := s.State.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+
+	// Setting info does not change the snapshot's lifecycle.
+	err = s.State.SetVolumeSnapshotInfo(id, state.VolumeSnapshotInfo{SnapshotId: "snap-123"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestWatchModelVolumeSnapshots(c *gc.C) {
+	_, volumeTag := s.setupProvisionedVolume(c, "persistent-block")
+
+	w := s.State.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	id, err := s.State.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(id)
+	wc.AssertNoChange()
+}
+
+func (s *VolumeSnapshotSuite) TestExportStorageFromSnapshotWithoutVolume(c *gc.C) {
+	_, id := s.setupVolumeSnapshot(c, "persistent-block")
+	service, err := s.State.Service("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddStorageForUnitFromSnapshot(
+		u.UnitTag(), "allecto", state.StorageConstraints{Count: 1}, id,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit is not assigned, so the storage has no volume yet.
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `exporting storage "allecto/\d+" before its volume is created from snapshot "0" not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
@@ -195,6 +195,13 @@ func (st *State) WatchIPAddresses() StringsWatcher {
 	return newLifecycleWatcher(st, legacyipaddressesC, nil, nil, nil)
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of the CIDRs of
+// subnets which are added or changed, including when they become Dead
+// before removal.
+func (st *State) WatchSubnets() StringsWatcher {
+	return newcollectionWatcher(st, colWCfg{col: subnetsC})
+}
+
 // WatchModelVolumes returns a StringsWatcher that notifies of changes to
 // the lifecycles of all model-scoped volumes.
 func (st *State) WatchModelVolumes() StringsWatcher {
@@ -246,6 +253,19 @@ func (st *State) watchMachineStorage(m names.MachineTag, collection string) Stri
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of model-scoped volumes.
+func (st *State) WatchModelVolumeSnapshots() StringsWatcher {
+	return st.watchModelMachinestorage(volumeSnapshotsC)
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to the lifecycles of all snapshots of volumes scoped to the
+// specified machine.
+func (st *State) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return st.watchMachineStorage(m, volumeSnapshotsC)
+}
+
 // WatchEnvironVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -108,6 +108,20 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an interface that may be implemented by a
+// VolumeSource that is able to take point-in-time snapshots of its
+// volumes. A VolumeSource that implements VolumeSnapshotter must
+// also honour VolumeParams.SnapshotId when creating volumes.
+//
+// Snapshots are never removed by Juju, not even when the model is
+// destroyed; they remain, and are charged for, until removed by the
+// user.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of the volumes with
+	// the specified parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -175,6 +189,39 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId is the unique provider-supplied ID of the volume
+	// snapshot from which the volume should be created, or empty if
+	// the volume should be created empty. SnapshotId will only be set
+	// for volume sources that implement VolumeSnapshotter.
+	SnapshotId string
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Snapshot is the unique ID assigned by Juju for the requested
+	// snapshot.
+	Snapshot string
+
+	// Volume is the unique tag assigned by Juju for the volume that
+	// should be snapshotted.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume that
+	// should be snapshotted.
+	VolumeId string
+
+	// Size is the size of the volume in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that is to be used
+	// to create the snapshot.
+	Provider ProviderType
+
+	// ResourceTags is a set of tags to set on the created snapshot, if
+	// the storage provider supports tags.
+	ResourceTags map[string]string
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -273,6 +320,14 @@ type DescribeVolumesResult struct {
 	Error      error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one snapshot.
+// VolumeSnapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshot *VolumeSnapshot
+	Error          error
+}
+
 // AttachVolumesResult contains the result of a VolumeSource.AttachVolumes call
 // for one volume. VolumeAttachment should only be used if Error is nil.
 type AttachVolumesResult struct {
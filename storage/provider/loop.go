@@ -100,6 +100,7 @@ type loopVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*loopVolumeSource)(nil)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -120,6 +121,14 @@ func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	if params.SnapshotId != "" {
+		// Start with a copy of the snapshot; the file is then
+		// extended to the requested size below, if necessary.
+		snapshotFilePath := lvs.snapshotFilePath(params.SnapshotId)
+		if err := copyBlockFile(lvs.run, snapshotFilePath, loopFilePath); err != nil {
+			return storage.Volume{}, errors.Annotate(err, "could not restore snapshot")
+		}
+	}
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -136,6 +145,50 @@ func (lvs *loopVolumeSource) volumeFilePath(tag names.VolumeTag) string {
 	return filepath.Join(lvs.storageDir, tag.String())
 }
 
+// snapshotFilePath returns the path of the file holding the snapshot
+// with the specified ID.
+func (lvs *loopVolumeSource) snapshotFilePath(snapshotId string) string {
+	return filepath.Join(lvs.storageDir, "snapshots", snapshotId)
+}
+
+// CreateVolumeSnapshots is defined on the VolumeSnapshotter interface.
+//
+// Snapshots of loop volumes are copies of their backing files, kept
+// alongside the volumes on the machine. They are consistent only to
+// the extent that the volume is not written to while it is copied.
+func (lvs *loopVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		snapshot, err := lvs.createVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating snapshot of volume %s", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeSnapshot = snapshot
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) createVolumeSnapshot(arg storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	// Snapshot IDs of machine-scoped volumes are prefixed with
+	// the machine ID, e.g. "0/1".
+	snapshotId := "snapshot-" + strings.Replace(arg.Snapshot, "/", "-", -1)
+	snapshotFilePath := lvs.snapshotFilePath(snapshotId)
+	if err := ensureDir(lvs.dirFuncs, filepath.Dir(snapshotFilePath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := copyBlockFile(lvs.run, lvs.volumeFilePath(arg.Volume), snapshotFilePath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeSnapshot{
+		arg.Snapshot,
+		storage.VolumeSnapshotInfo{
+			SnapshotId: snapshotId,
+			Size:       arg.Size,
+		},
+	}, nil
+}
+
 // ListVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) ListVolumes() ([]string, error) {
 	// TODO(axw) implement this when we need it.
@@ -250,6 +303,16 @@ func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) erro
 	return nil
 }
 
+// copyBlockFile copies the file at the source path to the destination
+// path, preserving any holes in the source file.
+func copyBlockFile(run runCommandFunc, srcPath, dstPath string) error {
+	_, err := run("cp", "--sparse=always", srcPath, dstPath)
+	if err != nil {
+		return errors.Annotatef(err, "copying loop backing file %q to %q", srcPath, dstPath)
+	}
+	return nil
+}
+
 // attachLoopDevice attaches a loop device to the file with the
 // specified path, and returns the loop device's name (e.g. "loop0").
 // losetup will create additional loop devices as necessary.
@@ -125,6 +125,72 @@ func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestCreateVolumesFromSnapshot(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	volumeFile := filepath.Join(s.storageDir, "volume-0")
+	snapshotFile := filepath.Join(s.storageDir, "snapshots", "snapshot-1-2")
+	s.commands.expect("cp", "--sparse=always", snapshotFile, volumeFile)
+	s.commands.expect("fallocate", "-l", "4MiB", volumeFile)
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       4,
+		SnapshotId: "snapshot-1-2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "volume-0",
+			Size:     4,
+		},
+	})
+}
+
+func (s *loopSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	source, dirFuncs := s.loopVolumeSource(c)
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	snapshotDir := filepath.Join(s.storageDir, "snapshots")
+	s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-1-0"),
+		filepath.Join(snapshotDir, "snapshot-1-2"),
+	)
+	s.commands.expect(
+		"cp", "--sparse=always",
+		filepath.Join(s.storageDir, "volume-1-1"),
+		filepath.Join(snapshotDir, "snapshot-1-3"),
+	).respond("", errors.New("no space left on device"))
+
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Snapshot: "1/2",
+		Volume:   names.NewVolumeTag("1/0"),
+		VolumeId: "volume-1-0",
+		Size:     2,
+	}, {
+		Snapshot: "1/3",
+		Volume:   names.NewVolumeTag("1/1"),
+		VolumeId: "volume-1-1",
+		Size:     2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.CreateVolumeSnapshotsResult{
+		VolumeSnapshot: &storage.VolumeSnapshot{
+			"1/2",
+			storage.VolumeSnapshotInfo{
+				SnapshotId: "snapshot-1-2",
+				Size:       2,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `creating snapshot of volume 1/1: copying loop backing file .*: no space left on device`)
+	c.Assert(dirFuncs.Dirs.Contains(snapshotDir), jc.IsTrue)
+}
+
 func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -32,6 +32,25 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot identifies and describes a point-in-time snapshot
+// of a volume.
+type VolumeSnapshot struct {
+	// Snapshot is the unique ID assigned by Juju to the snapshot.
+	Snapshot string
+
+	VolumeSnapshotInfo
+}
+
+// VolumeSnapshotInfo describes a volume snapshot.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the snapshotted volume, in MiB. A volume
+	// created from the snapshot must be at least this large.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
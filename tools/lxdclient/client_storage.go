@@ -0,0 +1,229 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
+)
+
+// StorageVolume describes a custom storage volume in an LXD storage
+// pool.
+type StorageVolume struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+}
+
+// DiskDevice describes a storage volume attached to a container as a
+// disk device.
+type DiskDevice struct {
+	// Pool is the name of the storage pool holding the volume.
+	Pool string
+
+	// Source is the name of the volume in the pool.
+	Source string
+
+	// Path is the path at which the volume is mounted in the
+	// container.
+	Path string
+
+	// ReadOnly indicates that the volume is mounted read-only.
+	ReadOnly bool
+}
+
+func (d DiskDevice) props() []string {
+	props := []string{
+		"pool=" + d.Pool,
+		"source=" + d.Source,
+		"path=" + d.Path,
+	}
+	if d.ReadOnly {
+		props = append(props, "readonly=true")
+	}
+	return props
+}
+
+// storageVolumeTypeCustom is the type of storage volumes created for
+// use by containers, as opposed to those backing containers or images.
+const storageVolumeTypeCustom = "custom"
+
+type rawStorageClient interface {
+	ContainerInfo(name string) (*shared.ContainerInfo, error)
+	ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error)
+	ContainerDeviceDelete(container, devname string) (*lxd.Response, error)
+
+	WaitForSuccess(waitURL string) error
+}
+
+// httpDoer sends HTTP requests; it is implemented by *http.Client.
+type httpDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type storageClient struct {
+	raw     rawStorageClient
+	http    httpDoer
+	baseURL string
+}
+
+func newStorageClient(raw *lxd.Client) *storageClient {
+	return &storageClient{
+		raw:     raw,
+		http:    &raw.Http,
+		baseURL: raw.BaseURL,
+	}
+}
+
+// storageResponse holds the parts of an LXD API response used by the
+// storage client. The storage API is newer than the LXD client library
+// so it is called directly.
+type storageResponse struct {
+	Type      string          `json:"type"`
+	Error     string          `json:"error"`
+	ErrorCode int             `json:"error_code"`
+	Operation string          `json:"operation"`
+	Metadata  json.RawMessage `json:"metadata"`
+}
+
+// request sends a request to the LXD storage API, waiting for the
+// operation to complete if it is asynchronous, and returns the
+// metadata of the response.
+func (client *storageClient) request(method string, elem []string, query url.Values, body interface{}) (json.RawMessage, error) {
+	u := client.baseURL + "/" + path.Join("1.0", "storage-pools", path.Join(escapePath(elem)...))
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	req, err := http.NewRequest(method, u, &reqBody)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.http.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	var result storageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Annotatef(err, "cannot decode response to %s %s", method, u)
+	}
+	switch result.Type {
+	case "error":
+		if result.ErrorCode == http.StatusNotFound {
+			return nil, errors.NewNotFound(nil, result.Error)
+		}
+		return nil, errors.New(result.Error)
+	case "async":
+		if err := client.raw.WaitForSuccess(result.Operation); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result.Metadata, nil
+}
+
+func escapePath(elem []string) []string {
+	escaped := make([]string, len(elem))
+	for i, e := range elem {
+		escaped[i] = url.QueryEscape(e)
+	}
+	return escaped
+}
+
+// StorageVolumes returns the custom storage volumes in the pool.
+func (client *storageClient) StorageVolumes(pool string) ([]StorageVolume, error) {
+	query := url.Values{"recursion": {"1"}}
+	metadata, err := client.request("GET", []string{pool, "volumes"}, query, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing volumes in storage pool %q", pool)
+	}
+	var all []StorageVolume
+	if err := json.Unmarshal(metadata, &all); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var volumes []StorageVolume
+	for _, volume := range all {
+		if volume.Type == storageVolumeTypeCustom {
+			volumes = append(volumes, volume)
+		}
+	}
+	return volumes, nil
+}
+
+// CreateStorageVolume creates a custom storage volume in the pool,
+// with the given configuration.
+func (client *storageClient) CreateStorageVolume(pool, name string, config map[string]string) error {
+	volume := StorageVolume{
+		Name:   name,
+		Type:   storageVolumeTypeCustom,
+		Config: config,
+	}
+	if _, err := client.request("POST", []string{pool, "volumes"}, nil, volume); err != nil {
+		return errors.Annotatef(err, "creating volume %q in storage pool %q", name, pool)
+	}
+	return nil
+}
+
+// RemoveStorageVolume removes the custom storage volume from the pool.
+func (client *storageClient) RemoveStorageVolume(pool, name string) error {
+	elem := []string{pool, "volumes", storageVolumeTypeCustom, name}
+	if _, err := client.request("DELETE", elem, nil, nil); err != nil {
+		return errors.Annotatef(err, "removing volume %q from storage pool %q", name, pool)
+	}
+	return nil
+}
+
+// AttachDisk adds the disk device with the given name to the
+// container. Nothing is done if the container already has a device
+// with that name.
+func (client *storageClient) AttachDisk(container, device string, disk DiskDevice) error {
+	info, err := client.raw.ContainerInfo(container)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := info.Devices[device]; ok {
+		return nil
+	}
+	resp, err := client.raw.ContainerDeviceAdd(container, device, "disk", disk.props())
+	if err != nil {
+		return errors.Annotatef(err, "attaching disk %q to %q", device, container)
+	}
+	if resp != nil && resp.Operation != "" {
+		if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// DetachDisk removes the disk device with the given name from the
+// container.
+func (client *storageClient) DetachDisk(container, device string) error {
+	resp, err := client.raw.ContainerDeviceDelete(container, device)
+	if err != nil {
+		return errors.Annotatef(err, "detaching disk %q from %q", device, container)
+	}
+	if resp != nil && resp.Operation != "" {
+		if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
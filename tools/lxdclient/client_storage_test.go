@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared"
+	gc "gopkg.in/check.v1"
+)
+
+type storageSuite struct {
+	testing.IsolationSuite
+	stub   *testing.Stub
+	raw    *stubRawStorageClient
+	http   *stubHTTPDoer
+	client *storageClient
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.raw = &stubRawStorageClient{stub: s.stub}
+	s.http = &stubHTTPDoer{stub: s.stub}
+	s.client = &storageClient{
+		raw:     s.raw,
+		http:    s.http,
+		baseURL: "https://lxd",
+	}
+}
+
+type stubRawStorageClient struct {
+	stub     *testing.Stub
+	response *lxd.Response
+	devices  shared.Devices
+}
+
+func (s *stubRawStorageClient) ContainerInfo(name string) (*shared.ContainerInfo, error) {
+	s.stub.AddCall("ContainerInfo", name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &shared.ContainerInfo{Name: name, Devices: s.devices}, nil
+}
+
+func (s *stubRawStorageClient) ContainerDeviceAdd(container, devname, devtype string, props []string) (*lxd.Response, error) {
+	s.stub.AddCall("ContainerDeviceAdd", container, devname, devtype, props)
+	return s.response, s.stub.NextErr()
+}
+
+func (s *stubRawStorageClient) ContainerDeviceDelete(container, devname string) (*lxd.Response, error) {
+	s.stub.AddCall("ContainerDeviceDelete", container, devname)
+	return s.response, s.stub.NextErr()
+}
+
+func (s *stubRawStorageClient) WaitForSuccess(waitURL string) error {
+	s.stub.AddCall("WaitForSuccess", waitURL)
+	return s.stub.NextErr()
+}
+
+type stubHTTPDoer struct {
+	stub     *testing.Stub
+	response string
+	body     string
+}
+
+func (s *stubHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	s.stub.AddCall("Do", req.Method, req.URL.String())
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		s.body = string(body)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(s.response)),
+	}, nil
+}
+
+func (s *storageSuite) TestStorageVolumes(c *gc.C) {
+	s.http.response = `{"type": "sync", "metadata": [
+		{"name": "juju-fs-0", "type": "custom", "config": {"size": "1024MiB"}},
+		{"name": "juju-machine-0", "type": "container", "config": {}}
+	]}`
+
+	volumes, err := s.client.StorageVolumes("default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, jc.DeepEquals, []StorageVolume{{
+		Name:   "juju-fs-0",
+		Type:   "custom",
+		Config: map[string]string{"size": "1024MiB"},
+	}})
+	s.stub.CheckCall(c, 0, "Do", "GET", "https://lxd/1.0/storage-pools/default/volumes?recursion=1")
+}
+
+func (s *storageSuite) TestCreateStorageVolume(c *gc.C) {
+	s.http.response = `{"type": "sync", "metadata": {}}`
+
+	err := s.client.CreateStorageVolume("default", "juju-fs-0", map[string]string{"size": "1024MiB"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCall(c, 0, "Do", "POST", "https://lxd/1.0/storage-pools/default/volumes")
+	c.Assert(s.http.body, jc.JSONEquals, StorageVolume{
+		Name:   "juju-fs-0",
+		Type:   "custom",
+		Config: map[string]string{"size": "1024MiB"},
+	})
+}
+
+func (s *storageSuite) TestCreateStorageVolumeAsync(c *gc.C) {
+	s.http.response = `{"type": "async", "operation": "/1.0/operations/1234"}`
+
+	err := s.client.CreateStorageVolume("default", "juju-fs-0", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Do", "WaitForSuccess")
+	s.stub.CheckCall(c, 1, "WaitForSuccess", "/1.0/operations/1234")
+}
+
+func (s *storageSuite) TestRemoveStorageVolumeNotFound(c *gc.C) {
+	s.http.response = `{"type": "error", "error": "not found", "error_code": 404}`
+
+	err := s.client.RemoveStorageVolume("default", "juju-fs-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `removing volume "juju-fs-0" from storage pool "default": not found`)
+	s.stub.CheckCall(c, 0, "Do", "DELETE", "https://lxd/1.0/storage-pools/default/volumes/custom/juju-fs-0")
+}
+
+func (s *storageSuite) TestAttachDisk(c *gc.C) {
+	err := s.client.AttachDisk("juju-machine-0", "juju-fs-0", DiskDevice{
+		Pool:     "default",
+		Source:   "juju-fs-0",
+		Path:     "/srv/data",
+		ReadOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerInfo", []interface{}{"juju-machine-0"}},
+		{"ContainerDeviceAdd", []interface{}{
+			"juju-machine-0", "juju-fs-0", "disk",
+			[]string{"pool=default", "source=juju-fs-0", "path=/srv/data", "readonly=true"},
+		}},
+	})
+}
+
+func (s *storageSuite) TestAttachDiskAlreadyAttached(c *gc.C) {
+	s.raw.devices = shared.Devices{
+		"juju-fs-0": shared.Device{"type": "disk"},
+	}
+
+	err := s.client.AttachDisk("juju-machine-0", "juju-fs-0", DiskDevice{
+		Pool:   "default",
+		Source: "juju-fs-0",
+		Path:   "/srv/data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "ContainerInfo")
+}
+
+func (s *storageSuite) TestDetachDisk(c *gc.C) {
+	s.raw.response = &lxd.Response{Operation: "/1.0/operations/1234"}
+
+	err := s.client.DetachDisk("juju-machine-0", "juju-fs-0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerDeviceDelete", []interface{}{"juju-machine-0", "juju-fs-0"}},
+		{"WaitForSuccess", []interface{}{"/1.0/operations/1234"}},
+	})
+}
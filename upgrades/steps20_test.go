@@ -0,0 +1,24 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type steps20Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps20Suite{})
+
+func (s *steps20Suite) TestStateStepsFor20(c *gc.C) {
+	expected := []string{
+		"grant add-model access to existing users",
+	}
+	assertStateSteps(c, version.MustParse("2.0.0"), expected)
+}
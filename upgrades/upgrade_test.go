@@ -671,6 +671,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 	c.Assert(versions, gc.DeepEquals, []string{
 		// TODO(axw) change to 2.0 when we update version
 		"1.26.0",
+		"2.0.0",
 	})
 }
 
@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/status"
+)
+
+// failedStatusPrefix starts the controller model's status message
+// while the most recent scheduled backup has failed.
+const failedStatusPrefix = "scheduled backup failed: "
+
+// NewStateBackend returns a Backend which makes backups of the
+// controller whose state is st, from the machine with the given ID.
+func NewStateBackend(st *state.State, paths backups.Paths, machineID string) Backend {
+	return &stateBackend{
+		State:     st,
+		paths:     paths,
+		machineID: machineID,
+	}
+}
+
+type stateBackend struct {
+	*state.State
+	paths     backups.Paths
+	machineID string
+}
+
+// CreateBackup is part of the Backend interface.
+func (b *stateBackend) CreateBackup() (*backups.Metadata, error) {
+	stor, err := backups.NewStorage(b.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+
+	session := b.MongoSession().Copy()
+	defer session.Close()
+
+	dbInfo, err := backups.NewDBInfo(b.MongoConnectionInfo(), session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.State, b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = "scheduled backup"
+	meta.Scheduled = true
+
+	cfg, err := b.State.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	enc := backups.Encryption{PublicKey: cfg.BackupPublicKey()}
+	if err := backups.NewBackups(stor).Create(meta, &b.paths, dbInfo, enc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// ListBackups is part of the Backend interface.
+func (b *stateBackend) ListBackups() ([]*backups.Metadata, error) {
+	stor, err := backups.NewStorage(b.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b *stateBackend) RemoveBackup(id string) error {
+	stor, err := backups.NewStorage(b.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
+
+// SetBackupStatus is part of the Backend interface. A failure is shown
+// in the controller model's status message until a scheduled backup
+// next succeeds; the status of a model which is no longer available
+// is left alone.
+func (b *stateBackend) SetBackupStatus(backupErr error) error {
+	model, err := b.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := model.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != status.StatusAvailable {
+		return nil
+	}
+	if backupErr != nil {
+		return model.SetStatus(status.StatusAvailable, failedStatusPrefix+backupErr.Error(), nil)
+	}
+	if strings.HasPrefix(current.Message, failedStatusPrefix) {
+		return model.SetStatus(status.StatusAvailable, "", nil)
+	}
+	return nil
+}
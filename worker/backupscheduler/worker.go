@@ -0,0 +1,201 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker which backs up the
+// controller on the schedule given in the controller's config, and
+// removes the scheduled backups which its retention policy no longer
+// keeps.
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend exposes the controller functionality needed by a Worker.
+type Backend interface {
+
+	// ModelConfig returns the controller model's config.
+	ModelConfig() (*config.Config, error)
+
+	// WatchForModelConfigChanges returns a watcher which reports
+	// when the controller model's config changes.
+	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// CreateBackup creates and stores a new scheduled backup.
+	CreateBackup() (*backups.Metadata, error)
+
+	// ListBackups returns the metadata of all stored backups.
+	ListBackups() ([]*backups.Metadata, error)
+
+	// RemoveBackup removes the stored backup with the given ID.
+	RemoveBackup(id string) error
+
+	// SetBackupStatus records the outcome of the most recent
+	// scheduled backup in the controller's status; err is nil if
+	// the backup succeeded.
+	SetBackupStatus(err error) error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker creates scheduled backups of the controller and prunes old
+// ones according to the controller's backup retention policy.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchForModelConfigChanges()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		schedule *corebackups.Schedule
+		policy   corebackups.RetentionPolicy
+		timer    <-chan time.Time
+	)
+	resetTimer := func() {
+		timer = nil
+		if schedule == nil {
+			return
+		}
+		now := w.config.Clock.Now()
+		next := schedule.Next(now)
+		if next.IsZero() {
+			logger.Warningf("backup schedule never matches; no backups will be made")
+			return
+		}
+		logger.Debugf("next scheduled backup at %s", next)
+		timer = w.config.Clock.After(next.Sub(now))
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			cfg, err := w.config.Backend.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot read backup schedule")
+			}
+			schedule = cfg.BackupSchedule()
+			policy = cfg.BackupRetentionPolicy()
+			resetTimer()
+		case <-timer:
+			if err := w.backup(policy); err != nil {
+				return errors.Trace(err)
+			}
+			resetTimer()
+		}
+	}
+}
+
+// backup creates a scheduled backup, records the outcome and removes
+// the scheduled backups which policy no longer keeps. A failure to
+// back up is reported rather than stopping the worker.
+func (w *Worker) backup(policy corebackups.RetentionPolicy) error {
+	logger.Infof("creating scheduled backup")
+	meta, err := w.config.Backend.CreateBackup()
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+	} else {
+		logger.Infof("created scheduled backup %q", meta.ID())
+	}
+	if err := w.config.Backend.SetBackupStatus(err); err != nil {
+		return errors.Annotate(err, "cannot set backup status")
+	}
+	if err != nil {
+		// Don't remove old backups when a new one couldn't be made.
+		return nil
+	}
+	w.prune(policy)
+	return nil
+}
+
+// prune removes the scheduled backups which policy does not keep.
+// Backups created by hand are never removed.
+func (w *Worker) prune(policy corebackups.RetentionPolicy) {
+	if policy.IsZero() {
+		return
+	}
+	metas, err := w.config.Backend.ListBackups()
+	if err != nil {
+		logger.Errorf("cannot list backups to prune: %v", err)
+		return
+	}
+	var scheduled []corebackups.Backup
+	for _, meta := range metas {
+		if meta.Scheduled {
+			scheduled = append(scheduled, corebackups.Backup{
+				ID:      meta.ID(),
+				Started: meta.Started,
+			})
+		}
+	}
+	for _, id := range policy.Expired(scheduled, w.config.Clock.Now()) {
+		if err := w.config.Backend.RemoveBackup(id); err != nil {
+			logger.Errorf("cannot remove expired backup %q: %v", id, err)
+			continue
+		}
+		logger.Infof("removed expired backup %q", id)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	clock   *coretesting.Clock
+	backend *stubBackend
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+// startTime is half an hour before the next hourly backup.
+var startTime = time.Date(2016, 6, 1, 10, 30, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(startTime)
+	s.backend = newStubBackend(c, coretesting.Attrs{
+		"backup-schedule":  "@hourly",
+		"backup-keep-last": 2,
+	})
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *backupscheduler.Worker {
+	w, err := backupscheduler.New(backupscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w.(*backupscheduler.Worker)
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait for the next backup")
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.New(backupscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = backupscheduler.New(backupscheduler.Config{Backend: s.backend})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	s.backend = newStubBackend(c, nil)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case <-s.clock.Alarms():
+		c.Fatalf("unexpected wait for a backup")
+	case <-time.After(coretesting.ShortWait):
+	}
+	c.Check(s.backend.getCalls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestScheduledBackup(c *gc.C) {
+	s.backend.backups = []*backups.Metadata{
+		makeMetadata("manual", startTime.Add(-4*time.Hour), false),
+		makeMetadata("oldest", startTime.Add(-3*time.Hour), true),
+		makeMetadata("older", startTime.Add(-2*time.Hour), true),
+		makeMetadata("newer", startTime.Add(-time.Hour), true),
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(29 * time.Minute)
+	c.Check(s.backend.getCalls(), gc.HasLen, 0)
+
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+	c.Check(s.backend.getCalls(), jc.DeepEquals, []string{
+		"CreateBackup",
+		"SetBackupStatus(<nil>)",
+		"ListBackups",
+		"RemoveBackup(oldest)",
+		"RemoveBackup(older)",
+	})
+}
+
+func (s *WorkerSuite) TestBackupFailure(c *gc.C) {
+	s.backend.createErr = errors.New("disk full")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	s.waitAlarm(c)
+	c.Check(s.backend.getCalls(), jc.DeepEquals, []string{
+		"CreateBackup",
+		"SetBackupStatus(disk full)",
+	})
+
+	// The next backup is still attempted.
+	s.clock.Advance(time.Hour)
+	s.waitAlarm(c)
+	c.Check(s.backend.getCalls(), gc.HasLen, 4)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestSetStatusError(c *gc.C) {
+	s.backend.statusErr = errors.New("boom")
+	w := s.startWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "cannot set backup status: boom")
+}
+
+func (s *WorkerSuite) TestConfigChange(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitAlarm(c)
+
+	s.backend.setConfig(c, coretesting.Attrs{"backup-schedule": "0 12 * * *"})
+	s.waitAlarm(c)
+
+	// The hourly backup is no longer made.
+	s.clock.Advance(time.Hour)
+	c.Check(s.backend.getCalls(), gc.HasLen, 0)
+
+	s.clock.Advance(30 * time.Minute)
+	s.waitAlarm(c)
+	c.Check(s.backend.getCalls(), jc.DeepEquals, []string{
+		"CreateBackup",
+		"SetBackupStatus(<nil>)",
+		"ListBackups",
+	})
+}
+
+func makeMetadata(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+type stubBackend struct {
+	mu        sync.Mutex
+	cfg       *config.Config
+	changes   chan struct{}
+	calls     []string
+	backups   []*backups.Metadata
+	createErr error
+	statusErr error
+}
+
+func newStubBackend(c *gc.C, attrs coretesting.Attrs) *stubBackend {
+	b := &stubBackend{changes: make(chan struct{}, 1)}
+	b.setConfig(c, attrs)
+	return b
+}
+
+func (b *stubBackend) setConfig(c *gc.C, attrs coretesting.Attrs) {
+	b.mu.Lock()
+	b.cfg = coretesting.CustomModelConfig(c, attrs)
+	b.mu.Unlock()
+	b.changes <- struct{}{}
+}
+
+func (b *stubBackend) addCall(call string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, call)
+}
+
+func (b *stubBackend) getCalls() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+func (b *stubBackend) ModelConfig() (*config.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, nil
+}
+
+func (b *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	return &stubWatcher{changes: b.changes}
+}
+
+func (b *stubBackend) CreateBackup() (*backups.Metadata, error) {
+	b.addCall("CreateBackup")
+	if b.createErr != nil {
+		return nil, b.createErr
+	}
+	meta := makeMetadata("new", startTime.Add(30*time.Minute), true)
+	b.mu.Lock()
+	b.backups = append(b.backups, meta)
+	b.mu.Unlock()
+	return meta, nil
+}
+
+func (b *stubBackend) ListBackups() ([]*backups.Metadata, error) {
+	b.addCall("ListBackups")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.backups, nil
+}
+
+func (b *stubBackend) RemoveBackup(id string) error {
+	b.addCall("RemoveBackup(" + id + ")")
+	return nil
+}
+
+func (b *stubBackend) SetBackupStatus(err error) error {
+	b.addCall("SetBackupStatus(" + errString(err) + ")")
+	return b.statusErr
+}
+
+func errString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
+
+type stubWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+}
+
+func (w *stubWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *stubWatcher) Kill() {}
+
+func (w *stubWatcher) Wait() error {
+	return nil
+}
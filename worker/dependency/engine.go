@@ -11,11 +11,22 @@ import (
 	"github.com/juju/utils/set"
 	"launchpad.net/tomb"
 
+	"github.com/juju/juju/instrument"
 	"github.com/juju/juju/worker"
 )
 
 var logger = loggo.GetLogger("juju.worker.dependency")
 
+// workerRestarts counts the workers restarted by all engines in the
+// process, by manifold name and the reason for the restart: "dependency"
+// when the engine stopped the worker because its inputs changed,
+// "bounce" when it asked to be restarted, and "error" when it failed.
+var workerRestarts = instrument.Default.NewCounterVec(
+	"juju_dependency_engine_worker_restarts_total",
+	"Workers restarted by dependency engines, by manifold and reason.",
+	"manifold", "reason",
+)
+
 // EngineConfig defines the parameters needed to create a new engine.
 type EngineConfig struct {
 
@@ -503,6 +514,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	// If we told the worker to stop, we should start it again immediately,
 	// whatever else happened.
 	if info.stopping {
+		workerRestarts.With(name, "dependency").Inc()
 		engine.requestStart(name, engine.config.BounceDelay)
 	} else {
 		// If we didn't stop it ourselves, we need to interpret the error.
@@ -517,6 +529,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 			// anyway).
 		case ErrBounce:
 			// The task exited but wanted to restart immediately.
+			workerRestarts.With(name, "bounce").Inc()
 			engine.requestStart(name, engine.config.BounceDelay)
 		case ErrUninstall:
 			// The task should never run again, and can be removed completely.
@@ -524,6 +537,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 		default:
 			// Something went wrong but we don't know what. Try again soon.
 			logger.Errorf("%q manifold worker returned unexpected error: %v", name, err)
+			workerRestarts.With(name, "error").Inc()
 			engine.requestStart(name, engine.config.ErrorDelay)
 		}
 	}
@@ -39,7 +39,7 @@ type Firewaller struct {
 	serviceds       map[names.ServiceTag]*serviceData
 	exposedChange   chan *exposedChange
 	globalMode      bool
-	globalPortRef   map[network.PortRange]int
+	globalRuleRef   map[network.IngressRule]int
 	machinePorts    map[names.MachineTag]machineRanges
 }
 
@@ -89,7 +89,7 @@ func (fw *Firewaller) setUp() error {
 	case config.FwInstance:
 	case config.FwGlobal:
 		fw.globalMode = true
-		fw.globalPortRef = make(map[network.PortRange]int)
+		fw.globalRuleRef = make(map[network.IngressRule]int)
 	case config.FwNone:
 		logger.Infof("stopping firewaller (not required)")
 		fw.Kill()
@@ -181,7 +181,7 @@ func (fw *Firewaller) loop() error {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
-			change.serviced.exposed = change.exposed
+			change.serviced.sourceCIDRs = change.sourceCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -200,7 +200,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		fw:           fw,
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
-		openedPorts:  make([]network.PortRange, 0),
+		openedRules:  make([]network.IngressRule, 0),
 		definedPorts: make(map[network.PortRange]names.UnitTag),
 	}
 	m, err := machined.machine()
@@ -307,20 +307,20 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startService creates a new data value for tracking details of the
 // service and starts watching the service for exposure changes.
 func (fw *Firewaller) startService(service *firewaller.Service) error {
-	exposed, err := service.IsExposed()
+	sourceCIDRs, err := service.ExposedSourceCIDRs()
 	if err != nil {
 		return err
 	}
 	serviced := &serviceData{
-		fw:      fw,
-		service: service,
-		exposed: exposed,
-		unitds:  make(map[names.UnitTag]*unitData),
+		fw:          fw,
+		service:     service,
+		sourceCIDRs: sourceCIDRs,
+		unitds:      make(map[names.UnitTag]*unitData),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(sourceCIDRs)
 		},
 	})
 	if err != nil {
@@ -334,14 +334,15 @@ func (fw *Firewaller) startService(service *firewaller.Service) error {
 }
 
 // reconcileGlobal compares the initially started watcher for machines,
-// units and services with the opened and closed ports globally and
-// opens and closes the appropriate ports for the whole environment.
+// units and services with the opened and closed ingress rules globally
+// and opens and closes the appropriate rules for the whole environment.
 func (fw *Firewaller) reconcileGlobal() error {
-	initialPortRanges, err := fw.environ.Ports()
+	environFirewaller := environIngressFirewaller(fw.environ)
+	initialRules, err := environFirewaller.IngressRules()
 	if err != nil {
 		return err
 	}
-	collector := make(map[network.PortRange]bool)
+	collector := make(map[network.IngressRule]bool)
 	for _, machined := range fw.machineds {
 		for portRange, unitTag := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -349,38 +350,38 @@ func (fw *Firewaller) reconcileGlobal() error {
 				delete(machined.unitds, unitTag)
 				continue
 			}
-			if unitd.serviced.exposed {
-				collector[portRange] = true
+			for _, rule := range unitd.serviced.ingressRules(portRange) {
+				collector[rule] = true
 			}
 		}
 	}
-	wantedPorts := []network.PortRange{}
-	for port := range collector {
-		wantedPorts = append(wantedPorts, port)
+	wantedRules := []network.IngressRule{}
+	for rule := range collector {
+		wantedRules = append(wantedRules, rule)
 	}
-	// Check which ports to open or to close.
-	toOpen := diffRanges(wantedPorts, initialPortRanges)
-	toClose := diffRanges(initialPortRanges, wantedPorts)
+	// Check which rules to open or to close.
+	toOpen := diffRules(wantedRules, initialRules)
+	toClose := diffRules(initialRules, wantedRules)
 	if len(toOpen) > 0 {
-		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		logger.Infof("opening global ingress rules %v", toOpen)
+		if err := environFirewaller.OpenIngressRules(toOpen); err != nil {
 			return err
 		}
-		network.SortPortRanges(toOpen)
+		network.SortIngressRules(toOpen)
 	}
 	if len(toClose) > 0 {
-		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		logger.Infof("closing global ingress rules %v", toClose)
+		if err := environFirewaller.CloseIngressRules(toClose); err != nil {
 			return err
 		}
-		network.SortPortRanges(toClose)
+		network.SortIngressRules(toClose)
 	}
 	return nil
 }
 
 // reconcileInstances compares the initially started watcher for machines,
-// units and services with the opened and closed ports of the instances and
-// opens and closes the appropriate ports for each instance.
+// units and services with the opened and closed ingress rules of the
+// instances and opens and closes the appropriate rules for each instance.
 func (fw *Firewaller) reconcileInstances() error {
 	for _, machined := range fw.machineds {
 		m, err := machined.machine()
@@ -406,32 +407,32 @@ func (fw *Firewaller) reconcileInstances() error {
 		} else if err != nil {
 			return err
 		}
-		machineId := machined.tag.Id()
-		initialPortRanges, err := instances[0].Ports(machineId)
+		instanceFirewaller := instanceIngressFirewaller(instances[0], machined.tag.Id())
+		initialRules, err := instanceFirewaller.IngressRules()
 		if err != nil {
 			return err
 		}
 
-		// Check which ports to open or to close.
-		toOpen := diffRanges(machined.openedPorts, initialPortRanges)
-		toClose := diffRanges(initialPortRanges, machined.openedPorts)
+		// Check which rules to open or to close.
+		toOpen := diffRules(machined.openedRules, initialRules)
+		toClose := diffRules(initialRules, machined.openedRules)
 		if len(toOpen) > 0 {
-			logger.Infof("opening instance port ranges %v for %q",
+			logger.Infof("opening instance ingress rules %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			if err := instanceFirewaller.OpenIngressRules(toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toOpen)
+			network.SortIngressRules(toOpen)
 		}
 		if len(toClose) > 0 {
-			logger.Infof("closing instance port ranges %v for %q",
+			logger.Infof("closing instance ingress rules %v for %q",
 				toClose, machined.tag)
-			if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+			if err := instanceFirewaller.CloseIngressRules(toClose); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
-			network.SortPortRanges(toClose)
+			network.SortIngressRules(toClose)
 		}
 	}
 	return nil
@@ -550,70 +551,69 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
-// flushMachine opens and closes ports for the passed machine.
+// flushMachine opens and closes ingress rules for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
-	// Gather ports to open and close.
-	want := []network.PortRange{}
+	// Gather rules to open and close.
+	want := []network.IngressRule{}
 	for portRange, unitTag := range machined.definedPorts {
 		unitd, known := machined.unitds[unitTag]
 		if !known {
 			delete(machined.unitds, unitTag)
 			continue
 		}
-		if unitd.serviced.exposed {
-			want = append(want, portRange)
-		}
+		want = append(want, unitd.serviced.ingressRules(portRange)...)
 	}
-	toOpen := diffRanges(want, machined.openedPorts)
-	toClose := diffRanges(machined.openedPorts, want)
-	machined.openedPorts = want
+	toOpen := diffRules(want, machined.openedRules)
+	toClose := diffRules(machined.openedRules, want)
+	machined.openedRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		return fw.flushGlobalRules(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.flushInstanceRules(machined, toOpen, toClose)
 }
 
-// flushGlobalPorts opens and closes global ports in the environment.
-// It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
-// modify the environment.
-func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.PortRange) error {
-	// Filter which ports are really to open or close.
-	var toOpen, toClose []network.PortRange
-	for _, portRange := range rawOpen {
-		if fw.globalPortRef[portRange] == 0 {
-			toOpen = append(toOpen, portRange)
+// flushGlobalRules opens and closes global ingress rules in the
+// environment. It keeps a reference count for rules so that only 0-to-1
+// and 1-to-0 events modify the environment.
+func (fw *Firewaller) flushGlobalRules(rawOpen, rawClose []network.IngressRule) error {
+	// Filter which rules are really to open or close.
+	var toOpen, toClose []network.IngressRule
+	for _, rule := range rawOpen {
+		if fw.globalRuleRef[rule] == 0 {
+			toOpen = append(toOpen, rule)
 		}
-		fw.globalPortRef[portRange]++
+		fw.globalRuleRef[rule]++
 	}
-	for _, portRange := range rawClose {
-		fw.globalPortRef[portRange]--
-		if fw.globalPortRef[portRange] == 0 {
-			toClose = append(toClose, portRange)
-			delete(fw.globalPortRef, portRange)
+	for _, rule := range rawClose {
+		fw.globalRuleRef[rule]--
+		if fw.globalRuleRef[rule] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalRuleRef, rule)
 		}
 	}
-	// Open and close the ports.
+	// Open and close the rules.
+	environFirewaller := environIngressFirewaller(fw.environ)
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := environFirewaller.OpenIngressRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v in environment", toOpen)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environ.ClosePorts(toClose); err != nil {
+		if err := environFirewaller.CloseIngressRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v in environment", toClose)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v in environment", toClose)
 	}
 	return nil
 }
 
-// flushInstancePorts opens and closes ports global on the machine.
-func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.PortRange) error {
+// flushInstanceRules opens and closes ingress rules on the machine.
+func (fw *Firewaller) flushInstanceRules(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
 	// This is important because when a machine is first created,
 	// it will have no instance id but also no open ports -
@@ -628,7 +628,6 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	machineId := machined.tag.Id()
 	instanceId, err := m.InstanceId()
 	if err != nil {
 		return err
@@ -637,22 +636,23 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	if err != nil {
 		return err
 	}
-	// Open and close the ports.
+	instanceFirewaller := instanceIngressFirewaller(instances[0], machined.tag.Id())
+	// Open and close the rules.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		if err := instanceFirewaller.OpenIngressRules(toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toOpen)
-		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened ingress rules %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
+		if err := instanceFirewaller.CloseIngressRules(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
-		network.SortPortRanges(toClose)
-		logger.Infof("closed port ranges %v on %q", toClose, machined.tag)
+		network.SortIngressRules(toClose)
+		logger.Infof("closed ingress rules %v on %q", toClose, machined.tag)
 	}
 	return nil
 }
@@ -751,7 +751,7 @@ type machineData struct {
 	fw          *Firewaller
 	tag         names.MachineTag
 	unitds      map[names.UnitTag]*unitData
-	openedPorts []network.PortRange
+	openedRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[network.PortRange]names.UnitTag
 }
@@ -801,10 +801,11 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposure source CIDRs for one
+// specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced    *serviceData
+	sourceCIDRs []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -812,12 +813,26 @@ type serviceData struct {
 	catacomb catacomb.Catacomb
 	fw       *Firewaller
 	service  *firewaller.Service
-	exposed  bool
-	unitds   map[names.UnitTag]*unitData
+	// sourceCIDRs holds the CIDRs the service is exposed to; it is
+	// empty when the service is not exposed.
+	sourceCIDRs []string
+	unitds      map[names.UnitTag]*unitData
+}
+
+// ingressRules returns the ingress rules opening the port range to
+// each of the CIDRs the service is exposed to.
+func (sd *serviceData) ingressRules(portRange network.PortRange) []network.IngressRule {
+	rules := make([]network.IngressRule, len(sd.sourceCIDRs))
+	for i, cidr := range sd.sourceCIDRs {
+		rules[i] = network.NewIngressRule(portRange, cidr)
+	}
+	return rules
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// watchLoop watches the service's exposure for changes, and the
+// model's subnets, which determine the CIDRs of any spaces the service
+// is exposed to.
+func (sd *serviceData) watchLoop(sourceCIDRs []string) error {
 	serviceWatcher, err := sd.service.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -825,6 +840,13 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 	if err := sd.catacomb.Add(serviceWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	subnetsWatcher, err := sd.fw.st.WatchSubnets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := sd.catacomb.Add(subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
 	for {
 		select {
 		case <-sd.catacomb.Dying():
@@ -839,20 +861,26 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := sd.service.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				continue
+		case _, ok := <-subnetsWatcher.Changes():
+			if !ok {
+				return errors.New("subnets watcher closed")
 			}
+		}
+		change, err := sd.service.ExposedSourceCIDRs()
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if stringsEqual(change, sourceCIDRs) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
-			case <-sd.catacomb.Dying():
-				return sd.catacomb.ErrDying()
-			}
+		sourceCIDRs = change
+		select {
+		case sd.fw.exposedChange <- &exposedChange{sd, change}:
+		case <-sd.catacomb.Dying():
+			return sd.catacomb.ErrDying()
 		}
 	}
 }
@@ -867,8 +895,22 @@ func (sd *serviceData) Wait() error {
 	return sd.catacomb.Wait()
 }
 
-// diffRanges returns all the port rangess that exist in A but not B.
-func diffRanges(A, B []network.PortRange) (missing []network.PortRange) {
+// stringsEqual returns whether a and b hold the same strings in the
+// same order.
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diffRules returns all the ingress rules that exist in A but not B.
+func diffRules(A, B []network.IngressRule) (missing []network.IngressRule) {
 next:
 	for _, a := range A {
 		for _, b := range B {
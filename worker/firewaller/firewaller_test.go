@@ -115,7 +115,7 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Por
 }
 
 func (s *firewallerBaseSuite) addUnit(c *gc.C, svc *state.Service) (*state.Unit, *state.Machine) {
-	units, err := juju.AddUnits(s.State, svc, 1, nil)
+	units, err := juju.AddUnits(s.State, svc, 1, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	u := units[0]
 	id, err := u.AssignedMachineId()
@@ -200,6 +200,62 @@ func (s *InstanceModeSuite) TestExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), []network.PortRange{{8080, 8080, "tcp"}})
 }
 
+func (s *InstanceModeSuite) TestServiceExposedToCIDRs(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The dummy provider cannot restrict ports to particular CIDRs,
+	// so ports exposed only to those are never opened.
+	err = svc.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+
+	err = svc.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 80, "tcp"}})
+
+	// Restricting the exposure again closes the ports.
+	err = svc.SetExposedTo([]string{"10.0.0.0/8"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestServiceExposedToSpaces(c *gc.C) {
+	fw, err := firewaller.NewFirewaller(s.firewaller)
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	svc := s.AddTestingService(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, svc)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The space has no subnets yet, so there is nowhere to allow
+	// access from.
+	_, err = s.State.AddSpace("everywhere", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.SetExposedTo(nil, []string{"everywhere"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+
+	// Adding a subnet to the space changes the CIDRs the service is
+	// exposed to, without the service itself changing. The dummy
+	// provider only opens ports to all CIDRs, so use that subnet.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "0.0.0.0/0", SpaceName: "everywhere"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.PortRange{{80, 80, "tcp"}})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedServices(c *gc.C) {
 	fw, err := firewaller.NewFirewaller(s.firewaller)
 	c.Assert(err, jc.ErrorIsNil)
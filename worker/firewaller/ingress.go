@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewaller
+
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+// ingressFirewaller opens and closes ingress rules, either for the
+// whole environment or for a single machine's instance.
+type ingressFirewaller interface {
+	OpenIngressRules(rules []network.IngressRule) error
+	CloseIngressRules(rules []network.IngressRule) error
+	IngressRules() ([]network.IngressRule, error)
+}
+
+// environIngressFirewaller returns an ingressFirewaller for the whole
+// environment. Environs which cannot restrict ingress to particular
+// source CIDRs are managed through their port ranges.
+func environIngressFirewaller(env environs.Environ) ingressFirewaller {
+	if fw, ok := env.(environs.IngressFirewaller); ok {
+		return fw
+	}
+	return portsFirewaller{
+		openPorts:  env.OpenPorts,
+		closePorts: env.ClosePorts,
+		ports:      env.Ports,
+	}
+}
+
+// instanceIngressFirewaller returns an ingressFirewaller for the
+// instance of the machine with the given id. Instances which cannot
+// restrict ingress to particular source CIDRs are managed through
+// their port ranges.
+func instanceIngressFirewaller(inst instance.Instance, machineId string) ingressFirewaller {
+	if fw, ok := inst.(instance.InstanceIngressFirewaller); ok {
+		return machineIngressFirewaller{fw, machineId}
+	}
+	return portsFirewaller{
+		openPorts: func(ports []network.PortRange) error {
+			return inst.OpenPorts(machineId, ports)
+		},
+		closePorts: func(ports []network.PortRange) error {
+			return inst.ClosePorts(machineId, ports)
+		},
+		ports: func() ([]network.PortRange, error) {
+			return inst.Ports(machineId)
+		},
+	}
+}
+
+// machineIngressFirewaller adapts an instance.InstanceIngressFirewaller
+// to the ingressFirewaller interface for a single machine.
+type machineIngressFirewaller struct {
+	inst      instance.InstanceIngressFirewaller
+	machineId string
+}
+
+func (m machineIngressFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	return m.inst.OpenIngressRules(m.machineId, rules)
+}
+
+func (m machineIngressFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	return m.inst.CloseIngressRules(m.machineId, rules)
+}
+
+func (m machineIngressFirewaller) IngressRules() ([]network.IngressRule, error) {
+	return m.inst.IngressRules(m.machineId)
+}
+
+// portsFirewaller implements ingressFirewaller using port ranges
+// alone. Rules restricted to particular source CIDRs are never
+// opened, so that services are not made more widely accessible than
+// they were exposed to.
+type portsFirewaller struct {
+	openPorts  func([]network.PortRange) error
+	closePorts func([]network.PortRange) error
+	ports      func() ([]network.PortRange, error)
+}
+
+func (p portsFirewaller) OpenIngressRules(rules []network.IngressRule) error {
+	ports := portRangesOpenToAll(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return p.openPorts(ports)
+}
+
+func (p portsFirewaller) CloseIngressRules(rules []network.IngressRule) error {
+	ports := portRangesOpenToAll(rules)
+	if len(ports) == 0 {
+		return nil
+	}
+	return p.closePorts(ports)
+}
+
+func (p portsFirewaller) IngressRules() ([]network.IngressRule, error) {
+	ports, err := p.ports()
+	if err != nil {
+		return nil, err
+	}
+	return network.IngressRulesFromPortRanges(ports), nil
+}
+
+// portRangesOpenToAll returns the port ranges of the rules which allow
+// access from anywhere, logging those which are skipped.
+func portRangesOpenToAll(rules []network.IngressRule) []network.PortRange {
+	var ports []network.PortRange
+	for _, rule := range rules {
+		if !rule.OpenToAll() {
+			logger.Warningf("cannot restrict access to %v: not supported by the provider", rule)
+			continue
+		}
+		ports = append(ports, rule.PortRange)
+	}
+	return ports
+}
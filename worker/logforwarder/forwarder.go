@@ -0,0 +1,174 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+const (
+	// maxUnsavedRecords is the number of records which may be
+	// forwarded before the forwarding position is recorded.
+	maxUnsavedRecords = 100
+
+	// savePositionDelay is how long after a record is forwarded the
+	// forwarding position is recorded, if it has not been already.
+	savePositionDelay = time.Second
+)
+
+// forwarderConfig defines the operation of a forwarder.
+type forwarderConfig struct {
+	Sink       string
+	SinkConfig syslog.ClientConfig
+	Backend    Backend
+	OpenSender func(syslog.ClientConfig) (Sender, error)
+	Clock      clock.Clock
+}
+
+// forwarder sends logs to a single sink, resuming from where it last
+// left off. When a sink is first used, only logs written from then on
+// are sent.
+type forwarder struct {
+	catacomb catacomb.Catacomb
+	config   forwarderConfig
+}
+
+func newForwarder(config forwarderConfig) (worker.Worker, error) {
+	f := &forwarder{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &f.catacomb,
+		Work: f.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Kill implements worker.Worker.
+func (f *forwarder) Kill() {
+	f.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (f *forwarder) Wait() error {
+	return f.catacomb.Wait()
+}
+
+func (f *forwarder) loop() (err error) {
+	lastSent := f.config.Backend.LastSent(f.config.Sink)
+	pos, err := f.loadPosition(lastSent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	sender, err := f.config.OpenSender(f.config.SinkConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer sender.Close()
+
+	tailer, err := f.config.Backend.NewLogTailer(pos.time)
+	if err != nil {
+		return errors.Annotate(err, "cannot tail logs")
+	}
+	defer tailer.Stop()
+
+	var (
+		unsaved   int
+		saveTimer <-chan time.Time
+	)
+	save := func() error {
+		if unsaved == 0 {
+			return nil
+		}
+		if err := lastSent.SetPosition(pos.time, pos.ids.SortedValues()); err != nil {
+			return errors.Annotate(err, "cannot record log forwarding position")
+		}
+		unsaved = 0
+		saveTimer = nil
+		return nil
+	}
+	defer func() {
+		// Record the records which were sent before stopping, so
+		// that they are not sent again.
+		if saveErr := save(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}()
+
+	for {
+		select {
+		case <-f.catacomb.Dying():
+			return f.catacomb.ErrDying()
+		case rec, ok := <-tailer.Logs():
+			if !ok {
+				return errors.Annotate(tailer.Err(), "log tailer stopped")
+			}
+			if pos.sent(rec) {
+				continue
+			}
+			if err := sender.Send(rec); err != nil {
+				return errors.Annotatef(err, "cannot forward logs to %s", f.config.Sink)
+			}
+			pos.add(rec)
+			unsaved++
+			if unsaved >= maxUnsavedRecords {
+				if err := save(); err != nil {
+					return errors.Trace(err)
+				}
+			} else if saveTimer == nil {
+				saveTimer = f.config.Clock.After(savePositionDelay)
+			}
+		case <-saveTimer:
+			if err := save(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// loadPosition returns the position from which forwarding resumes.
+func (f *forwarder) loadPosition(lastSent LastSentTracker) (*position, error) {
+	t, ids, err := lastSent.GetPosition()
+	if errors.Cause(err) == state.ErrNeverForwarded {
+		logger.Infof("forwarding logs to %s for the first time", f.config.Sink)
+		return &position{time: f.config.Clock.Now(), ids: set.NewStrings()}, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read log forwarding position")
+	}
+	return &position{time: t, ids: set.NewStrings(ids...)}, nil
+}
+
+// position holds the time of the most recently forwarded records, and
+// the IDs of the records with that time which have been forwarded.
+type position struct {
+	time time.Time
+	ids  set.Strings
+}
+
+// sent returns whether the record has already been forwarded.
+func (p *position) sent(rec *state.LogRecord) bool {
+	return rec.Time.Equal(p.time) && p.ids.Contains(rec.ID)
+}
+
+// add records that the record has been forwarded.
+func (p *position) add(rec *state.LogRecord) {
+	switch {
+	case rec.Time.After(p.time):
+		p.time = rec.Time
+		p.ids = set.NewStrings(rec.ID)
+	case rec.Time.Equal(p.time):
+		p.ids.Add(rec.ID)
+	}
+}
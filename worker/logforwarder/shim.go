@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+)
+
+// NewStateBackend returns a Backend which forwards the logs of every
+// model in the controller whose state is st.
+func NewStateBackend(st *state.State) Backend {
+	return stateBackend{st}
+}
+
+type stateBackend struct {
+	*state.State
+}
+
+// NewLogTailer is part of the Backend interface.
+func (b stateBackend) NewLogTailer(start time.Time) (state.LogTailer, error) {
+	return state.NewLogTailer(b.State, &state.LogTailerParams{
+		StartTime: start,
+		AllModels: true,
+	})
+}
+
+// LastSent is part of the Backend interface.
+func (b stateBackend) LastSent(sink string) LastSentTracker {
+	return state.NewLastSentLogger(b.State, sink)
+}
+
+// OpenSyslogSender returns a Sender which sends log records to the
+// syslog collector described by cfg.
+func OpenSyslogSender(cfg syslog.ClientConfig) (Sender, error) {
+	client, err := syslog.Dial(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return syslogSender{client}, nil
+}
+
+type syslogSender struct {
+	client *syslog.Client
+}
+
+// Send is part of the Sender interface.
+func (s syslogSender) Send(rec *state.LogRecord) error {
+	return s.client.Send(syslogMessage(rec))
+}
+
+// Close is part of the Sender interface.
+func (s syslogSender) Close() error {
+	return s.client.Close()
+}
+
+// sdID identifies the structured data element holding the details of
+// a record which have no place in a syslog message's header. 28978 is
+// Canonical's IANA private enterprise number.
+const sdID = "juju@28978"
+
+// syslogMessage returns the syslog message which represents rec.
+func syslogMessage(rec *state.LogRecord) syslog.Message {
+	return syslog.Message{
+		Facility: syslog.FacilityUser,
+		Severity: syslogSeverity(rec.Level),
+		Time:     rec.Time,
+		Hostname: rec.Entity + "." + rec.ModelUUID,
+		AppName:  "juju",
+		StructuredData: []syslog.SDElement{{
+			ID: sdID,
+			Params: []syslog.Param{
+				{Name: "model-uuid", Value: rec.ModelUUID},
+				{Name: "entity", Value: rec.Entity},
+				{Name: "module", Value: rec.Module},
+				{Name: "location", Value: rec.Location},
+			},
+		}},
+		Msg: rec.Message,
+	}
+}
+
+func syslogSeverity(level loggo.Level) syslog.Severity {
+	switch level {
+	case loggo.CRITICAL:
+		return syslog.SeverityCritical
+	case loggo.ERROR:
+		return syslog.SeverityError
+	case loggo.WARNING:
+		return syslog.SeverityWarning
+	case loggo.INFO:
+		return syslog.SeverityInformational
+	default:
+		return syslog.SeverityDebug
+	}
+}
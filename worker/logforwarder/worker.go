@@ -0,0 +1,222 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logforwarder provides a worker which forwards the logs of
+// every model in the controller to the syslog collectors given in the
+// controller's config.
+package logforwarder
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.logforwarder")
+
+// Backend exposes the controller functionality needed by a Worker.
+type Backend interface {
+
+	// ModelConfig returns the controller model's config.
+	ModelConfig() (*config.Config, error)
+
+	// WatchForModelConfigChanges returns a watcher which reports
+	// when the controller model's config changes.
+	WatchForModelConfigChanges() state.NotifyWatcher
+
+	// NewLogTailer returns a tailer of the logs of every model
+	// which were written at or after start.
+	NewLogTailer(start time.Time) (state.LogTailer, error)
+
+	// LastSent returns the record of the logs forwarded to the
+	// named sink.
+	LastSent(sink string) LastSentTracker
+}
+
+// LastSentTracker records how far through the logs forwarding to a
+// sink has got; it is implemented by *state.DbLoggerLastSent.
+type LastSentTracker interface {
+
+	// GetPosition returns the time of the most recently forwarded
+	// log records and the IDs of the records forwarded with that
+	// time, or an error satisfying state.ErrNeverForwarded if
+	// nothing has been forwarded.
+	GetPosition() (time.Time, []string, error)
+
+	// SetPosition records the time of the most recently forwarded
+	// log records and the IDs of the records forwarded with that
+	// time.
+	SetPosition(t time.Time, recordIDs []string) error
+}
+
+// Sender sends log records to a sink.
+type Sender interface {
+	Send(rec *state.LogRecord) error
+	Close() error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Backend Backend
+
+	// OpenSender connects to the syslog collector described by
+	// the config.
+	OpenSender func(syslog.ClientConfig) (Sender, error)
+
+	Clock clock.Clock
+
+	// RetryDelay is how long to wait before forwarding to a sink
+	// again after it fails.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.OpenSender == nil {
+		return errors.NotValidf("nil OpenSender")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// New returns a Worker backed by config, or an error.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+		runner: worker.NewRunner(neverFatal, neverImportant, config.RetryDelay),
+		sinks:  make(map[string]syslog.ClientConfig),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{w.runner},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker runs a forwarder for each syslog collector given in the
+// controller's config, restarting it after RetryDelay whenever it
+// fails.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	runner   worker.Runner
+
+	// sinks holds the config of each running forwarder, keyed by
+	// the name of its sink.
+	sinks map[string]syslog.ClientConfig
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchForModelConfigChanges()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			cfg, err := w.config.Backend.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot read log forwarding config")
+			}
+			if err := w.update(cfg.LogForwardSyslogConfigs()); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// update starts and stops forwarders so that exactly one runs for
+// each of the given collectors.
+func (w *Worker) update(configs []syslog.ClientConfig) error {
+	wanted := make(map[string]syslog.ClientConfig)
+	for _, cfg := range configs {
+		wanted[sinkName(cfg)] = cfg
+	}
+	for sink, cfg := range w.sinks {
+		if wantedCfg, ok := wanted[sink]; ok && reflect.DeepEqual(cfg, wantedCfg) {
+			continue
+		}
+		logger.Infof("stopping log forwarding to %s", sink)
+		if err := w.runner.StopWorker(sink); err != nil {
+			return errors.Trace(err)
+		}
+		delete(w.sinks, sink)
+	}
+	for sink, cfg := range wanted {
+		if _, ok := w.sinks[sink]; ok {
+			continue
+		}
+		logger.Infof("starting log forwarding to %s", sink)
+		if err := w.runner.StartWorker(sink, w.starter(sink, cfg)); err != nil {
+			return errors.Trace(err)
+		}
+		w.sinks[sink] = cfg
+	}
+	return nil
+}
+
+func (w *Worker) starter(sink string, cfg syslog.ClientConfig) func() (worker.Worker, error) {
+	return func() (worker.Worker, error) {
+		return newForwarder(forwarderConfig{
+			Sink:       sink,
+			SinkConfig: cfg,
+			Backend:    w.config.Backend,
+			OpenSender: w.config.OpenSender,
+			Clock:      w.config.Clock,
+		})
+	}
+}
+
+// sinkName returns the name under which the position of forwarding to
+// the collector is recorded.
+func sinkName(cfg syslog.ClientConfig) string {
+	return "syslog:" + cfg.Address
+}
+
+func neverFatal(error) bool {
+	return false
+}
+
+func neverImportant(error, error) bool {
+	return false
+}
@@ -0,0 +1,450 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	clock   *coretesting.Clock
+	backend *stubBackend
+	senders chan *stubSender
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var startTime = time.Date(2016, 6, 1, 10, 30, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(startTime)
+	s.backend = newStubBackend(c, coretesting.Attrs{
+		"logforward-syslog-hosts": "logs.example.com:6514",
+	})
+	s.senders = make(chan *stubSender, 10)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *logforwarder.Worker {
+	w, err := logforwarder.New(logforwarder.Config{
+		Backend:    s.backend,
+		OpenSender: s.openSender,
+		Clock:      s.clock,
+		RetryDelay: time.Millisecond,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w.(*logforwarder.Worker)
+}
+
+func (s *WorkerSuite) openSender(cfg syslog.ClientConfig) (logforwarder.Sender, error) {
+	sender := &stubSender{
+		cfg:  cfg,
+		sent: make(chan *state.LogRecord, 10),
+	}
+	s.senders <- sender
+	return sender, nil
+}
+
+func (s *WorkerSuite) nextSender(c *gc.C) *stubSender {
+	select {
+	case sender := <-s.senders:
+		return sender
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for a sender to be opened")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the position to be saved")
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config logforwarder.Config
+		err    string
+	}{{
+		config: logforwarder.Config{OpenSender: s.openSender, Clock: s.clock, RetryDelay: time.Second},
+		err:    "nil Backend not valid",
+	}, {
+		config: logforwarder.Config{Backend: s.backend, Clock: s.clock, RetryDelay: time.Second},
+		err:    "nil OpenSender not valid",
+	}, {
+		config: logforwarder.Config{Backend: s.backend, OpenSender: s.openSender, RetryDelay: time.Second},
+		err:    "nil Clock not valid",
+	}, {
+		config: logforwarder.Config{Backend: s.backend, OpenSender: s.openSender, Clock: s.clock},
+		err:    "non-positive RetryDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := logforwarder.New(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestNoSinks(c *gc.C) {
+	s.backend = newStubBackend(c, nil)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case <-s.senders:
+		c.Fatalf("unexpected sender opened")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestForwardFirstTime(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	sender := s.nextSender(c)
+	c.Check(sender.cfg, jc.DeepEquals, syslog.ClientConfig{Address: "logs.example.com:6514"})
+	tailer := s.backend.nextTailer(c)
+	// Only logs written from now on are forwarded.
+	c.Check(tailer.start, gc.Equals, startTime)
+
+	rec0 := makeRecord("a", startTime)
+	rec1 := makeRecord("b", startTime.Add(time.Second))
+	tailer.logs <- rec0
+	tailer.logs <- rec1
+	sender.checkSent(c, rec0, rec1)
+
+	s.waitAlarm(c)
+	s.clock.Advance(time.Second)
+	s.backend.checkPosition(c, "syslog:logs.example.com:6514", rec1.Time, []string{"b"})
+}
+
+func (s *WorkerSuite) TestResume(c *gc.C) {
+	lastTime := startTime.Add(-time.Hour)
+	s.backend.setPosition("syslog:logs.example.com:6514", lastTime, []string{"a"})
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	sender := s.nextSender(c)
+	tailer := s.backend.nextTailer(c)
+	c.Check(tailer.start, gc.Equals, lastTime)
+
+	// The record which was already sent is skipped, but not the
+	// other record written at the same time.
+	tailer.logs <- makeRecord("a", lastTime)
+	rec1 := makeRecord("b", lastTime)
+	rec2 := makeRecord("c", lastTime.Add(time.Second))
+	tailer.logs <- rec1
+	tailer.logs <- rec2
+	sender.checkSent(c, rec1, rec2)
+}
+
+func (s *WorkerSuite) TestSavesPositionWhenStopped(c *gc.C) {
+	w := s.startWorker(c)
+
+	sender := s.nextSender(c)
+	tailer := s.backend.nextTailer(c)
+	rec0 := makeRecord("a", startTime)
+	rec1 := makeRecord("b", startTime)
+	tailer.logs <- rec0
+	tailer.logs <- rec1
+	sender.checkSent(c, rec0, rec1)
+
+	workertest.CleanKill(c, w)
+	s.backend.checkPosition(c, "syslog:logs.example.com:6514", startTime, []string{"a", "b"})
+	c.Check(sender.isClosed(), jc.IsTrue)
+	c.Check(tailer.isStopped(), jc.IsTrue)
+}
+
+func (s *WorkerSuite) TestRetryAfterSendFailure(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	sender := s.nextSender(c)
+	tailer := s.backend.nextTailer(c)
+	rec0 := makeRecord("a", startTime)
+	tailer.logs <- rec0
+	sender.checkSent(c, rec0)
+	sender.setSendErr(errors.New("connection reset"))
+	tailer.logs <- makeRecord("b", startTime.Add(time.Second))
+
+	// Forwarding is restarted from the last record which was sent.
+	sender = s.nextSender(c)
+	tailer = s.backend.nextTailer(c)
+	c.Check(tailer.start, gc.Equals, startTime)
+	tailer.logs <- rec0
+	rec1 := makeRecord("b", startTime.Add(time.Second))
+	tailer.logs <- rec1
+	sender.checkSent(c, rec1)
+}
+
+func (s *WorkerSuite) TestConfigChange(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	sender := s.nextSender(c)
+	tailer := s.backend.nextTailer(c)
+
+	s.backend.setConfig(c, coretesting.Attrs{
+		"logforward-syslog-hosts": "logs2.example.com:6514",
+	})
+	sender2 := s.nextSender(c)
+	c.Check(sender2.cfg.Address, gc.Equals, "logs2.example.com:6514")
+	s.backend.nextTailer(c)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if sender.isClosed() && tailer.isStopped() {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("forwarding to the removed collector was not stopped")
+		}
+	}
+}
+
+func (s *WorkerSuite) TestSyslogMessage(c *gc.C) {
+	rec := makeRecord("a", startTime)
+	c.Check(logforwarder.SyslogMessage(rec), jc.DeepEquals, syslog.Message{
+		Facility: syslog.FacilityUser,
+		Severity: syslog.SeverityWarning,
+		Time:     startTime,
+		Hostname: "machine-0.deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		AppName:  "juju",
+		StructuredData: []syslog.SDElement{{
+			ID: "juju@28978",
+			Params: []syslog.Param{
+				{Name: "model-uuid", Value: "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+				{Name: "entity", Value: "machine-0"},
+				{Name: "module", Value: "juju.worker"},
+				{Name: "location", Value: "worker.go:42"},
+			},
+		}},
+		Msg: "message a",
+	})
+}
+
+func makeRecord(id string, t time.Time) *state.LogRecord {
+	return &state.LogRecord{
+		ID:        id,
+		Time:      t,
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Entity:    "machine-0",
+		Module:    "juju.worker",
+		Location:  "worker.go:42",
+		Level:     loggo.WARNING,
+		Message:   "message " + id,
+	}
+}
+
+type stubBackend struct {
+	mu        sync.Mutex
+	cfg       *config.Config
+	changes   chan struct{}
+	tailers   chan *stubTailer
+	positions map[string]*stubLastSent
+}
+
+func newStubBackend(c *gc.C, attrs coretesting.Attrs) *stubBackend {
+	b := &stubBackend{
+		changes:   make(chan struct{}, 1),
+		tailers:   make(chan *stubTailer, 10),
+		positions: make(map[string]*stubLastSent),
+	}
+	b.setConfig(c, attrs)
+	return b
+}
+
+func (b *stubBackend) setConfig(c *gc.C, attrs coretesting.Attrs) {
+	b.mu.Lock()
+	b.cfg = coretesting.CustomModelConfig(c, attrs)
+	b.mu.Unlock()
+	b.changes <- struct{}{}
+}
+
+func (b *stubBackend) setPosition(sink string, t time.Time, ids []string) {
+	b.LastSent(sink).SetPosition(t, ids)
+}
+
+// checkPosition waits for the position of forwarding to the sink to be
+// recorded, and checks it.
+func (b *stubBackend) checkPosition(c *gc.C, sink string, t time.Time, ids []string) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		posTime, posIDs, err := b.LastSent(sink).GetPosition()
+		if errors.Cause(err) == state.ErrNeverForwarded && a.HasNext() {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(posTime, gc.Equals, t)
+		c.Check(posIDs, jc.DeepEquals, ids)
+		return
+	}
+}
+
+func (b *stubBackend) nextTailer(c *gc.C) *stubTailer {
+	select {
+	case tailer := <-b.tailers:
+		return tailer
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for logs to be tailed")
+	}
+	panic("unreachable")
+}
+
+func (b *stubBackend) ModelConfig() (*config.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, nil
+}
+
+func (b *stubBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	return &stubWatcher{changes: b.changes}
+}
+
+func (b *stubBackend) NewLogTailer(start time.Time) (state.LogTailer, error) {
+	tailer := &stubTailer{
+		start: start,
+		logs:  make(chan *state.LogRecord, 10),
+	}
+	b.tailers <- tailer
+	return tailer, nil
+}
+
+func (b *stubBackend) LastSent(sink string) logforwarder.LastSentTracker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lastSent, ok := b.positions[sink]
+	if !ok {
+		lastSent = &stubLastSent{}
+		b.positions[sink] = lastSent
+	}
+	return lastSent
+}
+
+type stubLastSent struct {
+	mu   sync.Mutex
+	set  bool
+	time time.Time
+	ids  []string
+}
+
+func (l *stubLastSent) GetPosition() (time.Time, []string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.set {
+		return time.Time{}, nil, errors.Trace(state.ErrNeverForwarded)
+	}
+	return l.time, l.ids, nil
+}
+
+func (l *stubLastSent) SetPosition(t time.Time, ids []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.set = true
+	l.time = t
+	l.ids = ids
+	return nil
+}
+
+type stubTailer struct {
+	state.LogTailer
+	mu      sync.Mutex
+	start   time.Time
+	logs    chan *state.LogRecord
+	stopped bool
+}
+
+func (t *stubTailer) Logs() <-chan *state.LogRecord {
+	return t.logs
+}
+
+func (t *stubTailer) Stop() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	return nil
+}
+
+func (t *stubTailer) isStopped() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stopped
+}
+
+type stubSender struct {
+	mu      sync.Mutex
+	cfg     syslog.ClientConfig
+	sent    chan *state.LogRecord
+	sendErr error
+	closed  bool
+}
+
+func (s *stubSender) Send(rec *state.LogRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent <- rec
+	return nil
+}
+
+func (s *stubSender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *stubSender) setSendErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sendErr = err
+}
+
+func (s *stubSender) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *stubSender) checkSent(c *gc.C, expected ...*state.LogRecord) {
+	for _, rec := range expected {
+		select {
+		case sent := <-s.sent:
+			c.Check(sent, gc.Equals, rec)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for record %q to be sent", rec.ID)
+		}
+	}
+}
+
+type stubWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+}
+
+func (w *stubWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *stubWatcher) Kill() {}
+
+func (w *stubWatcher) Wait() error {
+	return nil
+}
@@ -74,6 +74,11 @@ func (ctx *limitedContext) Flush(_ string, err error) error {
 	return err
 }
 
+// AbortAction implements runner.Context.
+func (ctx *limitedContext) AbortAction(status, message string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 
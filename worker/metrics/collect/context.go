@@ -76,6 +76,11 @@ func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// AbortAction implements runner.Context.
+func (ctx *hookContext) AbortAction(status, message string) error {
+	return jujuc.ErrRestrictedContext
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 
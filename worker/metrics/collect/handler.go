@@ -54,12 +54,16 @@ func (l *handler) Handle(c net.Conn) (err error) {
 		l.config.unitTag.String(),
 	)
 	if err != nil {
-		return errors.Annotate(err, "failed to create the metric recorder")
+		err = errors.Annotate(err, "failed to create the metric recorder")
+		recordFailure(l.config.metricsFactory, l.config.unitTag, spool.SpoolFailure, err)
+		return err
 	}
 	defer recorder.Close()
 	err = l.config.runner.do(recorder)
 	if err != nil {
-		return errors.Annotate(err, "failed to collect metrics")
+		err = errors.Annotate(err, "failed to collect metrics")
+		recordFailure(l.config.metricsFactory, l.config.unitTag, spool.CollectFailure, err)
+		return err
 	}
 	return nil
 }
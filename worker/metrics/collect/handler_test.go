@@ -4,6 +4,7 @@
 package collect_test
 
 import (
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -32,6 +33,7 @@ type handlerSuite struct {
 	dataDir        string
 	resources      dt.StubResources
 	recorder       *dummyRecorder
+	factory        *mockMetricFactory
 	listener       *mockListener
 }
 
@@ -63,9 +65,10 @@ func (s *handlerSuite) SetUpTest(c *gc.C) {
 		},
 	}
 
+	s.factory = &mockMetricFactory{recorder: s.recorder}
 	s.resources = dt.StubResources{
 		"agent-name":        dt.StubResource{Output: &dummyAgent{dataDir: s.dataDir}},
-		"metric-spool-name": dt.StubResource{Output: &mockMetricFactory{recorder: s.recorder}},
+		"metric-spool-name": dt.StubResource{Output: s.factory},
 		"charmdir-name":     dt.StubResource{Output: &dummyCharmdir{aborted: false}},
 	}
 
@@ -149,6 +152,9 @@ func (s *handlerSuite) TestHandlerError(c *gc.C) {
 	responseString := strings.Trim(string(conn.data), " \n\t")
 	c.Assert(responseString, gc.Matches, ".*well, this is embarassing")
 	c.Assert(s.recorder.batches, gc.HasLen, 0)
+	c.Assert(s.factory.failures, jc.DeepEquals, []string{
+		"unit-u-0 collect: failed to collect metrics: error adding 'juju-units' metric: well, this is embarassing",
+	})
 
 	worker.Kill()
 	err = worker.Wait()
@@ -207,6 +213,13 @@ func (c *mockConnection) Close() error {
 type mockMetricFactory struct {
 	spool.MetricFactory
 	recorder *dummyRecorder
+	failures []string
+}
+
+// RecordFailure implements the spool.MetricFactory interface.
+func (f *mockMetricFactory) RecordFailure(unitTag, kind, message string) error {
+	f.failures = append(f.failures, fmt.Sprintf("%s %s: %s", unitTag, kind, message))
+	return nil
 }
 
 // Recorder implements the spool.MetricFactory interface.
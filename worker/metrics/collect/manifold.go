@@ -211,7 +211,9 @@ func (w *collect) Do(stop <-chan struct{}) error {
 		logger.Tracef("%v", err)
 		return nil
 	} else if err != nil {
-		return errors.Annotate(err, "failed to instantiate metric recorder")
+		err = errors.Annotate(err, "failed to instantiate metric recorder")
+		recordFailure(w.metricFactory, unitTag, spool.SpoolFailure, err)
+		return err
 	}
 
 	err = w.charmdir.Visit(func() error {
@@ -221,9 +223,20 @@ func (w *collect) Do(stop <-chan struct{}) error {
 		logger.Tracef("cannot execute collect-metrics: %v", err)
 		return nil
 	}
+	if err != nil {
+		recordFailure(w.metricFactory, unitTag, spool.CollectFailure, err)
+	}
 	return err
 }
 
+// recordFailure spools a metric failure so that it is reported to the
+// controller along with the unit's metrics.
+func recordFailure(factory spool.MetricFactory, unitTag names.UnitTag, kind string, err error) {
+	if ferr := factory.RecordFailure(unitTag.String(), kind, err.Error()); ferr != nil {
+		logger.Warningf("failed to record metric failure: %v", ferr)
+	}
+}
+
 type hookRunner struct {
 	m sync.Mutex
 
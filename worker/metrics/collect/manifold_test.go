@@ -198,6 +198,28 @@ func (s *ManifoldSuite) TestNoMetricsDeclared(c *gc.C) {
 	c.Assert(recorder.batches, gc.HasLen, 0)
 }
 
+// TestRecorderFailure tests that a failure to create the metric recorder
+// is recorded as a metric failure.
+func (s *ManifoldSuite) TestRecorderFailure(c *gc.C) {
+	factory := &dummyMetricFactory{}
+	s.resources["metric-spool-name"] = dt.StubResource{Output: factory}
+	s.PatchValue(collect.NewRecorder,
+		func(_ names.UnitTag, _ context.Paths, _ spool.MetricFactory) (spool.MetricRecorder, error) {
+			return nil, errors.New("disk full")
+		})
+	s.PatchValue(collect.ReadCharm,
+		func(_ names.UnitTag, _ context.Paths) (*corecharm.URL, map[string]corecharm.Metric, error) {
+			return corecharm.MustParseURL("cs:wordpress-37"), map[string]corecharm.Metric{"pings": corecharm.Metric{Description: "test metric", Type: corecharm.MetricTypeAbsolute}}, nil
+		})
+	collectEntity, err := collect.NewCollect(s.manifoldConfig, s.resources.Context())
+	c.Assert(err, jc.ErrorIsNil)
+	err = collectEntity.Do(nil)
+	c.Assert(err, gc.ErrorMatches, "failed to instantiate metric recorder: disk full")
+	c.Assert(factory.failures, jc.DeepEquals, []string{
+		"spool: failed to instantiate metric recorder: disk full",
+	})
+}
+
 type dummyAgent struct {
 	agent.Agent
 	dataDir string
@@ -237,6 +259,13 @@ func (a *dummyCharmdir) Visit(visit fortress.Visit, _ fortress.Abort) error {
 
 type dummyMetricFactory struct {
 	spool.MetricFactory
+	failures []string
+}
+
+// RecordFailure implements the spool.MetricFactory interface.
+func (f *dummyMetricFactory) RecordFailure(unitTag, kind, message string) error {
+	f.failures = append(f.failures, kind+": "+message)
+	return nil
 }
 
 type dummyRecorder struct {
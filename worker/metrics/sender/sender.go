@@ -31,6 +31,7 @@ type sender struct {
 	client   metricsadder.MetricsAdderClient
 	factory  spool.MetricFactory
 	listener stopper
+	unitTag  string
 }
 
 // Do sends metrics from the metric spool to the
@@ -48,22 +49,32 @@ func (s *sender) sendMetrics(reader spool.MetricReader) error {
 	batches, err := reader.Read()
 	if err != nil {
 		logger.Warningf("failed to open the metric reader: %v", err)
+		s.recordFailure(s.unitTag, spool.SpoolFailure, fmt.Sprintf("failed to read metrics: %v", err))
 		return errors.Trace(err)
 	}
 	var sendBatches []params.MetricBatchParam
+	batchUnits := make(map[string]string)
 	for _, batch := range batches {
 		sendBatches = append(sendBatches, spool.APIMetricBatch(batch))
+		batchUnits[batch.UUID] = batch.UnitTag
 	}
 	results, err := s.client.AddMetricBatches(sendBatches)
 	if err != nil {
 		logger.Warningf("could not send metrics: %v", err)
+		if len(sendBatches) > 0 {
+			s.recordFailure(s.unitTag, spool.SendFailure, fmt.Sprintf("could not send metrics: %v", err))
+		}
 		return errors.Trace(err)
 	}
 	for batchUUID, resultErr := range results {
 		// if we fail to send any metric batch we log a warning with the assumption that
 		// the unsent metric batches remain in the spool directory and will be sent to the
 		// controller when the network partition is restored.
-		if _, ok := resultErr.(*params.Error); ok || params.IsCodeAlreadyExists(resultErr) {
+		if apiErr, ok := resultErr.(*params.Error); ok || params.IsCodeAlreadyExists(resultErr) {
+			if apiErr != nil && !params.IsCodeAlreadyExists(apiErr) {
+				// The controller rejected the batch, so it is dropped.
+				s.recordFailure(batchUnits[batchUUID], spool.SendFailure, fmt.Sprintf("metric batch %q dropped: %v", batchUUID, apiErr))
+			}
 			err = reader.Remove(batchUUID)
 			if err != nil {
 				logger.Warningf("could not remove batch %q from spool: %v", batchUUID, err)
@@ -72,9 +83,50 @@ func (s *sender) sendMetrics(reader spool.MetricReader) error {
 			logger.Warningf("failed to send batch %q: %v", batchUUID, resultErr)
 		}
 	}
+	s.sendFailures(reader)
 	return nil
 }
 
+// recordFailure spools a metric failure for the given unit, to be
+// reported to the controller by sendFailures.
+func (s *sender) recordFailure(unitTag, kind, message string) {
+	if err := s.factory.RecordFailure(unitTag, kind, message); err != nil {
+		logger.Warningf("failed to record metric failure: %v", err)
+	}
+}
+
+// sendFailures reports spooled metric failures to the controller. Failures
+// which cannot be reported remain in the spool directory until the next
+// attempt.
+func (s *sender) sendFailures(reader spool.MetricReader) {
+	failures, err := reader.ReadFailures()
+	if err != nil {
+		logger.Warningf("failed to read metric failures: %v", err)
+		return
+	}
+	if len(failures) == 0 {
+		return
+	}
+	sendFailures := make([]params.MetricFailureParam, len(failures))
+	for i, failure := range failures {
+		sendFailures[i] = spool.APIMetricFailure(failure)
+	}
+	results, err := s.client.AddMetricFailures(sendFailures)
+	if err != nil {
+		logger.Warningf("could not send metric failures: %v", err)
+		return
+	}
+	for failureUUID, resultErr := range results {
+		if _, ok := resultErr.(*params.Error); ok || params.IsCodeAlreadyExists(resultErr) {
+			if err := reader.RemoveFailure(failureUUID); err != nil {
+				logger.Warningf("could not remove metric failure %q from spool: %v", failureUUID, err)
+			}
+		} else {
+			logger.Warningf("failed to send metric failure %q: %v", failureUUID, resultErr)
+		}
+	}
+}
+
 // Handle sends metrics from the spool directory to the
 // controller.
 func (s *sender) Handle(c net.Conn) (err error) {
@@ -118,6 +170,7 @@ func newSender(client metricsadder.MetricsAdderClient, factory spool.MetricFacto
 	s := &sender{
 		client:  client,
 		factory: factory,
+		unitTag: unitTag,
 	}
 	listener, err := spool.NewSocketListener(socketName(baseDir, unitTag), s)
 	if err != nil {
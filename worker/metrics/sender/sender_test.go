@@ -162,6 +162,50 @@ func (s *senderSuite) TestSendingFails(c *gc.C) {
 	batches, err := reader.Read()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(batches, gc.HasLen, 1)
+
+	// The failure is spooled, to be reported when the controller
+	// can be reached again.
+	c.Assert(apiSender.failures, gc.HasLen, 0)
+	failures, err := reader.ReadFailures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].UnitTag, gc.Equals, "test-unit-0")
+	c.Assert(failures[0].Kind, gc.Equals, spool.SendFailure)
+	c.Assert(failures[0].Message, gc.Equals, "could not send metrics: something went wrong")
+}
+
+func (s *senderSuite) TestSendingRejected(c *gc.C) {
+	apiSender := newTestAPIMetricSender()
+
+	select {
+	case apiSender.errors <- &params.Error{Message: "charm not found"}:
+	default:
+		c.Fatalf("blocked error channel")
+	}
+
+	metricSender, err := sender.NewSender(apiSender, s.metricfactory, s.socketDir, "test-unit-0")
+	c.Assert(err, jc.ErrorIsNil)
+	stopCh := make(chan struct{})
+	err = metricSender.Do(stopCh)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(apiSender.batches, gc.HasLen, 1)
+	batchUUID := apiSender.batches[0].Batch.UUID
+
+	// The rejected batch is dropped, and the drop is reported.
+	c.Assert(apiSender.failures, gc.HasLen, 1)
+	c.Assert(apiSender.failures[0].Tag, gc.Equals, "testcharm/0")
+	c.Assert(apiSender.failures[0].Failure.Kind, gc.Equals, spool.SendFailure)
+	c.Assert(apiSender.failures[0].Failure.Message, gc.Equals, fmt.Sprintf("metric batch %q dropped: charm not found", batchUUID))
+
+	reader, err := spool.NewJSONMetricReader(s.spoolDir)
+	c.Assert(err, jc.ErrorIsNil)
+	batches, err := reader.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches, gc.HasLen, 0)
+	failures, err := reader.ReadFailures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
 }
 
 func (s *senderSuite) TestNoSpoolDirectory(c *gc.C) {
@@ -205,10 +249,20 @@ func newTestAPIMetricSender() *testAPIMetricSender {
 
 type testAPIMetricSender struct {
 	batches   []params.MetricBatchParam
+	failures  []params.MetricFailureParam
 	errors    chan error
 	sendError chan error
 }
 
+func (t *testAPIMetricSender) AddMetricFailures(failures []params.MetricFailureParam) (map[string]error, error) {
+	t.failures = append(t.failures, failures...)
+	errors := make(map[string]error)
+	for _, f := range failures {
+		errors[f.Failure.UUID] = (*params.Error)(nil)
+	}
+	return errors, nil
+}
+
 func (t *testAPIMetricSender) AddMetricBatches(batches []params.MetricBatchParam) (map[string]error, error) {
 	t.batches = batches
 
@@ -258,6 +312,11 @@ func (s *stubMetricFactory) Reader() (spool.MetricReader, error) {
 
 }
 
+func (s *stubMetricFactory) RecordFailure(unitTag, kind, message string) error {
+	s.MethodCall(s, "RecordFailure", unitTag, kind, message)
+	return spool.RecordFailure(s.spoolDir, unitTag, kind, message)
+}
+
 type mockConnection struct {
 	net.Conn
 	testing.Stub
@@ -40,6 +40,12 @@ type MetricReader interface {
 	// Remove removes the metric batch with the specified uuid
 	// from the spool directory.
 	Remove(uuid string) error
+	// ReadFailures returns all metric failures stored in the spool
+	// directory.
+	ReadFailures() ([]MetricFailure, error)
+	// RemoveFailure removes the metric failure with the specified uuid
+	// from the spool directory.
+	RemoveFailure(uuid string) error
 	// Close implements io.Closer.
 	Close() error
 }
@@ -51,6 +57,10 @@ type MetricFactory interface {
 
 	// Reader returns a new MetricReader.
 	Reader() (MetricReader, error)
+
+	// RecordFailure records a failure of the given kind to collect,
+	// spool or send metrics for the specified unit.
+	RecordFailure(unitTag, kind, message string) error
 }
 
 type factory struct {
@@ -72,6 +82,11 @@ func (f *factory) Recorder(declaredMetrics map[string]corecharm.Metric, charmURL
 	})
 }
 
+// RecordFailure implements the MetricFactory interface.
+func (f *factory) RecordFailure(unitTag, kind, message string) error {
+	return RecordFailure(f.spoolDir, unitTag, kind, message)
+}
+
 var newFactory = func(spoolDir string) MetricFactory {
 	return &factory{spoolDir: spoolDir}
 }
@@ -97,6 +97,75 @@ func APIMetricBatch(batch MetricBatch) params.MetricBatchParam {
 	}
 }
 
+// Kinds of metric failures, matching those recorded by the controller.
+const (
+	// CollectFailure is recorded when the collect-metrics hook fails.
+	CollectFailure = "collect"
+	// SpoolFailure is recorded when metrics cannot be written to or
+	// read from the spool directory.
+	SpoolFailure = "spool"
+	// SendFailure is recorded when metrics cannot be sent to the
+	// controller, or are rejected by it and dropped.
+	SendFailure = "send"
+)
+
+// MetricFailure stores a failure to collect, spool or send metrics
+// until it can be reported to the controller.
+type MetricFailure struct {
+	UUID    string    `json:"uuid"`
+	Kind    string    `json:"kind"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	UnitTag string    `json:"unit-tag"`
+}
+
+// APIMetricFailure converts the specified MetricFailure to a
+// params.MetricFailureParam, which can then be sent to the controller.
+func APIMetricFailure(failure MetricFailure) params.MetricFailureParam {
+	return params.MetricFailureParam{
+		Tag: failure.UnitTag,
+		Failure: params.MetricFailure{
+			UUID:    failure.UUID,
+			Kind:    failure.Kind,
+			Message: failure.Message,
+			Time:    failure.Time,
+		},
+	}
+}
+
+// RecordFailure writes a metric failure of the given kind for the
+// specified unit to the spool directory.
+func RecordFailure(spoolDir, unitTag, kind, message string) error {
+	failureUUID, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	failure := MetricFailure{
+		UUID:    failureUUID.String(),
+		Kind:    kind,
+		Message: message,
+		// TODO(fwereade): 2016-03-17 lp:1558657
+		Time:    time.Now().UTC(),
+		UnitTag: unitTag,
+	}
+	// The use of a metricFile here ensures that the JSONMetricReader will
+	// only find a fully-written failure file.
+	w, err := createMetricFile(failureFile(spoolDir, failure.UUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := json.NewEncoder(w).Encode(failure); err != nil {
+		w.File.Close()
+		os.Remove(w.Name())
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.Close())
+}
+
+func failureFile(spoolDir, uuid string) string {
+	return filepath.Join(spoolDir, fmt.Sprintf("%s.failure", uuid))
+}
+
 // MetricMetadata is used to store metadata for the current metric batch.
 type MetricMetadata struct {
 	CharmURL string    `json:"charmurl"`
@@ -336,6 +405,32 @@ func (r *JSONMetricReader) Remove(uuid string) error {
 	return nil
 }
 
+// ReadFailures implements the MetricsReader interface.
+func (r *JSONMetricReader) ReadFailures() ([]MetricFailure, error) {
+	paths, err := filepath.Glob(filepath.Join(r.dir, "*.failure"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var failures []MetricFailure
+	for _, path := range paths {
+		failure, err := decodeFailure(path)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+// RemoveFailure implements the MetricsReader interface.
+func (r *JSONMetricReader) RemoveFailure(uuid string) error {
+	err := os.Remove(failureFile(r.dir, uuid))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Close implements the MetricsReader interface.
 func (r *JSONMetricReader) Close() error {
 	return nil
@@ -356,6 +451,19 @@ func decodeBatch(file string) (MetricBatch, error) {
 	return batch, nil
 }
 
+func decodeFailure(file string) (MetricFailure, error) {
+	var failure MetricFailure
+	f, err := os.Open(file)
+	if err != nil {
+		return MetricFailure{}, errors.Trace(err)
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&failure); err != nil {
+		return MetricFailure{}, errors.Trace(err)
+	}
+	return failure, nil
+}
+
 func decodeMetrics(file string) ([]jujuc.Metric, error) {
 	var metrics []jujuc.Metric
 	f, err := os.Open(file)
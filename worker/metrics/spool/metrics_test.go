@@ -288,3 +288,37 @@ func (s *metricsReaderSuite) TestRemoval(c *gc.C) {
 	err = r.Close()
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *metricsReaderSuite) TestFailures(c *gc.C) {
+	spoolDir := s.paths.GetMetricsSpoolDir()
+	err := spool.RecordFailure(spoolDir, s.unitTag, spool.CollectFailure, "hook failed")
+	c.Assert(err, jc.ErrorIsNil)
+
+	r, err := spool.NewJSONMetricReader(spoolDir)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+
+	// Failures are not read as metric batches.
+	batches, err := r.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(batches, gc.HasLen, 1)
+
+	failures, err := r.ReadFailures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].UUID, gc.Not(gc.Equals), "")
+	c.Assert(failures[0].Kind, gc.Equals, spool.CollectFailure)
+	c.Assert(failures[0].Message, gc.Equals, "hook failed")
+	c.Assert(failures[0].UnitTag, gc.Equals, s.unitTag)
+
+	apiFailure := spool.APIMetricFailure(failures[0])
+	c.Assert(apiFailure.Tag, gc.Equals, s.unitTag)
+	c.Assert(apiFailure.Failure.UUID, gc.Equals, failures[0].UUID)
+	c.Assert(apiFailure.Failure.Kind, gc.Equals, spool.CollectFailure)
+
+	err = r.RemoveFailure(failures[0].UUID)
+	c.Assert(err, jc.ErrorIsNil)
+	failures, err = r.ReadFailures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
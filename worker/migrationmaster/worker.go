@@ -4,6 +4,9 @@
 package migrationmaster
 
 import (
+	"io"
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/juju/errors"
@@ -13,6 +16,7 @@ import (
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
@@ -48,6 +52,14 @@ type Facade interface {
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// OpenResource returns a reader for the content of a resource of
+	// the model associated with the API connection.
+	OpenResource(serviceID, name string) (io.ReadCloser, error)
+
+	// SetProgress records the counts of the work done so far by the
+	// currently active model migration.
+	SetProgress(migrationmaster.MigrationProgress) error
 }
 
 // Config defines the operation of a Worker.
@@ -90,6 +102,7 @@ func New(config Config) (*Worker, error) {
 type Worker struct {
 	catacomb catacomb.Catacomb
 	config   Config
+	progress migrationmaster.MigrationProgress
 }
 
 // Kill implements worker.Worker.
@@ -129,7 +142,7 @@ func (w *Worker) run() error {
 		case migration.PRECHECK:
 			phase, err = w.doPRECHECK()
 		case migration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo)
+			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
 		case migration.VALIDATION:
 			phase, err = w.doVALIDATION(status.TargetInfo, status.ModelUUID)
 		case migration.SUCCESS:
@@ -197,13 +210,21 @@ func (w *Worker) doPRECHECK() (migration.Phase, error) {
 	return migration.IMPORT, nil
 }
 
-func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, error) {
+func (w *Worker) doIMPORT(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
 	logger.Infof("exporting model")
 	bytes, err := w.config.Facade.Export()
 	if err != nil {
 		logger.Errorf("model export failed: %v", err)
 		return migration.ABORT, nil
 	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		logger.Errorf("failed to read exported model: %v", err)
+		return migration.ABORT, nil
+	}
+	entities := countEntities(model)
+	w.progress.EntitiesExported = entities
+	w.reportProgress()
 
 	logger.Infof("opening API connection to target controller")
 	conn, err := openAPIConn(targetInfo)
@@ -215,15 +236,92 @@ func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, err
 
 	logger.Infof("importing model into target controller")
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(bytes)
+	imported, err := targetClient.Import(bytes)
 	if err != nil {
 		logger.Errorf("failed to import model into target controller: %v", err)
 		return migration.ABORT, nil
 	}
+	w.progress.EntitiesImported = imported
+	w.reportProgress()
+
+	// The serialized model only describes the resources, so their
+	// content has to be transferred separately.
+	for _, res := range model.Resources() {
+		if res.Revision().FingerprintHex() == "" {
+			// Placeholder resources have no content to transfer.
+			continue
+		}
+		logger.Infof("transferring resource %s/%s", res.Service(), res.Name())
+		size, err := w.transferResource(targetClient, modelUUID, res.Service(), res.Name())
+		if err != nil {
+			logger.Errorf("failed to transfer resource %s/%s: %v", res.Service(), res.Name(), err)
+			return migration.ABORT, nil
+		}
+		w.progress.BinaryBytes += size
+		w.reportProgress()
+	}
 
 	return migration.VALIDATION, nil
 }
 
+// reportProgress records the work done so far by the migration. The
+// progress is only informational, so failing to record it isn't
+// allowed to disrupt the migration.
+func (w *Worker) reportProgress() {
+	if err := w.config.Facade.SetProgress(w.progress); err != nil {
+		logger.Warningf("failed to record migration progress: %v", err)
+	}
+}
+
+// countEntities returns the number of machines, services, units and
+// relations in the model.
+func countEntities(model description.Model) int {
+	count := countMachines(model.Machines()) + len(model.Relations())
+	for _, service := range model.Services() {
+		count += 1 + len(service.Units())
+	}
+	return count
+}
+
+func countMachines(machines []description.Machine) int {
+	count := len(machines)
+	for _, machine := range machines {
+		count += countMachines(machine.Containers())
+	}
+	return count
+}
+
+// transferResource copies the content of a resource from the source
+// controller to the target controller, and returns its size. The
+// content is spooled to a temporary file because the upload must be
+// able to rewind its body.
+func (w *Worker) transferResource(targetClient migrationtarget.Client, modelUUID, serviceID, name string) (int64, error) {
+	reader, err := w.config.Facade.OpenResource(serviceID, name)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer reader.Close()
+
+	file, err := ioutil.TempFile("", "juju-migration-resource")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	size, err := io.Copy(file, reader)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if _, err := file.Seek(0, os.SEEK_SET); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if err := targetClient.UploadResource(modelUUID, serviceID, name, file); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return size, nil
+}
+
 func (w *Worker) doVALIDATION(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
 	// TODO(mjs) - Wait for all agents to report back.
 
@@ -4,6 +4,7 @@
 package migrationmaster_test
 
 import (
+	"io"
 	"time"
 
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/api"
 	masterapi "github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/core/migration"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -35,7 +37,7 @@ type Suite struct {
 var _ = gc.Suite(&Suite{})
 
 var (
-	fakeSerializedModel = []byte("model")
+	fakeSerializedModel = serializeModel(newFakeModel())
 	modelTagString      = names.NewModelTag("model-uuid").String()
 
 	// Define stub calls that commonly appear in tests here to allow reuse.
@@ -70,8 +72,30 @@ var (
 			params.ModelArgs{ModelTag: modelTagString},
 		},
 	}
+
+	// The fake model is empty, so no entities are counted.
+	exportedProgressCall = jujutesting.StubCall{
+		"masterClient.SetProgress",
+		[]interface{}{masterapi.MigrationProgress{}},
+	}
+	importedProgressCall = exportedProgressCall
 )
 
+func newFakeModel() description.Model {
+	return description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("admin"),
+		Config: map[string]interface{}{"uuid": "model-uuid"},
+	})
+}
+
+func serializeModel(model description.Model) []byte {
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
@@ -117,8 +141,10 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
+		exportedProgressCall,
 		apiOpenCall,
 		importCall,
+		importedProgressCall,
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
 		apiOpenCall,
@@ -131,6 +157,38 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestProgressCountsEntities(c *gc.C) {
+	model := newFakeModel()
+	machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	machine.AddContainer(description.MachineArgs{Id: names.NewMachineTag("0/lxc/0")})
+	model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("1")})
+
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.serializedModel = serializeModel(model)
+	// The imported count is the one reported by the target.
+	s.connection.entitiesImported = 2
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	var progress []masterapi.MigrationProgress
+	for _, call := range s.stub.Calls() {
+		if call.FuncName == "masterClient.SetProgress" {
+			progress = append(progress, call.Args[0].(masterapi.MigrationProgress))
+		}
+	}
+	c.Assert(progress, jc.DeepEquals, []masterapi.MigrationProgress{
+		{EntitiesExported: 3},
+		{EntitiesExported: 3, EntitiesImported: 2},
+	})
+}
+
 func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 
@@ -333,6 +391,7 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
+		exportedProgressCall,
 		apiOpenCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
@@ -361,6 +420,7 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
+		exportedProgressCall,
 		apiOpenCall,
 		importCall,
 		connCloseCall,
@@ -372,6 +432,69 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestResourceTransferFailure(c *gc.C) {
+	model := newFakeModel()
+	model.AddResource(description.ResourceArgs{
+		Service: "wordpress",
+		Name:    "blob",
+		Revision: description.ResourceRevisionArgs{
+			Revision:       1,
+			Type:           "file",
+			Path:           "blob.tgz",
+			Origin:         "upload",
+			FingerprintHex: "abcdef0123456789",
+			Size:           10,
+		},
+	})
+	// Placeholder resources have no content to transfer.
+	model.AddResource(description.ResourceArgs{
+		Service: "wordpress",
+		Name:    "placeholder",
+		Revision: description.ResourceRevisionArgs{
+			Type:   "file",
+			Path:   "placeholder.tgz",
+			Origin: "upload",
+		},
+	})
+	serialized := serializeModel(model)
+
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.serializedModel = serialized
+	masterClient.resourceErr = errors.New("boom")
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
+		{"masterClient.Export", nil},
+		exportedProgressCall,
+		apiOpenCall,
+		{"APICall:MigrationTarget.Import", []interface{}{
+			params.SerializedModel{Bytes: serialized},
+		}},
+		importedProgressCall,
+		{"masterClient.OpenResource", []interface{}{"wordpress", "blob"}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
 	return &stubGuard{stub: stub}
 }
@@ -394,8 +517,9 @@ func (g *stubGuard) Unlock() error {
 
 func newStubMasterClient(stub *jujutesting.Stub) *stubMasterClient {
 	return &stubMasterClient{
-		stub:           stub,
-		watcherChanges: make(chan struct{}, 1),
+		stub:            stub,
+		watcherChanges:  make(chan struct{}, 1),
+		serializedModel: fakeSerializedModel,
 		status: masterapi.MigrationStatus{
 			ModelUUID: "model-uuid",
 			Attempt:   2,
@@ -419,6 +543,9 @@ type stubMasterClient struct {
 	status         masterapi.MigrationStatus
 	statusErr      error
 	exportErr      error
+
+	serializedModel []byte
+	resourceErr     error
 }
 
 func (c *stubMasterClient) Watch() (watcher.NotifyWatcher, error) {
@@ -443,7 +570,15 @@ func (c *stubMasterClient) Export() ([]byte, error) {
 	if c.exportErr != nil {
 		return nil, c.exportErr
 	}
-	return fakeSerializedModel, nil
+	return c.serializedModel, nil
+}
+
+func (c *stubMasterClient) OpenResource(serviceID, name string) (io.ReadCloser, error) {
+	c.stub.AddCall("masterClient.OpenResource", serviceID, name)
+	if c.resourceErr != nil {
+		return nil, c.resourceErr
+	}
+	return nil, errors.New("unexpected resource download")
 }
 
 func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
@@ -451,6 +586,11 @@ func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
 	return nil
 }
 
+func (c *stubMasterClient) SetProgress(progress masterapi.MigrationProgress) error {
+	c.stub.AddCall("masterClient.SetProgress", progress)
+	return nil
+}
+
 func newMockWatcher(changes chan struct{}) *mockWatcher {
 	return &mockWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -469,8 +609,9 @@ func (w *mockWatcher) Changes() watcher.NotifyChannel {
 
 type stubConnection struct {
 	api.Connection
-	stub      *jujutesting.Stub
-	importErr error
+	stub             *jujutesting.Stub
+	importErr        error
+	entitiesImported int
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
@@ -483,7 +624,11 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 	if objType == "MigrationTarget" {
 		switch request {
 		case "Import":
-			return c.importErr
+			if c.importErr != nil {
+				return c.importErr
+			}
+			*(response.(*params.ImportResult)) = params.ImportResult{EntitiesImported: c.entitiesImported}
+			return nil
 		case "Activate":
 			return nil
 		}
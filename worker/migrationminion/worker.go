@@ -8,6 +8,7 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
@@ -25,13 +26,18 @@ type Facade interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report allows a migration minion to report if it successfully
+	// completed its activities for a given migration phase.
+	Report(phase migration.Phase, success bool) error
 }
 
 // Config defines the operation of a Worker.
 type Config struct {
-	Agent  agent.Agent
-	Facade Facade
-	Guard  fortress.Guard
+	Agent   agent.Agent
+	Facade  Facade
+	Guard   fortress.Guard
+	APIOpen api.OpenFunc
 }
 
 // Validate returns an error if config cannot drive a Worker.
@@ -45,6 +51,9 @@ func (config Config) Validate() error {
 	if config.Guard == nil {
 		return errors.NotValidf("nil Guard")
 	}
+	if config.APIOpen == nil {
+		return errors.NotValidf("nil APIOpen")
+	}
 	return nil
 }
 
@@ -123,17 +132,25 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 	case migration.QUIESCE:
 		// TODO(mjs) - once Will's stable mode work comes
 		// together this worker will only start up when a
-		// migration is active. Here the minion should report
-		// to the controller that it is running so that the
-		// migration can progress to READONLY.
+		// migration is active. For now, reaching here means the
+		// agent is locked down and is running, which is what the
+		// controller needs to know.
+		return w.report(status.Phase, true)
 	case migration.VALIDATION:
-		// TODO(mjs) - check connection to the target
-		// controller here and report success/failure.
+		err := w.doVALIDATION(status.TargetAPIAddrs, status.TargetCACert)
+		if err != nil {
+			// The failure is reported to the migration master,
+			// which decides whether to abort; it isn't fatal to
+			// the minion.
+			logger.Errorf("validation failed: %v", err)
+		}
+		return w.report(status.Phase, err == nil)
 	case migration.SUCCESS:
 		err := w.doSUCCESS(status.TargetAPIAddrs, status.TargetCACert)
 		if err != nil {
 			return errors.Trace(err)
 		}
+		return w.report(status.Phase, true)
 	case migration.ABORT:
 		// TODO(mjs) - exit here once Will's stable mode work
 		// comes together. The minion is done if these phases
@@ -145,6 +162,31 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 	return nil
 }
 
+func (w *Worker) report(phase migration.Phase, success bool) error {
+	logger.Debugf("reporting back for phase %s: %v", phase, success)
+	err := w.config.Facade.Report(phase, success)
+	return errors.Annotate(err, "failed to report phase progress")
+}
+
+// doVALIDATION checks that the agent can connect to the model on the
+// target controller, using its own credentials.
+func (w *Worker) doVALIDATION(targetAddrs []string, caCert string) error {
+	apiInfo, ok := w.config.Agent.CurrentConfig().APIInfo()
+	if !ok {
+		return errors.New("no API connection details")
+	}
+	apiInfo.Addrs = targetAddrs
+	apiInfo.CACert = caCert
+	// Use zero DialOpts (no retries) because the worker must stay
+	// responsive to Kill requests. We don't want it to be blocked by
+	// a long set of retry attempts.
+	conn, err := w.config.APIOpen(apiInfo, api.DialOpts{})
+	if err != nil {
+		return errors.Annotate(err, "failed to open API to target controller")
+	}
+	return errors.Trace(conn.Close())
+}
+
 func (w *Worker) doSUCCESS(targetAddrs []string, caCert string) error {
 	hps, err := apiAddrsToHostPorts(targetAddrs)
 	if err != nil {
@@ -8,11 +8,13 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
@@ -43,9 +45,10 @@ func (s *Suite) SetUpTest(c *gc.C) {
 
 func (s *Suite) TestStartAndStop(c *gc.C) {
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	workertest.CleanKill(c, w)
@@ -55,9 +58,10 @@ func (s *Suite) TestStartAndStop(c *gc.C) {
 func (s *Suite) TestWatchFailure(c *gc.C) {
 	s.client.watchErr = errors.New("boom")
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, w)
@@ -67,9 +71,10 @@ func (s *Suite) TestWatchFailure(c *gc.C) {
 func (s *Suite) TestClosedWatcherChannel(c *gc.C) {
 	close(s.client.watcher.changes)
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	err = workertest.CheckKilled(c, w)
@@ -82,9 +87,10 @@ func (s *Suite) TestUnlockError(c *gc.C) {
 	}
 	s.guard.unlockErr = errors.New("squish")
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -99,9 +105,10 @@ func (s *Suite) TestLockdownError(c *gc.C) {
 	}
 	s.guard.lockdownErr = errors.New("squash")
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -115,9 +122,10 @@ func (s *Suite) TestNONE(c *gc.C) {
 		Phase: migration.NONE,
 	}
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -134,9 +142,10 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 		TargetCACert:   "top secret",
 	}
 	w, err := migrationminion.New(migrationminion.Config{
-		Facade: s.client,
-		Guard:  s.guard,
-		Agent:  s.agent,
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -145,10 +154,109 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 	case <-time.After(coretesting.LongWait):
 		c.Fatal("timed out waiting for config to be changed")
 	}
+	s.waitForReport(c)
 	workertest.CleanKill(c, w)
 	c.Assert(s.agent.conf.addrs, gc.DeepEquals, addrs)
 	c.Assert(s.agent.conf.caCert, gc.DeepEquals, "top secret")
-	s.stub.CheckCallNames(c, "Watch", "Lockdown")
+	s.stub.CheckCallNames(c, "Watch", "Lockdown", "Report")
+	s.stub.CheckCall(c, 2, "Report", migration.SUCCESS, true)
+}
+
+func (s *Suite) TestQUIESCE(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		Phase: migration.QUIESCE,
+	}
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.waitForReport(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "Watch", "Lockdown", "Report")
+	s.stub.CheckCall(c, 2, "Report", migration.QUIESCE, true)
+}
+
+func (s *Suite) TestVALIDATION(c *gc.C) {
+	addrs := []string{"1.1.1.1:1", "9.9.9.9:9"}
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		Phase:          migration.VALIDATION,
+		TargetAPIAddrs: addrs,
+		TargetCACert:   "top secret",
+	}
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.waitForReport(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "Watch", "Lockdown", "APIOpen", "Close", "Report")
+	s.stub.CheckCall(c, 2, "APIOpen", &api.Info{
+		Addrs:    addrs,
+		CACert:   "top secret",
+		Tag:      names.NewMachineTag("42"),
+		Password: "sekret",
+	}, api.DialOpts{})
+	s.stub.CheckCall(c, 4, "Report", migration.VALIDATION, true)
+}
+
+func (s *Suite) TestVALIDATIONCantConnect(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		Phase: migration.VALIDATION,
+	}
+	s.stub.SetErrors(errors.New("no route"))
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.waitForReport(c)
+	workertest.CleanKill(c, w)
+	s.stub.CheckCallNames(c, "Watch", "Lockdown", "APIOpen", "Report")
+	s.stub.CheckCall(c, 3, "Report", migration.VALIDATION, false)
+}
+
+func (s *Suite) TestReportError(c *gc.C) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		Phase: migration.QUIESCE,
+	}
+	s.client.reportErr = errors.New("splat")
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade:  s.client,
+		Guard:   s.guard,
+		Agent:   s.agent,
+		APIOpen: s.apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "failed to report phase progress: splat")
+}
+
+func (s *Suite) apiOpen(info *api.Info, dialOpts api.DialOpts) (api.Connection, error) {
+	s.stub.AddCall("APIOpen", info, dialOpts)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &stubConnection{stub: s.stub}, nil
+}
+
+func (s *Suite) waitForReport(c *gc.C) {
+	select {
+	case <-s.client.reported:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for minion report")
+	}
 }
 
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
@@ -173,15 +281,18 @@ func (g *stubGuard) Unlock() error {
 
 func newStubMinionClient(stub *jujutesting.Stub) *stubMinionClient {
 	return &stubMinionClient{
-		stub:    stub,
-		watcher: newStubWatcher(),
+		stub:     stub,
+		watcher:  newStubWatcher(),
+		reported: make(chan bool, 1),
 	}
 }
 
 type stubMinionClient struct {
-	stub     *jujutesting.Stub
-	watcher  *stubWatcher
-	watchErr error
+	stub      *jujutesting.Stub
+	watcher   *stubWatcher
+	watchErr  error
+	reportErr error
+	reported  chan bool
 }
 
 func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
@@ -192,6 +303,12 @@ func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
 	return c.watcher, nil
 }
 
+func (c *stubMinionClient) Report(phase migration.Phase, success bool) error {
+	c.stub.MethodCall(c, "Report", phase, success)
+	c.reported <- true
+	return c.reportErr
+}
+
 func newStubWatcher() *stubWatcher {
 	return &stubWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -237,6 +354,15 @@ type stubConfig struct {
 	caCert string
 }
 
+func (mc *stubConfig) APIInfo() (*api.Info, bool) {
+	return &api.Info{
+		Addrs:    []string{"10.0.0.1:17070"},
+		CACert:   "source cert",
+		Tag:      names.NewMachineTag("42"),
+		Password: "sekret",
+	}, true
+}
+
 func (mc *stubConfig) setAddresses(addrs ...string) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
@@ -259,3 +385,13 @@ func (mc *stubConfig) SetCACert(cert string) {
 	defer mc.mu.Unlock()
 	mc.caCert = cert
 }
+
+type stubConnection struct {
+	api.Connection
+	stub *jujutesting.Stub
+}
+
+func (c *stubConnection) Close() error {
+	c.stub.AddCall("Close")
+	return nil
+}
@@ -555,6 +555,7 @@ func constructStartInstanceParams(
 				},
 				Volume: volumeTag,
 			},
+			v.SnapshotId,
 		}
 	}
 
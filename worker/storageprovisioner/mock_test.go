@@ -125,13 +125,16 @@ type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
+	snapshotsWatcher       *mockStringsWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	takenSnapshots         map[string]params.VolumeSnapshot
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo   func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -153,6 +156,10 @@ func (w *mockVolumeAccessor) WatchVolumeAttachments() (watcher.MachineStorageIds
 	return w.attachmentsWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeSnapshots() (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchBlockDevices(tag names.MachineTag) (watcher.NotifyWatcher, error) {
 	return w.blockDevicesWatcher, nil
 }
@@ -244,6 +251,30 @@ func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageI
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) VolumeSnapshotParams(ids []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, id := range ids {
+		if _, ok := v.takenSnapshots[id]; ok {
+			result = append(result, params.VolumeSnapshotParamsResult{
+				Error: common.ServerError(errors.AlreadyExistsf("volume snapshot %q", id)),
+			})
+			continue
+		}
+		// Snapshot "<n>" is taken of volume "<n>".
+		result = append(result, params.VolumeSnapshotParamsResult{Result: params.VolumeSnapshotParams{
+			Snapshot:  id,
+			VolumeTag: names.NewVolumeTag(id).String(),
+			VolumeId:  "vol-" + id,
+			Size:      1024,
+			Provider:  "dummy",
+			Tags: map[string]string{
+				"very": "fancy",
+			},
+		}})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, error) {
 	if v.setVolumeInfo != nil {
 		return v.setVolumeInfo(volumes)
@@ -258,15 +289,24 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
+		snapshotsWatcher:       newMockStringsWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		takenSnapshots:         make(map[string]params.VolumeSnapshot),
 	}
 }
 
@@ -466,6 +506,7 @@ type dummyProvider struct {
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
+	createVolumeSnapshotsFunc    func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
 }
 
 type dummyVolumeSource struct {
@@ -531,6 +572,25 @@ func (s *dummyVolumeSource) CreateVolumes(params []storage.VolumeParams) ([]stor
 	return results, nil
 }
 
+// CreateVolumeSnapshots makes some volume snapshots that we can check
+// later to ensure things went as expected.
+func (s *dummyVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	if s.provider != nil && s.provider.createVolumeSnapshotsFunc != nil {
+		return s.provider.createVolumeSnapshotsFunc(params)
+	}
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		results[i].VolumeSnapshot = &storage.VolumeSnapshot{
+			p.Snapshot,
+			storage.VolumeSnapshotInfo{
+				SnapshotId: "snap-" + p.Snapshot,
+				Size:       p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
 // DestroyVolumes destroys volumes.
 func (s *dummyVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	if s.provider.destroyVolumesFunc != nil {
@@ -61,6 +61,10 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeSnapshots watches for changes to volume snapshots
+	// that this storage provisioner is responsible for.
+	WatchVolumeSnapshots() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -80,12 +84,20 @@ type VolumeAccessor interface {
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
 
+	// VolumeSnapshotParams returns the parameters for taking the
+	// volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken volume
+	// snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -214,6 +226,7 @@ func (w *storageProvisioner) loop() error {
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -275,6 +288,15 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
+
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots()
+		if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
 		return nil
 	}
 
@@ -353,6 +375,13 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -384,6 +413,7 @@ func processSchedule(ctx *context) error {
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -404,6 +434,8 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[key.(string)] = op
 		}
 	}
 	if len(destroyVolumeOps) > 0 {
@@ -416,6 +448,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "creating volumes")
 		}
 	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
 	if len(detachVolumeOps) > 0 {
 		if err := detachVolumes(ctx, detachVolumeOps); err != nil {
 			return errors.Annotate(err, "detaching volumes")
@@ -272,6 +272,110 @@ func (s *storageProvisionerSuite) TestCreateVolumeRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestVolumeSnapshotAdded(c *gc.C) {
+	expectedSnapshots := []params.VolumeSnapshot{{
+		Snapshot:  "1",
+		VolumeTag: "volume-1",
+		Info: params.VolumeSnapshotInfo{
+			SnapshotId: "snap-1",
+			Size:       1024,
+		},
+	}}
+
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.takenSnapshots["2"] = params.VolumeSnapshot{}
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		defer close(snapshotInfoSet)
+		c.Assert(snapshots, jc.DeepEquals, expectedSnapshots)
+		return nil, nil
+	}
+
+	var createSnapshotsArgs [][]storage.VolumeSnapshotParams
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		createSnapshotsArgs = append(createSnapshotsArgs, args)
+		return []storage.CreateVolumeSnapshotsResult{{
+			VolumeSnapshot: &storage.VolumeSnapshot{
+				args[0].Snapshot,
+				storage.VolumeSnapshotInfo{
+					SnapshotId: "snap-" + args[0].Snapshot,
+					Size:       args[0].Size,
+				},
+			},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The worker should take snapshot "1", and ignore snapshot "2"
+	// which has already been taken ...
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1", "2"}
+	// ... but not until the environment config is available.
+	assertNoEvent(c, snapshotInfoSet, "volume snapshot info set")
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(createSnapshotsArgs, jc.DeepEquals, [][]storage.VolumeSnapshotParams{{{
+		Snapshot: "1",
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     1024,
+		Provider: "dummy",
+		ResourceTags: map[string]string{
+			"very": "fancy",
+		},
+	}}})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshotRetry(c *gc.C) {
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		defer close(snapshotInfoSet)
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	// mockFunc's After will progress the current time by the specified
+	// duration and signal the channel immediately.
+	clock := &mockClock{}
+	var createSnapshotTimes []time.Time
+
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		createSnapshotTimes = append(createSnapshotTimes, clock.Now())
+		if len(createSnapshotTimes) < 4 {
+			return []storage.CreateVolumeSnapshotsResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.CreateVolumeSnapshotsResult{{
+			VolumeSnapshot: &storage.VolumeSnapshot{Snapshot: args[0].Snapshot},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+	c.Assert(createSnapshotTimes, gc.HasLen, 4)
+
+	// The first attempt should have been immediate: T0.
+	c.Assert(createSnapshotTimes[0], gc.Equals, time.Time{})
+
+	delays := make([]time.Duration, len(createSnapshotTimes)-1)
+	for i := range createSnapshotTimes[1:] {
+		delays[i] = createSnapshotTimes[i+1].Sub(createSnapshotTimes[i])
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		30 * time.Second,
+		1 * time.Minute,
+		2 * time.Minute,
+	})
+}
+
 func (s *storageProvisionerSuite) TestCreateFilesystemRetry(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
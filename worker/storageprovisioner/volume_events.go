@@ -54,6 +54,37 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeSnapshotsChanged is called when the volume snapshots with the
+// provided IDs have been seen to have changed. Volume snapshots that
+// have not yet been taken are scheduled for creation.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	var ops []scheduleOp
+	for i, result := range paramsResults {
+		if params.IsCodeAlreadyExists(result.Error) {
+			// The snapshot has already been taken.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", changes[i],
+			)
+		}
+		args, err := volumeSnapshotParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Annotatef(
+				err, "getting parameters for volume snapshot %q", changes[i],
+			)
+		}
+		ops = append(ops, &createVolumeSnapshotOp{args: args})
+	}
+	logger.Debugf("volume snapshots to create: %v", len(ops))
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -475,6 +506,22 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
+	}, nil
+}
+
+func volumeSnapshotParamsFromParams(in params.VolumeSnapshotParams) (storage.VolumeSnapshotParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeSnapshotParams{}, errors.Trace(err)
+	}
+	return storage.VolumeSnapshotParams{
+		Snapshot:     in.Snapshot,
+		Volume:       volumeTag,
+		VolumeId:     in.VolumeId,
+		Size:         in.Size,
+		Provider:     storage.ProviderType(in.Provider),
+		ResourceTags: in.Tags,
 	}, nil
 }
 
@@ -122,6 +122,79 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 	return nil
 }
 
+// createVolumeSnapshots creates volume snapshots with the specified
+// parameters.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	paramsBySource := make(map[string][]storage.VolumeSnapshotParams)
+	for _, op := range ops {
+		sourceName := string(op.args.Provider)
+		paramsBySource[sourceName] = append(paramsBySource[sourceName], op.args)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshot
+	for sourceName, snapshotParams := range paramsBySource {
+		logger.Debugf("creating volume snapshots: %+v", snapshotParams)
+		volumeSource, err := volumeSource(
+			ctx.modelConfig, ctx.config.StorageDir,
+			sourceName, snapshotParams[0].Provider,
+		)
+		if errors.Cause(err) == errNonDynamic {
+			// Non-dynamic volumes cannot be snapshotted.
+			logger.Errorf("volume source %q does not support snapshots", sourceName)
+			continue
+		} else if err != nil {
+			return errors.Annotate(err, "getting volume source")
+		}
+		snapshotter, ok := volumeSource.(storage.VolumeSnapshotter)
+		if !ok {
+			logger.Errorf("volume source %q does not support snapshots", sourceName)
+			continue
+		}
+		results, err := snapshotter.CreateVolumeSnapshots(snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", sourceName)
+		}
+		for i, result := range results {
+			p := snapshotParams[i]
+			if result.Error != nil {
+				// Reschedule the volume snapshot.
+				reschedule = append(reschedule, ops[p.Snapshot])
+				logger.Debugf(
+					"failed to create snapshot %q of %s: %v",
+					p.Snapshot, names.ReadableString(p.Volume), result.Error,
+				)
+				continue
+			}
+			snapshots = append(snapshots, params.VolumeSnapshot{
+				Snapshot:  p.Snapshot,
+				VolumeTag: p.Volume.String(),
+				Info: params.VolumeSnapshotInfo{
+					SnapshotId: result.VolumeSnapshot.SnapshotId,
+					Size:       result.VolumeSnapshot.Size,
+				},
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				snapshots[i].Snapshot,
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
 // attachVolumes creates volume attachments with the specified parameters.
 func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -450,6 +523,15 @@ func (op *createVolumeOp) key() interface{} {
 	return op.args.Tag
 }
 
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args storage.VolumeSnapshotParams
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return op.args.Snapshot
+}
+
 type destroyVolumeOp struct {
 	exponentialBackoff
 	tag names.VolumeTag
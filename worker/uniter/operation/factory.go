@@ -6,6 +6,7 @@ package operation
 import (
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils/clock"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/worker/uniter/charm"
@@ -20,6 +21,13 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// Clock is used to enforce action timeouts.
+	Clock clock.Clock
+
+	// ActionCancelled, if non-nil, returns a channel that is closed
+	// when cancellation of the identified action is requested.
+	ActionCancelled func(actionId string) <-chan struct{}
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -102,6 +110,8 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		clock:         f.config.Clock,
+		cancelled:     f.config.ActionCancelled,
 	}, nil
 }
 
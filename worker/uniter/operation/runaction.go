@@ -5,9 +5,12 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/runner"
 )
 
@@ -16,9 +19,12 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	clock         clock.Clock
+	cancelled     func(actionId string) <-chan struct{}
 
-	name   string
-	runner runner.Runner
+	name    string
+	timeout time.Duration
+	runner  runner.Runner
 
 	RequiresMachineLock
 }
@@ -54,6 +60,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 	ra.name = actionData.Name
+	ra.timeout = actionData.Timeout
 	ra.runner = rnr
 	return stateChange{
 		Kind:     RunAction,
@@ -72,7 +79,7 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
-	err := ra.runner.RunAction(ra.name)
+	err := ra.runAction()
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
@@ -86,6 +93,46 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// runAction runs the action, aborting it if its timeout expires or if
+// cancellation is requested before it completes.
+func (ra *runAction) runAction() error {
+	var timeout <-chan time.Time
+	if ra.timeout > 0 {
+		clk := ra.clock
+		if clk == nil {
+			clk = clock.WallClock
+		}
+		timeout = clk.After(ra.timeout)
+	}
+	var cancelled <-chan struct{}
+	if ra.cancelled != nil {
+		cancelled = ra.cancelled(ra.actionId)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ra.runner.RunAction(ra.name)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-timeout:
+		ra.abort(params.ActionFailed, fmt.Sprintf("action timed out after %v", ra.timeout))
+	case <-cancelled:
+		ra.abort(params.ActionCancelled, "action cancelled")
+	}
+	return <-done
+}
+
+// abort records the final status of the running action and kills its
+// process; the runner then reports the action with that status.
+func (ra *runAction) abort(status, message string) {
+	logger.Infof("aborting action %s: %s", ra.actionId, message)
+	if err := ra.runner.Context().AbortAction(status, message); err != nil {
+		logger.Errorf("cannot abort action %s: %v", ra.actionId, err)
+	}
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
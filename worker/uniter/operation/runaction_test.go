@@ -4,12 +4,16 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -227,6 +231,64 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteTimeout(c *gc.C) {
+	runnerFactory := NewAbortableRunActionRunnerFactory(time.Minute)
+	clock := coretesting.NewClock(time.Time{})
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		Clock:         clock,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	go func() {
+		<-clock.Alarms()
+		clock.Advance(time.Minute)
+	}()
+	newState, err := op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Done,
+		ActionId: &someActionId,
+	})
+	ctx := runnerFactory.MockNewActionRunner.runner.context.(*MockContext)
+	ctx.CheckCall(c, 1, "AbortAction", params.ActionFailed, "action timed out after 1m0s")
+}
+
+func (s *RunActionSuite) TestExecuteCancelled(c *gc.C) {
+	runnerFactory := NewAbortableRunActionRunnerFactory(0)
+	cancelled := make(chan struct{})
+	var gotActionId string
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{},
+		ActionCancelled: func(actionId string) <-chan struct{} {
+			gotActionId = actionId
+			return cancelled
+		},
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	close(cancelled)
+	newState, err := op.Execute(*midState)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newState, jc.DeepEquals, &operation.State{
+		Kind:     operation.RunAction,
+		Step:     operation.Done,
+		ActionId: &someActionId,
+	})
+	c.Assert(gotActionId, gc.Equals, someActionId)
+	ctx := runnerFactory.MockNewActionRunner.runner.context.(*MockContext)
+	ctx.CheckCall(c, 1, "AbortAction", params.ActionCancelled, "action cancelled")
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	utilexec "github.com/juju/utils/exec"
@@ -290,6 +292,7 @@ type MockContext struct {
 	actionData      *context.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	aborted         chan struct{}
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -326,13 +329,26 @@ func (mock *MockContext) Prepare() error {
 	return mock.NextErr()
 }
 
+func (mock *MockContext) AbortAction(status, message string) error {
+	mock.MethodCall(mock, "AbortAction", status, message)
+	if mock.aborted != nil {
+		close(mock.aborted)
+	}
+	return mock.NextErr()
+}
+
 type MockRunAction struct {
 	gotName *string
 	err     error
+	// wait, if non-nil, must be closed before Call returns.
+	wait <-chan struct{}
 }
 
 func (mock *MockRunAction) Call(actionName string) error {
 	mock.gotName = &actionName
+	if mock.wait != nil {
+		<-mock.wait
+	}
 	return mock.err
 }
 
@@ -422,6 +438,26 @@ func NewRunActionRunnerFactory(runErr error) *MockRunnerFactory {
 	}
 }
 
+// NewAbortableRunActionRunnerFactory returns a runner factory whose
+// action runs until the action is aborted.
+func NewAbortableRunActionRunnerFactory(timeout time.Duration) *MockRunnerFactory {
+	aborted := make(chan struct{})
+	return &MockRunnerFactory{
+		MockNewActionRunner: &MockNewActionRunner{
+			runner: &MockRunner{
+				MockRunAction: &MockRunAction{wait: aborted},
+				context: &MockContext{
+					actionData: &context.ActionData{
+						Name:    "some-action-name",
+						Timeout: timeout,
+					},
+					aborted: aborted,
+				},
+			},
+		},
+	}
+}
+
 func NewRunCommandsRunnerFactory(runResponse *utilexec.ExecResponse, runErr error) *MockRunnerFactory {
 	return &MockRunnerFactory{
 		MockNewCommandRunner: &MockNewCommandRunner{
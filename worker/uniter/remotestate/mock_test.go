@@ -97,6 +97,8 @@ func (w *mockRelationUnitsWatcher) Changes() watcher.RelationUnitsChannel {
 }
 
 type mockState struct {
+	mu                        sync.Mutex
+	actionsCancelRequested    map[names.ActionTag]bool
 	unit                      mockUnit
 	relations                 map[names.RelationTag]*mockRelation
 	storageAttachment         map[params.StorageAttachmentId]params.StorageAttachment
@@ -104,6 +106,21 @@ type mockState struct {
 	storageAttachmentWatchers map[names.StorageTag]*mockNotifyWatcher
 }
 
+func (st *mockState) ActionCancelRequested(tag names.ActionTag) (bool, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.actionsCancelRequested[tag], nil
+}
+
+func (st *mockState) setActionCancelRequested(tag names.ActionTag) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.actionsCancelRequested == nil {
+		st.actionsCancelRequested = make(map[names.ActionTag]bool)
+	}
+	st.actionsCancelRequested[tag] = true
+}
+
 func (st *mockState) Relation(tag names.RelationTag) (remotestate.Relation, error) {
 	r, ok := st.relations[tag]
 	if !ok {
@@ -13,6 +13,7 @@ import (
 )
 
 type State interface {
+	ActionCancelRequested(names.ActionTag) (bool, error)
 	Relation(names.RelationTag) (Relation, error)
 	StorageAttachment(names.StorageTag, names.UnitTag) (params.StorageAttachment, error)
 	StorageAttachmentLife([]params.StorageAttachmentId) ([]params.LifeResult, error)
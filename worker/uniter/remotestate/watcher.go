@@ -41,6 +41,13 @@ type RemoteStateWatcher struct {
 	out     chan struct{}
 	mu      sync.Mutex
 	current Snapshot
+
+	// cancelledActions holds the ids of running actions which have
+	// been cancelled, and watchedAction and watchedActionCancelled
+	// track the action passed to the latest ActionCancelled call.
+	cancelledActions       map[string]bool
+	watchedAction          string
+	watchedActionCancelled chan struct{}
 }
 
 // WatcherConfig holds configuration parameters for the
@@ -76,6 +83,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 			Relations: make(map[int]RelationSnapshot),
 			Storage:   make(map[names.StorageTag]StorageSnapshot),
 		},
+		cancelledActions: make(map[string]bool),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -122,6 +130,23 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	return snapshot
 }
 
+// ActionCancelled returns a channel which is closed when the watcher
+// learns that the action with the given id was cancelled while running.
+// The uniter runs one action at a time, so only the action passed to
+// the latest call is watched.
+func (w *RemoteStateWatcher) ActionCancelled(id string) <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	cancelled := make(chan struct{})
+	if w.cancelledActions[id] {
+		close(cancelled)
+		return cancelled
+	}
+	w.watchedAction = id
+	w.watchedActionCancelled = cancelled
+	return cancelled
+}
+
 func (w *RemoteStateWatcher) ClearResolvedMode() {
 	w.mu.Lock()
 	w.current.ResolvedMode = params.ResolvedNone
@@ -618,13 +643,61 @@ func (w *RemoteStateWatcher) storageAttachmentChanged(change storageAttachmentCh
 	return nil
 }
 
+// actionsChanged responds to action notification changes. Actions are
+// only reported again once known when they are cancelled while running.
 func (w *RemoteStateWatcher) actionsChanged(actions []string) error {
+	var known []string
 	w.mu.Lock()
-	defer w.mu.Unlock()
-	w.current.Actions = append(w.current.Actions, actions...)
+	for _, id := range actions {
+		if containsString(w.current.Actions, id) {
+			known = append(known, id)
+		} else {
+			w.current.Actions = append(w.current.Actions, id)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, id := range known {
+		if !names.IsValidAction(id) {
+			continue
+		}
+		cancelled, err := w.st.ActionCancelRequested(names.NewActionTag(id))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if cancelled {
+			w.actionCancelled(id)
+		}
+	}
 	return nil
 }
 
+// actionCancelled records that the action with the given id was
+// cancelled, and signals it if it is being watched.
+func (w *RemoteStateWatcher) actionCancelled(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancelledActions[id] {
+		return
+	}
+	logger.Debugf("action %s cancelled", id)
+	w.cancelledActions[id] = true
+	if w.watchedAction == id {
+		close(w.watchedActionCancelled)
+		w.watchedAction = ""
+		w.watchedActionCancelled = nil
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // storageChanged responds to unit storage changes.
 func (w *RemoteStateWatcher) storageChanged(keys []string) error {
 	tags := make([]names.StorageTag, len(keys))
@@ -195,6 +195,30 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestActionCancelled(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	id := "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	s.st.unit.actionWatcher.changes <- []string{id}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	cancelled := s.watcher.ActionCancelled(id)
+
+	// A notification for a running action which isn't cancelled is
+	// ignored.
+	s.st.unit.actionWatcher.changes <- []string{id}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	assertNoNotifyEvent(c, cancelled, "action cancelled")
+
+	s.st.setActionCancelRequested(names.NewActionTag(id))
+	s.st.unit.actionWatcher.changes <- []string{id}
+	assertNotifyEvent(c, cancelled, "waiting for action cancelled")
+	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{id})
+
+	// The cancellation is remembered.
+	assertNotifyEvent(c, s.watcher.ActionCancelled(id), "waiting for action cancelled")
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	signalAll(s.st, s.leadership)
@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"github.com/juju/names"
 )
 
@@ -15,6 +17,15 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// Timeout is how long the Action may run before it is failed;
+	// zero means it may run indefinitely.
+	Timeout time.Duration
+
+	// AbortStatus and AbortMessage are set when the Action's process
+	// is killed before it finishes, and override the Action's outcome.
+	AbortStatus  string
+	AbortMessage string
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -509,6 +509,16 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the running action.
+// Unlike the action's results, the message is sent to the controller
+// immediately.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.ActionLogMessage(ctx.actionData.Tag, message)
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
@@ -563,6 +573,23 @@ func (c *HookContext) ActionData() (*ActionData, error) {
 	return c.actionData, nil
 }
 
+// AbortAction kills the process running the context's action, and
+// records the status and message the action should be finished with,
+// whatever the result of the killed process.
+func (ctx *HookContext) AbortAction(status, message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	mutex.Lock()
+	ctx.actionData.AbortStatus = status
+	ctx.actionData.AbortMessage = message
+	mutex.Unlock()
+	if err := ctx.killCharmHook(); err != ErrNoProcess {
+		return err
+	}
+	return nil
+}
+
 // HookVars returns an os.Environ-style list of strings necessary to run a hook
 // such that it can know what environment it's operating in, and can call back
 // into context.
@@ -747,6 +774,14 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		status = params.ActionFailed
 	}
 
+	// If the action was killed, report why rather than how it died.
+	mutex.Lock()
+	if ctx.actionData.AbortStatus != "" {
+		status = ctx.actionData.AbortStatus
+		message = ctx.actionData.AbortMessage
+	}
+	mutex.Unlock()
+
 	callErr := ctx.state.ActionFinish(tag, status, results, message)
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
@@ -282,6 +282,28 @@ func (s *InterfaceSuite) TestSetActionMessage(c *gc.C) {
 	c.Check(actionData.ResultsMessage, gc.Equals, "because reasons")
 }
 
+func (s *InterfaceSuite) TestAbortAction(c *gc.C) {
+	hctx := context.GetStubActionContext(nil)
+	err := hctx.AbortAction(params.ActionFailed, "action timed out after 1m0s")
+	c.Assert(err, jc.ErrorIsNil)
+	actionData, err := hctx.ActionData()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(actionData.AbortStatus, gc.Equals, params.ActionFailed)
+	c.Check(actionData.AbortMessage, gc.Equals, "action timed out after 1m0s")
+}
+
+func (s *InterfaceSuite) TestAbortActionNotAction(c *gc.C) {
+	ctx := context.HookContext{}
+	err := ctx.AbortAction(params.ActionCancelled, "action cancelled")
+	c.Assert(err, gc.ErrorMatches, "not running an action")
+}
+
+func (s *InterfaceSuite) TestLogActionMessageNotAction(c *gc.C) {
+	ctx := context.HookContext{}
+	err := ctx.LogActionMessage("halfway there")
+	c.Assert(err, gc.ErrorMatches, "not running an action")
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
 	p := &mockProcess{func() error {
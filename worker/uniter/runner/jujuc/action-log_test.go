@@ -0,0 +1,106 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ActionLogSuite struct {
+	ContextSuite
+}
+
+type actionLogContext struct {
+	jujuc.Context
+	logged []string
+}
+
+func (ctx *actionLogContext) LogActionMessage(message string) error {
+	ctx.logged = append(ctx.logged, message)
+	return nil
+}
+
+type nonActionLogContext struct {
+	jujuc.Context
+}
+
+func (ctx *nonActionLogContext) LogActionMessage(message string) error {
+	return fmt.Errorf("not running an action")
+}
+
+var _ = gc.Suite(&ActionLogSuite{})
+
+func (s *ActionLogSuite) TestActionLog(c *gc.C) {
+	var actionLogTests = []struct {
+		summary string
+		command []string
+		logged  []string
+		errMsg  string
+		code    int
+	}{{
+		summary: "no parameters is an error",
+		command: []string{},
+		errMsg:  "error: no message specified\n",
+		code:    2,
+	}, {
+		summary: "a message is logged",
+		command: []string{"dumped 3 of 10 tables"},
+		logged:  []string{"dumped 3 of 10 tables"},
+	}, {
+		summary: "several arguments are joined into one message",
+		command: []string{"dumped", "3", "of", "10", "tables"},
+		logged:  []string{"dumped 3 of 10 tables"},
+	}}
+
+	for i, t := range actionLogTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx := &actionLogContext{}
+		com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := testing.Context(c)
+		code := cmd.Main(com, ctx, t.command)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.errMsg)
+		c.Check(hctx.logged, jc.DeepEquals, t.logged)
+	}
+}
+
+func (s *ActionLogSuite) TestNonActionLogFails(c *gc.C) {
+	hctx := &nonActionLogContext{}
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"oops"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: not running an action\n")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *ActionLogSuite) TestHelp(c *gc.C) {
+	hctx, _ := s.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString("action-log"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, `Usage: action-log <message>
+
+Summary:
+record a progress message for the current action
+
+Details:
+action-log records a timestamped progress message for the running action.
+Unlike action-set, the message is sent immediately, so it can be seen with
+juju show-action-output and juju show-action-status --wait while the action
+is still running.
+`)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+}
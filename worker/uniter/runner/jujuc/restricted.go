@@ -133,6 +133,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
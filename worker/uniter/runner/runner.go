@@ -49,6 +49,7 @@ type Context interface {
 	Id() string
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
+	AbortAction(status, message string) error
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
@@ -76,6 +76,12 @@ type Uniter struct {
 	operationExecutor    operation.Executor
 	newOperationExecutor NewExecutorFunc
 
+	// watcher is the current remote state watcher; it is replaced
+	// whenever the watcher is restarted, so access is guarded by
+	// watcherMu.
+	watcherMu sync.Mutex
+	watcher   *remotestate.RemoteStateWatcher
+
 	leadershipTracker leadership.Tracker
 	charmDirGuard     fortress.Guard
 
@@ -195,11 +201,6 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 	}
 
-	var (
-		watcher   *remotestate.RemoteStateWatcher
-		watcherMu sync.Mutex
-	)
-
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
 	retryHookChan := make(chan struct{}, 1)
 	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
@@ -226,15 +227,14 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	}()
 
 	restartWatcher := func() error {
-		watcherMu.Lock()
-		defer watcherMu.Unlock()
+		u.watcherMu.Lock()
+		defer u.watcherMu.Unlock()
 
-		if watcher != nil {
+		if u.watcher != nil {
 			// watcher added to catacomb, will kill uniter if there's an error.
-			worker.Stop(watcher)
+			worker.Stop(u.watcher)
 		}
-		var err error
-		watcher, err = remotestate.NewWatcher(
+		watcher, err := remotestate.NewWatcher(
 			remotestate.WatcherConfig{
 				State:               remotestate.NewAPIState(u.st),
 				LeadershipTracker:   u.leadershipTracker,
@@ -249,6 +249,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		if err := u.catacomb.Add(watcher); err != nil {
 			return errors.Trace(err)
 		}
+		u.watcher = watcher
 		return nil
 	}
 
@@ -268,7 +269,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		if err := u.unit.ClearResolved(); err != nil {
 			return errors.Trace(err)
 		}
-		watcher.ClearResolvedMode()
+		u.watcher.ClearResolvedMode()
 		return nil
 	}
 
@@ -290,7 +291,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Relations:           relation.NewRelationsResolver(u.relations),
 			Storage:             storage.NewResolver(u.storage),
 			Commands: runcommands.NewCommandsResolver(
-				u.commands, watcher.CommandCompleted,
+				u.commands, u.watcher.CommandCompleted,
 			),
 		})
 
@@ -300,7 +301,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		select {
 		case <-u.catacomb.Dying():
 			return u.catacomb.ErrDying()
-		case <-watcher.RemoteStateChanged():
+		case <-u.watcher.RemoteStateChanged():
 		}
 
 		localState := resolver.LocalState{
@@ -310,7 +311,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		for err == nil {
 			err = resolver.Loop(resolver.LoopConfig{
 				Resolver:      uniterResolver,
-				Watcher:       watcher,
+				Watcher:       u.watcher,
 				Executor:      u.operationExecutor,
 				Factory:       u.operationFactory,
 				Abort:         u.catacomb.Dying(),
@@ -467,11 +468,13 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return errors.Trace(err)
 	}
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
-		Deployer:       u.deployer,
-		RunnerFactory:  runnerFactory,
-		Callbacks:      &operationCallbacks{u},
-		Abort:          u.catacomb.Dying(),
-		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
+		Deployer:        u.deployer,
+		RunnerFactory:   runnerFactory,
+		Callbacks:       &operationCallbacks{u},
+		Abort:           u.catacomb.Dying(),
+		MetricSpoolDir:  u.paths.GetMetricsSpoolDir(),
+		Clock:           u.clock,
+		ActionCancelled: u.actionCancelled,
 	})
 
 	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, u.getServiceCharmURL, u.acquireExecutionLock)
@@ -532,6 +535,18 @@ func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse,
 	return u.runListener.RunCommands(args)
 }
 
+// actionCancelled returns a channel that is closed when cancellation of
+// the identified action has been requested. It returns nil if there is no
+// remote state watcher to report cancellation.
+func (u *Uniter) actionCancelled(actionId string) <-chan struct{} {
+	u.watcherMu.Lock()
+	defer u.watcherMu.Unlock()
+	if u.watcher == nil {
+		return nil
+	}
+	return u.watcher.ActionCancelled(actionId)
+}
+
 // acquireExecutionLock acquires the machine-level execution lock, and
 // returns a func that must be called to unlock it. It's used by operation.Executor
 // when running operations that execute external code.